@@ -0,0 +1,134 @@
+// Package access 负责共享端的可见性和目录密码访问控制，所有共享接口都通过这里判断。
+//
+// 可见性规则：
+//   - 目录可见：由目录链上离目录最近的、单独设置了共享状态的目录决定；
+//     设置为沿用（inherit）的目录跟随上级目录，共享的目录下的子目录默认可见，
+//     单独取消共享的子目录除外。隐藏目录中单独共享的子目录提升到最近的可见上级目录下显示
+//   - 文件可见：文件本身已共享，并且所在目录可见
+//   - 密码继承：目录密码同样保护所有子目录，访问子目录需要持有目录链上每个密码目录的令牌
+//
+// 目录设置了IP访问限制时，不满足限制的客户端无法访问该目录及其子目录（见ipfilter包）。
+//
+// 验证目录密码后签发访问令牌，令牌对该目录及其所有子目录有效。
+// 令牌通过Cookie自动携带，也可以通过X-Directory-Token请求头（多个令牌用逗号分隔）
+// 或dirToken查询参数传递。
+package access
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/common"
+	"fileshare/ipfilter"
+	"fileshare/models"
+	"fileshare/proxy"
+	"fileshare/utils"
+)
+
+// 目录访问令牌Cookie名称前缀
+const cookiePrefix = "fs_dir_"
+
+// 目录访问令牌请求头
+const TokenHeader = "X-Directory-Token"
+
+// GrantDirectoryAccess 为目录签发访问令牌并写入Cookie
+func GrantDirectoryAccess(c *gin.Context, dir *models.Directory) (string, string) {
+	token, expiresAt := utils.GenerateDirectoryToken(dir.ID, dir.Password)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(cookiePrefix+dir.ID, token, int(utils.DirectoryTokenTTL.Seconds()), "/", "", proxy.IsHTTPS(c), true)
+	return token, expiresAt.Format("2006-01-02 15:04:05")
+}
+
+// IPAllowed 检查客户端IP是否满足目录的IP访问限制（不检查上级目录）
+func IPAllowed(c *gin.Context, dir *models.Directory) bool {
+	return ipfilter.Allows(dir.IPFilter, c.ClientIP())
+}
+
+// HasDirectoryAccess 检查请求是否可以访问目录（不检查上级目录）
+func HasDirectoryAccess(c *gin.Context, dir *models.Directory) bool {
+	if !IPAllowed(c, dir) {
+		return false
+	}
+	if dir.Password == "" {
+		return true
+	}
+
+	for _, token := range requestTokens(c, dir.ID) {
+		if utils.ValidateDirectoryToken(token, dir.ID, dir.Password) {
+			return true
+		}
+	}
+	return false
+}
+
+// LockedDirectory 返回目录链中第一个未授权的受保护目录，全部可访问时返回nil
+func LockedDirectory(c *gin.Context, chain []*models.Directory) *models.Directory {
+	for _, dir := range chain {
+		if !HasDirectoryAccess(c, dir) {
+			return dir
+		}
+	}
+	return nil
+}
+
+// CanAccessDirectory 检查请求是否可以访问目录及其所有上级目录
+func CanAccessDirectory(c *gin.Context, dirID string) bool {
+	chain := common.FindDirectoryChain(models.Directories, dirID)
+	return LockedDirectory(c, chain) == nil
+}
+
+// RequireNetwork 检查客户端IP是否满足目录链上的IP访问限制
+func RequireNetwork(c *gin.Context, chain []*models.Directory) bool {
+	if ipfilter.AllowsChain(chain, c.ClientIP()) {
+		return true
+	}
+
+	c.JSON(http.StatusForbidden, gin.H{"error": "Access from your network is not allowed"})
+	return false
+}
+
+// RequireDirectoryChain 检查目录链的访问权限，未授权时返回需要验证的目录
+func RequireDirectoryChain(c *gin.Context, chain []*models.Directory) bool {
+	if !RequireNetwork(c, chain) {
+		return false
+	}
+
+	locked := LockedDirectory(c, chain)
+	if locked == nil {
+		return true
+	}
+
+	c.JSON(http.StatusForbidden, gin.H{
+		"error":           "Directory password required",
+		"requirePassword": true,
+		"directoryId":     locked.ID,
+	})
+	return false
+}
+
+// 获取请求中携带的目录访问令牌
+func requestTokens(c *gin.Context, dirID string) []string {
+	tokens := []string{}
+
+	if cookie, err := c.Cookie(cookiePrefix + dirID); err == nil && cookie != "" {
+		tokens = append(tokens, cookie)
+	}
+
+	for _, header := range c.Request.Header.Values(TokenHeader) {
+		for _, token := range strings.Split(header, ",") {
+			if token = strings.TrimSpace(token); token != "" {
+				tokens = append(tokens, token)
+			}
+		}
+	}
+
+	for _, token := range c.QueryArray("dirToken") {
+		if token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+
+	return tokens
+}
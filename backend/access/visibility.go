@@ -0,0 +1,168 @@
+package access
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/common"
+	"fileshare/models"
+)
+
+// 访客视图中的文件
+type GuestFile struct {
+	*models.File
+	LockedBy string `json:"lockedBy,omitempty"` // 需要先验证密码的目录ID
+}
+
+// IsChainVisible 判断目录链（从根目录到目标目录）的目标目录对访客是否可见。
+// 离目标最近的、单独设置了共享状态的目录决定结果，目录链上都没有设置时不可见
+func IsChainVisible(chain []*models.Directory) bool {
+	for i := len(chain) - 1; i >= 0; i-- {
+		if !chain[i].Inherit {
+			return chain[i].IsShared
+		}
+	}
+	return false
+}
+
+// IsSharedUnder 判断上级目录可见性为parentVisible时目录是否可见（根目录下的目录parentVisible为false）
+func IsSharedUnder(dir *models.Directory, parentVisible bool) bool {
+	if dir.Inherit {
+		return parentVisible
+	}
+	return dir.IsShared
+}
+
+// ListMode 目录在共享端列表中的显示方式：不允许客户端IP访问的目录连同子目录一起隐藏，
+// 可见的目录显示，其他目录中可见的子目录提升到上级目录中（和共享API的目录树相同）
+func ListMode(c *gin.Context, dir *models.Directory) common.DirMode {
+	if !IPAllowed(c, dir) {
+		return common.DirHidden
+	}
+	if IsDirectoryVisible(dir.ID) {
+		return common.DirShown
+	}
+	return common.DirElided
+}
+
+// IsDirectoryVisible 判断目录对访客是否可见
+func IsDirectoryVisible(dirID string) bool {
+	return IsChainVisible(common.FindDirectoryChain(models.Directories, dirID))
+}
+
+// IsFileVisible 判断文件对访客是否可见
+func IsFileVisible(file *models.File) bool {
+	return file.IsShared && IsDirectoryVisible(file.DirectoryID)
+}
+
+// VisibleDirectories 返回访客可见的目录树（已去掉密码哈希），不考虑IP访问限制
+func VisibleDirectories(dirs []*models.Directory) []*models.Directory {
+	return visibleDirectories(dirs, nil)
+}
+
+// ClientDirectories 返回当前请求可见的目录树，去掉不允许客户端IP访问的目录
+func ClientDirectories(c *gin.Context, dirs []*models.Directory) []*models.Directory {
+	return visibleDirectories(dirs, c)
+}
+
+// 返回可见的目录树，c不为nil时同时检查IP访问限制
+func visibleDirectories(dirs []*models.Directory, c *gin.Context) []*models.Directory {
+	return collectVisible(dirs, c, false, "", false)
+}
+
+// 递归收集可见的目录。隐藏的目录不出现在目录树中，其中可见的子目录提升到最近的可见上级目录下；
+// 不允许客户端IP访问的目录连同子目录一起去掉。
+// parentVisible为上级目录是否可见，parentID为最近的可见上级目录，
+// locked表示两者之间隐藏的目录设置了密码（访问时同样需要验证）
+func collectVisible(dirs []*models.Directory, c *gin.Context, parentVisible bool, parentID string, locked bool) []*models.Directory {
+	result := []*models.Directory{}
+
+	for _, dir := range dirs {
+		if c != nil && !IPAllowed(c, dir) {
+			continue
+		}
+
+		hasPassword := locked || dir.Password != ""
+		if !IsSharedUnder(dir, parentVisible) {
+			result = append(result, collectVisible(dir.Children, c, false, parentID, hasPassword)...)
+			continue
+		}
+
+		result = append(result, &models.Directory{
+			ID:          dir.ID,
+			Name:        dir.Name,
+			ParentID:    parentID,
+			IsShared:    true,
+			HasPassword: hasPassword,
+			Children:    collectVisible(dir.Children, c, true, dir.ID, false),
+		})
+	}
+
+	return result
+}
+
+// RequireVisibleDirectory 检查目录是否可见且请求持有所需的访问令牌，成功时返回目录链
+func RequireVisibleDirectory(c *gin.Context, dirID string) ([]*models.Directory, bool) {
+	chain := common.FindDirectoryChain(models.Directories, dirID)
+	if !IsChainVisible(chain) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Directory not found"})
+		return nil, false
+	}
+
+	if !RequireDirectoryChain(c, chain) {
+		return nil, false
+	}
+
+	return chain, true
+}
+
+// RequireVisibleFile 检查文件是否可见且请求持有所需的访问令牌
+func RequireVisibleFile(c *gin.Context, file *models.File) bool {
+	chain := common.FindDirectoryChain(models.Directories, file.DirectoryID)
+	if !file.IsShared || !IsChainVisible(chain) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "File is not shared"})
+		return false
+	}
+
+	return RequireDirectoryChain(c, chain)
+}
+
+// CanListFile 判断文件是否可以出现在当前请求的共享文件列表中
+func CanListFile(c *gin.Context, file *models.File) bool {
+	if !file.IsShared {
+		return false
+	}
+
+	chain := common.FindDirectoryChain(models.Directories, file.DirectoryID)
+	return IsChainVisible(chain) && LockedDirectory(c, chain) == nil
+}
+
+// GetGuestView 管理员以访客身份查看可见的目录和文件（不持有任何目录访问令牌）
+func GetGuestView(c *gin.Context) {
+	files := []*GuestFile{}
+	for _, file := range models.Files {
+		if !file.IsShared {
+			continue
+		}
+
+		chain := common.FindDirectoryChain(models.Directories, file.DirectoryID)
+		if !IsChainVisible(chain) {
+			continue
+		}
+
+		guestFile := &GuestFile{File: file}
+		for _, dir := range chain {
+			if dir.Password != "" {
+				guestFile.LockedBy = dir.ID
+				break
+			}
+		}
+		files = append(files, guestFile)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"directories": VisibleDirectories(models.Directories),
+		"files":       files,
+	})
+}
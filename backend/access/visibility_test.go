@@ -0,0 +1,119 @@
+package access
+
+import (
+	"testing"
+
+	"fileshare/models"
+)
+
+// 目录共享状态：显式共享、显式取消共享、沿用上级目录
+var (
+	shared   = models.Directory{IsShared: true}
+	unshared = models.Directory{}
+	inherit  = models.Directory{Inherit: true}
+)
+
+func chainOf(dirs ...models.Directory) []*models.Directory {
+	chain := make([]*models.Directory, len(dirs))
+	for i := range dirs {
+		dir := dirs[i]
+		chain[i] = &dir
+	}
+	return chain
+}
+
+func TestIsChainVisible(t *testing.T) {
+	tests := []struct {
+		name  string
+		chain []*models.Directory
+		want  bool
+	}{
+		{"空目录链", nil, false},
+		{"共享的根目录", chainOf(shared), true},
+		{"未共享的根目录", chainOf(unshared), false},
+		{"沿用设置的根目录", chainOf(inherit), false},
+		{"共享目录下沿用设置的子目录", chainOf(shared, inherit), true},
+		{"共享目录下取消共享的子目录", chainOf(shared, unshared), false},
+		{"共享目录下共享的子目录", chainOf(shared, shared), true},
+		{"未共享目录下共享的子目录", chainOf(unshared, shared), true},
+		{"未共享目录下沿用设置的子目录", chainOf(unshared, inherit), false},
+		{"未共享目录下未共享的子目录", chainOf(unshared, unshared), false},
+		{"多级沿用共享的上级目录", chainOf(shared, inherit, inherit), true},
+		{"取消共享的中间目录隐藏沿用设置的子目录", chainOf(shared, unshared, inherit), false},
+		{"取消共享的中间目录下单独共享的子目录", chainOf(shared, unshared, shared), true},
+		{"未共享目录下共享目录中沿用设置的子目录", chainOf(unshared, shared, inherit), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsChainVisible(tt.chain); got != tt.want {
+				t.Errorf("IsChainVisible() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVisibleDirectories(t *testing.T) {
+	// root1（未共享）
+	//   ├─ a（共享）
+	//   │   └─ a1（沿用）
+	//   └─ b（沿用，有密码）
+	//       └─ b1（共享）
+	// root2（共享）
+	//   ├─ c（沿用）
+	//   └─ d（取消共享）
+	//       └─ d1（沿用）
+	dirs := []*models.Directory{
+		{ID: "root1", Children: []*models.Directory{
+			{ID: "a", ParentID: "root1", IsShared: true, Children: []*models.Directory{
+				{ID: "a1", ParentID: "a", Inherit: true},
+			}},
+			{ID: "b", ParentID: "root1", Inherit: true, Password: "hash", Children: []*models.Directory{
+				{ID: "b1", ParentID: "b", IsShared: true},
+			}},
+		}},
+		{ID: "root2", IsShared: true, Children: []*models.Directory{
+			{ID: "c", ParentID: "root2", Inherit: true},
+			{ID: "d", ParentID: "root2", Children: []*models.Directory{
+				{ID: "d1", ParentID: "d", Inherit: true},
+			}},
+		}},
+	}
+
+	type visibleDir struct {
+		parentID    string
+		hasPassword bool
+	}
+	want := map[string]visibleDir{
+		"a":     {parentID: ""},
+		"a1":    {parentID: "a"},
+		"b1":    {parentID: "", hasPassword: true},
+		"root2": {parentID: ""},
+		"c":     {parentID: "root2"},
+	}
+
+	got := map[string]visibleDir{}
+	var walk func(dirs []*models.Directory, parentID string)
+	walk = func(dirs []*models.Directory, parentID string) {
+		for _, dir := range dirs {
+			if dir.ParentID != parentID {
+				t.Errorf("%s: ParentID = %q, want %q", dir.ID, dir.ParentID, parentID)
+			}
+			if !dir.IsShared || dir.Password != "" {
+				t.Errorf("%s: IsShared = %v, Password = %q", dir.ID, dir.IsShared, dir.Password)
+			}
+			got[dir.ID] = visibleDir{parentID: dir.ParentID, hasPassword: dir.HasPassword}
+			walk(dir.Children, dir.ID)
+		}
+	}
+	walk(VisibleDirectories(dirs), "")
+
+	if len(got) != len(want) {
+		t.Errorf("visible directories = %v, want %v", got, want)
+	}
+	for id, w := range want {
+		if g, ok := got[id]; !ok || g != w {
+			t.Errorf("%s = %+v (visible %v), want %+v", id, g, ok, w)
+		}
+	}
+}
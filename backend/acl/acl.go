@@ -0,0 +1,168 @@
+// Package acl 负责管理端的目录访问控制。
+//
+// 规则：
+//   - 管理员（admin角色）不受访问控制限制
+//   - 目录链（从根目录到目标目录）上没有任何ACL条目时，按用户角色的权限判断
+//   - 目录链上有ACL条目时，以匹配当前用户（用户名或用户组）的条目授予的权限为准，
+//     子目录继承所有上级目录的授权
+//   - 权限逐级包含：manage 包含 upload，upload 包含 read
+//   - 目录列表中不显示没有浏览权限的目录，其下可以浏览的子目录提升到上级目录中（见ListMode）
+//
+// 使用API密钥时，还要同时满足密钥的权限范围和目录限制。
+package acl
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/apikey"
+	"fileshare/common"
+	"fileshare/models"
+	"fileshare/user"
+)
+
+// 授权对象类型
+const (
+	SubjectUser  = "user"
+	SubjectGroup = "group"
+)
+
+// 权限等级
+var permissionLevels = map[string]int{
+	user.PermRead:   1,
+	user.PermUpload: 2,
+	user.PermManage: 3,
+}
+
+// IsValidPermission 判断ACL权限是否有效
+func IsValidPermission(perm string) bool {
+	_, exists := permissionLevels[perm]
+	return exists
+}
+
+// IsValidSubjectType 判断授权对象类型是否有效
+func IsValidSubjectType(subjectType string) bool {
+	return subjectType == SubjectUser || subjectType == SubjectGroup
+}
+
+// Allows 判断用户对目录是否拥有指定权限（不考虑API密钥）
+func Allows(u *models.User, dirID, perm string) bool {
+	if u == nil {
+		return false
+	}
+	if user.HasPermission(u, user.PermAdmin) {
+		return true
+	}
+
+	chain := common.FindDirectoryChain(models.Directories, dirID)
+	level, restricted := grantedLevel(u, chain)
+	if !restricted {
+		return user.HasPermission(u, perm)
+	}
+
+	required, exists := permissionLevels[perm]
+	return exists && level >= required
+}
+
+// CanAccess 判断当前请求对目录是否拥有指定权限，同时检查用户ACL和API密钥限制
+func CanAccess(c *gin.Context, dirID, perm string) bool {
+	return Check(user.Current(c), apikey.Current(c), dirID, perm)
+}
+
+// Check 判断用户对目录是否拥有指定权限，k为用户使用的API密钥（未使用时为nil）
+func Check(u *models.User, k *models.APIKey, dirID, perm string) bool {
+	return Allows(u, dirID, perm) &&
+		apikey.AllowsPermission(k, perm) &&
+		apikey.AllowsDirectory(k, dirID)
+}
+
+// CheckRole 按用户角色和API密钥检查不针对具体目录的权限，限制了目录的API密钥没有这类权限
+func CheckRole(u *models.User, k *models.APIKey, perm string) bool {
+	return user.HasPermission(u, perm) &&
+		apikey.AllowsPermission(k, perm) &&
+		(k == nil || k.DirectoryID == "")
+}
+
+// ListMode 目录在管理端列表中的显示方式：拥有指定权限时显示，
+// 否则不显示目录本身，其下有权限的子目录提升到上级目录中（管理API、WebDAV、SFTP和目录归档相同）
+func ListMode(u *models.User, k *models.APIKey, dir *models.Directory, perm string) common.DirMode {
+	if Check(u, k, dir.ID, perm) {
+		return common.DirShown
+	}
+	return common.DirElided
+}
+
+// FilterDirectories 返回当前请求拥有指定权限的目录树（已去掉密码哈希），规则见ListMode
+func FilterDirectories(c *gin.Context, dirs []*models.Directory, perm string) []*models.Directory {
+	u, k := user.Current(c), apikey.Current(c)
+	result := []*models.Directory{}
+	for _, dir := range dirs {
+		children := FilterDirectories(c, dir.Children, perm)
+		if ListMode(u, k, dir, perm) != common.DirShown {
+			result = append(result, children...)
+			continue
+		}
+
+		copied := *dir
+		copied.Password = ""
+		copied.HasPassword = dir.Password != ""
+		copied.Children = nil
+		if len(children) > 0 {
+			copied.Children = children
+		}
+		result = append(result, &copied)
+	}
+	return result
+}
+
+// CanAccessTree 判断当前请求对目录及其所有子目录是否都拥有指定权限
+func CanAccessTree(c *gin.Context, dir *models.Directory, perm string) bool {
+	return CheckTree(user.Current(c), apikey.Current(c), dir, perm)
+}
+
+// CheckTree 判断用户对目录及其所有子目录是否都拥有指定权限
+func CheckTree(u *models.User, k *models.APIKey, dir *models.Directory, perm string) bool {
+	if !Check(u, k, dir.ID, perm) {
+		return false
+	}
+	for _, child := range dir.Children {
+		if !CheckTree(u, k, child, perm) {
+			return false
+		}
+	}
+	return true
+}
+
+// 计算目录链上授予用户的最高权限等级，restricted表示目录链上是否存在ACL条目
+func grantedLevel(u *models.User, chain []*models.Directory) (level int, restricted bool) {
+	for _, dir := range chain {
+		for _, entry := range dir.ACL {
+			restricted = true
+			if !matches(u, entry) {
+				continue
+			}
+			for _, perm := range entry.Permissions {
+				if permissionLevels[perm] > level {
+					level = permissionLevels[perm]
+				}
+			}
+		}
+	}
+	return level, restricted
+}
+
+// 判断ACL条目是否适用于用户
+func matches(u *models.User, entry *models.ACLEntry) bool {
+	switch entry.Type {
+	case SubjectUser:
+		return strings.EqualFold(entry.Subject, u.Username)
+	case SubjectGroup:
+		for _, group := range u.Groups {
+			if group == entry.Subject {
+				return true
+			}
+		}
+	}
+	return false
+}
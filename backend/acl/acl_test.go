@@ -0,0 +1,115 @@
+package acl
+
+import (
+	"testing"
+
+	"fileshare/common"
+	"fileshare/models"
+	"fileshare/user"
+)
+
+// 测试用的目录树：
+//
+//	open            没有ACL
+//	  └─ open-sub
+//	team            group:dev read，user:alice manage
+//	  ├─ team-sub   group:ops upload
+//	  │   └─ deep
+//	  └─ team-other
+func setupDirectories(t *testing.T) {
+	saved := models.Directories
+	t.Cleanup(func() { models.Directories = saved })
+
+	models.Directories = []*models.Directory{
+		{ID: "open", Children: []*models.Directory{
+			{ID: "open-sub", ParentID: "open"},
+		}},
+		{ID: "team", ACL: []*models.ACLEntry{
+			{Type: SubjectGroup, Subject: "dev", Permissions: []string{user.PermRead}},
+			{Type: SubjectUser, Subject: "Alice", Permissions: []string{user.PermManage}},
+		}, Children: []*models.Directory{
+			{ID: "team-sub", ParentID: "team", ACL: []*models.ACLEntry{
+				{Type: SubjectGroup, Subject: "ops", Permissions: []string{user.PermUpload}},
+			}, Children: []*models.Directory{
+				{ID: "deep", ParentID: "team-sub"},
+			}},
+			{ID: "team-other", ParentID: "team"},
+		}},
+	}
+}
+
+func TestAllows(t *testing.T) {
+	setupDirectories(t)
+
+	admin := &models.User{Username: "root", Role: user.RoleAdmin}
+	viewer := &models.User{Username: "bob", Role: user.RoleViewer}
+	editor := &models.User{Username: "carol", Role: user.RoleEditor}
+	dev := &models.User{Username: "dave", Role: user.RoleViewer, Groups: []string{"dev"}}
+	ops := &models.User{Username: "olga", Role: user.RoleEditor, Groups: []string{"ops"}}
+	alice := &models.User{Username: "alice", Role: user.RoleViewer}
+
+	tests := []struct {
+		name  string
+		user  *models.User
+		dirID string
+		perm  string
+		want  bool
+	}{
+		{"未登录", nil, "open", user.PermRead, false},
+		{"管理员不受ACL限制", admin, "deep", user.PermManage, true},
+		{"没有ACL时按角色：查看者可以浏览", viewer, "open-sub", user.PermRead, true},
+		{"没有ACL时按角色：查看者不能上传", viewer, "open-sub", user.PermUpload, false},
+		{"没有ACL时按角色：编辑可以管理", editor, "open", user.PermManage, true},
+		{"有ACL时角色不再授权", editor, "team", user.PermRead, false},
+		{"用户组授权", dev, "team", user.PermRead, true},
+		{"用户组授权不包含上传", dev, "team", user.PermUpload, false},
+		{"子目录继承上级目录的授权", dev, "deep", user.PermRead, true},
+		{"子目录的ACL不影响上级目录的授权", dev, "team-sub", user.PermUpload, false},
+		{"只在子目录授权", ops, "team-sub", user.PermUpload, true},
+		{"子目录的授权不适用于上级目录", ops, "team", user.PermRead, false},
+		{"子目录的授权不适用于兄弟目录", ops, "team-other", user.PermRead, false},
+		{"上传包含浏览", ops, "deep", user.PermRead, true},
+		{"上传不包含管理", ops, "deep", user.PermManage, false},
+		{"用户名不区分大小写", alice, "team", user.PermManage, true},
+		{"管理权限继承到子目录", alice, "deep", user.PermManage, true},
+		{"无效的权限", alice, "team", "bogus", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Allows(tt.user, tt.dirID, tt.perm); got != tt.want {
+				t.Errorf("Allows(%s, %s) = %v, want %v", tt.dirID, tt.perm, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListMode(t *testing.T) {
+	setupDirectories(t)
+
+	ops := &models.User{Username: "olga", Role: user.RoleEditor, Groups: []string{"ops"}}
+	restricted := &models.APIKey{Scopes: []string{user.PermRead}, DirectoryID: "deep"}
+
+	tests := []struct {
+		name  string
+		key   *models.APIKey
+		dirID string
+		want  common.DirMode
+	}{
+		{"有权限的目录显示", nil, "open", common.DirShown},
+		{"没有权限的上级目录不显示，子目录提升", nil, "team", common.DirElided},
+		{"有权限的子目录显示", nil, "team-sub", common.DirShown},
+		{"API密钥限制的目录之外不显示", restricted, "team-sub", common.DirElided},
+		{"API密钥限制的目录显示", restricted, "deep", common.DirShown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dir *models.Directory
+			common.FindDirectory(models.Directories, tt.dirID, &dir)
+			if got := ListMode(ops, tt.key, dir, user.PermRead); got != tt.want {
+				t.Errorf("ListMode(%s) = %v, want %v", tt.dirID, got, tt.want)
+			}
+		})
+	}
+}
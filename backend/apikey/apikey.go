@@ -0,0 +1,398 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"fileshare/common"
+	"fileshare/models"
+	"fileshare/user"
+)
+
+// 配置文件路径
+const (
+	APIKeyConfigPath = "./config/config-apikey.json"
+)
+
+// API密钥前缀，便于和会话token区分
+const KeyPrefix = "fsk_"
+
+// API密钥请求头，也可以通过Authorization: Bearer传递
+const HeaderName = "X-API-Key"
+
+// 上下文中保存当前API密钥的键
+const ContextKey = "apikey"
+
+// 时间格式
+const timeLayout = "2006-01-02 15:04:05"
+
+// 最近使用时间的持久化间隔，避免每个请求都写文件
+const lastUsedSaveInterval = time.Minute
+
+// API密钥可以授予的权限范围
+var validScopes = []string{user.PermRead, user.PermUpload, user.PermManage, ScopeS3}
+
+// API密钥的锁（最近使用时间会被并发更新）
+var (
+	keysMu    sync.Mutex
+	lastSaved time.Time
+)
+
+// 加载API密钥配置
+func LoadAPIKeys() {
+	keysMu.Lock()
+	defer keysMu.Unlock()
+
+	models.APIKeys = []*models.APIKey{}
+
+	data, err := os.ReadFile(APIKeyConfigPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Failed to read API key config: %v", err)
+		}
+		return
+	}
+
+	if err := json.Unmarshal(data, &models.APIKeys); err != nil {
+		log.Printf("Failed to parse API key config: %v", err)
+		models.APIKeys = []*models.APIKey{}
+	}
+}
+
+// 保存API密钥配置（调用方需持有锁）
+func saveAPIKeys() error {
+	data, err := json.MarshalIndent(models.APIKeys, "", "  ")
+	if err != nil {
+		return err
+	}
+	lastSaved = time.Now()
+	return os.WriteFile(APIKeyConfigPath, data, 0600)
+}
+
+// 计算密钥哈希
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsAPIKey 判断凭据是否为API密钥格式
+func IsAPIKey(credential string) bool {
+	return strings.HasPrefix(credential, KeyPrefix)
+}
+
+// Authenticate 校验API密钥，成功时记录最近使用时间并返回密钥信息的副本
+func Authenticate(key, clientIP string) (*models.APIKey, bool) {
+	hash := hashKey(key)
+	now := time.Now()
+
+	keysMu.Lock()
+	defer keysMu.Unlock()
+
+	for _, k := range models.APIKeys {
+		if subtle.ConstantTimeCompare([]byte(k.KeyHash), []byte(hash)) != 1 {
+			continue
+		}
+		if k.Revoked || isExpired(k, now) {
+			return nil, false
+		}
+
+		touch(k, clientIP, now)
+		copied := *k
+		return &copied, true
+	}
+	return nil, false
+}
+
+// FindByID 根据ID查找有效（未吊销、未过期）的API密钥，返回密钥信息的副本。
+// 用于在长时间保持的连接（如SFTP）中重新检查认证时使用的密钥
+func FindByID(id string) (*models.APIKey, bool) {
+	keysMu.Lock()
+	defer keysMu.Unlock()
+
+	for _, k := range models.APIKeys {
+		if k.ID != id {
+			continue
+		}
+		if k.Revoked || isExpired(k, time.Now()) {
+			return nil, false
+		}
+		copied := *k
+		return &copied, true
+	}
+	return nil, false
+}
+
+// 记录最近使用时间和IP，按间隔持久化（调用方需持有锁）
+func touch(k *models.APIKey, clientIP string, now time.Time) {
+	k.LastUsedAt = now.Format(timeLayout)
+	k.LastUsedIP = clientIP
+	if now.Sub(lastSaved) > lastUsedSaveInterval {
+		if err := saveAPIKeys(); err != nil {
+			log.Printf("Failed to save API key config: %v", err)
+		}
+	}
+}
+
+// 判断密钥是否过期
+func isExpired(k *models.APIKey, now time.Time) bool {
+	if k.ExpiresAt == "" {
+		return false
+	}
+	expiresAt, err := time.ParseInLocation(timeLayout, k.ExpiresAt, time.Local)
+	return err != nil || now.After(expiresAt)
+}
+
+// Current 获取当前请求使用的API密钥，使用会话登录时返回nil
+func Current(c *gin.Context) *models.APIKey {
+	if value, exists := c.Get(ContextKey); exists {
+		if k, ok := value.(*models.APIKey); ok {
+			return k
+		}
+	}
+	return nil
+}
+
+// AllowsPermission 判断密钥的权限范围是否包含指定权限，k为nil表示非密钥请求
+func AllowsPermission(k *models.APIKey, perm string) bool {
+	if k == nil {
+		return true
+	}
+	for _, scope := range k.Scopes {
+		if scope == perm {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsDirectory 判断密钥是否可以访问目录，限制目录时只能访问该目录及其子目录
+func AllowsDirectory(k *models.APIKey, dirID string) bool {
+	if k == nil || k.DirectoryID == "" {
+		return true
+	}
+	for _, dir := range common.FindDirectoryChain(models.Directories, dirID) {
+		if dir.ID == k.DirectoryID {
+			return true
+		}
+	}
+	return false
+}
+
+// 复制密钥信息用于接口响应，去掉密钥哈希和S3私有访问密钥
+func public(k *models.APIKey) *models.APIKey {
+	copied := *k
+	copied.KeyHash = ""
+	copied.S3Secret = ""
+	return &copied
+}
+
+// 获取API密钥列表，管理员传入all=true时返回所有用户的密钥
+func GetAPIKeys(c *gin.Context) {
+	currentUser := user.Current(c)
+	all := c.Query("all") == "true" && user.HasPermission(currentUser, user.PermAdmin)
+
+	keysMu.Lock()
+	defer keysMu.Unlock()
+
+	result := []*models.APIKey{}
+	for _, k := range models.APIKeys {
+		if all || k.UserID == currentUser.ID {
+			result = append(result, public(k))
+		}
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// 创建API密钥，密钥明文（以及s3权限范围的S3访问密钥）只在创建时返回一次
+func CreateAPIKey(c *gin.Context) {
+	var req struct {
+		Name        string   `json:"name" binding:"required"`
+		Scopes      []string `json:"scopes" binding:"required"`
+		DirectoryID string   `json:"directoryId"`
+		ExpiresAt   string   `json:"expiresAt"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	currentUser := user.Current(c)
+
+	// 权限范围必须有效，并且不能超过当前用户的权限
+	if len(req.Scopes) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one scope is required"})
+		return
+	}
+	for _, scope := range req.Scopes {
+		if !isValidScope(scope) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid scope: " + scope})
+			return
+		}
+		// s3权限范围没有对应的角色权限，需要管理员授予
+		required := scope
+		if scope == ScopeS3 {
+			required = user.PermAdmin
+		}
+		if !user.HasPermission(currentUser, required) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Scope exceeds your permissions: " + scope})
+			return
+		}
+	}
+
+	if req.DirectoryID != "" {
+		var dir *models.Directory
+		common.FindDirectory(models.Directories, req.DirectoryID, &dir)
+		if dir == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Directory not found"})
+			return
+		}
+	}
+
+	if req.ExpiresAt != "" {
+		expiresAt, err := time.ParseInLocation(timeLayout, req.ExpiresAt, time.Local)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid expiresAt, expected format " + timeLayout})
+			return
+		}
+		if expiresAt.Before(time.Now()) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "expiresAt must be in the future"})
+			return
+		}
+	}
+
+	key, err := generateKey()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate API key"})
+		return
+	}
+
+	newKey := &models.APIKey{
+		ID:          uuid.New().String(),
+		Name:        req.Name,
+		UserID:      currentUser.ID,
+		Prefix:      key[:len(KeyPrefix)+6],
+		KeyHash:     hashKey(key),
+		Scopes:      req.Scopes,
+		DirectoryID: req.DirectoryID,
+		ExpiresAt:   req.ExpiresAt,
+		CreatedAt:   time.Now().Format(timeLayout),
+	}
+
+	resp := gin.H{"key": key}
+	if AllowsPermission(newKey, ScopeS3) {
+		secret, err := newS3Secret(newKey)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate S3 credentials"})
+			return
+		}
+		resp["s3Credentials"] = s3Credentials(newKey, secret)
+	}
+
+	keysMu.Lock()
+	defer keysMu.Unlock()
+
+	models.APIKeys = append(models.APIKeys, newKey)
+
+	// 保存配置
+	if err := saveAPIKeys(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save API key"})
+		return
+	}
+
+	resp["apiKey"] = public(newKey)
+	c.JSON(http.StatusCreated, resp)
+}
+
+// 吊销API密钥，吊销后保留记录
+func RevokeAPIKey(c *gin.Context) {
+	keysMu.Lock()
+	defer keysMu.Unlock()
+
+	k := findOwnedKey(c, c.Param("id"))
+	if k == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+		return
+	}
+
+	k.Revoked = true
+
+	// 保存配置
+	if err := saveAPIKeys(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save API key"})
+		return
+	}
+
+	c.JSON(http.StatusOK, public(k))
+}
+
+// 删除API密钥
+func DeleteAPIKey(c *gin.Context) {
+	keysMu.Lock()
+	defer keysMu.Unlock()
+
+	k := findOwnedKey(c, c.Param("id"))
+	if k == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+		return
+	}
+
+	for i, existing := range models.APIKeys {
+		if existing == k {
+			models.APIKeys = append(models.APIKeys[:i], models.APIKeys[i+1:]...)
+			break
+		}
+	}
+
+	// 保存配置
+	if err := saveAPIKeys(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save API key"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "API key deleted successfully"})
+}
+
+// 查找当前用户可以管理的密钥，管理员可以管理所有密钥（调用方需持有锁）
+func findOwnedKey(c *gin.Context, id string) *models.APIKey {
+	currentUser := user.Current(c)
+	for _, k := range models.APIKeys {
+		if k.ID == id && (k.UserID == currentUser.ID || user.HasPermission(currentUser, user.PermAdmin)) {
+			return k
+		}
+	}
+	return nil
+}
+
+// 判断权限范围是否有效
+func isValidScope(scope string) bool {
+	for _, valid := range validScopes {
+		if scope == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// 生成随机密钥
+func generateKey() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return KeyPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
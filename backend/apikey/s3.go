@@ -0,0 +1,124 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/models"
+	"fileshare/user"
+	"fileshare/utils"
+)
+
+// S3访问密钥属于包含s3权限范围的API密钥（见s3包）：访问密钥ID来自API密钥的ID，私有访问密钥随机生成，
+// 签名验证需要明文，因此使用服务器密钥加密保存，只在创建API密钥或轮换时返回一次。
+// API密钥被吊销、过期或删除后，对应的S3访问密钥同时失效
+
+// ScopeS3 访问S3兼容接口的权限范围，只有管理员可以授予
+const ScopeS3 = "s3"
+
+// AccessKeyID API密钥对应的S3访问密钥ID
+func AccessKeyID(k *models.APIKey) string {
+	return "FS" + strings.ToUpper(strings.ReplaceAll(k.ID, "-", ""))
+}
+
+// 生成新的S3私有访问密钥，返回明文并在k中保存密文（调用方需持有锁）
+func newS3Secret(k *models.APIKey) (string, error) {
+	buf := make([]byte, 20)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	secret := hex.EncodeToString(buf)
+	encrypted, err := utils.Encrypt(secret)
+	if err != nil {
+		return "", err
+	}
+	k.S3Secret = encrypted
+	return secret, nil
+}
+
+// 接口响应中的S3访问密钥
+func s3Credentials(k *models.APIKey, secret string) gin.H {
+	return gin.H{
+		"accessKeyId":     AccessKeyID(k),
+		"secretAccessKey": secret,
+	}
+}
+
+// FindByAccessKey 根据S3访问密钥ID查找有效的API密钥，返回密钥信息的副本和私有访问密钥
+func FindByAccessKey(accessKeyID string) (*models.APIKey, string, bool) {
+	now := time.Now()
+
+	keysMu.Lock()
+	defer keysMu.Unlock()
+
+	for _, k := range models.APIKeys {
+		if AccessKeyID(k) != accessKeyID {
+			continue
+		}
+		if k.Revoked || isExpired(k, now) || k.S3Secret == "" || !AllowsPermission(k, ScopeS3) {
+			return nil, "", false
+		}
+		secret, err := utils.Decrypt(k.S3Secret)
+		if err != nil {
+			log.Printf("Failed to decrypt S3 secret of API key %s: %v", k.ID, err)
+			return nil, "", false
+		}
+		copied := *k
+		return &copied, secret, true
+	}
+	return nil, "", false
+}
+
+// MarkUsed 签名验证通过后记录密钥的最近使用时间
+func MarkUsed(id, clientIP string) {
+	keysMu.Lock()
+	defer keysMu.Unlock()
+
+	for _, k := range models.APIKeys {
+		if k.ID == id {
+			touch(k, clientIP, time.Now())
+			return
+		}
+	}
+}
+
+// 轮换API密钥对应的S3私有访问密钥，旧的私有访问密钥立即失效，新的只在这里返回一次
+func RotateS3Credentials(c *gin.Context) {
+	if !user.HasPermission(user.Current(c), user.PermAdmin) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Only administrators can manage S3 credentials"})
+		return
+	}
+
+	keysMu.Lock()
+	defer keysMu.Unlock()
+
+	k := findOwnedKey(c, c.Param("id"))
+	if k == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+		return
+	}
+	if k.Revoked || !AllowsPermission(k, ScopeS3) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "API key does not have the s3 scope"})
+		return
+	}
+
+	secret, err := newS3Secret(k)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate S3 credentials"})
+		return
+	}
+
+	// 保存配置
+	if err := saveAPIKeys(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save API key"})
+		return
+	}
+
+	c.JSON(http.StatusOK, s3Credentials(k, secret))
+}
@@ -0,0 +1,89 @@
+package apikey
+
+import (
+	"testing"
+	"time"
+
+	"fileshare/models"
+	"fileshare/utils"
+)
+
+func TestFindByAccessKey(t *testing.T) {
+	keyWithSecret := func(id string, scopes []string) (*models.APIKey, string) {
+		k := &models.APIKey{ID: id, Scopes: scopes}
+		secret, err := newS3Secret(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return k, secret
+	}
+
+	valid, validSecret := keyWithSecret("11111111-aaaa", []string{"read", ScopeS3})
+	noScope, _ := keyWithSecret("22222222-bbbb", []string{"read"})
+	revoked, _ := keyWithSecret("33333333-cccc", []string{"read", ScopeS3})
+	revoked.Revoked = true
+	expired, _ := keyWithSecret("44444444-dddd", []string{"read", ScopeS3})
+	expired.ExpiresAt = time.Now().Add(-time.Hour).Format(timeLayout)
+	noSecret := &models.APIKey{ID: "55555555-eeee", Scopes: []string{"read", ScopeS3}}
+	corrupt, _ := keyWithSecret("66666666-ffff", []string{"read", ScopeS3})
+	corrupt.S3Secret = "AAAA" + corrupt.S3Secret[4:]
+
+	models.APIKeys = []*models.APIKey{valid, noScope, revoked, expired, noSecret, corrupt}
+	defer func() { models.APIKeys = nil }()
+
+	tests := []struct {
+		name   string
+		key    *models.APIKey
+		secret string
+		found  bool
+	}{
+		{"有效", valid, validSecret, true},
+		{"没有s3权限范围", noScope, "", false},
+		{"已吊销", revoked, "", false},
+		{"已过期", expired, "", false},
+		{"没有生成私有访问密钥", noSecret, "", false},
+		{"密文无法解密", corrupt, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k, secret, found := FindByAccessKey(AccessKeyID(tt.key))
+			if found != tt.found || secret != tt.secret {
+				t.Fatalf("FindByAccessKey() = %q, %v, want %q, %v", secret, found, tt.secret, tt.found)
+			}
+			if found && k.ID != tt.key.ID {
+				t.Errorf("FindByAccessKey() returned key %s", k.ID)
+			}
+		})
+	}
+
+	if _, _, found := FindByAccessKey("FSUNKNOWN"); found {
+		t.Error("unknown access key found")
+	}
+}
+
+func TestNewS3SecretRotates(t *testing.T) {
+	k := &models.APIKey{ID: "rotate"}
+	first, err := newS3Secret(k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted := k.S3Secret
+	second, err := newS3Secret(k)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first == second || encrypted == k.S3Secret {
+		t.Error("rotation did not change the secret")
+	}
+	if len(second) != 40 {
+		t.Errorf("secret length = %d, want 40", len(second))
+	}
+	if decrypted, err := utils.Decrypt(k.S3Secret); err != nil || decrypted != second {
+		t.Errorf("stored secret = %q, %v", decrypted, err)
+	}
+	if public(k).S3Secret != "" {
+		t.Error("public() exposes the encrypted secret")
+	}
+}
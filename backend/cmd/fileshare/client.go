@@ -0,0 +1,178 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"fileshare/models"
+)
+
+// API密钥请求头
+const apiKeyHeader = "X-API-Key"
+
+// 未登录或登录已过期
+var errUnauthorized = errors.New("未登录或登录已过期，请先执行 fileshare login")
+
+// 管理API客户端
+type client struct {
+	server string // 管理端地址，不以/结尾
+	token  string
+	apiKey string
+	http   *http.Client
+}
+
+// 根据配置创建客户端
+func newClient(profile *Profile) (*client, error) {
+	if profile.Server == "" {
+		return nil, errors.New("没有配置服务器地址，请先执行 fileshare login -server URL")
+	}
+	if profile.Token == "" && profile.APIKey == "" {
+		return nil, errUnauthorized
+	}
+	return &client{
+		server: strings.TrimRight(profile.Server, "/"),
+		token:  profile.Token,
+		apiKey: profile.APIKey,
+		http:   &http.Client{},
+	}, nil
+}
+
+// apiError 接口返回的错误
+type apiError struct {
+	Status  int
+	Message string `json:"error"`
+	Offset  *int64 `json:"offset"`      // 续传位置不一致时返回的当前位置
+	TOTP    bool   `json:"requireTotp"` // 登录需要两步验证码
+}
+
+func (e *apiError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("请求失败：%s", http.StatusText(e.Status))
+	}
+	return fmt.Sprintf("请求失败（%d）：%s", e.Status, e.Message)
+}
+
+// 创建请求，path为相对于管理端地址的路径（以/api开头）
+func (c *client) newRequest(method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, c.server+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if c.apiKey != "" {
+		req.Header.Set(apiKeyHeader, c.apiKey)
+	} else if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	return req, nil
+}
+
+// 发送请求，状态码不是2xx时把响应转换为apiError
+func (c *client) do(req *http.Request) (*http.Response, error) {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	return nil, readError(resp)
+}
+
+// 解析错误响应
+func readError(resp *http.Response) error {
+	apiErr := &apiError{Status: resp.StatusCode}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	json.Unmarshal(data, apiErr)
+	if resp.StatusCode == http.StatusUnauthorized && !apiErr.TOTP {
+		return errUnauthorized
+	}
+	return apiErr
+}
+
+// 发送JSON请求并解析JSON响应，body和out可以为nil
+func (c *client) call(method, path string, body, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := c.newRequest(method, path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// 登录，返回会话令牌。需要两步验证时返回requireTotp为true的apiError
+func login(server, username, password, code string) (*loginResponse, error) {
+	c := &client{server: strings.TrimRight(server, "/"), http: &http.Client{}}
+	resp := &loginResponse{}
+	err := c.call(http.MethodPost, "/api/admin/login", map[string]string{
+		"username": username,
+		"password": password,
+		"code":     code,
+	}, resp)
+	if errors.Is(err, errUnauthorized) {
+		return nil, errors.New("用户名或密码错误")
+	}
+	return resp, err
+}
+
+// 登录响应
+type loginResponse struct {
+	Token              string       `json:"token"`
+	ExpiresAt          string       `json:"expiresAt"`
+	User               *models.User `json:"user"`
+	MustChangePassword bool         `json:"mustChangePassword"`
+}
+
+// 目录树
+func (c *client) directories() ([]*models.Directory, error) {
+	dirs := []*models.Directory{}
+	err := c.call(http.MethodGet, "/api/directories", nil, &dirs)
+	return dirs, err
+}
+
+// 目录中的文件，dirID为空时返回根目录下的文件
+func (c *client) files(dirID string) ([]*models.File, error) {
+	path := "/api/files"
+	if dirID != "" {
+		path += "?directoryId=" + url.QueryEscape(dirID)
+	}
+	all := []*models.File{}
+	if err := c.call(http.MethodGet, path, nil, &all); err != nil {
+		return nil, err
+	}
+
+	files := []*models.File{}
+	for _, f := range all {
+		if f.DirectoryID == dirID {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
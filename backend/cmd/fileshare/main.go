@@ -0,0 +1,525 @@
+// fileshare 是FileShare管理API的命令行客户端，用于在脚本中管理目录和文件。
+//
+// 用法：
+//
+//	go build -o fileshare ./cmd/fileshare
+//	fileshare login -server http://localhost:8080/fileshare -username admin
+//	fileshare mkdir -p /文档/2024
+//	fileshare upload report.pdf /文档/2024
+//	fileshare ls -l /文档/2024
+//	fileshare download /文档/2024/report.pdf
+//
+// 远程路径由目录名和文件名组成，以/分隔，根目录为/。
+// 连接配置保存在用户配置目录下的fileshare/profile.json中，可以用-config参数或FILESHARE_CONFIG环境变量指定其他文件，
+// 一个文件中可以保存多个配置，用-profile参数或FILESHARE_PROFILE环境变量选择（默认为default）。
+// FILESHARE_SERVER和FILESHARE_API_KEY环境变量会覆盖配置中的服务器地址和API密钥。
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"fileshare/models"
+)
+
+// 命令
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(app *app, args []string) error
+}
+
+var commands []*command
+
+func init() {
+	commands = []*command{
+		{"login", "[-server URL] [-username NAME] [-api-key KEY]", "登录并保存到配置中，指定-api-key时保存API密钥", runLogin},
+		{"logout", "", "退出登录并从配置中删除令牌和API密钥", runLogout},
+		{"ls", "[-l] [PATH]", "列出目录中的子目录和文件", runList},
+		{"mkdir", "[-p] PATH", "创建目录，-p同时创建不存在的上级目录", runMkdir},
+		{"upload", "[-q] LOCAL... DIR", "上传文件到目录，中断后再次执行同样的命令会继续上传", runUpload},
+		{"download", "[-q] [-f] PATH [LOCAL]", "下载文件，中断后再次执行同样的命令会继续下载", runDownload},
+		{"share", "PATH", "共享目录或文件", runShare},
+		{"unshare", "PATH", "取消共享目录或文件", runUnshare},
+		{"set-password", "[-clear] PATH", "设置目录的访问密码（从标准输入读取），-clear清除密码", runSetPassword},
+		{"delete", "PATH", "删除目录或文件", runDelete},
+	}
+}
+
+// 运行时的配置
+type app struct {
+	profilePath string
+	profileName string
+	profiles    *profileFile
+	profile     *Profile // 应用了环境变量的配置，不会写回文件
+	stdin       *bufio.Reader
+}
+
+func main() {
+	flag.Usage = usage
+	configFlag := flag.String("config", "", "配置文件路径（默认为用户配置目录下的fileshare/profile.json）")
+	profileFlag := flag.String("profile", "", "使用的配置名称（默认为default）")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+
+	var cmd *command
+	for _, c := range commands {
+		if c.name == flag.Arg(0) {
+			cmd = c
+		}
+	}
+	if cmd == nil {
+		fmt.Fprintf(os.Stderr, "fileshare: 未知命令 %s\n", flag.Arg(0))
+		usage()
+		os.Exit(2)
+	}
+
+	a, err := newApp(*configFlag, *profileFlag)
+	if err == nil {
+		err = cmd.run(a, flag.Args()[1:])
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "fileshare %s: %v\n", cmd.name, err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "用法：fileshare [-config FILE] [-profile NAME] COMMAND [ARGS]\n\n命令：\n")
+	for _, c := range commands {
+		fmt.Fprintf(os.Stderr, "  %-13s %s\n      %s\n", c.name, c.args, c.summary)
+	}
+	fmt.Fprintf(os.Stderr, "\n全局参数：\n")
+	flag.PrintDefaults()
+}
+
+func newApp(configFlag, profileFlag string) (*app, error) {
+	path, err := profilePath(configFlag)
+	if err != nil {
+		return nil, err
+	}
+	profiles, err := loadProfiles(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取配置文件失败：%w", err)
+	}
+
+	name := profileFlag
+	if name == "" {
+		name = os.Getenv(profileEnv)
+	}
+	if name == "" {
+		name = defaultProfile
+	}
+
+	profile := *profiles.get(name)
+	profile.applyEnv()
+	return &app{
+		profilePath: path,
+		profileName: name,
+		profiles:    profiles,
+		profile:     &profile,
+		stdin:       bufio.NewReader(os.Stdin),
+	}, nil
+}
+
+// 创建客户端
+func (a *app) client() (*client, error) {
+	return newClient(a.profile)
+}
+
+// 修改保存的配置
+func (a *app) saveProfile(update func(profile *Profile)) error {
+	saved, ok := a.profiles.Profiles[a.profileName]
+	if !ok {
+		saved = &Profile{}
+		a.profiles.Profiles[a.profileName] = saved
+	}
+	update(saved)
+	return a.profiles.save(a.profilePath)
+}
+
+// 查找要修改的目录或文件，不能是根目录
+func (a *app) lookup(path string) (*client, *entry, error) {
+	c, err := a.client()
+	if err != nil {
+		return nil, nil, err
+	}
+	r, err := newResolver(c)
+	if err != nil {
+		return nil, nil, err
+	}
+	e, err := r.lookup(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if e.isDir() && e.dir == nil {
+		return nil, nil, errors.New("不能修改根目录")
+	}
+	return c, e, nil
+}
+
+// 在标准错误中显示提示并从标准输入读取一行，输入不会隐藏
+func (a *app) prompt(label string) (string, error) {
+	fmt.Fprint(os.Stderr, label)
+	line, err := a.stdin.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", fmt.Errorf("读取输入失败：%w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// 解析命令参数，参数数量不在[minArgs, maxArgs]范围内时返回错误（maxArgs为-1表示不限制）
+func parseArgs(fs *flag.FlagSet, args []string, minArgs, maxArgs int) error {
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() < minArgs || (maxArgs >= 0 && fs.NArg() > maxArgs) {
+		return errors.New("参数数量错误，使用 fileshare -h 查看用法")
+	}
+	return nil
+}
+
+func runLogin(a *app, args []string) error {
+	fs := flag.NewFlagSet("login", flag.ContinueOnError)
+	server := fs.String("server", a.profile.Server, "管理端地址，包含上下文路径，例如 http://localhost:8080/fileshare")
+	username := fs.String("username", a.profile.Username, "用户名")
+	apiKey := fs.String("api-key", "", "使用API密钥代替用户名和密码")
+	if err := parseArgs(fs, args, 0, 0); err != nil {
+		return err
+	}
+	if *server == "" {
+		return errors.New("请使用-server指定服务器地址")
+	}
+
+	// 使用API密钥时检查密钥是否有效
+	if *apiKey != "" {
+		c, err := newClient(&Profile{Server: *server, APIKey: *apiKey})
+		if err != nil {
+			return err
+		}
+		if _, err := c.directories(); errors.Is(err, errUnauthorized) {
+			return errors.New("API密钥无效")
+		} else if err != nil {
+			return err
+		}
+		return a.saveProfile(func(profile *Profile) {
+			*profile = Profile{Server: *server, APIKey: *apiKey}
+		})
+	}
+
+	if *username == "" {
+		value, err := a.prompt("Username: ")
+		if err != nil {
+			return err
+		}
+		*username = value
+	}
+	password, err := a.prompt("Password: ")
+	if err != nil {
+		return err
+	}
+
+	resp, err := login(*server, *username, password, "")
+	var apiErr *apiError
+	if errors.As(err, &apiErr) && apiErr.TOTP {
+		code, err := a.prompt("两步验证码或恢复码: ")
+		if err != nil {
+			return err
+		}
+		resp, err = login(*server, *username, password, code)
+		if err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	if err := a.saveProfile(func(profile *Profile) {
+		*profile = Profile{Server: *server, Username: *username, Token: resp.Token, ExpiresAt: resp.ExpiresAt}
+	}); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "已登录为 %s，会话有效期至 %s\n", *username, resp.ExpiresAt)
+	if resp.MustChangePassword {
+		fmt.Fprintln(os.Stderr, "需要先在网页中修改密码才能使用管理功能")
+	}
+	return nil
+}
+
+func runLogout(a *app, args []string) error {
+	if err := parseArgs(flag.NewFlagSet("logout", flag.ContinueOnError), args, 0, 0); err != nil {
+		return err
+	}
+
+	// 服务器端的会话失效失败时仍然删除本地保存的令牌
+	if saved := a.profiles.get(a.profileName); saved.Token != "" {
+		if c, err := newClient(&Profile{Server: saved.Server, Token: saved.Token}); err == nil {
+			if err := c.call(http.MethodPost, "/api/admin/logout", nil, nil); err != nil {
+				fmt.Fprintf(os.Stderr, "退出登录失败：%v\n", err)
+			}
+		}
+	}
+	return a.saveProfile(func(profile *Profile) {
+		profile.Token, profile.ExpiresAt, profile.APIKey = "", "", ""
+	})
+}
+
+func runList(a *app, args []string) error {
+	fs := flag.NewFlagSet("ls", flag.ContinueOnError)
+	long := fs.Bool("l", false, "显示大小、添加时间和共享状态")
+	if err := parseArgs(fs, args, 0, 1); err != nil {
+		return err
+	}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	r, err := newResolver(c)
+	if err != nil {
+		return err
+	}
+	e, err := r.lookup(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	shared := func(isShared bool) string {
+		if isShared {
+			return "shared"
+		}
+		return "-"
+	}
+
+	// 指定的是文件时只显示该文件
+	if !e.isDir() {
+		if *long {
+			fmt.Printf("%-6s %10d %19s %s\n", shared(e.file.IsShared), e.file.Size, e.file.AddTime, e.file.Name)
+		} else {
+			fmt.Println(e.file.Name)
+		}
+		return nil
+	}
+
+	children := r.tree
+	if e.dir != nil {
+		children = e.dir.Children
+	}
+	for _, dir := range children {
+		if *long {
+			fmt.Printf("%-6s %10s %19s %s/\n", shared(dir.IsShared), "-", "-", dir.Name)
+		} else {
+			fmt.Println(dir.Name + "/")
+		}
+	}
+
+	files, err := c.files(e.dirID())
+	if err != nil {
+		return err
+	}
+	for _, f := range files {
+		if *long {
+			fmt.Printf("%-6s %10d %19s %s\n", shared(f.IsShared), f.Size, f.AddTime, f.Name)
+		} else {
+			fmt.Println(f.Name)
+		}
+	}
+	return nil
+}
+
+func runMkdir(a *app, args []string) error {
+	fs := flag.NewFlagSet("mkdir", flag.ContinueOnError)
+	parents := fs.Bool("p", false, "同时创建不存在的上级目录，目录已存在时不报错")
+	if err := parseArgs(fs, args, 1, 1); err != nil {
+		return err
+	}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	r, err := newResolver(c)
+	if err != nil {
+		return err
+	}
+
+	parts := splitPath(fs.Arg(0))
+	if len(parts) == 0 {
+		return errors.New("不能创建根目录")
+	}
+
+	parentID, children := "", r.tree
+	for i, name := range parts {
+		current := "/" + strings.Join(parts[:i+1], "/")
+		dir, err := findChild(children, name, current)
+		if err != nil {
+			return err
+		}
+		last := i == len(parts)-1
+		if dir != nil {
+			if last && !*parents {
+				return fmt.Errorf("%s: 已存在", current)
+			}
+			parentID, children = dir.ID, dir.Children
+			continue
+		}
+		if !last && !*parents {
+			return fmt.Errorf("%s: 不存在", current)
+		}
+
+		created := &models.Directory{}
+		if err := c.call(http.MethodPost, "/api/directories", map[string]string{"name": name, "parentId": parentID}, created); err != nil {
+			return err
+		}
+		parentID, children = created.ID, nil
+	}
+	return nil
+}
+
+func runUpload(a *app, args []string) error {
+	fs := flag.NewFlagSet("upload", flag.ContinueOnError)
+	quiet := fs.Bool("q", false, "不显示进度")
+	if err := parseArgs(fs, args, 2, -1); err != nil {
+		return err
+	}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	r, err := newResolver(c)
+	if err != nil {
+		return err
+	}
+	dir, err := r.directory(fs.Arg(fs.NArg() - 1))
+	if err != nil {
+		return err
+	}
+
+	state := loadUploadState(a.profilePath)
+	for _, localPath := range fs.Args()[:fs.NArg()-1] {
+		if _, err := c.upload(state, localPath, dir, filepath.Base(localPath), *quiet); err != nil {
+			return fmt.Errorf("%s: %w", localPath, err)
+		}
+	}
+	return nil
+}
+
+func runDownload(a *app, args []string) error {
+	fs := flag.NewFlagSet("download", flag.ContinueOnError)
+	quiet := fs.Bool("q", false, "不显示进度")
+	force := fs.Bool("f", false, "覆盖已存在的本地文件")
+	if err := parseArgs(fs, args, 1, 2); err != nil {
+		return err
+	}
+	c, err := a.client()
+	if err != nil {
+		return err
+	}
+	r, err := newResolver(c)
+	if err != nil {
+		return err
+	}
+	e, err := r.lookup(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if e.isDir() {
+		return fmt.Errorf("%s: 不能下载目录", e.path)
+	}
+
+	// 本地路径是目录时保存到该目录中
+	target := fs.Arg(1)
+	if target == "" {
+		target = e.file.Name
+	} else if info, err := os.Stat(target); err == nil && info.IsDir() {
+		target = filepath.Join(target, e.file.Name)
+	}
+	if _, err := os.Stat(target); err == nil && !*force {
+		return fmt.Errorf("%s: 本地文件已存在，使用-f覆盖", target)
+	}
+
+	return c.download(e.file, target, *quiet)
+}
+
+// 修改目录或文件的共享状态
+func setShared(a *app, name string, args []string, isShared bool) error {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	if err := parseArgs(fs, args, 1, 1); err != nil {
+		return err
+	}
+	c, e, err := a.lookup(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	var path string
+	if e.isDir() {
+		path = "/api/directories/" + e.dir.ID + "/share"
+	} else {
+		path = "/api/files/" + e.file.ID + "/share"
+	}
+	return c.call(http.MethodPatch, path, map[string]bool{"isShared": isShared}, nil)
+}
+
+func runShare(a *app, args []string) error {
+	return setShared(a, "share", args, true)
+}
+
+func runUnshare(a *app, args []string) error {
+	return setShared(a, "unshare", args, false)
+}
+
+func runSetPassword(a *app, args []string) error {
+	fs := flag.NewFlagSet("set-password", flag.ContinueOnError)
+	clearPassword := fs.Bool("clear", false, "清除目录密码")
+	if err := parseArgs(fs, args, 1, 1); err != nil {
+		return err
+	}
+	c, e, err := a.lookup(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	if !e.isDir() {
+		return fmt.Errorf("%s: 只能为目录设置密码", e.path)
+	}
+
+	password := ""
+	if !*clearPassword {
+		if password, err = a.prompt("Password: "); err != nil {
+			return err
+		}
+		if password == "" {
+			return errors.New("密码不能为空，使用-clear清除密码")
+		}
+	}
+	return c.call(http.MethodPatch, "/api/directories/"+e.dir.ID+"/password", map[string]string{"password": password}, nil)
+}
+
+func runDelete(a *app, args []string) error {
+	fs := flag.NewFlagSet("delete", flag.ContinueOnError)
+	if err := parseArgs(fs, args, 1, 1); err != nil {
+		return err
+	}
+	c, e, err := a.lookup(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	var path string
+	if e.isDir() {
+		path = "/api/directories/" + e.dir.ID
+	} else {
+		path = "/api/files/" + e.file.ID
+	}
+	return c.call(http.MethodDelete, path, nil, nil)
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// 配置文件和配置名称的环境变量，优先级低于命令行参数
+const (
+	configEnv  = "FILESHARE_CONFIG"
+	profileEnv = "FILESHARE_PROFILE"
+	serverEnv  = "FILESHARE_SERVER"
+	apiKeyEnv  = "FILESHARE_API_KEY"
+)
+
+// 默认配置名称
+const defaultProfile = "default"
+
+// Profile 一个服务器的连接配置
+type Profile struct {
+	Server    string `json:"server"`              // 管理端地址，包含上下文路径，例如 http://localhost:8080/fileshare
+	Username  string `json:"username,omitempty"`  // 登录的用户名，仅用于显示和下次登录的默认值
+	Token     string `json:"token,omitempty"`     // 登录会话令牌
+	ExpiresAt string `json:"expiresAt,omitempty"` // 会话过期时间
+	APIKey    string `json:"apiKey,omitempty"`    // API密钥，设置后优先于登录会话
+}
+
+// 配置文件，可以保存多个服务器的配置
+type profileFile struct {
+	Profiles map[string]*Profile `json:"profiles"`
+}
+
+// 配置文件路径：-config参数、FILESHARE_CONFIG环境变量，默认为用户配置目录下的fileshare/profile.json
+func profilePath(flagValue string) (string, error) {
+	if flagValue != "" {
+		return flagValue, nil
+	}
+	if path := os.Getenv(configEnv); path != "" {
+		return path, nil
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "fileshare", "profile.json"), nil
+}
+
+// 读取配置文件，文件不存在时返回空配置
+func loadProfiles(path string) (*profileFile, error) {
+	profiles := &profileFile{Profiles: map[string]*Profile{}}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return profiles, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, profiles); err != nil {
+		return nil, err
+	}
+	if profiles.Profiles == nil {
+		profiles.Profiles = map[string]*Profile{}
+	}
+	return profiles, nil
+}
+
+// 保存配置文件，其中包含令牌和API密钥，只允许当前用户读取
+func (p *profileFile) save(path string) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// 获取指定名称的配置，不存在时返回空配置（不会写入文件）
+func (p *profileFile) get(name string) *Profile {
+	if profile, ok := p.Profiles[name]; ok {
+		return profile
+	}
+	return &Profile{}
+}
+
+// 应用环境变量中的服务器地址和API密钥，便于在脚本中不使用配置文件
+func (profile *Profile) applyEnv() {
+	if server := os.Getenv(serverEnv); server != "" {
+		profile.Server = server
+	}
+	if key := os.Getenv(apiKeyEnv); key != "" {
+		profile.APIKey = key
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// 进度的刷新间隔
+const progressInterval = 200 * time.Millisecond
+
+// 在标准错误输出中显示传输进度，标准错误不是终端或使用-q参数时不显示
+type progress struct {
+	name    string
+	total   int64
+	done    int64
+	start   time.Time
+	started int64 // 开始时已传输的字节数（续传），不计入速度
+	printed time.Time
+	out     io.Writer
+}
+
+// 标准错误是否为终端
+func stderrIsTerminal() bool {
+	info, err := os.Stderr.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func newProgress(name string, total, done int64, quiet bool) *progress {
+	p := &progress{name: name, total: total, done: done, started: done, start: time.Now()}
+	if !quiet && stderrIsTerminal() {
+		p.out = os.Stderr
+	}
+	return p
+}
+
+// 设置已传输的字节数（续传位置变化时）
+func (p *progress) set(done int64) {
+	p.done = done
+	p.print(false)
+}
+
+// 实现io.Writer，用于统计通过io.TeeReader传输的字节数
+func (p *progress) Write(b []byte) (int, error) {
+	p.done += int64(len(b))
+	p.print(false)
+	return len(b), nil
+}
+
+// 传输结束，输出最后一次进度并换行
+func (p *progress) finish() {
+	p.print(true)
+	if p.out != nil {
+		fmt.Fprintln(p.out)
+	}
+}
+
+func (p *progress) print(force bool) {
+	if p.out == nil || (!force && time.Since(p.printed) < progressInterval) {
+		return
+	}
+	p.printed = time.Now()
+
+	percent := 100.0
+	if p.total > 0 {
+		percent = float64(p.done) * 100 / float64(p.total)
+	}
+	const width = 30
+	filled := int(percent / 100 * width)
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", width-filled)
+
+	speed := ""
+	if elapsed := time.Since(p.start).Seconds(); elapsed > 0 {
+		speed = formatSize(int64(float64(p.done-p.started)/elapsed)) + "/s"
+	}
+	fmt.Fprintf(p.out, "\r%s [%s] %5.1f%% %s/%s %s\033[K",
+		p.name, bar, percent, formatSize(p.done), formatSize(p.total), speed)
+}
+
+// 格式化文件大小
+func formatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%dB", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"fileshare/models"
+)
+
+// 远程路径对应的目录或文件，根目录的dir和file都为nil
+type entry struct {
+	path string
+	dir  *models.Directory
+	file *models.File
+}
+
+// 是否为目录（包括根目录）
+func (e *entry) isDir() bool {
+	return e.file == nil
+}
+
+// 目录ID，根目录为空
+func (e *entry) dirID() string {
+	if e.dir == nil {
+		return ""
+	}
+	return e.dir.ID
+}
+
+// 按目录名和文件名组成的路径查找目录和文件，例如 /文档/2024/报告.pdf
+type resolver struct {
+	c    *client
+	tree []*models.Directory
+}
+
+func newResolver(c *client) (*resolver, error) {
+	tree, err := c.directories()
+	if err != nil {
+		return nil, err
+	}
+	return &resolver{c: c, tree: tree}, nil
+}
+
+// 拆分路径，忽略多余的/
+func splitPath(path string) []string {
+	parts := []string{}
+	for _, part := range strings.Split(path, "/") {
+		if part != "" && part != "." {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// 在子目录中按名称查找，同名目录有多个时返回错误
+func findChild(children []*models.Directory, name, path string) (*models.Directory, error) {
+	var found *models.Directory
+	for _, dir := range children {
+		if dir.Name != name {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("%s: 存在多个同名目录", path)
+		}
+		found = dir
+	}
+	return found, nil
+}
+
+// 查找目录，路径不存在或不是目录时返回错误
+func (r *resolver) directory(path string) (*entry, error) {
+	e, err := r.lookup(path)
+	if err != nil {
+		return nil, err
+	}
+	if !e.isDir() {
+		return nil, fmt.Errorf("%s: 不是目录", path)
+	}
+	return e, nil
+}
+
+// 查找目录或文件。最后一级名称同时匹配目录和文件时返回错误
+func (r *resolver) lookup(path string) (*entry, error) {
+	parts := splitPath(path)
+	e := &entry{path: "/"}
+	children := r.tree
+
+	for i, name := range parts {
+		current := "/" + strings.Join(parts[:i+1], "/")
+		dir, err := findChild(children, name, current)
+		if err != nil {
+			return nil, err
+		}
+
+		if i == len(parts)-1 {
+			file, err := r.file(e, name, current)
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case dir != nil && file != nil:
+				return nil, fmt.Errorf("%s: 同时存在同名的目录和文件", current)
+			case file != nil:
+				return &entry{path: current, dir: e.dir, file: file}, nil
+			}
+		}
+
+		if dir == nil {
+			return nil, fmt.Errorf("%s: 不存在", current)
+		}
+		e = &entry{path: current, dir: dir}
+		children = dir.Children
+	}
+	return e, nil
+}
+
+// 在目录中按名称查找文件，同名文件有多个时返回错误
+func (r *resolver) file(parent *entry, name, path string) (*models.File, error) {
+	files, err := r.c.files(parent.dirID())
+	if err != nil {
+		return nil, err
+	}
+	var found *models.File
+	for _, f := range files {
+		if f.Name != name {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("%s: 存在多个同名文件", path)
+		}
+		found = f
+	}
+	return found, nil
+}
+
+// 拆分为上级目录路径和最后一级名称
+func splitLast(path string) (string, string) {
+	parts := splitPath(path)
+	if len(parts) == 0 {
+		return "/", ""
+	}
+	return "/" + strings.Join(parts[:len(parts)-1], "/"), parts[len(parts)-1]
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"fileshare/models"
+)
+
+// 连接到测试服务器的客户端
+func newTestClient(t *testing.T, handler http.Handler) *client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &client{server: server.URL, token: "token", http: server.Client()}
+}
+
+// 返回JSON响应
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func TestResolverLookup(t *testing.T) {
+	// /
+	//   ├─ 文档/（报告.pdf，2024/）
+	//   │   └─ 2024/（总结.txt）
+	//   ├─ 照片/ 照片/（两个同名目录）
+	//   ├─ 备份/ 和 备份（同名的目录和文件）
+	//   ├─ 重复.txt 重复.txt（两个同名文件）
+	//   └─ 说明.txt
+	year := &models.Directory{ID: "2024", Name: "2024"}
+	docs := &models.Directory{ID: "docs", Name: "文档", Children: []*models.Directory{year}}
+	tree := []*models.Directory{
+		docs,
+		{ID: "photos1", Name: "照片"},
+		{ID: "photos2", Name: "照片"},
+		{ID: "backup", Name: "备份"},
+	}
+	files := []*models.File{
+		{ID: "readme", Name: "说明.txt"},
+		{ID: "backup-file", Name: "备份"},
+		{ID: "dup1", Name: "重复.txt"},
+		{ID: "dup2", Name: "重复.txt"},
+		{ID: "report", Name: "报告.pdf", DirectoryID: "docs"},
+		{ID: "summary", Name: "总结.txt", DirectoryID: "2024"},
+		// 接口返回子目录中的文件时只保留当前目录的文件
+		{ID: "nested", Name: "说明.txt", DirectoryID: "2024"},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/directories", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, tree)
+	})
+	mux.HandleFunc("GET /api/files", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, files)
+	})
+	r, err := newResolver(newTestClient(t, mux))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		dirID   string
+		fileID  string
+		wantErr bool
+	}{
+		{"根目录", "/", "", "", false},
+		{"空路径为根目录", "", "", "", false},
+		{"根目录下的文件", "/说明.txt", "", "readme", false},
+		{"目录", "/文档", "docs", "", false},
+		{"多余的/", "//文档/./2024/", "2024", "", false},
+		{"子目录中的文件", "/文档/报告.pdf", "docs", "report", false},
+		{"多级子目录中的文件", "文档/2024/总结.txt", "2024", "summary", false},
+		{"不存在的文件", "/文档/不存在.txt", "", "", true},
+		{"不存在的上级目录", "/不存在/说明.txt", "", "", true},
+		{"文件不能作为目录", "/说明.txt/其他", "", "", true},
+		{"同名目录", "/照片", "", "", true},
+		{"同名目录下的路径", "/照片/1.jpg", "", "", true},
+		{"同名文件", "/重复.txt", "", "", true},
+		{"同名的目录和文件", "/备份", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := r.lookup(tt.path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("lookup(%q) = %+v, want error", tt.path, e)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("lookup(%q) error = %v", tt.path, err)
+			}
+			fileID := ""
+			if e.file != nil {
+				fileID = e.file.ID
+			}
+			if e.dirID() != tt.dirID || fileID != tt.fileID {
+				t.Errorf("lookup(%q) = dir %q file %q, want dir %q file %q", tt.path, e.dirID(), fileID, tt.dirID, tt.fileID)
+			}
+		})
+	}
+}
+
+func TestSplitLast(t *testing.T) {
+	tests := []struct {
+		path   string
+		parent string
+		name   string
+	}{
+		{"/", "/", ""},
+		{"/a.txt", "/", "a.txt"},
+		{"a/b/c.txt", "/a/b", "c.txt"},
+		{"/a/b/", "/a", "b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if parent, name := splitLast(tt.path); parent != tt.parent || name != tt.name {
+				t.Errorf("splitLast(%q) = %q, %q, want %q, %q", tt.path, parent, name, tt.parent, tt.name)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"fileshare/models"
+)
+
+// 每次PATCH提交的最大字节数
+const uploadChunkSize = 8 << 20
+
+// 网络错误或服务器错误时的最大连续重试次数
+const maxRetries = 5
+
+// 续传位置请求头，和服务器端file.UploadOffsetHeader一致
+const uploadOffsetHeader = "Upload-Offset"
+
+// 等待一段时间后重试，等待时间随重试次数增加
+func backoff(attempt int) {
+	time.Sleep(time.Duration(attempt) * time.Second)
+}
+
+// 是否为可以重试的错误（网络错误或服务器错误）
+func retryable(err error) bool {
+	var apiErr *apiError
+	if errors.As(err, &apiErr) {
+		return apiErr.Status >= http.StatusInternalServerError
+	}
+	return !errors.Is(err, errUnauthorized)
+}
+
+// 未完成的上传，保存在配置文件所在目录中，重新执行同样的上传命令时继续上传
+type uploadState struct {
+	path    string
+	Uploads map[string]string `json:"uploads"` // 本地文件和目标目录 -> 上传ID
+}
+
+func loadUploadState(profilePath string) *uploadState {
+	state := &uploadState{path: filepath.Join(filepath.Dir(profilePath), "uploads.json"), Uploads: map[string]string{}}
+	if data, err := os.ReadFile(state.path); err == nil {
+		json.Unmarshal(data, state)
+	}
+	if state.Uploads == nil {
+		state.Uploads = map[string]string{}
+	}
+	return state
+}
+
+func (s *uploadState) save() error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0600)
+}
+
+// 上传的标识，本地文件修改后不会继续之前的上传
+func uploadKey(server, localPath string, info os.FileInfo, dirID, name string) string {
+	return strings.Join([]string{server, localPath, strconv.FormatInt(info.Size(), 10),
+		strconv.FormatInt(info.ModTime().UnixNano(), 10), dirID, name}, "\n")
+}
+
+// 上传文件到目录，中断后再次执行时从服务器已接收的位置继续
+func (c *client) upload(state *uploadState, localPath string, dir *entry, name string, quiet bool) (*models.File, error) {
+	absPath, err := filepath.Abs(localPath)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(absPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s: 不能上传目录", localPath)
+	}
+
+	// 继续之前未完成的上传，服务器上已不存在时重新开始
+	key := uploadKey(c.server, absPath, info, dir.dirID(), name)
+	upload := &models.Upload{}
+	if id := state.Uploads[key]; id != "" {
+		var apiErr *apiError
+		err := c.call(http.MethodGet, "/api/uploads/"+id, nil, upload)
+		if errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound {
+			upload.ID = ""
+		} else if err != nil {
+			return nil, err
+		}
+	}
+	if upload.ID == "" {
+		err := c.call(http.MethodPost, "/api/uploads", map[string]interface{}{
+			"directoryId": dir.dirID(),
+			"name":        name,
+			"size":        info.Size(),
+		}, upload)
+		if err != nil {
+			return nil, err
+		}
+		state.Uploads[key] = upload.ID
+		if err := state.save(); err != nil {
+			return nil, err
+		}
+	}
+
+	p := newProgress(name, info.Size(), upload.Offset, quiet)
+	defer p.finish()
+
+	offset, attempt := upload.Offset, 0
+	for {
+		file, next, err := c.appendUpload(f, upload.ID, offset, info.Size(), p)
+		if err == nil && file != nil {
+			delete(state.Uploads, key)
+			state.save()
+			return file, nil
+		}
+		if err == nil {
+			offset, attempt = next, 0
+			continue
+		}
+
+		// 续传位置不一致时使用服务器返回的位置，其他错误重试几次后放弃。
+		// 服务器拒绝了它自己返回的位置时不再继续，避免无限循环
+		var apiErr *apiError
+		if errors.As(err, &apiErr) && apiErr.Offset != nil {
+			mismatch := *apiErr.Offset != offset
+			offset = *apiErr.Offset
+			if apiErr.Status == http.StatusConflict && mismatch {
+				continue
+			}
+		}
+		if !retryable(err) || attempt >= maxRetries {
+			return nil, err
+		}
+		attempt++
+		backoff(attempt)
+
+		// 连接中断时无法确定服务器接收了多少字节，重新查询位置
+		current := &models.Upload{}
+		if err := c.call(http.MethodGet, "/api/uploads/"+upload.ID, nil, current); err == nil {
+			offset = current.Offset
+		}
+	}
+}
+
+// 从offset开始提交一段内容，上传完成时返回文件，否则返回新的位置
+func (c *client) appendUpload(f *os.File, id string, offset, size int64, p *progress) (*models.File, int64, error) {
+	p.set(offset)
+	length := min(size-offset, uploadChunkSize)
+	body := io.TeeReader(io.NewSectionReader(f, offset, length), p)
+
+	req, err := c.newRequest(http.MethodPatch, "/api/uploads/"+id, body)
+	if err != nil {
+		return nil, offset, err
+	}
+	req.ContentLength = length
+	if length == 0 {
+		req.Body = http.NoBody
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set(uploadOffsetHeader, strconv.FormatInt(offset, 10))
+
+	resp, err := c.do(req)
+	if err != nil {
+		return nil, offset, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusCreated {
+		file := &models.File{}
+		return file, size, json.NewDecoder(resp.Body).Decode(file)
+	}
+	upload := &models.Upload{}
+	if err := json.NewDecoder(resp.Body).Decode(upload); err != nil {
+		return nil, offset, err
+	}
+	return nil, upload.Offset, nil
+}
+
+// 下载文件到本地，先写入.part文件，中断后再次执行时使用Range请求继续下载
+func (c *client) download(file *models.File, target string, quiet bool) error {
+	partPath := target + ".part"
+	attempt := 0
+	for {
+		err := c.downloadPart(file, partPath, quiet)
+		if err == nil {
+			return os.Rename(partPath, target)
+		}
+		if !retryable(err) || attempt >= maxRetries {
+			return err
+		}
+		attempt++
+		backoff(attempt)
+	}
+}
+
+// 从.part文件的末尾继续下载，返回前关闭.part文件，关闭失败时同样返回错误
+func (c *client) downloadPart(file *models.File, partPath string, quiet bool) (err error) {
+	part, err := os.OpenFile(partPath, os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := part.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	offset, err := part.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	if offset > file.Size {
+		offset = 0
+	}
+
+	req, err := c.newRequest(http.MethodGet, "/api/files/"+file.ID+"/download", nil)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && contentRangeMatches(resp, offset, file.Size):
+	case resp.StatusCode == http.StatusOK:
+		// 服务器返回完整内容，或者文件已经变化，从头开始
+		offset = 0
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset == file.Size:
+		// 上次已经下载完成
+		return nil
+	case resp.StatusCode == http.StatusPartialContent || resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		// 文件已经变化，丢弃已下载的部分
+		if err := part.Truncate(0); err != nil {
+			return err
+		}
+		return errors.New("文件已变化，重新下载")
+	default:
+		return readError(resp)
+	}
+
+	if err := part.Truncate(offset); err != nil {
+		return err
+	}
+	if _, err := part.Seek(offset, io.SeekStart); err != nil {
+		return err
+	}
+
+	p := newProgress(file.Name, file.Size, offset, quiet)
+	defer p.finish()
+	written, err := io.Copy(part, io.TeeReader(resp.Body, p))
+	if err != nil {
+		return err
+	}
+	if offset+written != file.Size {
+		return fmt.Errorf("下载不完整：%d/%d字节", offset+written, file.Size)
+	}
+	return nil
+}
+
+// 检查Content-Range是否从offset开始并且文件大小没有变化
+func contentRangeMatches(resp *http.Response, offset, size int64) bool {
+	return strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)) &&
+		strings.HasSuffix(resp.Header.Get("Content-Range"), "/"+strconv.FormatInt(size, 10))
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"fileshare/models"
+)
+
+func TestContentRangeMatches(t *testing.T) {
+	tests := []struct {
+		name         string
+		contentRange string
+		offset       int64
+		want         bool
+	}{
+		{"从续传位置到末尾", "bytes 4-9/10", 4, true},
+		{"起始位置不一致", "bytes 0-9/10", 4, false},
+		{"起始位置前缀相同", "bytes 40-99/100", 4, false},
+		{"文件大小变化", "bytes 4-11/12", 4, false},
+		{"文件大小后缀相同", "bytes 4-109/110", 4, false},
+		{"大小未知", "bytes 4-9/*", 4, false},
+		{"没有Content-Range", "", 4, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tt.contentRange != "" {
+				resp.Header.Set("Content-Range", tt.contentRange)
+			}
+			if got := contentRangeMatches(resp, tt.offset, 10); got != tt.want {
+				t.Errorf("contentRangeMatches(%q, %d) = %v, want %v", tt.contentRange, tt.offset, got, tt.want)
+			}
+		})
+	}
+}
+
+// 测试用的续传上传接口
+type uploadServer struct {
+	mu       sync.Mutex
+	uploads  map[string][]byte // 上传ID -> 已接收的内容
+	reported map[string]int64  // 查询时返回的位置，和实际接收的字节数不一致时模拟服务器状态变化
+	stuck    bool              // 总是拒绝提交的位置
+	created  int
+	offsets  []int64 // 每次提交的Upload-Offset
+	size     int64
+}
+
+func (s *uploadServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/uploads", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.created++
+		id := fmt.Sprintf("new%d", s.created)
+		s.uploads[id] = nil
+		writeJSON(w, http.StatusCreated, &models.Upload{ID: id, Size: s.size})
+	})
+	mux.HandleFunc("GET /api/uploads/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		id := r.PathValue("id")
+		data, ok := s.uploads[id]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "Upload not found"})
+			return
+		}
+		offset, ok := s.reported[id]
+		if !ok {
+			offset = int64(len(data))
+		}
+		writeJSON(w, http.StatusOK, &models.Upload{ID: id, Size: s.size, Offset: offset})
+	})
+	mux.HandleFunc("PATCH /api/uploads/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		id := r.PathValue("id")
+		requested, _ := strconv.ParseInt(r.Header.Get(uploadOffsetHeader), 10, 64)
+		s.offsets = append(s.offsets, requested)
+
+		data := s.uploads[id]
+		offset := int64(len(data))
+		if s.stuck {
+			offset = requested
+		}
+		if s.stuck || requested != offset {
+			writeJSON(w, http.StatusConflict, map[string]interface{}{"error": "Upload offset mismatch", "offset": offset})
+			return
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		s.uploads[id] = append(data, body...)
+		if int64(len(s.uploads[id])) < s.size {
+			writeJSON(w, http.StatusOK, &models.Upload{ID: id, Size: s.size, Offset: int64(len(s.uploads[id]))})
+			return
+		}
+		writeJSON(w, http.StatusCreated, &models.File{ID: "file-" + id, Size: s.size})
+	})
+	return mux
+}
+
+func TestUpload(t *testing.T) {
+	content := []byte("0123456789abcdef")
+	dir := t.TempDir()
+	localPath := filepath.Join(dir, "data.bin")
+	if err := os.WriteFile(localPath, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(localPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := &entry{path: "/docs", dir: &models.Directory{ID: "docs"}}
+
+	tests := []struct {
+		name     string
+		saved    string           // 本地保存的上传ID
+		received map[string]int   // 服务器上已接收的字节数
+		reported map[string]int64 // 查询时服务器返回的位置
+		stuck    bool
+		fileID   string
+		created  int
+		offsets  []int64
+		wantErr  bool
+	}{
+		{"新的上传", "", nil, nil, false, "file-new1", 1, []int64{0}, false},
+		{"继续之前的上传", "old", map[string]int{"old": 6}, nil, false, "file-old", 0, []int64{6}, false},
+		{"续传位置不一致时使用服务器返回的位置", "old", map[string]int{"old": 10}, map[string]int64{"old": 4}, false, "file-old", 0, []int64{4, 10}, false},
+		{"服务器上已不存在的上传重新开始", "missing", nil, nil, false, "file-new1", 1, []int64{0}, false},
+		{"服务器拒绝它返回的位置时放弃", "old", map[string]int{"old": 6}, nil, true, "", 0, []int64{6}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &uploadServer{uploads: map[string][]byte{}, reported: tt.reported, stuck: tt.stuck, size: int64(len(content))}
+			for id, n := range tt.received {
+				s.uploads[id] = append([]byte(nil), content[:n]...)
+			}
+			c := newTestClient(t, s.handler())
+
+			state := loadUploadState(filepath.Join(t.TempDir(), "profiles.json"))
+			key := uploadKey(c.server, localPath, info, "docs", "data.bin")
+			if tt.saved != "" {
+				state.Uploads[key] = tt.saved
+			}
+
+			file, err := c.upload(state, localPath, target, "data.bin", true)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("upload() error = %v, want error %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(s.offsets, tt.offsets) || s.created != tt.created {
+				t.Errorf("offsets %v, created %d, want offsets %v, created %d", s.offsets, s.created, tt.offsets, tt.created)
+			}
+			if tt.wantErr {
+				// 失败时保留上传ID，下次继续
+				if state.Uploads[key] == "" {
+					t.Error("upload id removed after failure")
+				}
+				return
+			}
+
+			if file.ID != tt.fileID {
+				t.Errorf("file = %s, want %s", file.ID, tt.fileID)
+			}
+			if got := s.uploads[strings.TrimPrefix(file.ID, "file-")]; !bytes.Equal(got, content) {
+				t.Errorf("server received %q, want %q", got, content)
+			}
+			// 完成后删除保存的上传ID
+			if saved := loadUploadState(state.path); len(saved.Uploads) != 0 {
+				t.Errorf("saved uploads = %v, want none", saved.Uploads)
+			}
+		})
+	}
+}
+
+func TestDownload(t *testing.T) {
+	content := "0123456789"
+	file := &models.File{ID: "file", Name: "file.txt", Size: int64(len(content))}
+
+	tests := []struct {
+		name    string
+		part    string // 已下载的.part文件内容
+		hasPart bool   // 是否已有.part文件
+		served  string // 服务器上的文件内容
+		ranges  bool   // 服务器是否支持Range
+		want    string // 下载后.part文件的内容
+		wantErr bool
+	}{
+		{"新的下载", "", false, content, true, content, false},
+		{"从.part文件末尾继续", "0123", true, content, true, content, false},
+		{"已经下载完成", content, true, content, true, content, false},
+		{"服务器不支持Range时从头下载", "0123", true, content, false, content, false},
+		{".part文件比文件大时从头下载", content + "extra", true, content, true, content, false},
+		{"文件已变化时丢弃已下载的部分", "0123", true, "abcdefghijklmnop", true, "", true},
+		{"下载不完整时保留已下载的部分", "", false, "01234", false, "01234", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /api/files/file/download", func(w http.ResponseWriter, r *http.Request) {
+				if !tt.ranges {
+					r.Header.Del("Range")
+				}
+				http.ServeContent(w, r, "file.txt", time.Time{}, strings.NewReader(tt.served))
+			})
+			c := newTestClient(t, mux)
+
+			target := filepath.Join(t.TempDir(), "file.txt")
+			partPath := target + ".part"
+			if tt.hasPart {
+				if err := os.WriteFile(partPath, []byte(tt.part), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := c.downloadPart(file, partPath, true)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("downloadPart() error = %v, want error %v", err, tt.wantErr)
+			}
+			if data, _ := os.ReadFile(partPath); string(data) != tt.want {
+				t.Errorf(".part = %q, want %q", data, tt.want)
+			}
+		})
+	}
+}
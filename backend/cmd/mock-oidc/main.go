@@ -0,0 +1,290 @@
+// mock-oidc 是用于本地开发和测试单点登录的简易OIDC身份提供方，不要用于生产环境。
+//
+// 用法：
+//
+//	go run ./cmd/mock-oidc -addr :9000 -client-id fileshare
+//
+// 然后在server.json中配置：
+//
+//	"oidc": {"enabled": true, "issuer": "http://localhost:9000", "clientId": "fileshare",
+//	         "roleMapping": {"fileshare-admins": "admin", "fileshare-editors": "editor"}}
+//
+// 登录时会显示一个表单，可以填写任意用户名和用户组。
+package main
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"html/template"
+	"log"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 授权码信息
+type authCode struct {
+	clientID    string
+	redirectURI string
+	nonce       string
+	challenge   string
+	username    string
+	groups      []string
+	expiresAt   time.Time
+}
+
+var (
+	addr     = flag.String("addr", ":9000", "监听地址")
+	issuer   = flag.String("issuer", "http://localhost:9000", "issuer地址，需要和FileShare配置一致")
+	clientID = flag.String("client-id", "fileshare", "允许的client_id")
+	secret   = flag.String("client-secret", "", "client_secret，为空时不校验")
+	username = flag.String("user", "alice", "登录表单的默认用户名")
+	groups   = flag.String("groups", "fileshare-admins", "登录表单的默认用户组，逗号分隔")
+
+	signingKey *rsa.PrivateKey
+
+	codes   = make(map[string]authCode)
+	tokens  = make(map[string]authCode)
+	codesMu sync.Mutex
+)
+
+// 登录表单
+var loginPage = template.Must(template.New("login").Parse(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"><title>Mock OIDC</title></head>
+<body>
+<h2>Mock OIDC 登录</h2>
+<form method="post">
+{{range $k, $v := .Params}}<input type="hidden" name="{{$k}}" value="{{$v}}">
+{{end}}<p>用户名 <input name="username" value="{{.Username}}"></p>
+<p>用户组 <input name="groups" value="{{.Groups}}"> （逗号分隔）</p>
+<button type="submit">登录</button>
+</form>
+</body></html>`))
+
+func main() {
+	flag.Parse()
+
+	var err error
+	signingKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		log.Fatalf("Failed to generate signing key: %v", err)
+	}
+
+	http.HandleFunc("/.well-known/openid-configuration", handleDiscovery)
+	http.HandleFunc("/authorize", handleAuthorize)
+	http.HandleFunc("/token", handleToken)
+	http.HandleFunc("/userinfo", handleUserinfo)
+	http.HandleFunc("/jwks", handleJWKS)
+
+	log.Printf("Mock OIDC provider listening on %s (issuer %s)", *addr, *issuer)
+	log.Fatal(http.ListenAndServe(*addr, nil))
+}
+
+// 发现文档
+func handleDiscovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"issuer":                                *issuer,
+		"authorization_endpoint":                *issuer + "/authorize",
+		"token_endpoint":                        *issuer + "/token",
+		"userinfo_endpoint":                     *issuer + "/userinfo",
+		"jwks_uri":                              *issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+// 授权端点：GET显示登录表单，POST签发授权码并跳转回客户端
+func handleAuthorize(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if r.Form.Get("client_id") != *clientID {
+		http.Error(w, "unknown client_id", http.StatusBadRequest)
+		return
+	}
+	redirectURI := r.Form.Get("redirect_uri")
+	if redirectURI == "" {
+		http.Error(w, "missing redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		params := map[string]string{}
+		for _, key := range []string{"client_id", "redirect_uri", "state", "nonce", "code_challenge", "code_challenge_method"} {
+			params[key] = r.Form.Get(key)
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_ = loginPage.Execute(w, map[string]interface{}{"Params": params, "Username": *username, "Groups": *groups})
+		return
+	}
+
+	code := randomString()
+	codesMu.Lock()
+	codes[code] = authCode{
+		clientID:    r.Form.Get("client_id"),
+		redirectURI: redirectURI,
+		nonce:       r.Form.Get("nonce"),
+		challenge:   r.Form.Get("code_challenge"),
+		username:    r.Form.Get("username"),
+		groups:      splitGroups(r.Form.Get("groups")),
+		expiresAt:   time.Now().Add(time.Minute),
+	}
+	codesMu.Unlock()
+
+	target, err := url.Parse(redirectURI)
+	if err != nil {
+		http.Error(w, "invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+	query := target.Query()
+	query.Set("code", code)
+	query.Set("state", r.Form.Get("state"))
+	target.RawQuery = query.Encode()
+	http.Redirect(w, r, target.String(), http.StatusFound)
+}
+
+// 令牌端点：校验授权码和PKCE，签发ID Token
+func handleToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	id, pass, hasBasic := r.BasicAuth()
+	if !hasBasic {
+		id, pass = r.Form.Get("client_id"), r.Form.Get("client_secret")
+	}
+	id, _ = url.QueryUnescape(id)
+	pass, _ = url.QueryUnescape(pass)
+	if id != *clientID || (*secret != "" && pass != *secret) {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	codesMu.Lock()
+	ac, exists := codes[r.Form.Get("code")]
+	delete(codes, r.Form.Get("code"))
+	codesMu.Unlock()
+
+	if !exists || time.Now().After(ac.expiresAt) || ac.redirectURI != r.Form.Get("redirect_uri") || ac.clientID != id {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+	if ac.challenge != "" {
+		sum := sha256.Sum256([]byte(r.Form.Get("code_verifier")))
+		if base64.RawURLEncoding.EncodeToString(sum[:]) != ac.challenge {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": "PKCE verification failed"})
+			return
+		}
+	}
+
+	now := time.Now()
+	idToken := signJWT(map[string]interface{}{
+		"iss":                *issuer,
+		"sub":                "mock-" + ac.username,
+		"aud":                ac.clientID,
+		"iat":                now.Unix(),
+		"exp":                now.Add(5 * time.Minute).Unix(),
+		"nonce":              ac.nonce,
+		"preferred_username": ac.username,
+		"email":              ac.username + "@example.com",
+		"groups":             ac.groups,
+	})
+
+	accessToken := randomString()
+	codesMu.Lock()
+	tokens[accessToken] = ac
+	codesMu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+// userinfo端点
+func handleUserinfo(w http.ResponseWriter, r *http.Request) {
+	codesMu.Lock()
+	ac, exists := tokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+	codesMu.Unlock()
+
+	if !exists {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_token"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"sub":                "mock-" + ac.username,
+		"preferred_username": ac.username,
+		"groups":             ac.groups,
+	})
+}
+
+// 公钥集合
+func handleJWKS(w http.ResponseWriter, r *http.Request) {
+	pub := signingKey.PublicKey
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "mock",
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}},
+	})
+}
+
+// 使用RS256签名JWT
+func signJWT(claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": "mock"})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	sum := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, signingKey, crypto.SHA256, sum[:])
+	if err != nil {
+		log.Fatalf("Failed to sign id_token: %v", err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// 拆分逗号分隔的用户组
+func splitGroups(value string) []string {
+	result := []string{}
+	for _, group := range strings.Split(value, ",") {
+		if group = strings.TrimSpace(group); group != "" {
+			result = append(result, group)
+		}
+	}
+	return result
+}
+
+// 写入JSON响应
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// 生成随机字符串
+func randomString() string {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		log.Fatalf("Failed to generate random value: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
@@ -2,6 +2,7 @@ package common
 
 import (
 	"fileshare/models"
+	"fileshare/thumbnail"
 )
 
 // 查找目录
@@ -21,11 +22,25 @@ func FindDirectory(dirs []*models.Directory, id string, result **models.Director
 	}
 }
 
-// 删除目录下的所有文件
-func DeleteFilesInDirectory(dirID string) {
+// FindFile 根据ID查找文件
+func FindFile(id string) *models.File {
+	for _, file := range models.Files {
+		if file.ID == id {
+			return file
+		}
+	}
+	return nil
+}
+
+// 删除目录下的所有文件，返回被删除的文件ID（需要在目录从目录树中移除之前调用）
+func DeleteFilesInDirectory(dirID string) []string {
+	removed := []string{}
+
 	// 删除直接属于该目录的文件
 	for i := 0; i < len(models.Files); {
 		if models.Files[i].DirectoryID == dirID {
+			thumbnail.Invalidate(models.Files[i].ID)
+			removed = append(removed, models.Files[i].ID)
 			models.Files = append(models.Files[:i], models.Files[i+1:]...)
 		} else {
 			i++
@@ -63,10 +78,60 @@ func DeleteFilesInDirectory(dirID string) {
 	for _, childID := range childDirIDs {
 		for i := 0; i < len(models.Files); {
 			if models.Files[i].DirectoryID == childID {
+				thumbnail.Invalidate(models.Files[i].ID)
+				removed = append(removed, models.Files[i].ID)
 				models.Files = append(models.Files[:i], models.Files[i+1:]...)
 			} else {
 				i++
 			}
 		}
 	}
+
+	return removed
 }
+
+// 查找目录链（从根目录到目标目录）
+func FindDirectoryChain(dirs []*models.Directory, id string) []*models.Directory {
+	for _, dir := range dirs {
+		if dir.ID == id {
+			return []*models.Directory{dir}
+		}
+
+		if len(dir.Children) > 0 {
+			if chain := FindDirectoryChain(dir.Children, id); chain != nil {
+				return append([]*models.Directory{dir}, chain...)
+			}
+		}
+	}
+
+	return nil
+}
+
+// 复制目录树用于接口响应，去掉密码哈希，只保留是否设置密码的标记
+func SanitizeDirectories(dirs []*models.Directory) []*models.Directory {
+	result := make([]*models.Directory, 0, len(dirs))
+	for _, dir := range dirs {
+		result = append(result, SanitizeDirectory(dir))
+	}
+	return result
+}
+
+// 复制单个目录（包括子目录）用于接口响应
+func SanitizeDirectory(dir *models.Directory) *models.Directory {
+	copied := *dir
+	copied.Password = ""
+	copied.HasPassword = dir.Password != ""
+	if dir.Children != nil {
+		copied.Children = SanitizeDirectories(dir.Children)
+	}
+	return &copied
+}
+
+// DirMode 目录在列表（目录树、WebDAV/SFTP目录列表、目录归档）中的显示方式
+type DirMode int
+
+const (
+	DirHidden DirMode = iota // 不显示目录及其子目录
+	DirShown                 // 显示目录
+	DirElided                // 不显示目录本身，其下显示的子目录提升到上级目录中
+)
@@ -4,20 +4,100 @@ import (
 	"encoding/json"
 	"os"
 	"sync"
+
+	"fileshare/models"
 )
 
 // ServerConfig 服务器配置结构体
 type ServerConfig struct {
 	Server struct {
-		Port              int    `json:"port"`
-		ContextPath       string `json:"contextPath"`
-		ContextManagePath string `json:"contextManagePath"` // 管理API的上下文路径，不是web页面路径
-		ContextSharePath  string `json:"contextSharePath"`  // 共享API的上下文路径，不是web页面路径
-		ManagePassword    int    `json:"managePassword"`
-		LogPath           string `json:"logPath"`
-		LinkDirAdd        bool   `json:"linkDirAdd"`
-		FilestorePath     string `json:"filestorePath"` // 文件存储路径
+		Port              int      `json:"port"`
+		BasePath          string   `json:"basePath"`          // 部署的子路径（例如/tools/fileshare），页面、静态资源和API都挂载在该路径下
+		ContextPath       string   `json:"contextPath"`       // web页面的上下文路径
+		ContextManagePath string   `json:"contextManagePath"` // 管理API的上下文路径，不是web页面路径
+		ContextSharePath  string   `json:"contextSharePath"`  // 共享API的上下文路径，不是web页面路径
+		ManagePassword    Password `json:"managePassword"`    // 初始管理员密码，只在首次创建管理员账号时使用
+		LogPath           string   `json:"logPath"`
+		LinkDirAdd        bool     `json:"linkDirAdd"`
+		FilestorePath     string   `json:"filestorePath"`   // 文件存储路径
+		SessionTTLHours   int      `json:"sessionTtlHours"` // 登录会话有效期（小时）
+		TrustedProxies    []string `json:"trustedProxies"`  // 可信的反向代理地址（IP或CIDR），只有来自这些地址的请求才使用X-Forwarded-For获取客户端IP
 	} `json:"server"`
+
+	// HTTPS配置
+	TLS struct {
+		Enabled          bool     `json:"enabled"`
+		CertFile         string   `json:"certFile"`         // 证书文件（PEM），和keyFile都为空时自动生成自签名证书
+		KeyFile          string   `json:"keyFile"`          // 私钥文件（PEM）
+		Hosts            []string `json:"hosts"`            // 自签名证书额外包含的域名或IP，本机名称和地址会自动加入
+		HTTPRedirectPort int      `json:"httpRedirectPort"` // 大于0时在该端口监听HTTP并跳转到HTTPS
+	} `json:"tls"`
+
+	// WebDAV配置
+	WebDAV struct {
+		Enabled bool `json:"enabled"` // 是否提供WebDAV入口（共享端只读，管理端可读写），默认关闭
+	} `json:"webdav"`
+
+	// SFTP配置
+	SFTP struct {
+		Enabled     bool   `json:"enabled"`     // 是否启动内嵌的SFTP服务（使用管理端账号和权限）
+		Port        int    `json:"port"`        // 监听端口
+		HostKeyFile string `json:"hostKeyFile"` // 主机私钥文件（PEM），不存在时自动生成
+	} `json:"sftp"`
+
+	// S3兼容接口配置
+	S3 struct {
+		Enabled bool   `json:"enabled"` // 是否提供S3兼容的只读接口（使用API密钥派生的访问密钥）
+		Bucket  string `json:"bucket"`  // 桶名称，目录作为桶中的前缀
+	} `json:"s3"`
+
+	// 跨域和安全响应头配置
+	Security struct {
+		AllowOrigins          []string        `json:"allowOrigins"`          // 允许跨域访问的来源，为空时只允许同源访问，"*"表示允许所有来源（不携带凭据）
+		ContentSecurityPolicy string          `json:"contentSecurityPolicy"` // Content-Security-Policy，为空时使用默认策略
+		FrameAncestors        []string        `json:"frameAncestors"`        // 允许嵌入页面的来源，默认只允许同源
+		ReferrerPolicy        string          `json:"referrerPolicy"`
+		HSTSMaxAge            int             `json:"hstsMaxAge"`     // 使用HTTPS时Strict-Transport-Security的max-age（秒），0表示不发送
+		ManageIPFilter        models.IPFilter `json:"manageIpFilter"` // 管理端（登录和管理API）的IP访问限制
+	} `json:"security"`
+
+	// OIDC单点登录配置
+	OIDC struct {
+		Enabled           bool              `json:"enabled"`
+		Name              string            `json:"name"`   // 登录按钮上显示的名称
+		Issuer            string            `json:"issuer"` // 身份提供方地址，通过/.well-known/openid-configuration发现端点
+		ClientID          string            `json:"clientId"`
+		ClientSecret      string            `json:"clientSecret"`
+		RedirectURL       string            `json:"redirectUrl"`       // 回调地址，为空时根据请求自动生成
+		Scopes            []string          `json:"scopes"`            // 请求的scope，默认openid profile email groups
+		UsernameClaim     string            `json:"usernameClaim"`     // 作为用户名的claim，默认preferred_username
+		GroupsClaim       string            `json:"groupsClaim"`       // 作为用户组的claim，默认groups
+		RoleMapping       map[string]string `json:"roleMapping"`       // 用户组到角色的映射，匹配多个时取权限最高的角色
+		DefaultRole       string            `json:"defaultRole"`       // 没有匹配的用户组时使用的角色，为空表示拒绝登录
+		PostLoginRedirect string            `json:"postLoginRedirect"` // 登录成功后跳转的页面，默认/manage
+	} `json:"oidc"`
+}
+
+// 默认管理密码，使用默认密码的账号首次登录后必须修改密码
+const DefaultManagePassword = "123456"
+
+// Password 密码配置，兼容旧版本配置文件中的数字密码
+type Password string
+
+// UnmarshalJSON 同时支持字符串和数字
+func (p *Password) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*p = Password(s)
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*p = Password(n.String())
+	return nil
 }
 
 var (
@@ -30,13 +110,25 @@ func GetServerConfig() *ServerConfig {
 	serverConfigOnce.Do(func() {
 		serverConfig = &ServerConfig{}
 		serverConfig.Server.Port = 8080 // 默认值
-		serverConfig.Server.ContextPath = "/fileshare"
+		serverConfig.Server.ContextPath = "/fileserver"
 		serverConfig.Server.ContextManagePath = "/fileshare" // 管理API的上下文路径，不是web页面路径
 		serverConfig.Server.ContextSharePath = "/fileshare"  // 共享API的上下文路径，不是web页面路径
-		serverConfig.Server.ManagePassword = 123456
+		serverConfig.Server.ManagePassword = DefaultManagePassword
 		serverConfig.Server.LogPath = "./recode.log"
 		serverConfig.Server.LinkDirAdd = true          // 默认允许添加链接型目录
 		serverConfig.Server.FilestorePath = "./static" // 默认文件存储路径
+		serverConfig.Server.SessionTTLHours = 24       // 默认会话有效期24小时
+		serverConfig.SFTP.Port = 2022
+		serverConfig.SFTP.HostKeyFile = "./config/sftp-host-key.pem"
+		serverConfig.S3.Bucket = "fileshare"
+		serverConfig.Security.FrameAncestors = []string{"'self'"}
+		serverConfig.Security.ReferrerPolicy = "strict-origin-when-cross-origin"
+		serverConfig.Security.HSTSMaxAge = 31536000 // 默认一年
+		serverConfig.OIDC.Name = "单点登录"
+		serverConfig.OIDC.Scopes = []string{"openid", "profile", "email", "groups"}
+		serverConfig.OIDC.UsernameClaim = "preferred_username"
+		serverConfig.OIDC.GroupsClaim = "groups"
+		serverConfig.OIDC.PostLoginRedirect = "/manage"
 
 		// 尝试从配置文件加载
 		data, err := os.ReadFile("./config/server.json")
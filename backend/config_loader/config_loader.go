@@ -3,8 +3,13 @@ package config_loader
 import (
 	"os"
 
+	"fileshare/apikey"
 	"fileshare/directory"
 	"fileshare/file"
+	"fileshare/sharelink"
+	"fileshare/stats"
+	"fileshare/user"
+	"fileshare/utils"
 )
 
 // 配置文件路径
@@ -37,4 +42,22 @@ func LoadAllConfigs() {
 
 	// 加载文件配置
 	file.LoadFiles()
+
+	// 加载未完成的上传
+	file.LoadUploads()
+
+	// 加载下载统计（依赖文件配置）
+	stats.LoadStats()
+
+	// 加载分享链接配置
+	sharelink.LoadShareLinks()
+
+	// 加载用户配置
+	user.LoadUsers()
+
+	// 加载API密钥
+	apikey.LoadAPIKeys()
+
+	// 加载登录会话
+	utils.LoadTokens()
 }
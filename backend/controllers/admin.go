@@ -1,10 +1,13 @@
 package controllers
 
 import (
-	"fmt"
 	"net/http"
+	"time"
 
-	"fileshare/config"
+	"fileshare/lockout"
+	"fileshare/middleware"
+	"fileshare/models"
+	"fileshare/user"
 	"fileshare/utils"
 
 	"github.com/gin-gonic/gin"
@@ -12,12 +15,18 @@ import (
 
 // AdminLoginRequest 管理员登录请求结构
 type AdminLoginRequest struct {
+	Username string `json:"username"` // 为空时使用默认管理员账号
 	Password string `json:"password" binding:"required"`
+	Code     string `json:"code"` // 启用两步验证时需要的验证码或恢复码
 }
 
 // AdminLoginResponse 管理员登录响应结构
 type AdminLoginResponse struct {
-	Token string `json:"token"`
+	Token              string       `json:"token"`
+	ExpiresAt          string       `json:"expiresAt"`
+	User               *models.User `json:"user"`
+	MustChangePassword bool         `json:"mustChangePassword,omitempty"` // 需要先修改密码才能使用管理功能
+	CSRFToken          string       `json:"csrfToken"`                    // 通过Cookie使用会话时，修改请求需放在X-CSRF-Token请求头中
 }
 
 // AdminLogin 处理管理员登录
@@ -28,16 +37,79 @@ func AdminLogin(c *gin.Context) {
 		return
 	}
 
-	// 验证密码
-	serverConfig := config.GetServerConfig()
-	if req.Password != fmt.Sprintf("%d", serverConfig.Server.ManagePassword) {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "密码错误"})
+	// 兼容只提交密码的旧登录方式
+	if req.Username == "" {
+		req.Username = user.DefaultAdminName
+	}
+
+	// 检查是否因多次失败被锁定（按客户端IP以及IP和账号的组合），账号本身的失败只会延迟登录
+	accountKey := lockout.AccountKey(req.Username)
+	lockoutKeys := []string{lockout.IPKey(c), lockout.ScopedKey(c, accountKey), accountKey}
+	if !lockout.Guard(c, lockoutKeys[:2]...) {
+		return
+	}
+	lockout.Throttle(accountKey)
+
+	// 启用了两步验证的账号先要求输入验证码，不检查密码，之后同时验证密码和验证码，
+	// 任一错误都返回相同的响应，避免通过响应判断密码是否正确
+	requireTotp := false
+	if u := user.FindByUsername(req.Username); u != nil && u.TOTPEnabled {
+		if req.Code == "" {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "请输入两步验证码", "requireTotp": true})
+			return
+		}
+		requireTotp = true
+	}
+
+	// 验证用户名和密码，密码正确后才验证两步验证码（避免密码错误时用掉恢复码）
+	loginUser := user.Authenticate(req.Username, req.Password)
+	if loginUser == nil || (loginUser.TOTPEnabled && !user.VerifySecondFactor(loginUser, req.Code)) {
+		lockout.Fail(lockoutKeys...)
+		if requireTotp {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "用户名、密码或两步验证码错误", "requireTotp": true})
+		} else {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "用户名或密码错误"})
+		}
 		return
 	}
+	lockout.Succeed(lockoutKeys[1:]...)
 
 	// 生成token
-	token := utils.GenerateToken()
+	token := utils.GenerateToken(loginUser.ID, c.ClientIP(), c.Request.UserAgent())
+	expiresAt := time.Now().Add(utils.SessionTTL())
+
+	// 浏览器使用Cookie中的会话，命令行工具和脚本使用返回的token
+	middleware.SetSessionCookies(c, token)
+	c.JSON(http.StatusOK, AdminLoginResponse{
+		Token:              token,
+		ExpiresAt:          expiresAt.Format("2006-01-02 15:04:05"),
+		User:               user.Public(loginUser),
+		MustChangePassword: loginUser.MustChangePassword,
+		CSRFToken:          utils.SessionCSRFToken(token),
+	})
+}
+
+// AdminLogout 退出登录，使当前token失效并删除浏览器中的会话Cookie
+func AdminLogout(c *gin.Context) {
+	utils.InvalidateToken(middleware.RequestToken(c))
+	middleware.ClearSessionCookies(c)
+	c.JSON(http.StatusOK, gin.H{"message": "已退出登录"})
+}
+
+// AdminRefresh 刷新token，返回新token并使旧token失效
+func AdminRefresh(c *gin.Context) {
+	token, expiresAt, ok := utils.RefreshToken(middleware.RequestToken(c))
+	if !ok {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "无效的token，请重新登录"})
+		return
+	}
 
-	// 返回token
-	c.JSON(http.StatusOK, AdminLoginResponse{Token: token})
+	session, _ := middleware.CurrentSession(c)
+	middleware.SetSessionCookies(c, token)
+	c.JSON(http.StatusOK, AdminLoginResponse{
+		Token:     token,
+		ExpiresAt: expiresAt.Format("2006-01-02 15:04:05"),
+		User:      user.Public(user.Current(c)),
+		CSRFToken: utils.CSRFToken(session.ID),
+	})
 }
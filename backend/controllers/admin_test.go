@@ -0,0 +1,115 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+
+	"fileshare/models"
+)
+
+func hashForTest(t *testing.T, password string) string {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(hash)
+}
+
+// 在临时目录中运行，登录时保存的配置不会写到源码目录
+func useTempDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "config"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+// 以指定客户端IP登录，返回状态码和响应
+func login(ip string, body gin.H) (int, gin.H) {
+	data, _ := json.Marshal(body)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/admin/login", strings.NewReader(string(data)))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.RemoteAddr = ip + ":12345"
+	AdminLogin(c)
+
+	var resp gin.H
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	return w.Code, resp
+}
+
+func TestAdminLoginSecondFactor(t *testing.T) {
+	useTempDir(t)
+
+	saved := models.Users
+	defer func() { models.Users = saved }()
+	models.Users = []*models.User{
+		{ID: "alice", Username: "alice", Password: hashForTest(t, "alice-password"), Role: "admin",
+			TOTPEnabled: true, TOTPSecret: "JBSWY3DPEHPK3PXP", RecoveryCodes: []string{hashForTest(t, "recovery-code")}},
+		{ID: "bob", Username: "bob", Password: hashForTest(t, "bob-password"), Role: "admin"},
+	}
+
+	askCode := gin.H{"error": "请输入两步验证码", "requireTotp": true}
+	wrongCode := gin.H{"error": "用户名、密码或两步验证码错误", "requireTotp": true}
+	wrongPassword := gin.H{"error": "用户名或密码错误"}
+
+	// 依次执行，恢复码在密码错误时不应被用掉
+	tests := []struct {
+		name   string
+		body   gin.H
+		status int
+		want   gin.H
+	}{
+		{"两步验证账号密码错误时要求验证码", gin.H{"username": "alice", "password": "wrong"}, http.StatusUnauthorized, askCode},
+		{"两步验证账号密码正确时同样要求验证码", gin.H{"username": "alice", "password": "alice-password"}, http.StatusUnauthorized, askCode},
+		{"密码错误时不验证恢复码", gin.H{"username": "alice", "password": "wrong", "code": "recovery-code"}, http.StatusUnauthorized, wrongCode},
+		{"密码正确但验证码错误", gin.H{"username": "alice", "password": "alice-password", "code": "000000"}, http.StatusUnauthorized, wrongCode},
+		{"密码和恢复码正确", gin.H{"username": "alice", "password": "alice-password", "code": "recovery-code"}, http.StatusOK, nil},
+		{"恢复码只能使用一次", gin.H{"username": "alice", "password": "alice-password", "code": "recovery-code"}, http.StatusUnauthorized, wrongCode},
+		{"普通账号密码错误", gin.H{"username": "bob", "password": "wrong"}, http.StatusUnauthorized, wrongPassword},
+		{"普通账号忽略验证码", gin.H{"username": "bob", "password": "wrong", "code": "000000"}, http.StatusUnauthorized, wrongPassword},
+		{"普通账号密码正确", gin.H{"username": "BOB", "password": "bob-password"}, http.StatusOK, nil},
+		{"不存在的账号", gin.H{"username": "carol", "password": "wrong"}, http.StatusUnauthorized, wrongPassword},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 每个请求使用不同的客户端IP，避免失败锁定影响后续请求
+			status, resp := login(fmt.Sprintf("10.0.1.%d", i+1), tt.body)
+			if status != tt.status {
+				t.Fatalf("status = %d, want %d (%v)", status, tt.status, resp)
+			}
+			if tt.want == nil {
+				if resp["token"] == "" || resp["token"] == nil {
+					t.Errorf("no token in response %v", resp)
+				}
+				return
+			}
+			if len(resp) != len(tt.want) {
+				t.Errorf("response = %v, want %v", resp, tt.want)
+			}
+			for key, value := range tt.want {
+				if resp[key] != value {
+					t.Errorf("response = %v, want %v", resp, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+
+	"fileshare/config"
+	"fileshare/lockout"
+	"fileshare/middleware"
+	"fileshare/user"
+	"fileshare/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ChangePasswordRequest 修改密码请求结构
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword" binding:"required"`
+	NewPassword     string `json:"newPassword" binding:"required"`
+}
+
+// ChangePassword 修改当前用户的密码，成功后注销该用户的其他会话
+func ChangePassword(c *gin.Context) {
+	var req ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "请求参数错误"})
+		return
+	}
+
+	if user.ValidatePassword(req.NewPassword) != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("新密码至少需要%d个字符", user.MinPasswordLength)})
+		return
+	}
+	if req.NewPassword == config.DefaultManagePassword {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "新密码不能使用默认密码"})
+		return
+	}
+	if req.NewPassword == req.CurrentPassword {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "新密码不能与当前密码相同"})
+		return
+	}
+
+	currentUser := user.Current(c)
+
+	// 检查是否因多次失败被锁定
+	lockoutKey := "password:" + currentUser.ID
+	if !lockout.Guard(c, lockoutKey) {
+		return
+	}
+	if !utils.CheckPassword(currentUser.Password, req.CurrentPassword) {
+		lockout.Fail(lockoutKey)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "当前密码错误"})
+		return
+	}
+	lockout.Succeed(lockoutKey)
+
+	if err := user.SetPassword(currentUser, req.NewPassword); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "保存密码失败"})
+		return
+	}
+
+	// 保留当前会话，注销其他会话
+	session, _ := middleware.CurrentSession(c)
+	utils.RevokeUserSessions(currentUser.ID, session.ID)
+
+	c.JSON(http.StatusOK, gin.H{"message": "密码修改成功"})
+}
@@ -0,0 +1,78 @@
+package controllers
+
+import (
+	"net/http"
+
+	"fileshare/middleware"
+	"fileshare/user"
+	"fileshare/utils"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SessionResponse 会话信息响应结构
+type SessionResponse struct {
+	ID         string `json:"id"`
+	UserID     string `json:"userId"`
+	Username   string `json:"username"`
+	ClientIP   string `json:"clientIp"`
+	UserAgent  string `json:"userAgent"`
+	CreatedAt  string `json:"createdAt"`
+	LastUsedAt string `json:"lastUsedAt"`
+	ExpiresAt  string `json:"expiresAt"`
+	Current    bool   `json:"current"`
+}
+
+// GetSessions 获取登录会话列表，管理员传入all=true时返回所有用户的会话
+func GetSessions(c *gin.Context) {
+	currentUser := user.Current(c)
+	current, _ := middleware.CurrentSession(c)
+
+	userID := currentUser.ID
+	if c.Query("all") == "true" && user.HasPermission(currentUser, user.PermAdmin) {
+		userID = ""
+	}
+
+	result := []SessionResponse{}
+	for _, s := range utils.ListSessions(userID) {
+		username := ""
+		if u := user.FindByID(s.UserID); u != nil {
+			username = u.Username
+		}
+		result = append(result, SessionResponse{
+			ID:         s.ID,
+			UserID:     s.UserID,
+			Username:   username,
+			ClientIP:   s.ClientIP,
+			UserAgent:  s.UserAgent,
+			CreatedAt:  s.CreatedAt.Format("2006-01-02 15:04:05"),
+			LastUsedAt: s.LastUsedAt.Format("2006-01-02 15:04:05"),
+			ExpiresAt:  s.ExpiresAt.Format("2006-01-02 15:04:05"),
+			Current:    s.ID == current.ID,
+		})
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RevokeSession 注销指定会话，普通用户只能注销自己的会话
+func RevokeSession(c *gin.Context) {
+	id := c.Param("id")
+	currentUser := user.Current(c)
+
+	s, exists := utils.FindSession(id)
+	if !exists || (s.UserID != currentUser.ID && !user.HasPermission(currentUser, user.PermAdmin)) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		return
+	}
+
+	utils.RevokeSession(id)
+	c.JSON(http.StatusOK, gin.H{"message": "Session revoked successfully"})
+}
+
+// RevokeOtherSessions 注销当前用户除当前会话外的所有会话
+func RevokeOtherSessions(c *gin.Context) {
+	current, _ := middleware.CurrentSession(c)
+	count := utils.RevokeUserSessions(user.Current(c).ID, current.ID)
+	c.JSON(http.StatusOK, gin.H{"message": "Sessions revoked successfully", "revoked": count})
+}
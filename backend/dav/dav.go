@@ -0,0 +1,158 @@
+// Package dav 通过WebDAV提供目录和文件，可以把FileShare映射为网络驱动器。
+//
+// 提供两个入口：
+//   - 共享端（<contextSharePath>/dav/share/）：只读，只包含访客可见的目录和文件，规则和共享页面相同（见access包）。
+//     访问有密码的目录时使用HTTP基本认证中的密码作为目录密码（用户名任意）
+//   - 管理端（<contextManagePath>/dav/manage/）：可读写，使用HTTP基本认证登录（用户名和密码，或者把API密钥作为密码），
+//     按目录访问控制检查权限（见acl包）。创建目录、上传、删除和管理API使用相同的存储逻辑
+//
+// 目录树到路径的映射见vfs包。
+package dav
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/webdav"
+
+	"fileshare/apikey"
+	"fileshare/models"
+	"fileshare/proxy"
+	"fileshare/stats"
+	"fileshare/user"
+	"fileshare/vfs"
+)
+
+// 入口路径（分别相对于共享API和管理API的上下文路径）
+const (
+	SharePath  = "/dav/share"
+	ManagePath = "/dav/manage"
+)
+
+// Realm HTTP基本认证的realm
+const Realm = "FileShare"
+
+// Methods WebDAV使用的请求方法，需要为入口注册这些方法
+var Methods = []string{
+	http.MethodOptions, http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete,
+	"PROPFIND", "PROPPATCH", "MKCOL", "COPY", "MOVE", "LOCK", "UNLOCK",
+}
+
+// 共享端允许的请求方法
+const shareAllow = "OPTIONS, GET, HEAD, PROPFIND"
+
+// 两个入口各自的锁
+var (
+	shareLocks  = webdav.NewMemLS()
+	manageLocks = webdav.NewMemLS()
+)
+
+// ShareHandler 共享端的只读入口，routePath为入口相对于basePath的路径
+func ShareHandler(routePath string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		switch c.Request.Method {
+		case http.MethodOptions:
+			c.Header("DAV", "1")
+			c.Header("Allow", shareAllow)
+			c.Status(http.StatusOK)
+			return
+		case http.MethodGet, http.MethodHead, "PROPFIND":
+		default:
+			c.Header("Allow", shareAllow)
+			c.JSON(http.StatusMethodNotAllowed, gin.H{"error": errReadOnly.Error()})
+			return
+		}
+
+		_, password, _ := c.Request.BasicAuth()
+		p := &sharePolicy{c: c, password: password, unlocked: map[string]bool{}}
+		fs := &fileSystem{FS: &vfs.FS{Policy: p}, c: c}
+		if !p.unlockPath(fs.FS, c.Param("path")) {
+			return
+		}
+
+		serve(c, routePath, fs, shareLocks)
+	}
+}
+
+// ManageHandler 管理端的读写入口，需在BasicAuth之后使用
+func ManageHandler(routePath string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		policy := &vfs.ManagePolicy{User: user.Current(c), Key: apikey.Current(c)}
+		fs := &fileSystem{FS: &vfs.FS{Policy: policy}, c: c}
+
+		// webdav对部分操作的权限错误返回404或405，这里先检查并返回403
+		if err := fs.checkWrite(c.Request.Method, c.Param("path")); err != nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
+
+		serve(c, routePath, fs, manageLocks)
+	}
+}
+
+// 检查上传、创建目录和删除的权限，路径不存在等其他错误交给webdav处理
+func (fs *fileSystem) checkWrite(method, name string) error {
+	switch method {
+	case http.MethodPut:
+		parent, _, err := fs.LookupParent(name)
+		if err != nil {
+			return nil
+		}
+		if parent == nil {
+			return vfs.ErrRootFiles
+		}
+		var existing *models.File
+		if n, _, err := fs.Lookup(name); err == nil {
+			if n.IsDir() {
+				return nil
+			}
+			existing = n.File
+		}
+		return fs.Policy.CanPut(parent, existing)
+	case "MKCOL":
+		parent, _, err := fs.LookupParent(name)
+		if err != nil {
+			return nil
+		}
+		return fs.Policy.CanMkdir(parent)
+	case http.MethodDelete:
+		n, _, err := fs.Lookup(name)
+		if err != nil {
+			return nil
+		}
+		if n.IsRoot() {
+			return vfs.ErrRootReadOnly
+		}
+		return fs.Policy.CanRemove(n)
+	}
+	return nil
+}
+
+// 交给webdav处理请求，GET下载文件时记录下载统计
+func serve(c *gin.Context, routePath string, fs *fileSystem, locks webdav.LockSystem) {
+	name := c.Param("path")
+
+	// 使用客户端看到的路径，响应中的链接和Destination请求头才能和客户端一致
+	prefix := proxy.Prefix(c) + routePath
+	c.Request.URL.Path = prefix + name
+	c.Request.URL.RawPath = ""
+
+	var downloaded *models.File
+	if c.Request.Method == http.MethodGet {
+		if n, _, err := fs.Lookup(name); err == nil && !n.IsDir() {
+			downloaded = n.File
+		}
+	}
+
+	handler := &webdav.Handler{
+		Prefix:     prefix,
+		FileSystem: fs,
+		LockSystem: locks,
+	}
+	handler.ServeHTTP(c.Writer, c.Request)
+
+	// 和下载接口一样记录统计，没有发送到文件末尾视为未完成
+	if downloaded != nil && c.Writer.Size() >= 0 {
+		stats.RecordResponse(c, downloaded.ID, downloaded.Size)
+	}
+}
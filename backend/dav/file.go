@@ -0,0 +1,75 @@
+package dav
+
+import (
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/vfs"
+)
+
+// 打开的目录，只能列出内容
+type dirFile struct {
+	info    *vfs.FileInfo
+	entries []*vfs.Node
+	offset  int
+}
+
+func (d *dirFile) Close() error                   { return nil }
+func (d *dirFile) Read([]byte) (int, error)       { return 0, os.ErrInvalid }
+func (d *dirFile) Seek(int64, int) (int64, error) { return 0, os.ErrInvalid }
+func (d *dirFile) Write([]byte) (int, error)      { return 0, os.ErrPermission }
+func (d *dirFile) Stat() (os.FileInfo, error)     { return d.info, nil }
+
+// Readdir 按os.File.Readdir的约定返回目录中的条目
+func (d *dirFile) Readdir(count int) ([]os.FileInfo, error) {
+	remaining := d.entries[d.offset:]
+	if count > 0 {
+		if len(remaining) == 0 {
+			return nil, io.EOF
+		}
+		if len(remaining) > count {
+			remaining = remaining[:count]
+		}
+	}
+
+	infos := make([]os.FileInfo, 0, len(remaining))
+	for _, entry := range remaining {
+		infos = append(infos, vfs.NewFileInfo(entry))
+	}
+	d.offset += len(remaining)
+	return infos, nil
+}
+
+// 打开用于读取的文件
+type readFile struct {
+	*os.File
+	info *vfs.FileInfo
+}
+
+func (f *readFile) Readdir(int) ([]os.FileInfo, error) { return nil, os.ErrInvalid }
+func (f *readFile) Stat() (os.FileInfo, error)         { return f.info, nil }
+func (f *readFile) Write([]byte) (int, error)          { return 0, os.ErrPermission }
+
+// 打开用于上传的文件，关闭时保存文件记录
+type writeFile struct {
+	*vfs.Writer
+	c *gin.Context
+}
+
+func (w *writeFile) Read([]byte) (int, error)           { return 0, os.ErrInvalid }
+func (w *writeFile) Seek(int64, int) (int64, error)     { return 0, os.ErrInvalid }
+func (w *writeFile) Readdir(int) ([]os.FileInfo, error) { return nil, os.ErrInvalid }
+func (w *writeFile) Stat() (os.FileInfo, error)         { return w.Writer.Stat(), nil }
+
+// Close 结束上传，PUT请求的内容不完整（客户端中断）时放弃保存
+func (w *writeFile) Close() error {
+	req := w.c.Request
+	if req.Method == http.MethodPut && req.ContentLength >= 0 && w.Written() != req.ContentLength {
+		w.Abort()
+		return vfs.ErrAborted
+	}
+	return w.Writer.Close()
+}
@@ -0,0 +1,71 @@
+package dav
+
+import (
+	"context"
+	"os"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/webdav"
+
+	"fileshare/vfs"
+)
+
+// fileSystem 把虚拟文件系统适配为webdav.FileSystem，每个请求创建一个
+type fileSystem struct {
+	*vfs.FS
+	c *gin.Context
+}
+
+// Mkdir 在上级目录中创建存储型目录
+func (fs *fileSystem) Mkdir(ctx context.Context, name string, perm os.FileMode) error {
+	return fs.FS.Mkdir(name)
+}
+
+// OpenFile 打开目录或文件，带有写入标志时打开用于上传的文件
+func (fs *fileSystem) OpenFile(ctx context.Context, name string, flag int, perm os.FileMode) (webdav.File, error) {
+	if flag&(os.O_WRONLY|os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND) != 0 {
+		if flag&os.O_CREATE == 0 {
+			if _, _, err := fs.Lookup(name); err != nil {
+				return nil, err
+			}
+		}
+		w, err := fs.Create(name, flag&os.O_TRUNC != 0, flag&os.O_EXCL != 0)
+		if err != nil {
+			return nil, err
+		}
+		return &writeFile{Writer: w, c: fs.c}, nil
+	}
+
+	n, _, err := fs.Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	if n.IsDir() {
+		return &dirFile{info: vfs.NewFileInfo(n), entries: fs.Children(n.Dir)}, nil
+	}
+
+	f, err := os.Open(n.File.Path)
+	if err != nil {
+		return nil, err
+	}
+	return &readFile{File: f, info: vfs.NewFileInfo(n)}, nil
+}
+
+// RemoveAll 删除目录（连同子目录和文件）或文件
+func (fs *fileSystem) RemoveAll(ctx context.Context, name string) error {
+	return fs.Remove(name)
+}
+
+// Rename 重命名或移动目录和文件
+func (fs *fileSystem) Rename(ctx context.Context, oldName, newName string) error {
+	return fs.FS.Rename(oldName, newName)
+}
+
+// Stat 获取目录或文件的信息
+func (fs *fileSystem) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	info, err := fs.FS.Stat(name)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
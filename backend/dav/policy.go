@@ -0,0 +1,89 @@
+package dav
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/access"
+	"fileshare/common"
+	"fileshare/lockout"
+	"fileshare/models"
+	"fileshare/utils"
+	"fileshare/vfs"
+)
+
+// 共享端只读，所有修改操作都不允许
+const errReadOnly = vfs.ForbiddenError("共享端WebDAV为只读")
+
+// 共享端：只包含访客可见的目录和文件，有密码的目录需要验证密码后才能查看内容
+type sharePolicy struct {
+	c        *gin.Context
+	password string          // HTTP基本认证中的密码，作为目录密码使用
+	unlocked map[string]bool // 本次请求中已验证密码的目录
+}
+
+func (p *sharePolicy) ShowDir(dir *models.Directory) common.DirMode {
+	return access.ListMode(p.c, dir)
+}
+
+func (p *sharePolicy) OpenDir(dir *models.Directory) bool {
+	return p.unlocked[dir.ID] || access.HasDirectoryAccess(p.c, dir)
+}
+
+func (p *sharePolicy) ShowFile(f *models.File) bool {
+	return f.IsShared
+}
+
+func (p *sharePolicy) CanMkdir(*models.Directory) error             { return errReadOnly }
+func (p *sharePolicy) CanPut(*models.Directory, *models.File) error { return errReadOnly }
+func (p *sharePolicy) CanRemove(*vfs.Node) error                    { return errReadOnly }
+func (p *sharePolicy) CanMove(*vfs.Node, *models.Directory) error   { return errReadOnly }
+
+// 访问路径上有未验证密码的目录时，使用基本认证中的密码验证，失败时返回401要求客户端输入密码
+func (p *sharePolicy) unlockPath(fs *vfs.FS, name string) bool {
+	for {
+		var locked *models.Directory
+		n, chain, err := fs.Lookup(name)
+		if err == nil && n.Dir != nil && !p.OpenDir(n.Dir) {
+			locked = n.Dir
+		} else if err != nil && len(chain) > 0 && !p.OpenDir(chain[len(chain)-1]) {
+			locked = chain[len(chain)-1]
+		}
+		if locked == nil {
+			return true
+		}
+		if !p.unlock(locked) {
+			return false
+		}
+	}
+}
+
+// 验证目录密码，和共享页面验证目录密码使用相同的失败锁定
+func (p *sharePolicy) unlock(dir *models.Directory) bool {
+	if p.password == "" {
+		p.challenge("Directory password required")
+		return false
+	}
+
+	ipKey, dirKey := lockout.IPKey(p.c), lockout.ScopedKey(p.c, "dir:"+dir.ID)
+	if !lockout.Guard(p.c, dirKey) {
+		return false
+	}
+	lockout.Throttle(ipKey)
+	if !utils.CheckPassword(dir.Password, p.password) {
+		lockout.Fail(ipKey, dirKey)
+		p.challenge("Invalid password")
+		return false
+	}
+	lockout.Succeed(dirKey)
+
+	p.unlocked[dir.ID] = true
+	return true
+}
+
+// 返回401，让客户端提示输入密码
+func (p *sharePolicy) challenge(message string) {
+	p.c.Header("WWW-Authenticate", `Basic realm="`+Realm+`", charset="UTF-8"`)
+	p.c.JSON(http.StatusUnauthorized, gin.H{"error": message, "requirePassword": true})
+}
@@ -0,0 +1,103 @@
+package directory
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/acl"
+	"fileshare/common"
+	"fileshare/models"
+	"fileshare/user"
+)
+
+// 获取目录的访问控制列表，包括从上级目录继承的条目
+func GetDirectoryACL(c *gin.Context) {
+	id := c.Param("id")
+
+	chain := common.FindDirectoryChain(models.Directories, id)
+	if len(chain) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Directory not found"})
+		return
+	}
+
+	inherited := []gin.H{}
+	for _, dir := range chain[:len(chain)-1] {
+		for _, entry := range dir.ACL {
+			inherited = append(inherited, gin.H{
+				"type":          entry.Type,
+				"subject":       entry.Subject,
+				"permissions":   entry.Permissions,
+				"directoryId":   dir.ID,
+				"directoryName": dir.Name,
+			})
+		}
+	}
+
+	entries := chain[len(chain)-1].ACL
+	if entries == nil {
+		entries = []*models.ACLEntry{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"acl":       entries,
+		"inherited": inherited,
+	})
+}
+
+// 设置目录的访问控制列表，传入空列表表示清除
+func SetDirectoryACL(c *gin.Context) {
+	id := c.Param("id")
+
+	var req struct {
+		ACL []*models.ACLEntry `json:"acl"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	for _, entry := range req.ACL {
+		entry.Subject = strings.TrimSpace(entry.Subject)
+		if !acl.IsValidSubjectType(entry.Type) || entry.Subject == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid ACL entry"})
+			return
+		}
+		if entry.Type == acl.SubjectUser && user.FindByUsername(entry.Subject) == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "User not found: " + entry.Subject})
+			return
+		}
+		if len(entry.Permissions) == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "ACL entry must grant at least one permission"})
+			return
+		}
+		for _, perm := range entry.Permissions {
+			if !acl.IsValidPermission(perm) {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid permission: " + perm})
+				return
+			}
+		}
+	}
+
+	var targetDir *models.Directory
+	common.FindDirectory(models.Directories, id, &targetDir)
+	if targetDir == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Directory not found"})
+		return
+	}
+
+	targetDir.ACL = req.ACL
+	if len(req.ACL) == 0 {
+		targetDir.ACL = nil
+	}
+
+	// 保存配置
+	if err := SaveDirectories(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save directory"})
+		return
+	}
+
+	c.JSON(http.StatusOK, common.SanitizeDirectory(targetDir))
+}
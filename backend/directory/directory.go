@@ -2,6 +2,7 @@ package directory
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
 	"os"
@@ -9,9 +10,15 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"fileshare/access"
+	"fileshare/acl"
 	"fileshare/common"
 	"fileshare/config"
+	"fileshare/lockout"
 	"fileshare/models"
+	"fileshare/stats"
+	"fileshare/user"
+	"fileshare/utils"
 )
 
 // 配置文件路径
@@ -19,6 +26,14 @@ const (
 	GroupConfigPath = "./config/config-group.json"
 )
 
+// 目录操作的错误
+var (
+	ErrLinkDirNotAllowed = errors.New("Adding link directories is not allowed by server configuration")
+	ErrParentNotFound    = errors.New("Parent directory not found")
+	ErrDirectoryNotFound = errors.New("Directory not found")
+	ErrInvalidMove       = errors.New("Cannot move a directory into itself or its subdirectory")
+)
+
 // 加载目录配置
 func LoadDirectories() {
 	data, err := os.ReadFile(GroupConfigPath)
@@ -34,7 +49,36 @@ func LoadDirectories() {
 	if err := json.Unmarshal(data, &models.Directories); err != nil {
 		log.Printf("Failed to parse directory config: %v", err)
 		models.Directories = []*models.Directory{}
+		return
+	}
+
+	// 将明文密码迁移为哈希
+	if migratePasswords(models.Directories) {
+		if err := SaveDirectories(); err != nil {
+			log.Printf("Failed to save migrated directory passwords: %v", err)
+		}
+	}
+}
+
+// 递归将明文目录密码转换为哈希，返回是否有修改
+func migratePasswords(dirs []*models.Directory) bool {
+	changed := false
+	for _, dir := range dirs {
+		if dir.Password != "" && !utils.IsPasswordHash(dir.Password) {
+			hash, err := utils.HashPassword(dir.Password)
+			if err != nil {
+				log.Printf("Failed to hash password of directory %s: %v", dir.ID, err)
+				continue
+			}
+			dir.Password = hash
+			changed = true
+		}
+
+		if len(dir.Children) > 0 && migratePasswords(dir.Children) {
+			changed = true
+		}
 	}
+	return changed
 }
 
 // 保存目录配置
@@ -48,55 +92,13 @@ func SaveDirectories() error {
 
 // 获取所有目录
 func GetDirectories(c *gin.Context) {
-	c.JSON(http.StatusOK, models.Directories)
+	// 只返回当前用户（或API密钥）可以浏览的目录
+	c.JSON(http.StatusOK, acl.FilterDirectories(c, models.Directories, user.PermRead))
 }
 
 // 获取共享目录
 func GetSharedDirectories(c *gin.Context) {
-	sharedDirs := FilterSharedDirectories(models.Directories)
-	c.JSON(http.StatusOK, sharedDirs)
-}
-
-// 过滤共享目录
-func FilterSharedDirectories(dirs []*models.Directory) []*models.Directory {
-	result := []*models.Directory{}
-
-	for _, dir := range dirs {
-		if dir.IsShared {
-			// 创建一个新的目录对象，避免修改原始数据
-			sharedDir := &models.Directory{
-				ID:       dir.ID,
-				Name:     dir.Name,
-				ParentID: dir.ParentID,
-				IsShared: dir.IsShared,
-				Password: dir.Password,
-			}
-
-			// 递归处理子目录
-			if len(dir.Children) > 0 {
-				sharedDir.Children = FilterSharedDirectories(dir.Children)
-			}
-
-			result = append(result, sharedDir)
-		} else if len(dir.Children) > 0 {
-			// 检查子目录是否有共享的
-			sharedChildren := FilterSharedDirectories(dir.Children)
-			if len(sharedChildren) > 0 {
-				// 创建一个新的目录对象，只包含共享的子目录
-				sharedDir := &models.Directory{
-					ID:       dir.ID,
-					Name:     dir.Name,
-					ParentID: dir.ParentID,
-					IsShared: dir.IsShared,
-					Password: dir.Password,
-					Children: sharedChildren,
-				}
-				result = append(result, sharedDir)
-			}
-		}
-	}
-
-	return result
+	c.JSON(http.StatusOK, access.ClientDirectories(c, models.Directories))
 }
 
 // 创建目录
@@ -112,36 +114,53 @@ func CreateDirectory(c *gin.Context) {
 		return
 	}
 
+	newDir, err := NewDirectory(req.Name, req.ParentID, req.DirType)
+	switch {
+	case errors.Is(err, ErrLinkDirNotAllowed):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	case errors.Is(err, ErrParentNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save directory"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, newDir)
+}
+
+// NewDirectory 创建目录并保存配置，parentID为空时创建在根目录下，dirType为空时为存储型
+func NewDirectory(name, parentID, dirType string) (*models.Directory, error) {
 	// 如果未指定目录类型，默认为存储型
-	if req.DirType == "" {
-		req.DirType = "storage"
+	if dirType == "" {
+		dirType = "storage"
 	}
 
 	// 检查是否允许添加链接型目录
 	serverConfig := config.GetServerConfig()
-	if req.DirType == "link" && !serverConfig.Server.LinkDirAdd {
-		c.JSON(http.StatusForbidden, gin.H{"error": "Adding link directories is not allowed by server configuration"})
-		return
+	if dirType == "link" && !serverConfig.Server.LinkDirAdd {
+		return nil, ErrLinkDirNotAllowed
 	}
 
 	// 创建新目录
 	newDir := &models.Directory{
 		ID:       uuid.New().String(),
-		Name:     req.Name,
-		ParentID: req.ParentID,
+		Name:     name,
+		ParentID: parentID,
 		IsShared: false,
-		DirType:  req.DirType,
+		Inherit:  parentID != "", // 子目录默认沿用上级目录的共享状态
+		DirType:  dirType,
 		Children: []*models.Directory{},
 	}
 
 	// 如果有父目录，添加到父目录的子目录中
-	if req.ParentID != "" {
+	if parentID != "" {
 		parentFound := false
-		AddToParent(models.Directories, req.ParentID, newDir, &parentFound)
+		AddToParent(models.Directories, parentID, newDir, &parentFound)
 
 		if !parentFound {
-			c.JSON(http.StatusNotFound, gin.H{"error": "Parent directory not found"})
-			return
+			return nil, ErrParentNotFound
 		}
 	} else {
 		// 添加到根目录
@@ -150,11 +169,9 @@ func CreateDirectory(c *gin.Context) {
 
 	// 保存配置
 	if err := SaveDirectories(); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save directory"})
-		return
+		return nil, err
 	}
-
-	c.JSON(http.StatusCreated, newDir)
+	return newDir, nil
 }
 
 // 递归添加到父目录
@@ -228,45 +245,103 @@ func UpdateDirectoryName(dirs []*models.Directory, id string, newName string, fo
 func DeleteDirectory(c *gin.Context) {
 	id := c.Param("id")
 
+	// 删除会连同子目录一起删除，需要拥有整个子树的管理权限
+	var targetDir *models.Directory
+	common.FindDirectory(models.Directories, id, &targetDir)
+	if targetDir != nil && !acl.CanAccessTree(c, targetDir, user.PermManage) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "权限不足"})
+		return
+	}
+
+	err := RemoveDirectory(id)
+	switch {
+	case errors.Is(err, ErrDirectoryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save directory"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Directory deleted successfully"})
+}
+
+// RemoveDirectory 删除目录及其子目录，同时删除其中的文件记录和下载统计，并保存配置
+func RemoveDirectory(id string) error {
+	var target *models.Directory
+	common.FindDirectory(models.Directories, id, &target)
+	if target == nil {
+		return ErrDirectoryNotFound
+	}
+
+	// 删除该目录下的所有文件（需要在移除目录之前查找子目录）
+	removed := common.DeleteFilesInDirectory(id)
+	stats.RemoveStats(removed...)
+
 	// 从根目录中删除
+	dirFound := false
 	for i, dir := range models.Directories {
 		if dir.ID == id {
-			// 删除该目录下的所有文件
-			common.DeleteFilesInDirectory(id)
-
-			// 删除目录
 			models.Directories = append(models.Directories[:i], models.Directories[i+1:]...)
-
-			// 保存配置
-			if err := SaveDirectories(); err != nil {
-				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save directory"})
-				return
-			}
-
-			c.JSON(http.StatusOK, gin.H{"message": "Directory deleted successfully"})
-			return
+			dirFound = true
+			break
 		}
 	}
 
 	// 从子目录中删除
-	dirFound := false
-	DeleteFromParent(models.Directories, id, &dirFound)
-
 	if !dirFound {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Directory not found"})
-		return
+		DeleteFromParent(models.Directories, id, &dirFound)
 	}
 
-	// 删除该目录下的所有文件
-	common.DeleteFilesInDirectory(id)
-
 	// 保存配置
-	if err := SaveDirectories(); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save directory"})
-		return
+	return SaveDirectories()
+}
+
+// MoveDirectory 重命名目录，parentID与原父目录不同时同时移动到新的父目录下（为空表示根目录），并保存配置
+func MoveDirectory(id, parentID, name string) error {
+	var dir *models.Directory
+	common.FindDirectory(models.Directories, id, &dir)
+	if dir == nil {
+		return ErrDirectoryNotFound
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Directory deleted successfully"})
+	if parentID != dir.ParentID {
+		// 不能移动到自身或子目录下
+		parentChain := common.FindDirectoryChain(models.Directories, parentID)
+		if parentID != "" && parentChain == nil {
+			return ErrParentNotFound
+		}
+		for _, parent := range parentChain {
+			if parent.ID == id {
+				return ErrInvalidMove
+			}
+		}
+
+		// 从原位置移除
+		detached := false
+		for i, root := range models.Directories {
+			if root.ID == id {
+				models.Directories = append(models.Directories[:i], models.Directories[i+1:]...)
+				detached = true
+				break
+			}
+		}
+		if !detached {
+			DeleteFromParent(models.Directories, id, &detached)
+		}
+
+		// 添加到新的父目录
+		if parentID == "" {
+			models.Directories = append(models.Directories, dir)
+		} else {
+			parentFound := false
+			AddToParent(models.Directories, parentID, dir, &parentFound)
+		}
+		dir.ParentID = parentID
+	}
+
+	dir.Name = name
+	return SaveDirectories()
 }
 
 // 递归从父目录中删除子目录
@@ -294,8 +369,10 @@ func DeleteFromParent(dirs []*models.Directory, id string, found *bool) {
 func ToggleDirectoryShare(c *gin.Context) {
 	id := c.Param("id")
 
+	// inherit为true时清除目录自身的设置，改为沿用上级目录的共享状态
 	var req struct {
 		IsShared bool `json:"isShared"`
+		Inherit  bool `json:"inherit"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -305,7 +382,7 @@ func ToggleDirectoryShare(c *gin.Context) {
 
 	// 查找并更新目录共享状态
 	dirFound := false
-	UpdateDirectoryShare(models.Directories, id, req.IsShared, &dirFound)
+	UpdateDirectoryShare(models.Directories, id, req.IsShared, req.Inherit, &dirFound)
 
 	if !dirFound {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Directory not found"})
@@ -321,17 +398,18 @@ func ToggleDirectoryShare(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "Directory share status updated successfully"})
 }
 
-// 递归更新目录共享状态
-func UpdateDirectoryShare(dirs []*models.Directory, id string, isShared bool, found *bool) {
+// 递归更新目录共享状态，inherit为true时目录沿用上级目录的共享状态
+func UpdateDirectoryShare(dirs []*models.Directory, id string, isShared, inherit bool, found *bool) {
 	for _, dir := range dirs {
 		if dir.ID == id {
-			dir.IsShared = isShared
+			dir.IsShared = isShared && !inherit
+			dir.Inherit = inherit
 			*found = true
 			return
 		}
 
 		if len(dir.Children) > 0 {
-			UpdateDirectoryShare(dir.Children, id, isShared, found)
+			UpdateDirectoryShare(dir.Children, id, isShared, inherit, found)
 			if *found {
 				return
 			}
@@ -352,9 +430,20 @@ func SetDirectoryPassword(c *gin.Context) {
 		return
 	}
 
+	// 密码以哈希形式保存，空密码表示清除
+	passwordHash := ""
+	if req.Password != "" {
+		hash, err := utils.HashPassword(req.Password)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash password"})
+			return
+		}
+		passwordHash = hash
+	}
+
 	// 查找并更新目录密码
 	dirFound := false
-	UpdateDirectoryPassword(models.Directories, id, req.Password, &dirFound)
+	UpdateDirectoryPassword(models.Directories, id, passwordHash, &dirFound)
 
 	if !dirFound {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Directory not found"})
@@ -401,22 +490,49 @@ func VerifyDirectoryPassword(c *gin.Context) {
 		return
 	}
 
-	// 查找目录
-	var targetDir *models.Directory
-	common.FindDirectory(models.Directories, id, &targetDir)
+	// 查找目录，对访客不可见的目录视为不存在
+	chain := common.FindDirectoryChain(models.Directories, id)
+	if !access.IsChainVisible(chain) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Directory not found"})
+		return
+	}
+	if !access.RequireNetwork(c, chain) {
+		return
+	}
 
+	// 校验目录链上第一个未验证的密码目录（可能是提升显示的目录的隐藏上级目录），
+	// 目录链上的密码都已验证时不需要新的访问令牌
+	targetDir := access.LockedDirectory(c, chain)
 	if targetDir == nil {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Directory not found"})
+		c.JSON(http.StatusOK, gin.H{"message": "Password verified successfully"})
+		return
+	}
+
+	// 按IP和目录的组合锁定（其他客户端的失败不会锁定目录），按客户端IP只延迟，
+	// 打开多个密码目录的访客不会因此被锁定
+	ipKey, dirKey := lockout.IPKey(c), lockout.ScopedKey(c, "dir:"+targetDir.ID)
+	if !lockout.Guard(c, dirKey) {
 		return
 	}
+	lockout.Throttle(ipKey)
 
-	// 验证密码
-	if targetDir.Password != "" && targetDir.Password != req.Password {
+	// 验证密码，空密码不计入失败次数
+	if !utils.CheckPassword(targetDir.Password, req.Password) {
+		if req.Password != "" {
+			lockout.Fail(ipKey, dirKey)
+		}
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid password"})
 		return
 	}
+	lockout.Succeed(dirKey)
 
-	c.JSON(http.StatusOK, gin.H{"message": "Password verified successfully"})
+	// 签发目录访问令牌，对该目录及其子目录有效
+	token, expiresAt := access.GrantDirectoryAccess(c, targetDir)
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Password verified successfully",
+		"token":     token,
+		"expiresAt": expiresAt,
+	})
 }
 
 // 检查目录是否存在
@@ -0,0 +1,97 @@
+package directory
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+
+	"fileshare/models"
+)
+
+const testPassword = "secret"
+
+// 创建count个共享的密码目录
+func passwordDirectories(t *testing.T, count int) []*models.Directory {
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dirs := make([]*models.Directory, count)
+	for i := range dirs {
+		dirs[i] = &models.Directory{ID: fmt.Sprintf("dir%d", i), IsShared: true, Password: string(hash)}
+	}
+	return dirs
+}
+
+// 以指定客户端IP验证目录密码，返回状态码
+func verify(ip, dirID, password string) int {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"password":"`+password+`"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.RemoteAddr = ip + ":12345"
+	c.Params = gin.Params{{Key: "id", Value: dirID}}
+	VerifyDirectoryPassword(c)
+	return w.Code
+}
+
+type attempt struct {
+	dirID    string
+	password string
+	want     int
+}
+
+func TestVerifyDirectoryPasswordLockout(t *testing.T) {
+	saved := models.Directories
+	defer func() { models.Directories = saved }()
+	models.Directories = passwordDirectories(t, 7)
+
+	// 连续5次错误后锁定
+	wrong := make([]attempt, 5)
+	for i := range wrong {
+		wrong[i] = attempt{"dir0", "wrong", http.StatusUnauthorized}
+	}
+
+	// 共享页面打开目录前会先用空密码检查，再提交正确的密码
+	var browse []attempt
+	for _, dir := range models.Directories {
+		browse = append(browse, attempt{dir.ID, "", http.StatusUnauthorized}, attempt{dir.ID, testPassword, http.StatusOK})
+	}
+
+	tests := []struct {
+		name     string
+		ip       string
+		attempts []attempt
+	}{
+		{"打开多个密码目录不会被锁定", "10.0.0.1", browse},
+		{"空密码不计入失败次数", "10.0.0.2", append(repeat(attempt{"dir0", "", http.StatusUnauthorized}, 10), attempt{"dir0", testPassword, http.StatusOK})},
+		{"多次错误后锁定目录", "10.0.0.3", append(wrong, attempt{"dir0", testPassword, http.StatusTooManyRequests})},
+		{"锁定一个目录不影响其他目录", "10.0.0.4", append(wrong, attempt{"dir1", testPassword, http.StatusOK})},
+		{"其他客户端的失败不会锁定目录", "10.0.0.5", []attempt{{"dir0", testPassword, http.StatusOK}}},
+		{"不存在的目录", "10.0.0.6", []attempt{{"missing", testPassword, http.StatusNotFound}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i, a := range tt.attempts {
+				if got := verify(tt.ip, a.dirID, a.password); got != a.want {
+					t.Fatalf("attempt %d (%s, %q) = %d, want %d", i, a.dirID, a.password, got, a.want)
+				}
+			}
+		})
+	}
+}
+
+func repeat(a attempt, count int) []attempt {
+	attempts := make([]attempt, count)
+	for i := range attempts {
+		attempts[i] = a
+	}
+	return attempts
+}
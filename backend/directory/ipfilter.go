@@ -0,0 +1,78 @@
+package directory
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/common"
+	"fileshare/ipfilter"
+	"fileshare/models"
+)
+
+// 获取目录的IP访问限制，包括从上级目录继承的限制
+func GetDirectoryIPFilter(c *gin.Context) {
+	id := c.Param("id")
+
+	chain := common.FindDirectoryChain(models.Directories, id)
+	if len(chain) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Directory not found"})
+		return
+	}
+
+	inherited := []gin.H{}
+	for _, dir := range chain[:len(chain)-1] {
+		if dir.IPFilter != nil {
+			inherited = append(inherited, gin.H{
+				"allow":         dir.IPFilter.Allow,
+				"deny":          dir.IPFilter.Deny,
+				"directoryId":   dir.ID,
+				"directoryName": dir.Name,
+			})
+		}
+	}
+
+	filter := chain[len(chain)-1].IPFilter
+	if filter == nil {
+		filter = &models.IPFilter{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"ipFilter":  filter,
+		"inherited": inherited,
+	})
+}
+
+// 设置目录的IP访问限制，allow和deny都为空表示清除
+func SetDirectoryIPFilter(c *gin.Context) {
+	id := c.Param("id")
+
+	var req models.IPFilter
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter, err := ipfilter.Normalize(&req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var targetDir *models.Directory
+	common.FindDirectory(models.Directories, id, &targetDir)
+	if targetDir == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Directory not found"})
+		return
+	}
+
+	targetDir.IPFilter = filter
+
+	// 保存配置
+	if err := SaveDirectories(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save directory"})
+		return
+	}
+
+	c.JSON(http.StatusOK, common.SanitizeDirectory(targetDir))
+}
@@ -0,0 +1,295 @@
+package file
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/access"
+	"fileshare/acl"
+	"fileshare/apikey"
+	"fileshare/common"
+	"fileshare/models"
+	"fileshare/user"
+)
+
+// 归档格式
+const (
+	ArchiveFormatZip   = "zip"
+	ArchiveFormatTarGz = "tar.gz"
+)
+
+// 归档条目，file为nil时表示目录
+type archiveEntry struct {
+	name string // 归档内的相对路径
+	file *models.File
+}
+
+// 下载共享目录归档（只包含可见的文件，跳过未验证密码的子目录）
+func DownloadDirectoryArchive(c *gin.Context) {
+	id := c.Param("id")
+
+	// 检查目录可见性及目录链的访问令牌
+	chain, ok := access.RequireVisibleDirectory(c, id)
+	if !ok {
+		return
+	}
+
+	// 子目录按共享端目录树的规则处理，跳过未验证密码的子目录
+	targetDir := chain[len(chain)-1]
+	entries := collectArchiveEntries(targetDir, "",
+		func(dir *models.Directory) common.DirMode {
+			if !access.HasDirectoryAccess(c, dir) {
+				return common.DirHidden
+			}
+			return access.ListMode(c, dir)
+		},
+		func(file *models.File) bool { return file.IsShared },
+	)
+
+	writeArchive(c, targetDir.Name, c.Query("format"), entries, nil)
+}
+
+// 管理员下载目录归档（不检查共享状态和密码）
+func AdminDownloadDirectoryArchive(c *gin.Context) {
+	id := c.Param("id")
+
+	// 查找目录
+	var targetDir *models.Directory
+	common.FindDirectory(models.Directories, id, &targetDir)
+	if targetDir == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Directory not found"})
+		return
+	}
+
+	// 没有浏览权限的子目录按管理端目录树的规则处理
+	u, k := user.Current(c), apikey.Current(c)
+	entries := collectArchiveEntries(targetDir, "",
+		func(dir *models.Directory) common.DirMode { return acl.ListMode(u, k, dir, user.PermRead) },
+		func(file *models.File) bool { return true },
+	)
+
+	writeArchive(c, targetDir.Name, c.Query("format"), entries, nil)
+}
+
+// 发送目录树中所有文件的归档（跳过不允许客户端IP访问的子目录），格式由format查询参数指定。
+// beforeSend不为nil时在开始发送前调用，返回false时不发送（由beforeSend写入错误响应）
+func SendDirectoryArchive(c *gin.Context, dir *models.Directory, beforeSend func() bool) {
+	entries := collectArchiveEntries(dir, "",
+		func(dir *models.Directory) common.DirMode {
+			if !access.IPAllowed(c, dir) {
+				return common.DirHidden
+			}
+			return common.DirShown
+		},
+		func(file *models.File) bool { return true },
+	)
+
+	writeArchive(c, dir.Name, c.Query("format"), entries, beforeSend)
+}
+
+// 递归收集目录树中的文件和子目录，listDir决定子目录的显示方式（不显示的目录中显示的子目录提升到上级目录中）
+func collectArchiveEntries(dir *models.Directory, prefix string, listDir func(*models.Directory) common.DirMode, includeFile func(*models.File) bool) []archiveEntry {
+	entries := []archiveEntry{}
+	usedNames := map[string]bool{}
+
+	for _, file := range models.Files {
+		if file.DirectoryID != dir.ID || !includeFile(file) {
+			continue
+		}
+		name := UniqueName(usedNames, file.Name)
+		entries = append(entries, archiveEntry{name: path.Join(prefix, name), file: file})
+	}
+
+	var addDirs func(dirs []*models.Directory)
+	addDirs = func(dirs []*models.Directory) {
+		for _, child := range dirs {
+			switch listDir(child) {
+			case common.DirShown:
+				childPrefix := path.Join(prefix, UniqueName(usedNames, child.Name))
+				entries = append(entries, archiveEntry{name: childPrefix + "/"})
+				entries = append(entries, collectArchiveEntries(child, childPrefix, listDir, includeFile)...)
+			case common.DirElided:
+				addDirs(child.Children)
+			}
+		}
+	}
+	addDirs(dir.Children)
+
+	return entries
+}
+
+// UniqueName 生成在同一目录中唯一且安全的名称，重名时加上序号（用于归档和WebDAV）
+func UniqueName(used map[string]bool, name string) string {
+	name = strings.NewReplacer("/", "_", "\\", "_").Replace(name)
+	if name == "" || name == "." || name == ".." {
+		name = "_"
+	}
+
+	ext := path.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	candidate := name
+	for i := 1; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s (%d)%s", base, i, ext)
+	}
+	used[candidate] = true
+
+	return candidate
+}
+
+// 以流的方式写出归档，不生成临时文件。beforeSend见SendDirectoryArchive
+func writeArchive(c *gin.Context, baseName, format string, entries []archiveEntry, beforeSend func() bool) {
+	if format == "" {
+		format = ArchiveFormatZip
+	}
+
+	var contentType string
+	switch format {
+	case ArchiveFormatZip:
+		contentType = "application/zip"
+	case ArchiveFormatTarGz, "tgz":
+		format = ArchiveFormatTarGz
+		contentType = "application/gzip"
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unsupported archive format"})
+		return
+	}
+	if beforeSend != nil && !beforeSend() {
+		return
+	}
+
+	// 设置响应头
+	fileName := baseName + "." + format
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	c.Header("Content-Type", contentType)
+	c.Status(http.StatusOK)
+
+	var err error
+	if format == ArchiveFormatZip {
+		err = writeZip(c.Writer, entries)
+	} else {
+		err = writeTarGz(c.Writer, entries)
+	}
+	if err != nil {
+		log.Printf("Failed to send archive: %v", err)
+	}
+}
+
+// 写出zip归档
+func writeZip(w io.Writer, entries []archiveEntry) error {
+	zw := zip.NewWriter(w)
+
+	for _, entry := range entries {
+		if entry.file == nil {
+			header := &zip.FileHeader{Name: entry.name, Modified: time.Now()}
+			if _, err := zw.CreateHeader(header); err != nil {
+				return err
+			}
+			continue
+		}
+
+		src, info, err := openArchiveFile(entry.file)
+		if err != nil {
+			log.Printf("Skipping file %s in archive: %v", entry.file.Path, err)
+			continue
+		}
+
+		header := &zip.FileHeader{
+			Name:     entry.name,
+			Method:   zip.Deflate,
+			Modified: info.ModTime(),
+		}
+		dst, err := zw.CreateHeader(header)
+		if err == nil {
+			_, err = io.Copy(dst, src)
+		}
+		src.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// 写出tar.gz归档
+func writeTarGz(w io.Writer, entries []archiveEntry) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	for _, entry := range entries {
+		if entry.file == nil {
+			header := &tar.Header{
+				Typeflag: tar.TypeDir,
+				Name:     entry.name,
+				Mode:     0755,
+				ModTime:  time.Now(),
+				Format:   tar.FormatPAX,
+			}
+			if err := tw.WriteHeader(header); err != nil {
+				return err
+			}
+			continue
+		}
+
+		src, info, err := openArchiveFile(entry.file)
+		if err != nil {
+			log.Printf("Skipping file %s in archive: %v", entry.file.Path, err)
+			continue
+		}
+
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     entry.name,
+			Mode:     0644,
+			Size:     info.Size(),
+			ModTime:  info.ModTime(),
+			Format:   tar.FormatPAX,
+		}
+		err = tw.WriteHeader(header)
+		if err == nil {
+			// 只写出头部声明的大小，避免文件在写出过程中被修改
+			_, err = io.CopyN(tw, src, info.Size())
+		}
+		src.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// 打开归档中的文件
+func openArchiveFile(file *models.File) (*os.File, os.FileInfo, error) {
+	src, err := os.Open(file.Path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info, err := src.Stat()
+	if err != nil {
+		src.Close()
+		return nil, nil, err
+	}
+	if info.IsDir() {
+		src.Close()
+		return nil, nil, fmt.Errorf("%s is a directory", file.Path)
+	}
+
+	return src, info, nil
+}
@@ -0,0 +1,188 @@
+package file
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/access"
+	"fileshare/models"
+	"fileshare/utils"
+)
+
+// 替换目录和文件列表，测试结束后恢复
+func useModels(t *testing.T, dirs []*models.Directory, files []*models.File) {
+	savedDirs, savedFiles := models.Directories, models.Files
+	t.Cleanup(func() { models.Directories, models.Files = savedDirs, savedFiles })
+	models.Directories, models.Files = dirs, files
+}
+
+// 在临时目录中创建文件记录，文件内容为名称
+func testFile(t *testing.T, dir, id, dirID, name string, shared bool) *models.File {
+	path := filepath.Join(dir, id)
+	if err := os.WriteFile(path, []byte(name), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return &models.File{ID: id, DirectoryID: dirID, Name: name, Path: path, IsShared: shared}
+}
+
+// 读取zip归档，返回条目名称到内容的映射，目录的内容为空
+func readZip(t *testing.T, data []byte) map[string]string {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		entries[f.Name] = string(content)
+	}
+	return entries
+}
+
+// 读取tar.gz归档，目录条目以/结尾，和zip相同
+func readTarGz(t *testing.T, data []byte) map[string]string {
+	gr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gr)
+	entries := map[string]string{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		name := header.Name
+		if header.Typeflag == tar.TypeDir && name[len(name)-1] != '/' {
+			name += "/"
+		}
+		entries[name] = string(content)
+	}
+	return entries
+}
+
+func TestDownloadDirectoryArchive(t *testing.T) {
+	tmp := t.TempDir()
+
+	// docs（共享）
+	//   ├─ a.txt（共享）、draft.txt（未共享）
+	//   ├─ public（继承共享）
+	//   │   └─ b.txt
+	//   ├─ hidden（不共享）
+	//   │   ├─ h.txt
+	//   │   └─ revealed（共享，提升到docs中）
+	//   │       └─ r.txt
+	//   ├─ locked（共享，设置了密码）
+	//   │   └─ l.txt
+	//   └─ blocked（共享，不允许客户端IP访问）
+	//       └─ x.txt
+	revealed := &models.Directory{ID: "revealed", ParentID: "hidden", Name: "revealed", IsShared: true}
+	locked := &models.Directory{ID: "locked", ParentID: "docs", Name: "locked", IsShared: true, Password: "hash"}
+	docs := &models.Directory{ID: "docs", Name: "docs", IsShared: true, Children: []*models.Directory{
+		{ID: "public", ParentID: "docs", Name: "public", Inherit: true},
+		{ID: "hidden", ParentID: "docs", Name: "hidden", Children: []*models.Directory{revealed}},
+		locked,
+		{ID: "blocked", ParentID: "docs", Name: "blocked", IsShared: true, IPFilter: &models.IPFilter{Deny: []string{"192.0.2.0/24"}}},
+	}}
+	private := &models.Directory{ID: "private", Name: "private"}
+	useModels(t, []*models.Directory{docs, private}, []*models.File{
+		testFile(t, tmp, "a", "docs", "a.txt", true),
+		testFile(t, tmp, "draft", "docs", "draft.txt", false),
+		testFile(t, tmp, "b", "public", "b.txt", true),
+		testFile(t, tmp, "h", "hidden", "h.txt", true),
+		testFile(t, tmp, "r", "revealed", "r.txt", true),
+		testFile(t, tmp, "l", "locked", "l.txt", true),
+		testFile(t, tmp, "x", "blocked", "x.txt", true),
+		testFile(t, tmp, "p", "private", "p.txt", true),
+	})
+	lockedToken, _ := utils.GenerateDirectoryToken("locked", locked.Password)
+
+	guest := []string{"a.txt", "public/", "public/b.txt", "revealed/", "revealed/r.txt"}
+	unlocked := append([]string{"locked/", "locked/l.txt"}, guest...)
+
+	tests := []struct {
+		name    string
+		dirID   string
+		format  string
+		token   string
+		status  int
+		entries []string
+	}{
+		{"zip", "docs", "", "", http.StatusOK, guest},
+		{"tar.gz", "docs", "tar.gz", "", http.StatusOK, guest},
+		{"zip包含已验证密码的子目录", "docs", "zip", lockedToken, http.StatusOK, unlocked},
+		{"tar.gz包含已验证密码的子目录", "docs", "tgz", lockedToken, http.StatusOK, unlocked},
+		{"密码目录本身需要令牌", "locked", "zip", "", http.StatusForbidden, nil},
+		{"已验证密码的目录", "locked", "zip", lockedToken, http.StatusOK, []string{"l.txt"}},
+		{"不共享的目录", "private", "zip", "", http.StatusNotFound, nil},
+		{"不支持的格式", "docs", "rar", "", http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/?format="+tt.format, nil)
+			c.Request.RemoteAddr = "192.0.2.10:12345"
+			if tt.token != "" {
+				c.Request.Header.Set(access.TokenHeader, tt.token)
+			}
+			c.Params = gin.Params{{Key: "id", Value: tt.dirID}}
+			DownloadDirectoryArchive(c)
+
+			if w.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.status, w.Body)
+			}
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			var entries map[string]string
+			if tt.format == "tar.gz" || tt.format == "tgz" {
+				entries = readTarGz(t, w.Body.Bytes())
+			} else {
+				entries = readZip(t, w.Body.Bytes())
+			}
+			names := []string{}
+			for name, content := range entries {
+				names = append(names, name)
+				if name[len(name)-1] != '/' && content != filepath.Base(name) {
+					t.Errorf("%s content = %q", name, content)
+				}
+			}
+			sort.Strings(names)
+			want := append([]string(nil), tt.entries...)
+			sort.Strings(want)
+			if !reflect.DeepEqual(names, want) {
+				t.Errorf("entries = %v, want %v", names, want)
+			}
+		})
+	}
+}
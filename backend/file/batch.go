@@ -0,0 +1,218 @@
+package file
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/access"
+	"fileshare/acl"
+	"fileshare/models"
+	"fileshare/user"
+)
+
+// 批量下载票据的有效期
+const batchTicketTTL = 5 * time.Minute
+
+// 批量下载票据
+type batchTicket struct {
+	fileIDs   []string
+	userID    string // 签发票据的用户，仅管理端票据使用
+	expiresAt time.Time
+}
+
+// 批量下载票据存储，共享端和管理端的票据分开存储，只能在签发的一端使用
+type ticketStore struct {
+	mu      sync.Mutex
+	tickets map[string]*batchTicket
+}
+
+var (
+	shareBatchTickets = &ticketStore{tickets: make(map[string]*batchTicket)}
+	adminBatchTickets = &ticketStore{tickets: make(map[string]*batchTicket)}
+)
+
+// 保存票据，同时清理过期票据
+func (s *ticketStore) add(id string, ticket *batchTicket) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for existing, t := range s.tickets {
+		if time.Now().After(t.expiresAt) {
+			delete(s.tickets, existing)
+		}
+	}
+	s.tickets[id] = ticket
+}
+
+// 查找未过期的票据
+func (s *ticketStore) get(id string) *batchTicket {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ticket, exists := s.tickets[id]
+	if !exists {
+		return nil
+	}
+	if time.Now().After(ticket.expiresAt) {
+		delete(s.tickets, id)
+		return nil
+	}
+	return ticket
+}
+
+// 批量下载请求结构
+type batchDownloadRequest struct {
+	FileIDs []string `json:"fileIds" binding:"required"`
+}
+
+// 创建共享文件批量下载票据
+func CreateBatchDownload(c *gin.Context) {
+	var req batchDownloadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	files, ok := findBatchFiles(c, req.FileIDs)
+	if !ok {
+		return
+	}
+
+	// 检查每个文件的可见性和目录访问令牌
+	for _, file := range files {
+		if !access.RequireVisibleFile(c, file) {
+			return
+		}
+	}
+
+	issueBatchTicket(c, shareBatchTickets, &batchTicket{fileIDs: req.FileIDs})
+}
+
+// 管理员创建批量下载票据（不检查共享状态）
+func AdminCreateBatchDownload(c *gin.Context) {
+	var req batchDownloadRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	files, ok := findBatchFiles(c, req.FileIDs)
+	if !ok {
+		return
+	}
+
+	// 检查每个文件所在目录的访问权限
+	for _, file := range files {
+		if !acl.CanAccess(c, file.DirectoryID, user.PermRead) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "权限不足", "fileId": file.ID})
+			return
+		}
+	}
+
+	issueBatchTicket(c, adminBatchTickets, &batchTicket{fileIDs: req.FileIDs, userID: user.Current(c).ID})
+}
+
+// 使用共享端票据下载批量文件，重新检查文件的可见性和目录访问令牌
+func DownloadBatch(c *gin.Context) {
+	ticket := shareBatchTickets.get(c.Param("ticket"))
+	if ticket == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Download ticket not found or expired"})
+		return
+	}
+
+	sendBatch(c, ticket, func(file *models.File) bool {
+		return access.RequireVisibleFile(c, file)
+	})
+}
+
+// 使用管理端票据下载批量文件，只有签发票据的用户可以使用，并重新检查目录权限
+func AdminDownloadBatch(c *gin.Context) {
+	ticket := adminBatchTickets.get(c.Param("ticket"))
+	if ticket == nil || ticket.userID != user.Current(c).ID {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Download ticket not found or expired"})
+		return
+	}
+
+	sendBatch(c, ticket, func(file *models.File) bool {
+		if !acl.CanAccess(c, file.DirectoryID, user.PermRead) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "权限不足", "fileId": file.ID})
+			return false
+		}
+		return true
+	})
+}
+
+// 打包票据中的文件，allow在不允许下载时返回false并写入错误响应
+func sendBatch(c *gin.Context, ticket *batchTicket, allow func(file *models.File) bool) {
+	// 文件可能在票据签发后被删除，跳过已不存在的文件，全部不存在时返回404而不是空的归档
+	entries := []archiveEntry{}
+	usedNames := map[string]bool{}
+	for _, id := range ticket.fileIDs {
+		for _, file := range models.Files {
+			if file.ID != id {
+				continue
+			}
+			if !allow(file) {
+				return
+			}
+			entries = append(entries, archiveEntry{name: UniqueName(usedNames, file.Name), file: file})
+			break
+		}
+	}
+
+	if len(entries) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Files not found"})
+		return
+	}
+
+	writeArchive(c, "files-"+time.Now().Format("20060102150405"), ArchiveFormatZip, entries, nil)
+}
+
+// 查找批量下载的文件
+func findBatchFiles(c *gin.Context, ids []string) ([]*models.File, bool) {
+	if len(ids) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No files selected"})
+		return nil, false
+	}
+
+	files := make([]*models.File, 0, len(ids))
+	for _, id := range ids {
+		var found *models.File
+		for _, file := range models.Files {
+			if file.ID == id {
+				found = file
+				break
+			}
+		}
+
+		if found == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "File not found", "fileId": id})
+			return nil, false
+		}
+		files = append(files, found)
+	}
+
+	return files, true
+}
+
+// 签发批量下载票据
+func issueBatchTicket(c *gin.Context, store *ticketStore, ticket *batchTicket) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create download ticket"})
+		return
+	}
+	ticketID := hex.EncodeToString(buf)
+	ticket.expiresAt = time.Now().Add(batchTicketTTL)
+	store.add(ticketID, ticket)
+
+	c.JSON(http.StatusCreated, gin.H{
+		"ticket":    ticketID,
+		"expiresAt": ticket.expiresAt.Format("2006-01-02 15:04:05"),
+	})
+}
@@ -0,0 +1,208 @@
+package file
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/models"
+	"fileshare/user"
+)
+
+// 调用批量下载接口，u不为nil时作为当前登录用户
+func callBatch(handler gin.HandlerFunc, u *models.User, body, ticket string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Params = gin.Params{{Key: "ticket", Value: ticket}}
+	if u != nil {
+		c.Set(user.ContextKey, u)
+	}
+	handler(c)
+	return w
+}
+
+// 签发票据，返回票据ID
+func createTicket(t *testing.T, handler gin.HandlerFunc, u *models.User, fileIDs []string) string {
+	body, _ := json.Marshal(batchDownloadRequest{FileIDs: fileIDs})
+	w := callBatch(handler, u, string(body), "")
+	if w.Code != http.StatusCreated {
+		t.Fatalf("create ticket = %d: %s", w.Code, w.Body)
+	}
+	var resp struct {
+		Ticket string `json:"ticket"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &resp)
+	return resp.Ticket
+}
+
+func TestCreateBatchDownload(t *testing.T) {
+	tmp := t.TempDir()
+	useModels(t, []*models.Directory{
+		{ID: "docs", IsShared: true},
+		{ID: "locked", IsShared: true, Password: "hash"},
+	}, []*models.File{
+		testFile(t, tmp, "a", "docs", "a.txt", true),
+		testFile(t, tmp, "draft", "docs", "draft.txt", false),
+		testFile(t, tmp, "l", "locked", "l.txt", true),
+	})
+
+	tests := []struct {
+		name string
+		body string
+		want int
+	}{
+		{"共享的文件", `{"fileIds":["a"]}`, http.StatusCreated},
+		{"没有选择文件", `{"fileIds":[]}`, http.StatusBadRequest},
+		{"不存在的文件", `{"fileIds":["a","missing"]}`, http.StatusNotFound},
+		{"包含未共享的文件", `{"fileIds":["a","draft"]}`, http.StatusForbidden},
+		{"包含未验证密码的目录中的文件", `{"fileIds":["a","l"]}`, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := callBatch(CreateBatchDownload, nil, tt.body, ""); w.Code != tt.want {
+				t.Errorf("CreateBatchDownload(%s) = %d, want %d: %s", tt.body, w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
+
+// 归档中的文件名
+func zipNames(t *testing.T, w *httptest.ResponseRecorder) []string {
+	names := []string{}
+	for name := range readZip(t, w.Body.Bytes()) {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestDownloadBatch(t *testing.T) {
+	tmp := t.TempDir()
+	files := []*models.File{
+		testFile(t, tmp, "a", "docs", "a.txt", true),
+		testFile(t, tmp, "b", "docs", "b.txt", true),
+		testFile(t, tmp, "c", "other", "a.txt", true),
+	}
+
+	tests := []struct {
+		name    string
+		fileIDs []string
+		change  func(dirs []*models.Directory) // 签发票据后修改目录和文件
+		want    int
+		entries []string
+	}{
+		{"打包所有文件，重名时加序号", []string{"a", "b", "c"}, nil, http.StatusOK, []string{"a (1).txt", "a.txt", "b.txt"}},
+		{"跳过已删除的文件", []string{"a", "b"}, func([]*models.Directory) {
+			models.Files = models.Files[1:]
+		}, http.StatusOK, []string{"b.txt"}},
+		{"文件全部被删除", []string{"a", "b"}, func([]*models.Directory) {
+			models.Files = models.Files[2:]
+		}, http.StatusNotFound, nil},
+		{"文件取消共享", []string{"a", "b"}, func([]*models.Directory) {
+			models.Files[1].IsShared = false
+		}, http.StatusForbidden, nil},
+		{"目录取消共享", []string{"a", "c"}, func(dirs []*models.Directory) {
+			dirs[1].IsShared = false
+		}, http.StatusForbidden, nil},
+		{"目录设置了密码", []string{"a"}, func(dirs []*models.Directory) {
+			dirs[0].Password = "hash"
+		}, http.StatusForbidden, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dirs := []*models.Directory{{ID: "docs", IsShared: true}, {ID: "other", IsShared: true}}
+			copied := make([]*models.File, len(files))
+			for i, f := range files {
+				file := *f
+				copied[i] = &file
+			}
+			useModels(t, dirs, copied)
+
+			ticket := createTicket(t, CreateBatchDownload, nil, tt.fileIDs)
+			if tt.change != nil {
+				tt.change(dirs)
+			}
+
+			w := callBatch(DownloadBatch, nil, "", ticket)
+			if w.Code != tt.want {
+				t.Fatalf("DownloadBatch = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+			if tt.want == http.StatusOK {
+				if names := zipNames(t, w); !reflect.DeepEqual(names, tt.entries) {
+					t.Errorf("entries = %v, want %v", names, tt.entries)
+				}
+			}
+		})
+	}
+
+	t.Run("管理端的票据不能在共享端使用", func(t *testing.T) {
+		useModels(t, []*models.Directory{{ID: "docs", IsShared: true}}, files[:1])
+		admin := &models.User{ID: "admin", Role: user.RoleAdmin}
+		ticket := createTicket(t, AdminCreateBatchDownload, admin, []string{"a"})
+		if w := callBatch(DownloadBatch, nil, "", ticket); w.Code != http.StatusNotFound {
+			t.Errorf("DownloadBatch = %d, want 404", w.Code)
+		}
+	})
+}
+
+func TestAdminDownloadBatch(t *testing.T) {
+	tmp := t.TempDir()
+	files := []*models.File{
+		testFile(t, tmp, "a", "docs", "a.txt", false),
+		testFile(t, tmp, "b", "docs", "b.txt", false),
+	}
+	alice := &models.User{ID: "alice", Username: "alice", Role: user.RoleViewer}
+	bob := &models.User{ID: "bob", Username: "bob", Role: user.RoleViewer}
+
+	tests := []struct {
+		name    string
+		user    *models.User // 使用票据的用户
+		change  func(dir *models.Directory)
+		want    int
+		entries []string
+	}{
+		{"签发票据的用户下载未共享的文件", alice, nil, http.StatusOK, []string{"a.txt", "b.txt"}},
+		{"其他用户不能使用票据", bob, nil, http.StatusNotFound, nil},
+		{"跳过已删除的文件", alice, func(*models.Directory) {
+			models.Files = models.Files[:1]
+		}, http.StatusOK, []string{"a.txt"}},
+		{"文件全部被删除", alice, func(*models.Directory) {
+			models.Files = nil
+		}, http.StatusNotFound, nil},
+		{"目录权限被收回", alice, func(dir *models.Directory) {
+			dir.ACL = []*models.ACLEntry{{Type: "user", Subject: "bob", Permissions: []string{"read"}}}
+		}, http.StatusForbidden, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := &models.Directory{ID: "docs"}
+			useModels(t, []*models.Directory{dir}, append([]*models.File(nil), files...))
+
+			ticket := createTicket(t, AdminCreateBatchDownload, alice, []string{"a", "b"})
+			if tt.change != nil {
+				tt.change(dir)
+			}
+
+			w := callBatch(AdminDownloadBatch, tt.user, "", ticket)
+			if w.Code != tt.want {
+				t.Fatalf("AdminDownloadBatch = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+			if tt.want == http.StatusOK {
+				if names := zipNames(t, w); !reflect.DeepEqual(names, tt.entries) {
+					t.Errorf("entries = %v, want %v", names, tt.entries)
+				}
+			}
+		})
+	}
+}
@@ -7,14 +7,20 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 
+	"fileshare/access"
+	"fileshare/acl"
 	"fileshare/common"
 	"fileshare/config"
 	"fileshare/models"
+	"fileshare/stats"
+	"fileshare/thumbnail"
+	"fileshare/user"
 )
 
 // 配置文件路径
@@ -53,15 +59,13 @@ func SaveFiles() error {
 func GetFiles(c *gin.Context) {
 	directoryID := c.Query("directoryId")
 
-	if directoryID == "" {
-		c.JSON(http.StatusOK, models.Files)
-		return
-	}
-
-	// 过滤指定目录的文件
+	// 过滤指定目录的文件，未指定目录时返回所有可以浏览的文件
 	dirFiles := []*models.File{}
 	for _, file := range models.Files {
-		if file.DirectoryID == directoryID {
+		if directoryID != "" && file.DirectoryID != directoryID {
+			continue
+		}
+		if acl.CanAccess(c, file.DirectoryID, user.PermRead) {
 			dirFiles = append(dirFiles, file)
 		}
 	}
@@ -73,10 +77,20 @@ func GetFiles(c *gin.Context) {
 func GetSharedFiles(c *gin.Context) {
 	directoryID := c.Query("directoryId")
 
-	// 过滤共享文件
+	// 指定目录时检查目录可见性及目录链的访问令牌
+	if directoryID != "" {
+		if _, ok := access.RequireVisibleDirectory(c, directoryID); !ok {
+			return
+		}
+	}
+
+	// 过滤可见文件，未指定目录时跳过未验证密码的目录
 	sharedFiles := []*models.File{}
 	for _, file := range models.Files {
-		if file.IsShared && (directoryID == "" || file.DirectoryID == directoryID) {
+		if directoryID != "" && file.DirectoryID != directoryID {
+			continue
+		}
+		if access.CanListFile(c, file) {
 			sharedFiles = append(sharedFiles, file)
 		}
 	}
@@ -187,30 +201,20 @@ func UploadFiles(c *gin.Context) {
 
 		// 处理上传的文件
 		for _, fileHeader := range uploadedFiles {
-			// 生成唯一文件名
-			fileID := uuid.New().String()
-			fileExt := filepath.Ext(fileHeader.Filename)
-			filePath := filepath.Join(staticDir, fileID+fileExt)
-
-			// 保存文件到磁盘
-			if err := c.SaveUploadedFile(fileHeader, filePath); err != nil {
+			src, err := fileHeader.Open()
+			if err != nil {
 				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
 				return
 			}
 
-			// 创建文件记录
-			newFile := &models.File{
-				ID:          fileID,
-				Name:        fileHeader.Filename,
-				Path:        filePath,
-				Size:        fileHeader.Size,
-				Type:        fileExt[1:],                              // 去掉点号
-				AddTime:     time.Now().Format("2006-01-02 15:04:05"), // 格式化时间
-				IsShared:    false,
-				DirectoryID: directoryID,
+			// 保存文件到磁盘并创建文件记录
+			newFile, err := StoreFile(directoryID, fileHeader.Filename, src)
+			src.Close()
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
+				return
 			}
 
-			models.Files = append(models.Files, newFile)
 			newFiles = append(newFiles, newFile)
 		}
 	}
@@ -230,11 +234,9 @@ func DeleteFile(c *gin.Context) {
 
 	// 查找文件
 	var fileToDelete *models.File
-	var fileIndex int
-	for i, file := range models.Files {
+	for _, file := range models.Files {
 		if file.ID == id {
 			fileToDelete = file
-			fileIndex = i
 			break
 		}
 	}
@@ -244,6 +246,77 @@ func DeleteFile(c *gin.Context) {
 		return
 	}
 
+	RemoveFile(fileToDelete)
+
+	// 保存配置
+	if err := SaveFiles(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file records"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "File deleted successfully"})
+}
+
+// StoreFile 把上传的内容保存到文件存储目录，并在目录中添加文件记录（不保存配置，由调用方调用SaveFiles）
+func StoreFile(directoryID, name string, src io.Reader) (*models.File, error) {
+	// 生成唯一文件名
+	fileID := uuid.New().String()
+	fileExt := filepath.Ext(name)
+
+	// 确保文件存储目录存在
+	staticDir := config.GetServerConfig().Server.FilestorePath
+	if err := os.MkdirAll(staticDir, 0755); err != nil {
+		return nil, err
+	}
+
+	// 保存文件到磁盘
+	filePath := filepath.Join(staticDir, fileID+fileExt)
+	size, err := writeNewFile(filePath, src)
+	if err != nil {
+		return nil, err
+	}
+
+	return addFileRecord(fileID, directoryID, name, filePath, size), nil
+}
+
+// 为存储目录中已保存的文件创建文件记录
+func addFileRecord(fileID, directoryID, name, filePath string, size int64) *models.File {
+	newFile := &models.File{
+		ID:          fileID,
+		Name:        name,
+		Path:        filePath,
+		Size:        size,
+		Type:        strings.TrimPrefix(filepath.Ext(name), "."), // 去掉点号
+		AddTime:     time.Now().Format("2006-01-02 15:04:05"),    // 格式化时间
+		IsShared:    false,
+		DirectoryID: directoryID,
+	}
+
+	models.Files = append(models.Files, newFile)
+	return newFile
+}
+
+// ReplaceFile 用新内容替换存储型目录中已有文件的内容，写入完成前原文件保持不变（不保存配置）
+func ReplaceFile(file *models.File, src io.Reader) error {
+	// 先写入同一目录下的临时文件，完成后再替换
+	tempPath := file.Path + "." + uuid.New().String() + ".uploading"
+	size, err := writeNewFile(tempPath, src)
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tempPath, file.Path); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+
+	file.Size = size
+	file.AddTime = time.Now().Format("2006-01-02 15:04:05")
+	thumbnail.Invalidate(file.ID)
+	return nil
+}
+
+// RemoveFile 删除文件记录，存储型目录中的文件同时删除物理文件（不保存配置）
+func RemoveFile(fileToDelete *models.File) {
 	// 查找文件所在的目录，确定目录类型
 	var targetDir *models.Directory
 	common.FindDirectory(models.Directories, fileToDelete.DirectoryID, &targetDir)
@@ -257,16 +330,35 @@ func DeleteFile(c *gin.Context) {
 		}
 	}
 
+	// 删除缓存的缩略图和下载统计
+	thumbnail.Invalidate(fileToDelete.ID)
+	stats.RemoveStats(fileToDelete.ID)
+
 	// 从记录中删除
-	models.Files = append(models.Files[:fileIndex], models.Files[fileIndex+1:]...)
+	for i, file := range models.Files {
+		if file == fileToDelete {
+			models.Files = append(models.Files[:i], models.Files[i+1:]...)
+			break
+		}
+	}
+}
 
-	// 保存配置
-	if err := SaveFiles(); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file records"})
-		return
+// 把内容写入新文件，写入失败时删除不完整的文件
+func writeNewFile(filePath string, src io.Reader) (int64, error) {
+	dst, err := os.Create(filePath)
+	if err != nil {
+		return 0, err
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "File deleted successfully"})
+	size, err := io.Copy(dst, src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(filePath)
+		return 0, err
+	}
+	return size, nil
 }
 
 // 更新文件
@@ -361,29 +453,12 @@ func DownloadFile(c *gin.Context) {
 		return
 	}
 
-	// 检查文件是否共享
-	if !fileToDownload.IsShared {
-		c.JSON(http.StatusForbidden, gin.H{"error": "File is not shared"})
-		return
-	}
-
-	// 打开文件
-	file, err := os.Open(fileToDownload.Path)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to open file"})
+	// 检查文件可见性及目录访问令牌
+	if !access.RequireVisibleFile(c, fileToDownload) {
 		return
 	}
-	defer file.Close()
-
-	// 设置响应头
-	c.Header("Content-Disposition", "attachment; filename="+fileToDownload.Name)
-	c.Header("Content-Type", "application/octet-stream")
 
-	// 发送文件内容
-	_, err = io.Copy(c.Writer, file)
-	if err != nil {
-		log.Printf("Failed to send file: %v", err)
-	}
+	SendFile(c, fileToDownload)
 }
 
 // 管理员下载文件（不检查共享状态）
@@ -404,6 +479,17 @@ func AdminDownloadFile(c *gin.Context) {
 		return
 	}
 
+	SendFile(c, fileToDownload)
+}
+
+// 发送文件内容并记录下载统计
+func SendFile(c *gin.Context, fileToDownload *models.File) {
+	SendFileWith(c, fileToDownload, nil)
+}
+
+// SendFileWith 发送文件内容，beforeSend不为nil时在文件成功打开后、开始发送前调用，
+// 返回false时不发送（由beforeSend写入错误响应），用于分享链接计入下载次数
+func SendFileWith(c *gin.Context, fileToDownload *models.File, beforeSend func() bool) {
 	// 打开文件
 	file, err := os.Open(fileToDownload.Path)
 	if err != nil {
@@ -412,13 +498,22 @@ func AdminDownloadFile(c *gin.Context) {
 	}
 	defer file.Close()
 
+	info, err := file.Stat()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to open file"})
+		return
+	}
+	if beforeSend != nil && !beforeSend() {
+		return
+	}
+
 	// 设置响应头
 	c.Header("Content-Disposition", "attachment; filename="+fileToDownload.Name)
 	c.Header("Content-Type", "application/octet-stream")
 
-	// 发送文件内容
-	_, err = io.Copy(c.Writer, file)
-	if err != nil {
-		log.Printf("Failed to send file: %v", err)
-	}
+	// 发送文件内容，支持Range请求，客户端可以断点续传
+	http.ServeContent(c.Writer, c.Request, fileToDownload.Name, info.ModTime(), file)
+
+	// 记录下载统计，没有发送到文件末尾视为下载中断
+	stats.RecordResponse(c, fileToDownload.ID, info.Size())
 }
@@ -0,0 +1,60 @@
+package file
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/access"
+	"fileshare/common"
+	"fileshare/models"
+	"fileshare/preview"
+)
+
+// 获取共享文件的文本预览
+func GetPreview(c *gin.Context) {
+	fileToPreview := common.FindFile(c.Param("id"))
+	if fileToPreview == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		return
+	}
+
+	// 检查文件可见性及目录访问令牌
+	if !access.RequireVisibleFile(c, fileToPreview) {
+		return
+	}
+
+	servePreview(c, fileToPreview)
+}
+
+// 管理员获取文件文本预览（不检查共享状态）
+func AdminGetPreview(c *gin.Context) {
+	fileToPreview := common.FindFile(c.Param("id"))
+	if fileToPreview == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		return
+	}
+
+	servePreview(c, fileToPreview)
+}
+
+// 读取并返回文本预览
+func servePreview(c *gin.Context, file *models.File) {
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	result, err := preview.ReadText(file, limit)
+	if err != nil {
+		if errors.Is(err, preview.ErrUnsupported) {
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Preview not supported for this file type"})
+			return
+		}
+		log.Printf("Failed to read preview for %s: %v", file.Path, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file"})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
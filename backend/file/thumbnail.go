@@ -0,0 +1,61 @@
+package file
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/access"
+	"fileshare/common"
+	"fileshare/models"
+	"fileshare/thumbnail"
+)
+
+// 获取共享文件的缩略图
+func GetThumbnail(c *gin.Context) {
+	fileToPreview := common.FindFile(c.Param("id"))
+	if fileToPreview == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		return
+	}
+
+	// 检查文件可见性及目录访问令牌
+	if !access.RequireVisibleFile(c, fileToPreview) {
+		return
+	}
+
+	serveThumbnail(c, fileToPreview)
+}
+
+// 管理员获取文件缩略图（不检查共享状态）
+func AdminGetThumbnail(c *gin.Context) {
+	fileToPreview := common.FindFile(c.Param("id"))
+	if fileToPreview == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		return
+	}
+
+	serveThumbnail(c, fileToPreview)
+}
+
+// 生成并发送缩略图
+func serveThumbnail(c *gin.Context, file *models.File) {
+	size, _ := strconv.Atoi(c.Query("size"))
+
+	thumbPath, err := thumbnail.Get(file, size)
+	if err != nil {
+		if errors.Is(err, thumbnail.ErrUnsupported) {
+			c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Thumbnail not supported for this file type"})
+			return
+		}
+		log.Printf("Failed to generate thumbnail for %s: %v", file.Path, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate thumbnail"})
+		return
+	}
+
+	c.Header("Cache-Control", "private, max-age=86400")
+	c.File(thumbPath)
+}
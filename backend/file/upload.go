@@ -0,0 +1,334 @@
+package file
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"fileshare/common"
+	"fileshare/config"
+	"fileshare/models"
+	"fileshare/user"
+)
+
+// 配置文件路径
+const (
+	UploadConfigPath = "./config/config-upload.json"
+)
+
+// UploadOffsetHeader 续传时提交的起始位置，必须和已上传的字节数一致
+const UploadOffsetHeader = "Upload-Offset"
+
+// 超过该时间没有继续上传的记录会被清理
+const uploadExpiry = 7 * 24 * time.Hour
+
+// 上传记录的锁，以及正在写入的上传（同一上传不允许并发写入）
+var (
+	uploadsMu sync.Mutex
+	writing   = make(map[string]bool)
+)
+
+// 加载未完成的上传
+func LoadUploads() {
+	uploadsMu.Lock()
+	defer uploadsMu.Unlock()
+
+	models.Uploads = []*models.Upload{}
+
+	data, err := os.ReadFile(UploadConfigPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Failed to read upload config: %v", err)
+		}
+		return
+	}
+
+	if err := json.Unmarshal(data, &models.Uploads); err != nil {
+		log.Printf("Failed to parse upload config: %v", err)
+		models.Uploads = []*models.Upload{}
+	}
+}
+
+// 保存上传记录（调用方需持有锁）
+func saveUploads() error {
+	data, err := json.MarshalIndent(models.Uploads, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(UploadConfigPath, data, 0644)
+}
+
+// 上传内容的临时文件
+func uploadPartPath(u *models.Upload) string {
+	return filepath.Join(config.GetServerConfig().Server.FilestorePath, u.ID+".uploading")
+}
+
+// 已上传的字节数
+func uploadOffset(u *models.Upload) int64 {
+	info, err := os.Stat(uploadPartPath(u))
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
+
+// 返回带有已上传字节数的副本
+func uploadResponse(u *models.Upload) *models.Upload {
+	copied := *u
+	copied.Offset = uploadOffset(u)
+	return &copied
+}
+
+// 删除上传记录和临时文件（调用方需持有锁）
+func removeUpload(u *models.Upload) {
+	if err := os.Remove(uploadPartPath(u)); err != nil && !os.IsNotExist(err) {
+		log.Printf("Failed to delete upload %s: %v", u.ID, err)
+	}
+	for i, existing := range models.Uploads {
+		if existing == u {
+			models.Uploads = append(models.Uploads[:i], models.Uploads[i+1:]...)
+			break
+		}
+	}
+}
+
+// 清理长时间没有继续的上传（调用方需持有锁）
+func cleanupUploads(now time.Time) {
+	for _, u := range append([]*models.Upload{}, models.Uploads...) {
+		updatedAt, err := time.ParseInLocation("2006-01-02 15:04:05", u.UpdatedAt, time.Local)
+		if !writing[u.ID] && (err != nil || now.Sub(updatedAt) > uploadExpiry) {
+			removeUpload(u)
+		}
+	}
+}
+
+// UploadDirectory 返回上传的目标目录，上传不存在时返回false
+func UploadDirectory(id string) (string, bool) {
+	uploadsMu.Lock()
+	defer uploadsMu.Unlock()
+
+	for _, u := range models.Uploads {
+		if u.ID == id {
+			return u.DirectoryID, true
+		}
+	}
+	return "", false
+}
+
+// 查找当前用户的上传（调用方需持有锁）
+func findOwnedUpload(c *gin.Context, id string) *models.Upload {
+	currentUser := user.Current(c)
+	for _, u := range models.Uploads {
+		if u.ID == id && u.UserID == currentUser.ID {
+			return u
+		}
+	}
+	return nil
+}
+
+// 创建可续传的上传，之后通过PATCH分段提交内容
+func CreateUpload(c *gin.Context) {
+	var req struct {
+		DirectoryID string `json:"directoryId" binding:"required"`
+		Name        string `json:"name" binding:"required"`
+		Size        int64  `json:"size"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.Size < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file size"})
+		return
+	}
+	if req.Name == "." || req.Name == ".." || strings.ContainsAny(req.Name, `/\`) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file name"})
+		return
+	}
+
+	var targetDir *models.Directory
+	common.FindDirectory(models.Directories, req.DirectoryID, &targetDir)
+	if targetDir == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Directory not found"})
+		return
+	}
+	if targetDir.DirType == "link" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "链接型目录不能直接上传文件"})
+		return
+	}
+
+	now := time.Now()
+	newUpload := &models.Upload{
+		ID:          uuid.New().String(),
+		UserID:      user.Current(c).ID,
+		DirectoryID: req.DirectoryID,
+		Name:        req.Name,
+		Size:        req.Size,
+		CreatedAt:   now.Format("2006-01-02 15:04:05"),
+		UpdatedAt:   now.Format("2006-01-02 15:04:05"),
+	}
+
+	uploadsMu.Lock()
+	defer uploadsMu.Unlock()
+
+	cleanupUploads(now)
+
+	// 创建空的临时文件
+	if err := os.MkdirAll(config.GetServerConfig().Server.FilestorePath, 0755); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create file storage directory"})
+		return
+	}
+	part, err := os.Create(uploadPartPath(newUpload))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create upload"})
+		return
+	}
+	part.Close()
+
+	models.Uploads = append(models.Uploads, newUpload)
+
+	// 保存配置
+	if err := saveUploads(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save upload"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, uploadResponse(newUpload))
+}
+
+// 获取上传进度，客户端中断后据此确定续传位置
+func GetUpload(c *gin.Context) {
+	uploadsMu.Lock()
+	defer uploadsMu.Unlock()
+
+	u := findOwnedUpload(c, c.Param("id"))
+	if u == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Upload not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, uploadResponse(u))
+}
+
+// 从Upload-Offset位置继续写入上传的内容，全部上传后创建文件记录并返回文件
+func AppendUpload(c *gin.Context) {
+	uploadsMu.Lock()
+	u := findOwnedUpload(c, c.Param("id"))
+	if u == nil {
+		uploadsMu.Unlock()
+		c.JSON(http.StatusNotFound, gin.H{"error": "Upload not found"})
+		return
+	}
+	if writing[u.ID] {
+		uploadsMu.Unlock()
+		c.JSON(http.StatusConflict, gin.H{"error": "Upload is in progress"})
+		return
+	}
+	writing[u.ID] = true
+	uploadsMu.Unlock()
+
+	defer func() {
+		uploadsMu.Lock()
+		delete(writing, u.ID)
+		uploadsMu.Unlock()
+	}()
+
+	// 起始位置必须和已上传的字节数一致，不一致时返回当前位置
+	offset := uploadOffset(u)
+	requested, err := strconv.ParseInt(c.GetHeader(UploadOffsetHeader), 10, 64)
+	if err != nil || requested != offset {
+		c.JSON(http.StatusConflict, gin.H{"error": "Upload offset mismatch", "offset": offset})
+		return
+	}
+
+	part, err := os.OpenFile(uploadPartPath(u), os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to open upload"})
+		return
+	}
+	// 超过文件大小的内容会被忽略，连接中断时保留已写入的部分
+	written, copyErr := io.Copy(part, io.LimitReader(c.Request.Body, u.Size-offset))
+	closeErr := part.Close()
+	offset += written
+
+	uploadsMu.Lock()
+	defer uploadsMu.Unlock()
+
+	u.UpdatedAt = time.Now().Format("2006-01-02 15:04:05")
+	if copyErr != nil || closeErr != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to write upload", "offset": offset})
+		return
+	}
+	if offset < u.Size {
+		c.JSON(http.StatusOK, uploadResponse(u))
+		return
+	}
+
+	// 上传完成，把临时文件移动为存储的文件
+	var targetDir *models.Directory
+	common.FindDirectory(models.Directories, u.DirectoryID, &targetDir)
+	if targetDir == nil {
+		removeUpload(u)
+		saveUploads()
+		c.JSON(http.StatusNotFound, gin.H{"error": "Directory not found"})
+		return
+	}
+
+	fileID := uuid.New().String()
+	filePath := filepath.Join(config.GetServerConfig().Server.FilestorePath, fileID+filepath.Ext(u.Name))
+	if err := os.Rename(uploadPartPath(u), filePath); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file"})
+		return
+	}
+	newFile := addFileRecord(fileID, u.DirectoryID, u.Name, filePath, u.Size)
+	removeUpload(u)
+
+	// 保存配置
+	if err := SaveFiles(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save file records"})
+		return
+	}
+	if err := saveUploads(); err != nil {
+		log.Printf("Failed to save upload config: %v", err)
+	}
+
+	c.JSON(http.StatusCreated, newFile)
+}
+
+// 取消上传，删除已上传的内容
+func DeleteUpload(c *gin.Context) {
+	uploadsMu.Lock()
+	defer uploadsMu.Unlock()
+
+	u := findOwnedUpload(c, c.Param("id"))
+	if u == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Upload not found"})
+		return
+	}
+	if writing[u.ID] {
+		c.JSON(http.StatusConflict, gin.H{"error": "Upload is in progress"})
+		return
+	}
+
+	removeUpload(u)
+
+	// 保存配置
+	if err := saveUploads(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save upload"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Upload cancelled"})
+}
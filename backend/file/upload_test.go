@@ -0,0 +1,34 @@
+package file
+
+import (
+	"testing"
+
+	"fileshare/models"
+)
+
+func TestUploadDirectory(t *testing.T) {
+	saved := models.Uploads
+	t.Cleanup(func() { models.Uploads = saved })
+	models.Uploads = []*models.Upload{
+		{ID: "u1", DirectoryID: "d1"},
+		{ID: "u2", DirectoryID: "d2"},
+	}
+
+	tests := []struct {
+		id     string
+		wantID string
+		wantOK bool
+	}{
+		{"u1", "d1", true},
+		{"u2", "d2", true},
+		{"missing", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		dirID, ok := UploadDirectory(tt.id)
+		if dirID != tt.wantID || ok != tt.wantOK {
+			t.Errorf("UploadDirectory(%q) = %q, %v, want %q, %v", tt.id, dirID, ok, tt.wantID, tt.wantOK)
+		}
+	}
+}
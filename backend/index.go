@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"html/template"
+	"io/fs"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/config"
+	"fileshare/proxy"
+)
+
+// 构建前端时使用的资源路径前缀，返回页面时替换为实际的访问路径
+const builtAssetPrefix = `"/fileserver/`
+
+// 前端运行时配置，写入index.html的meta标签，前端据此拼接页面路由和接口地址
+type webConfig struct {
+	BasePath  string `json:"basePath"`  // 客户端看到的路径前缀
+	UIPath    string `json:"uiPath"`    // 共享页面的路由
+	ManageAPI string `json:"manageApi"` // 管理API地址
+	ShareAPI  string `json:"shareApi"`  // 共享API地址
+}
+
+// 返回index.html的处理函数，按请求的路径前缀改写资源地址并写入前端运行时配置
+func serveIndex(webFS fs.FS) gin.HandlerFunc {
+	data, err := fs.ReadFile(webFS, "index.html")
+	if err != nil {
+		log.Fatalf("Failed to read index.html: %v", err)
+	}
+	page := string(data)
+
+	return func(c *gin.Context) {
+		server := config.GetServerConfig().Server
+		prefix := proxy.Prefix(c)
+		uiPath := proxy.CleanPath(server.ContextPath)
+
+		runtimeConfig, _ := json.Marshal(webConfig{
+			BasePath:  prefix,
+			UIPath:    uiPath,
+			ManageAPI: prefix + server.ContextManagePath + "/api",
+			ShareAPI:  prefix + server.ContextSharePath + "/api",
+		})
+		meta := `<meta name="fileshare-config" content="` + template.HTMLEscapeString(string(runtimeConfig)) + `">`
+
+		// 路径已经限制为安全的字符，写入属性前仍然转义
+		html := strings.ReplaceAll(page, builtAssetPrefix, `"`+template.HTMLEscapeString(prefix+uiPath)+`/`)
+		html = strings.ReplaceAll(html, `"/favicon.ico"`, `"`+template.HTMLEscapeString(prefix)+`/favicon.ico"`)
+		html = strings.Replace(html, "</head>", "  "+meta+"\n  </head>", 1)
+
+		c.Header("Cache-Control", "no-cache")
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(html))
+	}
+}
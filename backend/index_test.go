@@ -0,0 +1,68 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/config"
+	"fileshare/proxy"
+)
+
+func TestServeIndex(t *testing.T) {
+	server := &config.GetServerConfig().Server
+	server.TrustedProxies = []string{"192.0.2.1"}
+	defer func() { server.TrustedProxies = nil }()
+
+	webFS := fstest.MapFS{"index.html": {Data: []byte(`<html><head>` +
+		`<link rel="icon" href="/favicon.ico">` +
+		`<script type="module" src="/fileserver/assets/index.js"></script>` +
+		`</head></html>`)}}
+	handler := serveIndex(webFS)
+
+	tests := []struct {
+		name   string
+		prefix string
+		want   []string
+		absent []string
+	}{
+		{
+			name:   "路径前缀",
+			prefix: "/proxy",
+			want:   []string{`src="/proxy/fileserver/assets/index.js"`, `href="/proxy/favicon.ico"`, `&#34;basePath&#34;:&#34;/proxy&#34;`},
+		},
+		{
+			name:   "不安全的路径前缀",
+			prefix: `/"><script>alert(1)</script>`,
+			want:   []string{`src="/fileserver/assets/index.js"`, `href="/favicon.ico"`},
+			absent: []string{"alert(1)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/fileserver/", nil)
+			c.Request.RemoteAddr = "192.0.2.1:1234"
+			c.Request.Header.Set(proxy.HeaderForwardedPrefix, tt.prefix)
+			handler(c)
+
+			body := w.Body.String()
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("page does not contain %s:\n%s", want, body)
+				}
+			}
+			for _, absent := range tt.absent {
+				if strings.Contains(body, absent) {
+					t.Errorf("page contains %s:\n%s", absent, body)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+// Package ipfilter 负责按客户端IP限制访问。
+//
+// 规则：
+//   - deny中的网段优先，匹配时拒绝
+//   - allow不为空时，只允许匹配其中网段的地址
+//   - 目录的限制会继承给所有子目录，目录链上的每个限制都要满足
+//
+// 客户端IP通过gin的ClientIP获取，只有来自server.trustedProxies的请求才会使用X-Forwarded-For。
+package ipfilter
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/config"
+	"fileshare/models"
+)
+
+// Normalize 校验并整理IP限制，去掉空白条目，没有任何条目时返回nil
+func Normalize(filter *models.IPFilter) (*models.IPFilter, error) {
+	if filter == nil {
+		return nil, nil
+	}
+
+	result := &models.IPFilter{}
+	for _, list := range []struct {
+		src []string
+		dst *[]string
+	}{{filter.Allow, &result.Allow}, {filter.Deny, &result.Deny}} {
+		for _, entry := range list.src {
+			entry = strings.TrimSpace(entry)
+			if entry == "" {
+				continue
+			}
+			if _, err := parseNetwork(entry); err != nil {
+				return nil, err
+			}
+			*list.dst = append(*list.dst, entry)
+		}
+	}
+
+	if len(result.Allow) == 0 && len(result.Deny) == 0 {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// Allows 判断IP是否满足限制，filter为nil表示不限制
+func Allows(filter *models.IPFilter, ip string) bool {
+	if filter == nil || (len(filter.Allow) == 0 && len(filter.Deny) == 0) {
+		return true
+	}
+
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	if matches(filter.Deny, addr) {
+		return false
+	}
+	return len(filter.Allow) == 0 || matches(filter.Allow, addr)
+}
+
+// AllowsChain 判断IP是否满足目录链上所有目录的限制
+func AllowsChain(chain []*models.Directory, ip string) bool {
+	for _, dir := range chain {
+		if !Allows(dir.IPFilter, ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// Manage 中间件用于限制管理端的访问来源，配置无效时启动失败
+func Manage() gin.HandlerFunc {
+	filter, err := Normalize(&config.GetServerConfig().Security.ManageIPFilter)
+	if err != nil {
+		log.Fatalf("Invalid security.manageIpFilter: %v", err)
+	}
+
+	return func(c *gin.Context) {
+		if !Allows(filter, c.ClientIP()) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "当前IP不允许访问管理端"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// 判断IP是否属于列表中的某个网段
+func matches(entries []string, addr net.IP) bool {
+	for _, entry := range entries {
+		network, err := parseNetwork(entry)
+		if err == nil && network.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// 解析CIDR网段，单个IP视为只包含该地址的网段
+func parseNetwork(entry string) (*net.IPNet, error) {
+	if strings.Contains(entry, "/") {
+		_, network, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q", entry)
+		}
+		return network, nil
+	}
+
+	ip := net.ParseIP(entry)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid IP address %q", entry)
+	}
+	bits := 128
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, bits = ip4, 32
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+}
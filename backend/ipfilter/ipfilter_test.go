@@ -0,0 +1,63 @@
+package ipfilter
+
+import (
+	"net"
+	"testing"
+
+	"fileshare/models"
+)
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []string
+		ip      string
+		want    bool
+	}{
+		{"空列表", nil, "10.0.0.1", false},
+		{"单个IPv4地址", []string{"10.0.0.1"}, "10.0.0.1", true},
+		{"单个IPv4地址不匹配", []string{"10.0.0.1"}, "10.0.0.2", false},
+		{"IPv4网段", []string{"192.168.0.0/16"}, "192.168.200.7", true},
+		{"IPv4网段外", []string{"192.168.0.0/16"}, "192.169.0.1", false},
+		{"IPv4映射的IPv6地址", []string{"10.0.0.0/8"}, "::ffff:10.1.2.3", true},
+		{"单个IPv6地址", []string{"::1"}, "::1", true},
+		{"IPv6网段", []string{"2001:db8::/32"}, "2001:db8:1::5", true},
+		{"IPv6网段不匹配IPv4", []string{"2001:db8::/32"}, "10.0.0.1", false},
+		{"IPv4网段不匹配IPv6", []string{"0.0.0.0/0"}, "2001:db8::1", false},
+		{"跳过无效条目", []string{"bogus", "10.0.0.0/33", "10.0.0.0/8"}, "10.9.9.9", true},
+		{"多个条目任一匹配", []string{"172.16.0.0/12", "127.0.0.1"}, "127.0.0.1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matches(tt.entries, net.ParseIP(tt.ip)); got != tt.want {
+				t.Errorf("matches(%v, %s) = %v, want %v", tt.entries, tt.ip, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllows(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter *models.IPFilter
+		ip     string
+		want   bool
+	}{
+		{"没有限制", nil, "10.0.0.1", true},
+		{"空限制", &models.IPFilter{}, "10.0.0.1", true},
+		{"在允许列表中", &models.IPFilter{Allow: []string{"10.0.0.0/8"}}, "10.0.0.1", true},
+		{"不在允许列表中", &models.IPFilter{Allow: []string{"10.0.0.0/8"}}, "192.168.0.1", false},
+		{"拒绝优先于允许", &models.IPFilter{Allow: []string{"10.0.0.0/8"}, Deny: []string{"10.0.0.1"}}, "10.0.0.1", false},
+		{"只有拒绝列表", &models.IPFilter{Deny: []string{"10.0.0.0/8"}}, "192.168.0.1", true},
+		{"无效的客户端IP", &models.IPFilter{Deny: []string{"10.0.0.0/8"}}, "not-an-ip", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Allows(tt.filter, tt.ip); got != tt.want {
+				t.Errorf("Allows() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
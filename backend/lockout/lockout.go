@@ -0,0 +1,213 @@
+package lockout
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 锁定策略
+const (
+	freeAttempts = 5                // 允许连续失败的次数，超过后开始锁定
+	baseDelay    = time.Second      // 第一次锁定的时长，之后每次失败翻倍
+	maxDelay     = 15 * time.Minute // 最长锁定时长
+	resetWindow  = time.Hour        // 超过该时间没有失败则清零
+	maxThrottle  = 3 * time.Second  // 只延迟不锁定的键最多延迟的时长
+)
+
+// 失败记录
+type entry struct {
+	Key         string
+	Failures    int
+	LastFailure time.Time
+	LockedUntil time.Time
+}
+
+// 存储失败记录
+var (
+	entries   = make(map[string]*entry)
+	entriesMu sync.Mutex
+)
+
+// IPKey 按客户端IP统计的键
+func IPKey(c *gin.Context) string {
+	return AddrKey(c.ClientIP())
+}
+
+// AddrKey 按IP地址统计的键，用于不经过HTTP的登录（如SFTP）
+func AddrKey(ip string) string {
+	return "ip:" + ip
+}
+
+// ScopedKey 按对象和客户端IP统计的键，一个客户端的失败不会锁定其他客户端
+func ScopedKey(c *gin.Context, scope string) string {
+	return ScopedAddrKey(scope, c.ClientIP())
+}
+
+// ScopedAddrKey 按对象和IP地址统计的键，用于不经过HTTP的登录（如SFTP）
+func ScopedAddrKey(scope, ip string) string {
+	return scope + "@" + ip
+}
+
+// AccountKey 按账号统计的键，用户名不区分大小写（和登录时查找账号一致）。
+// 任何人都可以对账号提交错误密码，因此该键只用于Throttle延迟，锁定使用AccountKey和IP组合的键
+func AccountKey(username string) string {
+	return "login:" + strings.ToLower(username)
+}
+
+// Guard 检查所有键是否被锁定，被锁定时返回429并设置Retry-After
+func Guard(c *gin.Context, keys ...string) bool {
+	retryAfter := RetryAfter(keys...)
+	if retryAfter <= 0 {
+		return true
+	}
+
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	c.Header("Retry-After", strconv.Itoa(seconds))
+	c.JSON(http.StatusTooManyRequests, gin.H{
+		"error":      "Too many failed attempts, please try again later",
+		"retryAfter": seconds,
+	})
+	return false
+}
+
+// RetryAfter 返回所有键中最长的剩余锁定时间
+func RetryAfter(keys ...string) time.Duration {
+	entriesMu.Lock()
+	defer entriesMu.Unlock()
+
+	now := time.Now()
+	var longest time.Duration
+	for _, key := range keys {
+		if item, exists := entries[key]; exists {
+			if remaining := item.LockedUntil.Sub(now); remaining > longest {
+				longest = remaining
+			}
+		}
+	}
+	return longest
+}
+
+// Throttle 按键的剩余锁定时间延迟（最多maxThrottle），用于不应该被锁定的键：
+// 分散在多个IP的猜测会被放慢，而正常用户最多等待几秒
+func Throttle(keys ...string) {
+	if delay := min(RetryAfter(keys...), maxThrottle); delay > 0 {
+		time.Sleep(delay)
+	}
+}
+
+// Fail 记录一次失败，超过允许次数后按指数退避锁定
+func Fail(keys ...string) {
+	entriesMu.Lock()
+	defer entriesMu.Unlock()
+
+	now := time.Now()
+	for _, key := range keys {
+		item, exists := entries[key]
+		if !exists || now.Sub(item.LastFailure) > resetWindow {
+			item = &entry{Key: key}
+			entries[key] = item
+		}
+
+		item.Failures++
+		item.LastFailure = now
+		if item.Failures >= freeAttempts {
+			item.LockedUntil = now.Add(lockDuration(item.Failures - freeAttempts))
+		}
+	}
+
+	cleanup(now)
+}
+
+// Succeed 验证成功后清除指定键的失败记录
+func Succeed(keys ...string) {
+	entriesMu.Lock()
+	defer entriesMu.Unlock()
+
+	for _, key := range keys {
+		delete(entries, key)
+	}
+}
+
+// 计算锁定时长
+func lockDuration(exponent int) time.Duration {
+	if exponent > 20 {
+		return maxDelay
+	}
+	delay := baseDelay << exponent
+	if delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+// 清理过期的失败记录（调用方需持有锁）
+func cleanup(now time.Time) {
+	for key, item := range entries {
+		if now.Sub(item.LastFailure) > resetWindow && now.After(item.LockedUntil) {
+			delete(entries, key)
+		}
+	}
+}
+
+// 获取失败记录和锁定列表
+func GetLockouts(c *gin.Context) {
+	entriesMu.Lock()
+	defer entriesMu.Unlock()
+
+	now := time.Now()
+	cleanup(now)
+
+	result := []gin.H{}
+	for _, item := range entries {
+		retryAfter := 0
+		if item.LockedUntil.After(now) {
+			retryAfter = int(math.Ceil(item.LockedUntil.Sub(now).Seconds()))
+		}
+		result = append(result, gin.H{
+			"key":         item.Key,
+			"failures":    item.Failures,
+			"lastFailure": item.LastFailure.Format("2006-01-02 15:04:05"),
+			"locked":      retryAfter > 0,
+			"retryAfter":  retryAfter,
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i]["key"].(string) < result[j]["key"].(string)
+	})
+
+	c.JSON(http.StatusOK, result)
+}
+
+// 清除指定键的锁定
+func ClearLockout(c *gin.Context) {
+	key := c.Param("key")
+
+	entriesMu.Lock()
+	_, exists := entries[key]
+	delete(entries, key)
+	entriesMu.Unlock()
+
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Lockout not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Lockout cleared successfully"})
+}
+
+// 清除所有锁定
+func ClearAllLockouts(c *gin.Context) {
+	entriesMu.Lock()
+	entries = make(map[string]*entry)
+	entriesMu.Unlock()
+
+	c.JSON(http.StatusOK, gin.H{"message": "All lockouts cleared successfully"})
+}
@@ -0,0 +1,166 @@
+package lockout
+
+import (
+	"testing"
+	"time"
+)
+
+func resetEntries() {
+	entriesMu.Lock()
+	entries = make(map[string]*entry)
+	entriesMu.Unlock()
+}
+
+func TestLockDuration(t *testing.T) {
+	tests := []struct {
+		exponent int
+		want     time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{5, 32 * time.Second},
+		{9, 512 * time.Second},
+		{10, maxDelay},
+		{20, maxDelay},
+		{100, maxDelay},
+	}
+
+	for _, tt := range tests {
+		if got := lockDuration(tt.exponent); got != tt.want {
+			t.Errorf("lockDuration(%d) = %v, want %v", tt.exponent, got, tt.want)
+		}
+	}
+}
+
+func TestFail(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration // 0表示未锁定
+	}{
+		{1, 0},
+		{freeAttempts - 1, 0},
+		{freeAttempts, baseDelay},
+		{freeAttempts + 1, 2 * baseDelay},
+		{freeAttempts + 3, 8 * baseDelay},
+		{freeAttempts + 30, maxDelay},
+	}
+
+	for _, tt := range tests {
+		resetEntries()
+		for i := 0; i < tt.failures; i++ {
+			Fail("a", "b")
+		}
+
+		for _, key := range []string{"a", "b"} {
+			got := RetryAfter(key)
+			if tt.want == 0 && got > 0 {
+				t.Errorf("%d failures: %s locked for %v, want unlocked", tt.failures, key, got)
+			}
+			if tt.want > 0 && (got <= tt.want-time.Second/2 || got > tt.want) {
+				t.Errorf("%d failures: %s locked for %v, want %v", tt.failures, key, got, tt.want)
+			}
+		}
+		if got := RetryAfter("c"); got != 0 {
+			t.Errorf("%d failures: unrelated key locked for %v", tt.failures, got)
+		}
+	}
+}
+
+func TestResetWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		elapsed time.Duration // 距上次失败的时间
+		locked  bool          // 再失败一次后是否锁定
+	}{
+		{"窗口内继续累计", resetWindow - time.Minute, true},
+		{"超过窗口重新计数", resetWindow + time.Minute, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetEntries()
+			for i := 0; i < freeAttempts; i++ {
+				Fail("key")
+			}
+
+			// 把失败时间和锁定时间移到过去
+			entriesMu.Lock()
+			entries["key"].LastFailure = time.Now().Add(-tt.elapsed)
+			entries["key"].LockedUntil = time.Now().Add(-time.Second)
+			entriesMu.Unlock()
+
+			Fail("key")
+			if locked := RetryAfter("key") > 0; locked != tt.locked {
+				t.Errorf("locked = %v, want %v", locked, tt.locked)
+			}
+		})
+	}
+}
+
+func TestSucceed(t *testing.T) {
+	resetEntries()
+	for i := 0; i < freeAttempts; i++ {
+		Fail("ip", "user@ip", "user")
+	}
+	Succeed("user@ip", "user")
+
+	tests := []struct {
+		key    string
+		locked bool
+	}{
+		{"ip", true},
+		{"user@ip", false},
+		{"user", false},
+	}
+	for _, tt := range tests {
+		if locked := RetryAfter(tt.key) > 0; locked != tt.locked {
+			t.Errorf("%s: locked = %v, want %v", tt.key, locked, tt.locked)
+		}
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	tests := []struct {
+		name   string
+		locked time.Duration // 剩余锁定时间
+		min    time.Duration
+		max    time.Duration
+	}{
+		{"未锁定时不延迟", 0, 0, 50 * time.Millisecond},
+		{"按剩余锁定时间延迟", 100 * time.Millisecond, 90 * time.Millisecond, time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetEntries()
+			if tt.locked > 0 {
+				entries["account"] = &entry{Key: "account", LastFailure: time.Now(), LockedUntil: time.Now().Add(tt.locked)}
+			}
+
+			start := time.Now()
+			Throttle("account")
+			if elapsed := time.Since(start); elapsed < tt.min || elapsed > tt.max {
+				t.Errorf("Throttle took %v, want between %v and %v", elapsed, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestAccountKey(t *testing.T) {
+	tests := []struct {
+		a, b string
+		same bool
+	}{
+		{"admin", "admin", true},
+		{"admin", "Admin", true},
+		{"admin", "ADMIN", true},
+		{"Zoë", "ZOË", true},
+		{"admin", "admin2", false},
+	}
+
+	for _, tt := range tests {
+		if same := AccountKey(tt.a) == AccountKey(tt.b); same != tt.same {
+			t.Errorf("AccountKey(%q) == AccountKey(%q): %v, want %v", tt.a, tt.b, same, tt.same)
+		}
+	}
+}
@@ -1,29 +1,54 @@
 package main
 
 import (
+	"bufio"
 	"embed"
+	"flag"
 	"fmt"
+	"io"
 	"io/fs"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
 	"strings"
-	"time"
+	"syscall"
 
-	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 
+	"fileshare/access"
+	"fileshare/apikey"
 	"fileshare/config"
 	"fileshare/config_loader"
 	"fileshare/controllers"
+	"fileshare/dav"
 	"fileshare/directory"
 	"fileshare/file"
+	"fileshare/ipfilter"
+	"fileshare/lockout"
 	"fileshare/middleware"
+	"fileshare/oidc"
+	"fileshare/proxy"
+	"fileshare/s3"
+	"fileshare/sftpd"
+	"fileshare/sharelink"
+	"fileshare/stats"
+	"fileshare/tlscert"
+	"fileshare/user"
 )
 
 //go:embed web/*
 var webFS embed.FS
 
+// 命令行参数
+var (
+	resetPassword = flag.Bool("reset-password", false, "重置用户密码后退出，新密码从标准输入读取，留空则随机生成")
+	resetUsername = flag.String("user", "admin", "要重置密码的用户名，配合-reset-password使用")
+)
+
 func main() {
+	flag.Parse()
+
 	// 确保配置目录存在
 	if err := config_loader.EnsureConfigDir(); err != nil {
 		log.Fatalf("Failed to create config directory: %v", err)
@@ -32,28 +57,53 @@ func main() {
 	// 加载配置
 	config_loader.LoadAllConfigs()
 
+	// 重置密码后直接退出，不启动服务
+	if *resetPassword {
+		runResetPassword(*resetUsername)
+		return
+	}
+
+	// 退出前保存延迟写入的下载统计
+	go func() {
+		quit := make(chan os.Signal, 1)
+		signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+		<-quit
+		stats.Flush()
+		os.Exit(0)
+	}()
+
 	// 获取服务器配置
 	serverConfig := config.GetServerConfig()
 
 	// 创建Gin路由
 	r := gin.New()
 
+	// 只信任配置的反向代理发送的X-Forwarded-For，未配置时直接使用连接地址作为客户端IP
+	if err := r.SetTrustedProxies(serverConfig.Server.TrustedProxies); err != nil {
+		log.Fatalf("Invalid server.trustedProxies: %v", err)
+	}
+
 	// 使用日志中间件
 	r.Use(middleware.Logger())
 	r.Use(gin.Recovery())
 
-	// 配置CORS
-	r.Use(cors.New(cors.Config{
-		AllowOrigins:     []string{"*"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
-		MaxAge:           12 * time.Hour,
-	}))
+	// 安全响应头
+	r.Use(middleware.SecurityHeaders())
+
+	// 配置CORS，只允许server.json中配置的来源跨域访问
+	if corsMiddleware := middleware.CORS(); corsMiddleware != nil {
+		r.Use(corsMiddleware)
+	}
+
+	// 拒绝跨站发起的修改请求
+	r.Use(middleware.CSRFProtection())
+
+	// 所有页面、静态资源和API都挂载在basePath下
+	basePath := proxy.BasePath()
+	root := r.Group(basePath)
 
 	// 静态文件服务
-	r.Static("/static", "./static")
+	root.Static("/static", "./static")
 
 	// 设置嵌入式web目录的静态文件服务
 	// 获取web子文件系统
@@ -61,55 +111,202 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to get web subdirectory: %v", err)
 	}
+	assetsFS, err := fs.Sub(subFS, "assets")
+	if err != nil {
+		log.Fatalf("Failed to get web assets subdirectory: %v", err)
+	}
 
 	// 设置上下文路径,管理端API的上下文路径
 	contextManagePath := serverConfig.Server.ContextManagePath
 
+	// 管理端只允许manageIpFilter中的地址访问
+	manageIPFilter := ipfilter.Manage()
+
 	// 管理员API路由组
-	admin := r.Group(contextManagePath + "/api/admin")
+	admin := root.Group(contextManagePath + "/api/admin")
+	admin.Use(manageIPFilter)
 	{
 		// 管理员登录
 		admin.POST("/login", controllers.AdminLogin)
+
+		// OIDC单点登录
+		admin.GET("/oidc", oidc.GetConfig)
+		admin.GET("/oidc/login", oidc.Login)
+		admin.GET("/oidc/callback", oidc.Callback)
 	}
 
 	// 管理API路由组（需要认证）
-	api := r.Group(serverConfig.Server.ContextManagePath + "/api")
+	api := root.Group(contextManagePath + "/api")
 	// 添加认证中间件
-	api.Use(middleware.AdminAuth())
+	api.Use(manageIPFilter, middleware.AdminAuth())
 	{
+		// 各角色的权限检查，限制了目录的API密钥通过目录解析函数检查访问范围
+		dirParam := middleware.DirectoryParam("id")
+		fileParam := middleware.FileParam("id")
+		filtered := middleware.DirectoryFiltered()
+		canRead := func(resolvers ...middleware.DirectoryResolver) gin.HandlerFunc {
+			return middleware.RequirePermission(user.PermRead, resolvers...)
+		}
+		canUpload := func(resolvers ...middleware.DirectoryResolver) gin.HandlerFunc {
+			return middleware.RequirePermission(user.PermUpload, resolvers...)
+		}
+		canManage := func(resolvers ...middleware.DirectoryResolver) gin.HandlerFunc {
+			return middleware.RequirePermission(user.PermManage, resolvers...)
+		}
+		canAdmin := middleware.RequirePermission(user.PermAdmin)
+		sessionOnly := middleware.RequireSession()
+
+		// 当前用户信息
+		api.GET("/account", sessionOnly, user.GetCurrentUser)
+		api.POST("/account/password", sessionOnly, controllers.ChangePassword)
+
+		// 两步验证
+		api.POST("/account/totp", sessionOnly, user.EnrollTOTP)
+		api.POST("/account/totp/confirm", sessionOnly, user.ConfirmTOTP)
+		api.DELETE("/account/totp", sessionOnly, user.DisableTOTP)
+		api.POST("/account/totp/recovery-codes", sessionOnly, user.RegenerateRecoveryCodes)
+
+		// SFTP登录使用的SSH公钥
+		api.GET("/account/ssh-keys", sessionOnly, user.GetSSHKeys)
+		api.POST("/account/ssh-keys", sessionOnly, user.AddSSHKey)
+		api.DELETE("/account/ssh-keys/:id", sessionOnly, user.DeleteSSHKey)
+
+		// 登录会话
+		api.POST("/admin/logout", controllers.AdminLogout)
+		api.POST("/admin/refresh", sessionOnly, controllers.AdminRefresh)
+		api.GET("/sessions", sessionOnly, controllers.GetSessions)
+		api.DELETE("/sessions", sessionOnly, controllers.RevokeOtherSessions)
+		api.DELETE("/sessions/:id", sessionOnly, controllers.RevokeSession)
+
+		// API密钥
+		api.GET("/apikeys", sessionOnly, apikey.GetAPIKeys)
+		api.POST("/apikeys", sessionOnly, apikey.CreateAPIKey)
+		api.POST("/apikeys/:id/revoke", sessionOnly, apikey.RevokeAPIKey)
+		api.DELETE("/apikeys/:id", sessionOnly, apikey.DeleteAPIKey)
+		api.POST("/apikeys/:id/s3-credentials", sessionOnly, apikey.RotateS3Credentials)
+
 		// 目录相关API
-		api.GET("/directories", directory.GetDirectories)
-		api.POST("/directories", directory.CreateDirectory)
-		api.PUT("/directories/:id", directory.UpdateDirectory)
-		api.DELETE("/directories/:id", directory.DeleteDirectory)
-		api.PATCH("/directories/:id/share", directory.ToggleDirectoryShare)
-		api.PATCH("/directories/:id/password", directory.SetDirectoryPassword)
+		api.GET("/directories", canRead(filtered), directory.GetDirectories)
+		api.POST("/directories", canManage(middleware.DirectoryJSON("parentId")), directory.CreateDirectory)
+		api.PUT("/directories/:id", canManage(dirParam), directory.UpdateDirectory)
+		api.DELETE("/directories/:id", canManage(dirParam), directory.DeleteDirectory)
+		api.PATCH("/directories/:id/share", canManage(dirParam), directory.ToggleDirectoryShare)
+		api.PATCH("/directories/:id/password", canManage(dirParam), directory.SetDirectoryPassword)
+		api.GET("/directories/:id/archive", canRead(dirParam), file.AdminDownloadDirectoryArchive)
+		api.GET("/directories/:id/stats", canRead(dirParam), stats.GetDirectoryStats)
+		api.GET("/directories/:id/acl", canAdmin, directory.GetDirectoryACL)
+		api.PUT("/directories/:id/acl", canAdmin, directory.SetDirectoryACL)
+		api.GET("/directories/:id/ip-filter", canAdmin, directory.GetDirectoryIPFilter)
+		api.PUT("/directories/:id/ip-filter", canAdmin, directory.SetDirectoryIPFilter)
 
 		// 文件相关API
-		api.GET("/files", file.GetFiles)
-		api.POST("/files", file.UploadFiles)
-		api.DELETE("/files/:id", file.DeleteFile)
-		api.PATCH("/files/:id", file.UpdateFile)
-		api.PATCH("/files/:id/share", file.ToggleFileShare)
-		api.GET("/files/:id/download", file.AdminDownloadFile)
+		api.GET("/files", canRead(middleware.DirectoryQuery("directoryId")), file.GetFiles)
+		api.POST("/files", canUpload(middleware.DirectoryForm("directoryId")), file.UploadFiles)
+		api.POST("/files/batch", canRead(filtered), file.AdminCreateBatchDownload)
+		api.GET("/files/batch/:ticket", canRead(filtered), file.AdminDownloadBatch)
+		api.DELETE("/files/:id", canManage(fileParam), file.DeleteFile)
+		api.PATCH("/files/:id", canManage(fileParam), file.UpdateFile)
+		api.PATCH("/files/:id/share", canManage(fileParam), file.ToggleFileShare)
+		api.GET("/files/:id/download", canRead(fileParam), file.AdminDownloadFile)
+		api.GET("/files/:id/thumbnail", canRead(fileParam), file.AdminGetThumbnail)
+		api.GET("/files/:id/preview", canRead(fileParam), file.AdminGetPreview)
+		api.GET("/files/:id/stats", canRead(fileParam), stats.GetFileStats)
+
+		// 可续传上传API
+		uploadParam := middleware.UploadParam("id")
+		api.POST("/uploads", canUpload(middleware.DirectoryJSON("directoryId")), file.CreateUpload)
+		api.GET("/uploads/:id", canUpload(uploadParam), file.GetUpload)
+		api.PATCH("/uploads/:id", canUpload(uploadParam), file.AppendUpload)
+		api.DELETE("/uploads/:id", canUpload(uploadParam), file.DeleteUpload)
+
+		// 以访客身份查看共享内容
+		api.GET("/guest-view", canRead(), access.GetGuestView)
+
+		// 分享链接相关API
+		api.GET("/sharelinks", canManage(filtered), sharelink.GetShareLinks)
+		api.POST("/sharelinks", canManage(filtered), sharelink.CreateShareLink)
+		api.PATCH("/sharelinks/:id", canManage(filtered), sharelink.UpdateShareLink)
+		api.POST("/sharelinks/:id/revoke", canManage(filtered), sharelink.RevokeShareLink)
+		api.DELETE("/sharelinks/:id", canManage(filtered), sharelink.DeleteShareLink)
+
+		// 用户管理API
+		api.GET("/users", canAdmin, user.GetUsers)
+		api.POST("/users", canAdmin, user.CreateUser)
+		api.PATCH("/users/:id", canAdmin, user.UpdateUser)
+		api.DELETE("/users/:id", canAdmin, user.DeleteUser)
+		api.DELETE("/users/:id/totp", canAdmin, user.ResetUserTOTP)
+
+		// 登录和目录密码的失败锁定
+		api.GET("/lockouts", canAdmin, lockout.GetLockouts)
+		api.DELETE("/lockouts", canAdmin, lockout.ClearAllLockouts)
+		api.DELETE("/lockouts/:key", canAdmin, lockout.ClearLockout)
 	}
 
 	// 共享预览API路由组（不需要认证）
-	shareApi := r.Group(serverConfig.Server.ContextSharePath + "/api")
+	shareApi := root.Group(serverConfig.Server.ContextSharePath + "/api")
 	{
 		// 共享目录和文件API
 		shareApi.GET("/directories/shared", directory.GetSharedDirectories)
 		shareApi.POST("/directories/:id/verify", directory.VerifyDirectoryPassword)
+		shareApi.GET("/directories/:id/archive", file.DownloadDirectoryArchive)
 		shareApi.GET("/files/shared", file.GetSharedFiles)
 		shareApi.GET("/files/:id/download", file.DownloadFile)
+		shareApi.GET("/files/:id/thumbnail", file.GetThumbnail)
+		shareApi.GET("/files/:id/preview", file.GetPreview)
+		shareApi.POST("/files/batch", file.CreateBatchDownload)
+		shareApi.GET("/files/batch/:ticket", file.DownloadBatch)
+
+		// 分享链接API
+		shareApi.GET("/links/:token", sharelink.ResolveShareLink)
+		shareApi.POST("/links/:token/verify", sharelink.VerifyShareLinkPassword)
+		shareApi.GET("/links/:token/download", sharelink.DownloadShareLink)
+		shareApi.GET("/links/:token/files/:fileId/download", sharelink.DownloadShareLinkFile)
 	}
 
-	// 提供嵌入式web目录
-	r.StaticFS("/fileserver", http.FS(subFS))
+	// WebDAV入口，可以把目录映射为网络驱动器
+	if serverConfig.WebDAV.Enabled {
+		shareDavPath := serverConfig.Server.ContextSharePath + dav.SharePath
+		manageDavPath := contextManagePath + dav.ManagePath
+		shareDav := root.Group(shareDavPath)
+		manageDav := root.Group(manageDavPath, manageIPFilter, middleware.BasicAuth(dav.Realm))
+		shareHandler := dav.ShareHandler(shareDavPath)
+		manageHandler := dav.ManageHandler(manageDavPath)
+		for _, method := range dav.Methods {
+			shareDav.Handle(method, "", shareHandler)
+			shareDav.Handle(method, "/*path", shareHandler)
+			manageDav.Handle(method, "", manageHandler)
+			manageDav.Handle(method, "/*path", manageHandler)
+		}
+	}
+
+	// S3兼容的只读接口，使用s3权限范围的API密钥对应的访问密钥
+	if serverConfig.S3.Enabled {
+		s3Path := contextManagePath + s3.RoutePath
+		s3Group := root.Group(s3Path, manageIPFilter)
+		s3Handler := s3.Handler(s3Path)
+		for _, method := range s3.Methods {
+			s3Group.Handle(method, "", s3Handler)
+			s3Group.Handle(method, "/*path", s3Handler)
+		}
+	}
+
+	// SFTP服务，使用管理端账号和权限
+	if serverConfig.SFTP.Enabled {
+		go sftpd.ListenAndServe()
+	}
+
+	// 提供嵌入式web目录，index.html根据访问路径动态生成
+	uiPath := proxy.CleanPath(serverConfig.Server.ContextPath)
+	index := serveIndex(subFS)
+	root.StaticFS(uiPath+"/assets", http.FS(assetsFS))
+	root.GET(uiPath+"/", index)
+	if uiPath != "" {
+		root.GET(uiPath, index)
+	}
 	// r.StaticFS("/index.html", http.FS(subFS))
 
 	// 提供favicon.ico
-	r.GET("/favicon.ico", func(c *gin.Context) {
+	root.GET("/favicon.ico", func(c *gin.Context) {
 		c.FileFromFS("favicon.ico", http.FS(subFS))
 	})
 	// 提供根路径和index.html的处理
@@ -126,22 +323,79 @@ func main() {
 
 	// 处理SPA路由 - 对于前端路由的所有请求返回index.html
 	r.NoRoute(func(c *gin.Context) {
+		// 不在basePath下的请求返回404
+		path := c.Request.URL.Path
+		if basePath != "" && path != basePath && !strings.HasPrefix(path, basePath+"/") {
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+
 		// 如果请求的是API路径，则返回404
-		if strings.HasPrefix(c.Request.URL.Path, "/api/") ||
-			strings.HasPrefix(c.Request.URL.Path, serverConfig.Server.ContextManagePath+"/api/") ||
-			strings.HasPrefix(c.Request.URL.Path, serverConfig.Server.ContextSharePath+"/api/") {
+		path = strings.TrimPrefix(path, basePath)
+		if strings.HasPrefix(path, "/api/") ||
+			strings.HasPrefix(path, contextManagePath+"/api/") ||
+			strings.HasPrefix(path, serverConfig.Server.ContextSharePath+"/api/") {
 			c.AbortWithStatus(http.StatusNotFound)
 			return
 		}
 
 		// 否则返回index.html以支持前端路由
-		c.FileFromFS("index.html", http.FS(subFS))
+		index(c)
 	})
 
 	// 启动服务器
 	port := serverConfig.Server.Port
-	log.Printf("\n\n 服务器已运行，访问地址： http://localhost:%d/fileserver/ \n\n", port)
+	if serverConfig.TLS.Enabled {
+		runTLS(r, port)
+		return
+	}
+	log.Printf("\n\n 服务器已运行，访问地址： http://localhost:%d%s/ \n\n", port, proxy.BasePath()+proxy.CleanPath(serverConfig.Server.ContextPath))
 	if err := r.Run(fmt.Sprintf(":%d", port)); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+// 使用HTTPS启动服务器，按配置同时启动HTTP跳转服务
+func runTLS(handler http.Handler, port int) {
+	tlsConfig := config.GetServerConfig().TLS
+	manager, err := tlscert.NewManager(tlsConfig.CertFile, tlsConfig.KeyFile, tlsConfig.Hosts)
+	if err != nil {
+		log.Fatalf("Failed to load TLS certificate: %v", err)
+	}
+
+	if tlsConfig.HTTPRedirectPort > 0 {
+		go func() {
+			redirectAddr := fmt.Sprintf(":%d", tlsConfig.HTTPRedirectPort)
+			if err := http.ListenAndServe(redirectAddr, tlscert.RedirectHandler(port)); err != nil {
+				log.Printf("Failed to start HTTP redirect server: %v", err)
+			}
+		}()
+	}
+
+	server := &http.Server{
+		Addr:      fmt.Sprintf(":%d", port),
+		Handler:   handler,
+		TLSConfig: manager.TLSConfig(),
+	}
+	log.Printf("\n\n 服务器已运行，访问地址： https://localhost:%d%s/ \n\n", port, proxy.BasePath()+proxy.CleanPath(config.GetServerConfig().Server.ContextPath))
+	if err := server.ListenAndServeTLS("", ""); err != nil {
+		log.Fatalf("Failed to start server: %v", err)
+	}
+}
+
+// 从标准输入读取新密码并重置用户密码，用于忘记管理员密码时恢复
+func runResetPassword(username string) {
+	fmt.Fprintf(os.Stderr, "New password for %s (leave empty to generate one): ", username)
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		log.Fatalf("Failed to read password: %v", err)
+	}
+
+	password, err := user.ResetPassword(username, strings.TrimRight(line, "\r\n"))
+	if err != nil {
+		log.Fatalf("Failed to reset password: %v", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "\nPassword of %s has been reset, all sessions were revoked. It must be changed after the next login.\n", username)
+	fmt.Println(password)
+}
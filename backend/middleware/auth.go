@@ -4,36 +4,201 @@ import (
 	"net/http"
 	"strings"
 
+	"fileshare/acl"
+	"fileshare/apikey"
+	"fileshare/config"
+	"fileshare/proxy"
+	"fileshare/user"
 	"fileshare/utils"
 
 	"github.com/gin-gonic/gin"
 )
 
-// AdminAuth 中间件用于验证管理员权限
+// 上下文中保存当前会话的键
+const SessionContextKey = "session"
+
+// RequestToken 获取请求中的会话token，优先使用Authorization请求头，其次使用Cookie
+func RequestToken(c *gin.Context) string {
+	auth := c.GetHeader("Authorization")
+	if auth == "" {
+		token, _ := c.Cookie(SessionCookieName)
+		return token
+	}
+
+	// 处理Bearer token格式
+	return strings.TrimPrefix(auth, "Bearer ")
+}
+
+// SetSessionCookies 在浏览器中保存登录会话：会话token使用HttpOnly Cookie，前端无法读取；
+// CSRF令牌需要由前端读取，不设置HttpOnly
+func SetSessionCookies(c *gin.Context, token string) {
+	maxAge := int(utils.SessionTTL().Seconds())
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(SessionCookieName, token, maxAge, "/", "", proxy.IsHTTPS(c), true)
+	c.SetCookie(CSRFCookieName, utils.SessionCSRFToken(token), maxAge, "/", "", proxy.IsHTTPS(c), false)
+}
+
+// ClearSessionCookies 删除浏览器中保存的登录会话
+func ClearSessionCookies(c *gin.Context) {
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(SessionCookieName, "", -1, "/", "", proxy.IsHTTPS(c), true)
+	c.SetCookie(CSRFCookieName, "", -1, "/", "", proxy.IsHTTPS(c), false)
+}
+
+// CurrentSession 获取当前请求的登录会话
+func CurrentSession(c *gin.Context) (utils.Session, bool) {
+	if value, exists := c.Get(SessionContextKey); exists {
+		if s, ok := value.(utils.Session); ok {
+			return s, true
+		}
+	}
+	return utils.Session{}, false
+}
+
+// 必须修改密码时仍然可以访问的接口（相对于管理API路径）
+var passwordChangeRoutes = map[string]bool{
+	"/account":          true,
+	"/account/password": true,
+	"/admin/logout":     true,
+}
+
+// 判断请求的接口在必须修改密码时是否仍可访问
+func passwordChangeExempt(c *gin.Context) bool {
+	prefix := proxy.BasePath() + config.GetServerConfig().Server.ContextManagePath + "/api"
+	return passwordChangeRoutes[strings.TrimPrefix(c.FullPath(), prefix)]
+}
+
+// AdminAuth 中间件用于验证管理员权限，支持会话token和API密钥
 func AdminAuth() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// 从请求头中获取token
-		auth := c.GetHeader("Authorization")
-		if auth == "" {
+		// 从请求头中获取token，没有请求头时使用Cookie中的会话
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if token == "" {
+			token = c.GetHeader(apikey.HeaderName)
+		}
+		fromCookie := false
+		if token == "" {
+			token = RequestToken(c)
+			fromCookie = token != ""
+		}
+		if token == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "未授权，请先登录"})
 			c.Abort()
 			return
 		}
 
-		// 处理Bearer token格式
-		token := auth
-		if strings.HasPrefix(auth, "Bearer ") {
-			token = strings.TrimPrefix(auth, "Bearer ")
+		// API密钥（不接受放在Cookie中的密钥）
+		if apikey.IsAPIKey(token) && !fromCookie {
+			authenticateAPIKey(c, token)
+			return
 		}
 
 		// 验证token
-		if !utils.ValidateToken(token) {
+		session, valid := utils.ValidateToken(token)
+		if !valid {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "无效的token，请重新登录"})
 			c.Abort()
 			return
 		}
 
+		// 查找token所属用户，用户被删除或禁用后token失效
+		currentUser := user.FindByID(session.UserID)
+		if currentUser == nil || currentUser.Disabled {
+			utils.InvalidateToken(token)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "用户不存在或已被禁用，请重新登录"})
+			c.Abort()
+			return
+		}
+		c.Set(user.ContextKey, currentUser)
+		c.Set(SessionContextKey, session)
+
+		// 浏览器会自动携带Cookie，使用Cookie会话修改数据时必须提交CSRF令牌
+		if fromCookie && !isSafeMethod(c.Request.Method) && !utils.ValidateCSRFToken(session.ID, c.GetHeader(CSRFHeaderName)) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "缺少或无效的CSRF令牌"})
+			c.Abort()
+			return
+		}
+
+		// 必须先修改密码才能使用其他接口
+		if currentUser.MustChangePassword && !passwordChangeExempt(c) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "请先修改默认密码", "mustChangePassword": true})
+			c.Abort()
+			return
+		}
+
 		// 继续处理请求
 		c.Next()
 	}
 }
+
+// 使用API密钥认证，密钥的权限不会超过所属用户的角色
+func authenticateAPIKey(c *gin.Context, key string) {
+	k, valid := apikey.Authenticate(key, c.ClientIP())
+	if !valid {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "无效的API密钥"})
+		c.Abort()
+		return
+	}
+
+	owner := user.FindByID(k.UserID)
+	if owner == nil || owner.Disabled {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "API密钥所属用户不存在或已被禁用"})
+		c.Abort()
+		return
+	}
+	c.Set(user.ContextKey, owner)
+	c.Set(apikey.ContextKey, k)
+
+	c.Next()
+}
+
+// RequireSession 中间件用于要求使用登录会话访问，API密钥不能管理账号、会话和密钥
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if apikey.Current(c) != nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": "API密钥不能访问该接口"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// RequirePermission 中间件用于检查当前用户是否拥有指定权限，需在AdminAuth之后使用。
+// 提供目录解析函数时按目录的访问控制列表检查（见acl包），否则按用户角色检查；
+// 使用API密钥时还会检查密钥的权限范围，限制了目录的密钥只能访问提供了目录解析函数的接口
+func RequirePermission(perm string, resolvers ...DirectoryResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		allowed := len(resolvers) > 0 || hasRolePermission(c, perm)
+		for _, resolve := range resolvers {
+			if !allowed {
+				break
+			}
+			dirID, ok := resolve(c)
+			switch {
+			case dirID == FilteredDirectory:
+				// 由处理函数按权限过滤
+				allowed = apikey.AllowsPermission(apikey.Current(c), perm)
+			case !ok || dirID == "":
+				// 无法确定目录或者是根目录，按角色检查，目录不存在时由处理函数返回错误
+				allowed = hasRolePermission(c, perm)
+			default:
+				allowed = acl.CanAccess(c, dirID, perm)
+			}
+		}
+
+		if !allowed {
+			c.JSON(http.StatusForbidden, gin.H{"error": "权限不足"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// 按用户角色和API密钥检查权限，限制了目录的API密钥不能访问不针对具体目录的接口
+func hasRolePermission(c *gin.Context, perm string) bool {
+	return acl.CheckRole(user.Current(c), apikey.Current(c), perm)
+}
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/utils"
+)
+
+// 执行设置Cookie的函数，返回响应中的Cookie
+func responseCookies(set func(c *gin.Context)) map[string]*http.Cookie {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, "/", nil)
+	set(c)
+
+	cookies := map[string]*http.Cookie{}
+	for _, cookie := range w.Result().Cookies() {
+		cookies[cookie.Name] = cookie
+	}
+	return cookies
+}
+
+func TestSessionCookies(t *testing.T) {
+	token := utils.GenerateToken("user", "127.0.0.1", "test")
+	defer utils.InvalidateToken(token)
+
+	set := responseCookies(func(c *gin.Context) { SetSessionCookies(c, token) })
+	cleared := responseCookies(ClearSessionCookies)
+
+	tests := []struct {
+		name     string
+		cookies  map[string]*http.Cookie
+		cookie   string
+		value    string
+		httpOnly bool
+		deleted  bool
+	}{
+		{"会话token不能被脚本读取", set, SessionCookieName, token, true, false},
+		{"CSRF令牌可以被脚本读取", set, CSRFCookieName, utils.SessionCSRFToken(token), false, false},
+		{"退出时删除会话token", cleared, SessionCookieName, "", true, true},
+		{"退出时删除CSRF令牌", cleared, CSRFCookieName, "", false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cookie := tt.cookies[tt.cookie]
+			if cookie == nil {
+				t.Fatalf("cookie %s not set", tt.cookie)
+			}
+			if cookie.Value != tt.value || cookie.HttpOnly != tt.httpOnly || cookie.Path != "/" {
+				t.Errorf("cookie = %+v", cookie)
+			}
+			if cookie.SameSite != http.SameSiteLaxMode {
+				t.Errorf("SameSite = %v, want Lax", cookie.SameSite)
+			}
+			if deleted := cookie.MaxAge < 0; deleted != tt.deleted {
+				t.Errorf("MaxAge = %d, deleted %v", cookie.MaxAge, tt.deleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"fileshare/apikey"
+	"fileshare/lockout"
+	"fileshare/models"
+	"fileshare/user"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HTTP基本认证通过后缓存的时长，WebDAV等客户端每个请求都会携带用户名和密码，避免每次都计算bcrypt
+const basicAuthCacheTTL = 5 * time.Minute
+
+// 缓存的基本认证结果
+type basicLogin struct {
+	userID       string
+	passwordHash string
+	expiresAt    time.Time
+}
+
+var (
+	basicLogins   = make(map[string]basicLogin)
+	basicLoginsMu sync.Mutex
+)
+
+// BasicAuth 中间件使用HTTP基本认证验证用户，供不支持登录会话的客户端（如WebDAV）使用。
+// 密码可以是账号密码，也可以是API密钥；启用了两步验证的账号只能使用API密钥
+func BasicAuth(realm string) gin.HandlerFunc {
+	challenge := `Basic realm="` + realm + `", charset="UTF-8"`
+
+	return func(c *gin.Context) {
+		username, password, ok := c.Request.BasicAuth()
+		if !ok || password == "" {
+			c.Header("WWW-Authenticate", challenge)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "未授权，请先登录"})
+			c.Abort()
+			return
+		}
+
+		// API密钥作为密码时忽略用户名
+		if apikey.IsAPIKey(password) {
+			authenticateAPIKey(c, password)
+			return
+		}
+
+		// 检查是否因多次失败被锁定（按客户端IP以及IP和账号的组合），账号本身的失败只会延迟登录
+		accountKey := lockout.AccountKey(username)
+		lockoutKeys := []string{lockout.IPKey(c), lockout.ScopedKey(c, accountKey), accountKey}
+		if !lockout.Guard(c, lockoutKeys[:2]...) {
+			c.Abort()
+			return
+		}
+
+		currentUser := cachedBasicLogin(username, password)
+		if currentUser == nil {
+			lockout.Throttle(accountKey)
+			currentUser = user.Authenticate(username, password)
+			// 启用了两步验证的账号不能只凭密码登录，和密码错误返回相同的响应并计为失败，
+			// 避免通过响应判断密码是否正确
+			if currentUser == nil || currentUser.TOTPEnabled {
+				lockout.Fail(lockoutKeys...)
+				c.Header("WWW-Authenticate", challenge)
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "用户名或密码错误"})
+				c.Abort()
+				return
+			}
+			lockout.Succeed(lockoutKeys[1:]...)
+			cacheBasicLogin(username, password, currentUser)
+		}
+
+		if currentUser.MustChangePassword {
+			c.JSON(http.StatusForbidden, gin.H{"error": "请先修改默认密码", "mustChangePassword": true})
+			c.Abort()
+			return
+		}
+		c.Set(user.ContextKey, currentUser)
+
+		c.Next()
+	}
+}
+
+// 基本认证缓存的键，不保存明文密码
+func basicLoginKey(username, password string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(username) + "\x00" + password))
+	return hex.EncodeToString(sum[:])
+}
+
+// 查找缓存的认证结果，用户被禁用、修改密码或启用两步验证后缓存失效
+func cachedBasicLogin(username, password string) *models.User {
+	key := basicLoginKey(username, password)
+
+	basicLoginsMu.Lock()
+	login, exists := basicLogins[key]
+	if exists && time.Now().After(login.expiresAt) {
+		delete(basicLogins, key)
+		exists = false
+	}
+	basicLoginsMu.Unlock()
+	if !exists {
+		return nil
+	}
+
+	u := user.FindByID(login.userID)
+	if u == nil || u.Disabled || u.TOTPEnabled || u.Password != login.passwordHash {
+		return nil
+	}
+	return u
+}
+
+// 缓存认证成功的结果，同时清理过期的缓存
+func cacheBasicLogin(username, password string, u *models.User) {
+	now := time.Now()
+
+	basicLoginsMu.Lock()
+	defer basicLoginsMu.Unlock()
+
+	for key, login := range basicLogins {
+		if now.After(login.expiresAt) {
+			delete(basicLogins, key)
+		}
+	}
+	basicLogins[basicLoginKey(username, password)] = basicLogin{
+		userID:       u.ID,
+		passwordHash: u.Password,
+		expiresAt:    now.Add(basicAuthCacheTTL),
+	}
+}
@@ -3,7 +3,9 @@ package middleware
 import (
 	"fmt"
 	"io"
+	"net/url"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -42,7 +44,7 @@ func Logger() gin.HandlerFunc {
 		// 获取请求方法和路径
 		method := c.Request.Method
 		path := c.Request.URL.Path
-		query := c.Request.URL.RawQuery
+		query := redactQuery(c.Request.URL.RawQuery)
 		if query != "" {
 			path = path + "?" + query
 		}
@@ -73,3 +75,26 @@ func Logger() gin.HandlerFunc {
 		}
 	}
 }
+
+// 日志中隐藏值的查询参数：密码和访问令牌
+var redactedParams = map[string]bool{
+	"password":  true,
+	"dirToken":  true,
+	"linkToken": true,
+}
+
+// 隐藏查询字符串中敏感参数的值，其他参数保持原样
+func redactQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+
+	params := strings.Split(rawQuery, "&")
+	for i, param := range params {
+		key, _, hasValue := strings.Cut(param, "=")
+		if name, err := url.QueryUnescape(key); err == nil && redactedParams[name] && hasValue {
+			params[i] = key + "=***"
+		}
+	}
+	return strings.Join(params, "&")
+}
@@ -0,0 +1,26 @@
+package middleware
+
+import "testing"
+
+func TestRedactQuery(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", ""},
+		{"page=2", "page=2"},
+		{"password=secret", "password=***"},
+		{"dirToken=abc.123.sig&name=a", "dirToken=***&name=a"},
+		{"name=a&linkToken=abc&linkToken=def", "name=a&linkToken=***&linkToken=***"},
+		{"pass%77ord=secret", "pass%77ord=***"},
+		{"password", "password"},
+		{"password=", "password=***"},
+		{"passwords=1&mypassword=2", "passwords=1&mypassword=2"},
+	}
+
+	for _, tt := range tests {
+		if got := redactQuery(tt.query); got != tt.want {
+			t.Errorf("redactQuery(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
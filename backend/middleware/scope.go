@@ -0,0 +1,88 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/file"
+	"fileshare/models"
+)
+
+// DirectoryResolver 从请求中解析出要访问的目录ID。
+// ok为false表示无法确定目录（例如文件不存在），交给处理函数返回错误
+type DirectoryResolver func(c *gin.Context) (dirID string, ok bool)
+
+// FilteredDirectory 表示由处理函数自行按目录权限过滤结果或检查权限（如目录树列表、批量操作）
+const FilteredDirectory = "*"
+
+// DirectoryParam 从路径参数中获取目录ID
+func DirectoryParam(name string) DirectoryResolver {
+	return func(c *gin.Context) (string, bool) {
+		return c.Param(name), true
+	}
+}
+
+// FileParam 从路径参数中获取文件ID，并返回文件所在目录
+func FileParam(name string) DirectoryResolver {
+	return func(c *gin.Context) (string, bool) {
+		id := c.Param(name)
+		for _, f := range models.Files {
+			if f.ID == id {
+				return f.DirectoryID, true
+			}
+		}
+		return "", false
+	}
+}
+
+// UploadParam 从路径参数中获取上传ID，并返回上传的目标目录
+func UploadParam(name string) DirectoryResolver {
+	return func(c *gin.Context) (string, bool) {
+		return file.UploadDirectory(c.Param(name))
+	}
+}
+
+// DirectoryQuery 从查询参数中获取目录ID，未指定目录时由处理函数按权限过滤
+func DirectoryQuery(name string) DirectoryResolver {
+	return func(c *gin.Context) (string, bool) {
+		if id := c.Query(name); id != "" {
+			return id, true
+		}
+		return FilteredDirectory, true
+	}
+}
+
+// DirectoryForm 从表单字段中获取目录ID
+func DirectoryForm(name string) DirectoryResolver {
+	return func(c *gin.Context) (string, bool) {
+		return c.PostForm(name), true
+	}
+}
+
+// DirectoryJSON 从JSON请求体的字段中获取目录ID，读取后恢复请求体供处理函数使用
+func DirectoryJSON(field string) DirectoryResolver {
+	return func(c *gin.Context) (string, bool) {
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			return "", false
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		var values map[string]interface{}
+		if err := json.Unmarshal(body, &values); err != nil {
+			return "", false
+		}
+		id, _ := values[field].(string)
+		return id, true
+	}
+}
+
+// DirectoryFiltered 用于处理函数自行按目录权限过滤或检查的接口
+func DirectoryFiltered() DirectoryResolver {
+	return func(c *gin.Context) (string, bool) {
+		return FilteredDirectory, true
+	}
+}
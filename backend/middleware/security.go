@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+
+	"fileshare/config"
+	"fileshare/proxy"
+)
+
+// CSRF令牌请求头，使用Cookie中的登录会话发起修改请求时必须携带
+const CSRFHeaderName = "X-CSRF-Token"
+
+// 会话Cookie名称：会话token只能由浏览器携带（HttpOnly），前端读取CSRF令牌后放在X-CSRF-Token请求头中
+const (
+	SessionCookieName = "admin_token"
+	CSRFCookieName    = "csrf_token"
+)
+
+// 默认的Content-Security-Policy，frame-ancestors根据配置追加
+const defaultContentSecurityPolicy = "default-src 'self'; script-src 'self'; style-src 'self' 'unsafe-inline'; " +
+	"img-src 'self' data: blob:; media-src 'self' blob:; font-src 'self' data:; frame-src 'self' blob:; " +
+	"connect-src 'self'; object-src 'none'; base-uri 'self'; form-action 'self'"
+
+// CORS 根据配置创建跨域中间件，没有配置允许的来源时返回nil（只允许同源访问）
+func CORS() gin.HandlerFunc {
+	origins := config.GetServerConfig().Security.AllowOrigins
+	if len(origins) == 0 {
+		return nil
+	}
+
+	corsConfig := cors.Config{
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-API-Key", CSRFHeaderName},
+		ExposeHeaders:    []string{"Content-Length"},
+		AllowCredentials: true,
+		MaxAge:           12 * time.Hour,
+	}
+
+	// 允许所有来源时不能同时允许携带凭据
+	allowAll := false
+	for _, origin := range origins {
+		allowAll = allowAll || origin == "*"
+	}
+	if allowAll {
+		corsConfig.AllowAllOrigins = true
+		corsConfig.AllowCredentials = false
+	} else {
+		corsConfig.AllowOrigins = origins
+	}
+
+	if err := corsConfig.Validate(); err != nil {
+		log.Fatalf("Invalid security.allowOrigins: %v", err)
+	}
+	return cors.New(corsConfig)
+}
+
+// SecurityHeaders 中间件用于添加安全相关的响应头
+func SecurityHeaders() gin.HandlerFunc {
+	security := config.GetServerConfig().Security
+
+	csp := security.ContentSecurityPolicy
+	if csp == "" {
+		csp = defaultContentSecurityPolicy
+	}
+	if len(security.FrameAncestors) > 0 && !strings.Contains(csp, "frame-ancestors") {
+		csp += "; frame-ancestors " + strings.Join(security.FrameAncestors, " ")
+	}
+	hsts := "max-age=" + strconv.Itoa(security.HSTSMaxAge)
+
+	return func(c *gin.Context) {
+		header := c.Writer.Header()
+		header.Set("Content-Security-Policy", csp)
+		header.Set("X-Content-Type-Options", "nosniff")
+		if security.ReferrerPolicy != "" {
+			header.Set("Referrer-Policy", security.ReferrerPolicy)
+		}
+		if security.HSTSMaxAge > 0 && proxy.IsHTTPS(c) {
+			header.Set("Strict-Transport-Security", hsts)
+		}
+
+		c.Next()
+	}
+}
+
+// CSRFProtection 中间件用于拒绝来自其他站点的修改请求。
+// 浏览器跨站发起的POST等请求会携带Origin（或Referer）请求头，来源既不是本站也不在allowOrigins中时拒绝；
+// 没有这两个请求头的请求（命令行工具、脚本）不受影响
+func CSRFProtection() gin.HandlerFunc {
+	origins := config.GetServerConfig().Security.AllowOrigins
+
+	return func(c *gin.Context) {
+		if isSafeMethod(c.Request.Method) {
+			c.Next()
+			return
+		}
+
+		source := c.GetHeader("Origin")
+		if source == "" {
+			source = c.GetHeader("Referer")
+		}
+		if source != "" && !isSameOrigin(c, source) && !containsOrigin(origins, originOf(source)) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "跨站请求被拒绝"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// 判断请求方法是否不会修改数据
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// 判断来源是否与当前请求的主机相同
+func isSameOrigin(c *gin.Context, source string) bool {
+	u, err := url.Parse(source)
+	return err == nil && u.Host != "" && strings.EqualFold(u.Host, c.Request.Host)
+}
+
+// 获取URL的来源部分（scheme://host）
+func originOf(source string) string {
+	u, err := url.Parse(source)
+	if err != nil || u.Host == "" {
+		return source
+	}
+	return u.Scheme + "://" + u.Host
+}
+
+// 判断来源是否在列表中，"*"匹配所有来源
+func containsOrigin(origins []string, origin string) bool {
+	for _, allowed := range origins {
+		if allowed == origin || allowed == "*" {
+			return true
+		}
+	}
+	return false
+}
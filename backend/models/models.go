@@ -6,9 +6,27 @@ type Directory struct {
 	Name     string       `json:"name"`
 	ParentID string       `json:"parentId,omitempty"`
 	IsShared bool         `json:"isShared"`
-	Password string       `json:"password,omitempty"`
-	DirType  string       `json:"dirType,omitempty"` // 目录类型：link(链接型) 或 storage(存储型)
+	Inherit  bool         `json:"inherit,omitempty"`  // 未单独设置共享状态，沿用上级目录的共享状态
+	Password string       `json:"password,omitempty"` // 密码哈希，不对外返回
+	DirType  string       `json:"dirType,omitempty"`  // 目录类型：link(链接型) 或 storage(存储型)
 	Children []*Directory `json:"children,omitempty"`
+	ACL      []*ACLEntry  `json:"acl,omitempty"`      // 访问控制列表，子目录继承上级目录的授权
+	IPFilter *IPFilter    `json:"ipFilter,omitempty"` // 共享端的IP访问限制，子目录继承上级目录的限制
+
+	HasPassword bool `json:"hasPassword,omitempty"` // 仅用于接口响应，表示目录是否设置了密码
+}
+
+// IP访问限制，条目可以是CIDR网段或单个IP
+type IPFilter struct {
+	Allow []string `json:"allow,omitempty"` // 允许访问的网段，为空表示不限制
+	Deny  []string `json:"deny,omitempty"`  // 拒绝访问的网段，优先于allow
+}
+
+// 访问控制条目，授予用户或用户组对目录的权限
+type ACLEntry struct {
+	Type        string   `json:"type"`        // 授权对象类型：user 或 group
+	Subject     string   `json:"subject"`     // 用户名或用户组名
+	Permissions []string `json:"permissions"` // 权限：read、upload、manage
 }
 
 // 文件结构
@@ -23,6 +41,98 @@ type File struct {
 	DirectoryID string `json:"directoryId"`
 }
 
+// 文件下载统计
+type FileStats struct {
+	FileID             string `json:"fileId"`
+	DownloadCount      int64  `json:"downloadCount"`      // 下载次数（包括未完成的）
+	CompletedDownloads int64  `json:"completedDownloads"` // 完整下载次数
+	AbortedDownloads   int64  `json:"abortedDownloads"`   // 中断的下载次数
+	BytesServed        int64  `json:"bytesServed"`        // 已发送的字节数
+	LastDownloadTime   string `json:"lastDownloadTime,omitempty"`
+}
+
+// 分享链接
+type ShareLink struct {
+	ID            string    `json:"id"`
+	Token         string    `json:"token"`                  // 链接中使用的随机令牌
+	TargetType    string    `json:"targetType"`             // 分享目标类型：file 或 directory
+	TargetID      string    `json:"targetId"`               // 文件ID或目录ID
+	Password      string    `json:"password,omitempty"`     // 链接密码哈希，为空表示不需要密码
+	ExpiresAt     string    `json:"expiresAt,omitempty"`    // 过期时间，为空表示永不过期
+	MaxDownloads  int       `json:"maxDownloads,omitempty"` // 最大下载次数，0表示不限制
+	DownloadCount int       `json:"downloadCount"`
+	Revoked       bool      `json:"revoked"`
+	CreatedAt     string    `json:"createdAt"`
+	IPFilter      *IPFilter `json:"ipFilter,omitempty"` // IP访问限制
+
+	HasPassword bool `json:"hasPassword,omitempty"` // 仅用于接口响应，表示链接是否设置了密码
+}
+
+// 用户结构
+type User struct {
+	ID        string   `json:"id"`
+	Username  string   `json:"username"`
+	Password  string   `json:"password,omitempty"` // 密码哈希，不对外返回
+	Role      string   `json:"role"`               // 角色：admin、editor、uploader、viewer
+	Groups    []string `json:"groups,omitempty"`   // 用户组，用于目录访问控制
+	Disabled  bool     `json:"disabled,omitempty"`
+	CreatedAt string   `json:"createdAt"`
+
+	MustChangePassword bool `json:"mustChangePassword,omitempty"` // 下次登录后必须修改密码
+
+	// 外部身份（OIDC单点登录），本地密码为空
+	Provider string `json:"provider,omitempty"`
+	Subject  string `json:"subject,omitempty"`
+
+	// 两步验证（TOTP），密钥和恢复码哈希不对外返回
+	TOTPEnabled   bool     `json:"totpEnabled,omitempty"`
+	TOTPSecret    string   `json:"totpSecret,omitempty"`
+	TOTPPending   string   `json:"totpPending,omitempty"`   // 已生成但尚未确认的密钥
+	TOTPLastStep  int64    `json:"totpLastStep,omitempty"`  // 最后一次使用的验证码时间窗口，防止重放
+	RecoveryCodes []string `json:"recoveryCodes,omitempty"` // 恢复码哈希
+
+	// SSH公钥，用于登录SFTP
+	SSHKeys []*SSHKey `json:"sshKeys,omitempty"`
+}
+
+// SSH公钥
+type SSHKey struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	PublicKey   string `json:"publicKey"`   // authorized_keys格式的公钥
+	Fingerprint string `json:"fingerprint"` // SHA256指纹
+	CreatedAt   string `json:"createdAt"`
+}
+
+// API密钥，用于脚本和CI访问管理API
+type APIKey struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	UserID      string   `json:"userId"`                // 密钥所属用户，权限不会超过该用户的角色
+	Prefix      string   `json:"prefix"`                // 密钥前几位，用于识别
+	KeyHash     string   `json:"keyHash,omitempty"`     // 密钥哈希，不对外返回
+	S3Secret    string   `json:"s3Secret,omitempty"`    // 加密保存的S3私有访问密钥，不对外返回
+	Scopes      []string `json:"scopes"`                // 权限范围：read、upload、manage、s3
+	DirectoryID string   `json:"directoryId,omitempty"` // 限制只能访问该目录及其子目录，为空表示不限制
+	ExpiresAt   string   `json:"expiresAt,omitempty"`   // 过期时间，为空表示永不过期
+	Revoked     bool     `json:"revoked"`
+	CreatedAt   string   `json:"createdAt"`
+	LastUsedAt  string   `json:"lastUsedAt,omitempty"`
+	LastUsedIP  string   `json:"lastUsedIp,omitempty"`
+}
+
+// 可续传的上传，内容写入存储目录中的临时文件，全部上传后成为文件记录
+type Upload struct {
+	ID          string `json:"id"`
+	UserID      string `json:"userId"` // 创建上传的用户，只有该用户可以继续上传
+	DirectoryID string `json:"directoryId"`
+	Name        string `json:"name"`
+	Size        int64  `json:"size"`   // 文件的总大小
+	Offset      int64  `json:"offset"` // 已上传的字节数，仅用于接口响应（以临时文件的大小为准）
+	CreatedAt   string `json:"createdAt"`
+	UpdatedAt   string `json:"updatedAt"`
+}
+
 // 全局变量
 var (
 	// 目录存储
@@ -30,4 +140,19 @@ var (
 
 	// 文件存储
 	Files []*File
+
+	// 用户存储
+	Users []*User
+
+	// 分享链接存储
+	ShareLinks []*ShareLink
+
+	// API密钥存储
+	APIKeys []*APIKey
+
+	// 未完成的可续传上传
+	Uploads []*Upload
+
+	// 文件下载统计，按文件ID索引
+	Stats map[string]*FileStats
 )
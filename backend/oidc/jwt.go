@@ -0,0 +1,223 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// JSON Web Key
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// JWT头部
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// 允许的时钟偏差
+const clockSkew = time.Minute
+
+// 解析并验证ID Token的签名，返回claims
+func verifyIDToken(token string, keys func(kid string) ([]jsonWebKey, error)) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed id_token")
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("invalid id_token header: %w", err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid id_token signature: %w", err)
+	}
+
+	candidates, err := keys(header.Kid)
+	if err != nil {
+		return nil, err
+	}
+
+	signed := []byte(parts[0] + "." + parts[1])
+	verified := false
+	for _, key := range candidates {
+		if header.Kid != "" && key.Kid != "" && key.Kid != header.Kid {
+			continue
+		}
+		if verifySignature(header.Alg, key, signed, signature) == nil {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, errors.New("id_token signature verification failed")
+	}
+
+	var claims map[string]interface{}
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("invalid id_token claims: %w", err)
+	}
+	return claims, nil
+}
+
+// 校验ID Token的标准claims
+func validateClaims(claims map[string]interface{}, issuer, clientID, nonce string) error {
+	if iss, _ := claims["iss"].(string); iss != issuer {
+		return fmt.Errorf("unexpected issuer %q", iss)
+	}
+
+	audienceOK := false
+	switch aud := claims["aud"].(type) {
+	case string:
+		audienceOK = aud == clientID
+	case []interface{}:
+		for _, item := range aud {
+			if s, _ := item.(string); s == clientID {
+				audienceOK = true
+			}
+		}
+	}
+	if !audienceOK {
+		return errors.New("id_token audience does not match client id")
+	}
+
+	now := time.Now()
+	exp, ok := claims["exp"].(float64)
+	if !ok || now.After(time.Unix(int64(exp), 0).Add(clockSkew)) {
+		return errors.New("id_token has expired")
+	}
+	if iat, ok := claims["iat"].(float64); ok && time.Unix(int64(iat), 0).After(now.Add(clockSkew)) {
+		return errors.New("id_token issued in the future")
+	}
+
+	if got, _ := claims["nonce"].(string); got != nonce {
+		return errors.New("id_token nonce mismatch")
+	}
+	return nil
+}
+
+// 使用JWK验证签名，支持RS256/RS384/RS512和ES256/ES384，公钥声明了算法时必须一致
+func verifySignature(alg string, key jsonWebKey, signed, signature []byte) error {
+	if key.Alg != "" && key.Alg != alg {
+		return fmt.Errorf("id_token algorithm %q does not match key algorithm %q", alg, key.Alg)
+	}
+
+	switch alg {
+	case "RS256", "RS384", "RS512":
+		pub, err := rsaPublicKey(key)
+		if err != nil {
+			return err
+		}
+		hash := hashFor(alg)
+		h := hash.New()
+		h.Write(signed)
+		return rsa.VerifyPKCS1v15(pub, hash, h.Sum(nil), signature)
+	case "ES256", "ES384":
+		pub, err := ecdsaPublicKey(key)
+		if err != nil {
+			return err
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errors.New("invalid ecdsa signature length")
+		}
+		hash := hashFor(alg)
+		h := hash.New()
+		h.Write(signed)
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(pub, h.Sum(nil), r, s) {
+			return errors.New("ecdsa verification failed")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported id_token algorithm %q", alg)
+	}
+}
+
+// 签名算法对应的哈希
+func hashFor(alg string) crypto.Hash {
+	switch alg[2:] {
+	case "384":
+		return crypto.SHA384
+	case "512":
+		return crypto.SHA512
+	default:
+		return crypto.SHA256
+	}
+}
+
+// 从JWK构造RSA公钥
+func rsaPublicKey(key jsonWebKey) (*rsa.PublicKey, error) {
+	if key.Kty != "RSA" {
+		return nil, errors.New("key type is not RSA")
+	}
+	n, err := base64.RawURLEncoding.DecodeString(key.N)
+	if err != nil {
+		return nil, err
+	}
+	e, err := base64.RawURLEncoding.DecodeString(key.E)
+	if err != nil {
+		return nil, err
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
+
+// 从JWK构造ECDSA公钥
+func ecdsaPublicKey(key jsonWebKey) (*ecdsa.PublicKey, error) {
+	if key.Kty != "EC" {
+		return nil, errors.New("key type is not EC")
+	}
+
+	var curve elliptic.Curve
+	switch key.Crv {
+	case "P-256":
+		curve = elliptic.P256()
+	case "P-384":
+		curve = elliptic.P384()
+	default:
+		return nil, fmt.Errorf("unsupported curve %q", key.Crv)
+	}
+
+	x, err := base64.RawURLEncoding.DecodeString(key.X)
+	if err != nil {
+		return nil, err
+	}
+	y, err := base64.RawURLEncoding.DecodeString(key.Y)
+	if err != nil {
+		return nil, err
+	}
+	return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+}
+
+// 解码JWT的Base64URL段
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
@@ -0,0 +1,199 @@
+package oidc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+// 测试用的签名密钥
+type testSigner struct {
+	rsa *rsa.PrivateKey
+	ec  *ecdsa.PrivateKey
+}
+
+func newTestSigner(t *testing.T) testSigner {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return testSigner{rsa: rsaKey, ec: ecKey}
+}
+
+// RSA公钥对应的JWK
+func rsaJWK(key *rsa.PrivateKey, kid, alg string) jsonWebKey {
+	return jsonWebKey{
+		Kty: "RSA",
+		Kid: kid,
+		Alg: alg,
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+// ECDSA公钥对应的JWK
+func ecJWK(key *ecdsa.PrivateKey, kid string) jsonWebKey {
+	return jsonWebKey{
+		Kty: "EC",
+		Kid: kid,
+		Crv: "P-256",
+		X:   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		Y:   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+	}
+}
+
+func encodeSegment(t *testing.T, v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// 按指定算法签发JWT，alg为none时不签名，HS256使用hmacKey签名
+func signToken(t *testing.T, s testSigner, alg, kid string, claims map[string]interface{}, hmacKey []byte) string {
+	signed := encodeSegment(t, jwtHeader{Alg: alg, Kid: kid}) + "." + encodeSegment(t, claims)
+
+	var signature []byte
+	switch alg {
+	case "none":
+	case "HS256":
+		mac := hmac.New(sha256.New, hmacKey)
+		mac.Write([]byte(signed))
+		signature = mac.Sum(nil)
+	case "RS256", "RS384":
+		hash := hashFor(alg)
+		h := hash.New()
+		h.Write([]byte(signed))
+		var err error
+		if signature, err = rsa.SignPKCS1v15(rand.Reader, s.rsa, hash, h.Sum(nil)); err != nil {
+			t.Fatal(err)
+		}
+	case "ES256":
+		digest := sha256.Sum256([]byte(signed))
+		r, sig, err := ecdsa.Sign(rand.Reader, s.ec, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature = append(r.FillBytes(make([]byte, 32)), sig.FillBytes(make([]byte, 32))...)
+	default:
+		t.Fatalf("unsupported test algorithm %s", alg)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// 修改JWT的claims但保留原签名
+func tamperClaims(t *testing.T, token string, claims map[string]interface{}) string {
+	parts := strings.Split(token, ".")
+	return parts[0] + "." + encodeSegment(t, claims) + "." + parts[2]
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	s := newTestSigner(t)
+	other := newTestSigner(t)
+	claims := map[string]interface{}{"sub": "alice", "nonce": "n"}
+	forged := map[string]interface{}{"sub": "admin", "nonce": "n"}
+
+	rsaKey := rsaJWK(s.rsa, "rsa", "RS256")
+	keySet := []jsonWebKey{rsaKey, ecJWK(s.ec, "ec")}
+
+	tests := []struct {
+		name  string
+		token string
+		keys  []jsonWebKey
+		valid bool
+	}{
+		{"RS256签名", signToken(t, s, "RS256", "rsa", claims, nil), keySet, true},
+		{"ES256签名", signToken(t, s, "ES256", "ec", claims, nil), keySet, true},
+		{"没有kid时尝试所有公钥", signToken(t, s, "ES256", "", claims, nil), keySet, true},
+		{"修改claims后签名无效", tamperClaims(t, signToken(t, s, "RS256", "rsa", claims, nil), forged), keySet, false},
+		{"其他密钥的签名", signToken(t, other, "RS256", "rsa", claims, nil), keySet, false},
+		{"kid不匹配", signToken(t, s, "RS256", "other", claims, nil), keySet, false},
+		{"不签名的none算法", signToken(t, s, "none", "rsa", claims, nil), keySet, false},
+		{"用公钥作为HMAC密钥", signToken(t, s, "HS256", "rsa", claims, s.rsa.N.Bytes()), keySet, false},
+		{"和公钥声明不一致的算法", signToken(t, s, "RS384", "rsa", claims, nil), keySet, false},
+		{"公钥没有声明算法时接受RS384", signToken(t, s, "RS384", "rsa", claims, nil), []jsonWebKey{rsaJWK(s.rsa, "rsa", "")}, true},
+		{"ES256算法使用RSA公钥", signToken(t, s, "ES256", "rsa", claims, nil), []jsonWebKey{{Kty: "RSA", Kid: "rsa", N: rsaKey.N, E: rsaKey.E}}, false},
+		{"没有公钥", signToken(t, s, "RS256", "rsa", claims, nil), nil, false},
+		{"格式错误", "header.claims", keySet, false},
+		{"签名不是Base64URL", signToken(t, s, "RS256", "rsa", claims, nil) + "!", keySet, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := func(string) ([]jsonWebKey, error) { return tt.keys, nil }
+			got, err := verifyIDToken(tt.token, keys)
+			if (err == nil) != tt.valid {
+				t.Fatalf("verifyIDToken() error = %v, want valid %v", err, tt.valid)
+			}
+			if tt.valid && got["sub"] != "alice" {
+				t.Errorf("claims = %v", got)
+			}
+		})
+	}
+}
+
+func TestValidateClaims(t *testing.T) {
+	const issuer, clientID, nonce = "https://idp.example.com", "fileshare", "nonce-1"
+	now := time.Now().Unix()
+
+	valid := func(changes map[string]interface{}) map[string]interface{} {
+		claims := map[string]interface{}{
+			"iss":   issuer,
+			"aud":   clientID,
+			"exp":   float64(now + 300),
+			"iat":   float64(now),
+			"nonce": nonce,
+		}
+		for key, value := range changes {
+			if value == nil {
+				delete(claims, key)
+			} else {
+				claims[key] = value
+			}
+		}
+		return claims
+	}
+
+	tests := []struct {
+		name   string
+		claims map[string]interface{}
+		valid  bool
+	}{
+		{"有效的claims", valid(nil), true},
+		{"aud为包含客户端ID的数组", valid(map[string]interface{}{"aud": []interface{}{"other", clientID}}), true},
+		{"时钟偏差内过期", valid(map[string]interface{}{"exp": float64(now - 30)}), true},
+		{"错误的iss", valid(map[string]interface{}{"iss": "https://evil.example.com"}), false},
+		{"缺少iss", valid(map[string]interface{}{"iss": nil}), false},
+		{"错误的aud", valid(map[string]interface{}{"aud": "other"}), false},
+		{"aud数组不包含客户端ID", valid(map[string]interface{}{"aud": []interface{}{"other"}}), false},
+		{"缺少aud", valid(map[string]interface{}{"aud": nil}), false},
+		{"已过期", valid(map[string]interface{}{"exp": float64(now - 600)}), false},
+		{"缺少exp", valid(map[string]interface{}{"exp": nil}), false},
+		{"签发时间在未来", valid(map[string]interface{}{"iat": float64(now + 600)}), false},
+		{"nonce不一致", valid(map[string]interface{}{"nonce": "nonce-2"}), false},
+		{"缺少nonce", valid(map[string]interface{}{"nonce": nil}), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateClaims(tt.claims, issuer, clientID, nonce)
+			if (err == nil) != tt.valid {
+				t.Errorf("validateClaims() error = %v, want valid %v", err, tt.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,431 @@
+// Package oidc 实现管理端的OpenID Connect授权码登录（带PKCE），
+// 登录成功后根据身份提供方返回的用户组映射角色，并签发普通的登录会话。
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/config"
+	"fileshare/middleware"
+	"fileshare/proxy"
+	"fileshare/user"
+	"fileshare/utils"
+)
+
+// 用户记录中的身份提供方名称
+const providerName = "oidc"
+
+// 登录请求的有效期
+const pendingTTL = 10 * time.Minute
+
+// 保存state的Cookie，回调时必须和state一致，确保回调来自发起登录的浏览器
+const stateCookieName = "oidc_state"
+
+// 请求身份提供方的超时时间
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// 身份提供方的端点信息
+type discovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserinfoEndpoint      string `json:"userinfo_endpoint"`
+	JwksURI               string `json:"jwks_uri"`
+}
+
+// 进行中的登录请求
+type pendingLogin struct {
+	nonce       string
+	verifier    string
+	redirectURL string
+	expiresAt   time.Time
+}
+
+var (
+	// 端点信息和签名公钥缓存
+	providerMu sync.Mutex
+	provider   *discovery
+	jwks       []jsonWebKey
+
+	// 以state为键保存进行中的登录请求
+	pending   = make(map[string]pendingLogin)
+	pendingMu sync.Mutex
+)
+
+// 获取OIDC配置信息，前端据此决定是否显示单点登录按钮
+func GetConfig(c *gin.Context) {
+	cfg := config.GetServerConfig().OIDC
+	c.JSON(http.StatusOK, gin.H{
+		"enabled": cfg.Enabled,
+		"name":    cfg.Name,
+	})
+}
+
+// 跳转到身份提供方登录
+func Login(c *gin.Context) {
+	cfg := config.GetServerConfig().OIDC
+	if !cfg.Enabled {
+		c.JSON(http.StatusNotFound, gin.H{"error": "OIDC login is not enabled"})
+		return
+	}
+
+	p, err := getProvider()
+	if err != nil {
+		log.Printf("OIDC discovery failed: %v", err)
+		c.JSON(http.StatusBadGateway, gin.H{"error": "Failed to contact identity provider"})
+		return
+	}
+
+	state, nonce, verifier := randomString(), randomString(), randomString()
+	redirectURL := callbackURL(c)
+
+	pendingMu.Lock()
+	now := time.Now()
+	for key, item := range pending {
+		if now.After(item.expiresAt) {
+			delete(pending, key)
+		}
+	}
+	pending[state] = pendingLogin{nonce: nonce, verifier: verifier, redirectURL: redirectURL, expiresAt: now.Add(pendingTTL)}
+	pendingMu.Unlock()
+
+	// 身份提供方跳转回来是顶层导航，SameSite=Lax的Cookie会被携带
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(stateCookieName, state, int(pendingTTL.Seconds()), "/", "", proxy.IsHTTPS(c), true)
+
+	challenge := sha256.Sum256([]byte(verifier))
+	params := url.Values{}
+	params.Set("response_type", "code")
+	params.Set("client_id", cfg.ClientID)
+	params.Set("redirect_uri", redirectURL)
+	params.Set("scope", strings.Join(cfg.Scopes, " "))
+	params.Set("state", state)
+	params.Set("nonce", nonce)
+	params.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	params.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(p.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	c.Redirect(http.StatusFound, p.AuthorizationEndpoint+separator+params.Encode())
+}
+
+// 身份提供方回调，换取ID Token后创建登录会话
+func Callback(c *gin.Context) {
+	cfg := config.GetServerConfig().OIDC
+	if !cfg.Enabled {
+		c.JSON(http.StatusNotFound, gin.H{"error": "OIDC login is not enabled"})
+		return
+	}
+
+	if errCode := c.Query("error"); errCode != "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Identity provider returned an error: " + errCode})
+		return
+	}
+
+	login, ok := takePending(c)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Login request expired or invalid, please try again"})
+		return
+	}
+
+	code := c.Query("code")
+	if code == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Missing authorization code"})
+		return
+	}
+
+	claims, err := exchangeCode(code, login)
+	if err != nil {
+		log.Printf("OIDC login failed: %v", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "OIDC login failed"})
+		return
+	}
+
+	subject, _ := claims["sub"].(string)
+	username, _ := claims[cfg.UsernameClaim].(string)
+	if username == "" {
+		username, _ = claims["email"].(string)
+	}
+	if subject == "" || username == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Identity provider did not return a subject or username"})
+		return
+	}
+
+	groups := claimGroups(claims[cfg.GroupsClaim])
+	role := MapRole(groups)
+	if role == "" {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Your account is not allowed to access the manage interface"})
+		return
+	}
+
+	loginUser, err := user.SyncExternalUser(providerName, subject, username, role, groups)
+	if err != nil {
+		switch {
+		case errors.Is(err, user.ErrUserDisabled):
+			c.JSON(http.StatusForbidden, gin.H{"error": "User is disabled"})
+		case errors.Is(err, user.ErrUsernameTaken):
+			c.JSON(http.StatusConflict, gin.H{"error": "Username is already used by a local account"})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save user"})
+		}
+		return
+	}
+
+	// 签发登录会话，保存在HttpOnly Cookie中，前端通过csrf_token Cookie发起修改请求
+	token := utils.GenerateToken(loginUser.ID, c.ClientIP(), c.Request.UserAgent())
+	middleware.SetSessionCookies(c, token)
+	proxy.Redirect(c, cfg.PostLoginRedirect)
+}
+
+// 取出回调对应的登录请求。state必须和发起登录时写入Cookie的一致，
+// 否则他人持有的回调地址可以让访问者登录到他人的账号（登录CSRF）
+func takePending(c *gin.Context) (pendingLogin, bool) {
+	state := c.Query("state")
+	cookie, err := c.Cookie(stateCookieName)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(stateCookieName, "", -1, "/", "", proxy.IsHTTPS(c), true)
+	if err != nil || state == "" || subtle.ConstantTimeCompare([]byte(cookie), []byte(state)) != 1 {
+		return pendingLogin{}, false
+	}
+
+	pendingMu.Lock()
+	login, exists := pending[state]
+	delete(pending, state)
+	pendingMu.Unlock()
+
+	if !exists || time.Now().After(login.expiresAt) {
+		return pendingLogin{}, false
+	}
+	return login, true
+}
+
+// MapRole 根据用户组映射角色，匹配多个时取权限最高的角色，没有匹配时使用默认角色
+func MapRole(groups []string) string {
+	cfg := config.GetServerConfig().OIDC
+
+	role := ""
+	for _, group := range groups {
+		if mapped, exists := cfg.RoleMapping[group]; exists && user.IsValidRole(mapped) {
+			role = user.HigherRole(role, mapped)
+		}
+	}
+	if role == "" && user.IsValidRole(cfg.DefaultRole) {
+		role = cfg.DefaultRole
+	}
+	return role
+}
+
+// 将用户组claim统一为字符串数组，支持数组和以空格或逗号分隔的字符串
+func claimGroups(value interface{}) []string {
+	groups := []string{}
+	switch v := value.(type) {
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				groups = append(groups, s)
+			}
+		}
+	case string:
+		groups = strings.FieldsFunc(v, func(r rune) bool { return r == ' ' || r == ',' })
+	}
+	return groups
+}
+
+// 用授权码换取ID Token并验证，返回合并了userinfo的claims
+func exchangeCode(code string, login pendingLogin) (map[string]interface{}, error) {
+	cfg := config.GetServerConfig().OIDC
+
+	p, err := getProvider()
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", login.redirectURL)
+	form.Set("client_id", cfg.ClientID)
+	form.Set("code_verifier", login.verifier)
+
+	req, err := http.NewRequest(http.MethodPost, p.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(cfg.ClientID), url.QueryEscape(cfg.ClientSecret))
+	}
+
+	var tokens struct {
+		IDToken     string `json:"id_token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := doJSON(req, &tokens); err != nil {
+		return nil, fmt.Errorf("token exchange: %w", err)
+	}
+	if tokens.IDToken == "" {
+		return nil, errors.New("token response has no id_token")
+	}
+
+	claims, err := verifyIDToken(tokens.IDToken, signingKeys)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateClaims(claims, p.Issuer, cfg.ClientID, login.nonce); err != nil {
+		return nil, err
+	}
+
+	// ID Token中没有用户组时从userinfo端点获取
+	if _, exists := claims[cfg.GroupsClaim]; !exists && p.UserinfoEndpoint != "" && tokens.AccessToken != "" {
+		if info, err := fetchUserinfo(p.UserinfoEndpoint, tokens.AccessToken); err != nil {
+			log.Printf("OIDC userinfo request failed: %v", err)
+		} else if info["sub"] == claims["sub"] {
+			for key, value := range info {
+				if _, exists := claims[key]; !exists {
+					claims[key] = value
+				}
+			}
+		}
+	}
+
+	return claims, nil
+}
+
+// 获取userinfo
+func fetchUserinfo(endpoint, accessToken string) (map[string]interface{}, error) {
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	var info map[string]interface{}
+	if err := doJSON(req, &info); err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// 获取身份提供方的端点信息，首次使用时通过发现文档加载
+func getProvider() (*discovery, error) {
+	providerMu.Lock()
+	defer providerMu.Unlock()
+
+	if provider != nil {
+		return provider, nil
+	}
+
+	issuer := strings.TrimSuffix(config.GetServerConfig().OIDC.Issuer, "/")
+	req, err := http.NewRequest(http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var d discovery
+	if err := doJSON(req, &d); err != nil {
+		return nil, err
+	}
+	if strings.TrimSuffix(d.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("discovery issuer %q does not match configured issuer", d.Issuer)
+	}
+	if d.AuthorizationEndpoint == "" || d.TokenEndpoint == "" || d.JwksURI == "" {
+		return nil, errors.New("discovery document is missing required endpoints")
+	}
+
+	provider = &d
+	return provider, nil
+}
+
+// 获取签名公钥，找不到指定kid时重新加载（身份提供方可能轮换了密钥）
+func signingKeys(kid string) ([]jsonWebKey, error) {
+	providerMu.Lock()
+	defer providerMu.Unlock()
+
+	if jwks != nil && hasKey(jwks, kid) {
+		return jwks, nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, provider.JwksURI, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := doJSON(req, &set); err != nil {
+		return nil, fmt.Errorf("fetch jwks: %w", err)
+	}
+
+	jwks = set.Keys
+	return jwks, nil
+}
+
+// 判断公钥集合中是否有指定kid
+func hasKey(keys []jsonWebKey, kid string) bool {
+	if kid == "" {
+		return len(keys) > 0
+	}
+	for _, key := range keys {
+		if key.Kid == kid {
+			return true
+		}
+	}
+	return false
+}
+
+// 发送请求并解析JSON响应
+func doJSON(req *http.Request, v interface{}) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %d: %s", req.URL, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, v)
+}
+
+// 获取回调地址
+func callbackURL(c *gin.Context) string {
+	serverConfig := config.GetServerConfig()
+	if serverConfig.OIDC.RedirectURL != "" {
+		return serverConfig.OIDC.RedirectURL
+	}
+
+	return proxy.URL(c, serverConfig.Server.ContextManagePath+"/api/admin/oidc/callback")
+}
+
+// 生成随机字符串，用于state、nonce和PKCE
+func randomString() string {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		log.Fatalf("Failed to generate random value: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
@@ -0,0 +1,65 @@
+package oidc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestTakePending(t *testing.T) {
+	tests := []struct {
+		name    string
+		state   string
+		cookie  string
+		expires time.Duration
+		valid   bool
+	}{
+		{"state和Cookie一致", "state-1", "state-1", pendingTTL, true},
+		{"没有state Cookie（其他浏览器发起的登录）", "state-2", "", pendingTTL, false},
+		{"state Cookie不一致", "state-3", "state-other", pendingTTL, false},
+		{"state和Cookie都为空", "", "", pendingTTL, false},
+		{"登录请求已过期", "state-4", "state-4", -time.Second, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pendingMu.Lock()
+			pending[tt.state] = pendingLogin{nonce: "nonce", expiresAt: time.Now().Add(tt.expires)}
+			pendingMu.Unlock()
+			defer func() {
+				pendingMu.Lock()
+				delete(pending, tt.state)
+				pendingMu.Unlock()
+			}()
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/callback?code=code&state="+tt.state, nil)
+			if tt.cookie != "" {
+				c.Request.AddCookie(&http.Cookie{Name: stateCookieName, Value: tt.cookie})
+			}
+
+			login, ok := takePending(c)
+			if ok != tt.valid || (ok && login.nonce != "nonce") {
+				t.Fatalf("takePending() = %+v, %v, want valid %v", login, ok, tt.valid)
+			}
+
+			// 回调后删除state Cookie，成功时登录请求只能使用一次
+			cleared := false
+			for _, cookie := range w.Result().Cookies() {
+				cleared = cleared || (cookie.Name == stateCookieName && cookie.MaxAge < 0)
+			}
+			if !cleared {
+				t.Error("state cookie not cleared")
+			}
+			if ok {
+				if _, again := takePending(c); again {
+					t.Error("login request used twice")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package preview
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/simplifiedchinese"
+	"golang.org/x/text/encoding/unicode"
+
+	"fileshare/models"
+)
+
+// 预览长度限制（KB）
+const (
+	DefaultLimitKB = 64
+	MaxLimitKB     = 1024
+)
+
+// 支持文本预览的文件类型
+var textTypes = map[string]bool{
+	"txt": true, "log": true, "md": true, "markdown": true,
+	"json": true, "xml": true, "yaml": true, "yml": true, "toml": true,
+	"ini": true, "conf": true, "cfg": true, "csv": true, "sql": true,
+	"go": true, "py": true, "js": true, "ts": true, "vue": true,
+	"java": true, "c": true, "h": true, "cpp": true, "cs": true,
+	"html": true, "css": true, "sh": true, "bat": true, "ps1": true,
+}
+
+// Markdown渲染器，默认不输出原始HTML并过滤危险链接
+var markdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+// ErrUnsupported 文件类型不支持文本预览
+var ErrUnsupported = errors.New("unsupported text type")
+
+// TextPreview 文本预览结果
+type TextPreview struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Size      int64  `json:"size"`
+	Encoding  string `json:"encoding"`
+	Truncated bool   `json:"truncated"`
+	Content   string `json:"content"`
+	HTML      string `json:"html,omitempty"` // Markdown渲染后的HTML
+}
+
+// IsText 判断文件是否支持文本预览
+func IsText(file *models.File) bool {
+	return textTypes[strings.ToLower(file.Type)]
+}
+
+// ReadText 读取文件开头的内容并转换为UTF-8
+func ReadText(file *models.File, limitKB int) (*TextPreview, error) {
+	if !IsText(file) {
+		return nil, ErrUnsupported
+	}
+
+	if limitKB <= 0 {
+		limitKB = DefaultLimitKB
+	}
+	if limitKB > MaxLimitKB {
+		limitKB = MaxLimitKB
+	}
+	limit := int64(limitKB) * 1024
+
+	src, err := os.Open(file.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	info, err := src.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(io.LimitReader(src, limit))
+	if err != nil {
+		return nil, err
+	}
+	truncated := info.Size() > int64(len(data))
+
+	content, encodingName, err := decodeText(data, truncated)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TextPreview{
+		ID:        file.ID,
+		Name:      file.Name,
+		Type:      file.Type,
+		Size:      info.Size(),
+		Encoding:  encodingName,
+		Truncated: truncated,
+		Content:   content,
+	}
+
+	// 渲染Markdown
+	fileType := strings.ToLower(file.Type)
+	if fileType == "md" || fileType == "markdown" {
+		var buf bytes.Buffer
+		if err := markdown.Convert([]byte(content), &buf); err != nil {
+			return nil, err
+		}
+		result.HTML = buf.String()
+	}
+
+	return result, nil
+}
+
+// 检测编码并转换为UTF-8，返回内容和编码名称
+func decodeText(data []byte, truncated bool) (string, string, error) {
+	// 根据BOM判断编码
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		data = data[3:]
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		return decodeWith(unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM), data, truncated, "utf-16le")
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		return decodeWith(unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM), data, truncated, "utf-16be")
+	}
+
+	// 截断可能切断最后一个多字节字符
+	text := data
+	if truncated {
+		text = trimIncompleteUTF8(data)
+	}
+	if utf8.Valid(text) {
+		return string(text), "utf-8", nil
+	}
+
+	// Windows下常见的GBK编码，使用兼容GBK的GB18030解码
+	return decodeWith(simplifiedchinese.GB18030, data, truncated, "gb18030")
+}
+
+// 使用指定编码解码
+func decodeWith(enc encoding.Encoding, data []byte, truncated bool, name string) (string, string, error) {
+	decoded, err := enc.NewDecoder().Bytes(data)
+	if err != nil {
+		return "", "", err
+	}
+
+	content := string(decoded)
+	if truncated {
+		// 去掉被截断的最后一个字符
+		content = strings.TrimSuffix(content, string(utf8.RuneError))
+	}
+	return content, name, nil
+}
+
+// 去掉末尾不完整的UTF-8字符
+func trimIncompleteUTF8(data []byte) []byte {
+	start := len(data) - 1
+	for start > 0 && len(data)-start < utf8.UTFMax && !utf8.RuneStart(data[start]) {
+		start--
+	}
+	if start >= 0 && !utf8.FullRune(data[start:]) {
+		return data[:start]
+	}
+	return data
+}
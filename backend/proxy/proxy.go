@@ -0,0 +1,112 @@
+// Package proxy 负责反向代理部署时的请求信息：协议、外部访问路径前缀和生成对外的URL。
+//
+// 只有来自server.trustedProxies中地址的请求才会使用X-Forwarded-Proto和X-Forwarded-Prefix，
+// 其他请求中的这些请求头会被忽略。
+//
+// 对外的路径前缀由两部分组成：代理通过X-Forwarded-Prefix告知的前缀（代理去掉前缀后转发时使用），
+// 加上server.basePath（代理不去掉前缀、服务直接挂载在子路径下时使用）。
+package proxy
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/config"
+	"fileshare/ipfilter"
+	"fileshare/models"
+)
+
+// 反向代理请求头
+const (
+	HeaderForwardedProto  = "X-Forwarded-Proto"
+	HeaderForwardedPrefix = "X-Forwarded-Prefix"
+)
+
+// BasePath 获取服务挂载的子路径（以/开头，不以/结尾，未配置时为空）
+func BasePath() string {
+	return CleanPath(config.GetServerConfig().Server.BasePath)
+}
+
+// CleanPath 整理路径前缀：以/开头，不以/结尾，根路径返回空字符串。
+// 前缀会写入页面和跳转地址，每一段只能包含字母、数字和-._~（不能是.或..），否则视为没有前缀
+func CleanPath(path string) string {
+	path = strings.Trim(strings.TrimSpace(path), "/")
+	if path == "" {
+		return ""
+	}
+	for _, segment := range strings.Split(path, "/") {
+		if !isSafeSegment(segment) {
+			return ""
+		}
+	}
+	return "/" + path
+}
+
+// 判断路径中的一段是否只包含安全的字符
+func isSafeSegment(segment string) bool {
+	if segment == "" || segment == "." || segment == ".." {
+		return false
+	}
+	for i := 0; i < len(segment); i++ {
+		ch := segment[i]
+		if !('a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || '0' <= ch && ch <= '9' ||
+			ch == '-' || ch == '.' || ch == '_' || ch == '~') {
+			return false
+		}
+	}
+	return true
+}
+
+// IsTrusted 判断请求是否来自可信的反向代理
+func IsTrusted(c *gin.Context) bool {
+	trusted := config.GetServerConfig().Server.TrustedProxies
+	return len(trusted) > 0 && ipfilter.Allows(&models.IPFilter{Allow: trusted}, c.RemoteIP())
+}
+
+// Scheme 获取客户端访问使用的协议（http或https）
+func Scheme(c *gin.Context) string {
+	if c.Request.TLS != nil {
+		return "https"
+	}
+	if IsTrusted(c) {
+		proto := strings.ToLower(strings.TrimSpace(strings.Split(c.GetHeader(HeaderForwardedProto), ",")[0]))
+		if proto == "https" || proto == "http" {
+			return proto
+		}
+	}
+	return "http"
+}
+
+// IsHTTPS 判断客户端是否通过HTTPS访问
+func IsHTTPS(c *gin.Context) bool {
+	return Scheme(c) == "https"
+}
+
+// Prefix 获取客户端看到的路径前缀（代理前缀加上basePath）
+func Prefix(c *gin.Context) string {
+	prefix := ""
+	if IsTrusted(c) {
+		prefix = CleanPath(c.GetHeader(HeaderForwardedPrefix))
+	}
+	return prefix + BasePath()
+}
+
+// Path 把服务内的路径转换为客户端访问的路径
+func Path(c *gin.Context, path string) string {
+	return Prefix(c) + path
+}
+
+// URL 把服务内的路径转换为客户端访问的完整URL
+func URL(c *gin.Context, path string) string {
+	return Scheme(c) + "://" + c.Request.Host + Path(c, path)
+}
+
+// Redirect 跳转到服务内的路径，已经是完整URL时直接跳转
+func Redirect(c *gin.Context, path string) {
+	if strings.HasPrefix(path, "/") && !strings.HasPrefix(path, "//") {
+		path = Path(c, path)
+	}
+	c.Redirect(http.StatusFound, path)
+}
@@ -0,0 +1,69 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/config"
+)
+
+func TestCleanPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"", ""},
+		{"/", ""},
+		{" /tools/fileshare/ ", "/tools/fileshare"},
+		{"tools", "/tools"},
+		{"/a-b_c.d~e/", "/a-b_c.d~e"},
+		{"/a//b", ""},
+		{"/a/../b", ""},
+		{"/./a", ""},
+		{`/"><script>alert(1)</script>`, ""},
+		{"/a b", ""},
+		{"/a?b", ""},
+		{"/a#b", ""},
+		{"/a%2Fb", ""},
+		{"/a\\b", ""},
+		{"/中文", ""},
+	}
+
+	for _, tt := range tests {
+		if got := CleanPath(tt.path); got != tt.want {
+			t.Errorf("CleanPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestPrefix(t *testing.T) {
+	server := &config.GetServerConfig().Server
+	server.TrustedProxies = []string{"192.0.2.1"}
+	defer func() { server.TrustedProxies = nil }()
+
+	tests := []struct {
+		name   string
+		remote string
+		header string
+		want   string
+	}{
+		{"可信代理", "192.0.2.1:1234", "/proxy", "/proxy"},
+		{"不可信的地址", "198.51.100.1:1234", "/proxy", ""},
+		{"不安全的前缀", "192.0.2.1:1234", `/x"onload="alert(1)`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			c.Request.RemoteAddr = tt.remote
+			c.Request.Header.Set(HeaderForwardedPrefix, tt.header)
+			if got := Prefix(c); got != tt.want {
+				t.Errorf("Prefix() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
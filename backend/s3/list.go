@@ -0,0 +1,199 @@
+package s3
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/models"
+	"fileshare/vfs"
+)
+
+// 每次最多返回的对象和公共前缀数量
+const maxListKeys = 1000
+
+// ListObjects（V1和V2）的响应
+type listBucketResult struct {
+	XMLName               xml.Name       `xml:"ListBucketResult"`
+	Xmlns                 string         `xml:"xmlns,attr"`
+	Name                  string         `xml:"Name"`
+	Prefix                string         `xml:"Prefix"`
+	Delimiter             string         `xml:"Delimiter,omitempty"`
+	MaxKeys               int            `xml:"MaxKeys"`
+	EncodingType          string         `xml:"EncodingType,omitempty"`
+	IsTruncated           bool           `xml:"IsTruncated"`
+	Marker                *string        `xml:"Marker"`
+	NextMarker            string         `xml:"NextMarker,omitempty"`
+	KeyCount              *int           `xml:"KeyCount"`
+	ContinuationToken     string         `xml:"ContinuationToken,omitempty"`
+	NextContinuationToken string         `xml:"NextContinuationToken,omitempty"`
+	StartAfter            string         `xml:"StartAfter,omitempty"`
+	Contents              []object       `xml:"Contents"`
+	CommonPrefixes        []commonPrefix `xml:"CommonPrefixes"`
+}
+
+type object struct {
+	Key          string
+	LastModified string
+	ETag         string
+	Size         int64
+	StorageClass string
+}
+
+type commonPrefix struct {
+	Prefix string
+}
+
+// 目录树中的一项，目录的键以/结尾，只用于生成公共前缀
+type listEntry struct {
+	key  string
+	node *vfs.Node
+}
+
+// 列出桶中的对象，v2为true时使用ListObjectsV2的参数和响应格式
+func listObjects(c *gin.Context, fs *vfs.FS, v2 bool) {
+	query := c.Request.URL.Query()
+	prefix := query.Get("prefix")
+	delimiter := query.Get("delimiter")
+
+	encode := func(s string) string { return s }
+	encodingType := query.Get("encoding-type")
+	switch encodingType {
+	case "":
+	case "url":
+		encode = func(s string) string { return uriEncode(s, false) }
+	default:
+		writeError(c, invalidArgument("Invalid Encoding Method specified in Request"))
+		return
+	}
+
+	maxKeys := maxListKeys
+	if value := query.Get("max-keys"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			writeError(c, invalidArgument("max-keys must be a non-negative integer"))
+			return
+		}
+		maxKeys = min(n, maxListKeys)
+	}
+
+	result := listBucketResult{
+		Xmlns:        xmlNamespace,
+		Name:         bucketName(),
+		Prefix:       encode(prefix),
+		Delimiter:    encode(delimiter),
+		MaxKeys:      maxKeys,
+		EncodingType: encodingType,
+	}
+
+	// 从标记之后开始列出：V2使用continuation-token或start-after，V1使用marker
+	marker := ""
+	if v2 {
+		result.StartAfter = encode(query.Get("start-after"))
+		marker = query.Get("start-after")
+		if token := query.Get("continuation-token"); token != "" {
+			decoded, err := base64.RawURLEncoding.DecodeString(token)
+			if err != nil {
+				writeError(c, invalidArgument("The continuation token provided is incorrect"))
+				return
+			}
+			result.ContinuationToken = token
+			marker = string(decoded)
+		}
+	} else {
+		marker = query.Get("marker")
+		encodedMarker := encode(marker)
+		result.Marker = &encodedMarker
+	}
+
+	entries := []listEntry{}
+	collect(fs, nil, "", prefix, delimiter, &entries)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+
+	count, last, lastPrefix := 0, "", ""
+	for _, entry := range entries {
+		if maxKeys == 0 {
+			break
+		}
+		if entry.key <= marker {
+			continue
+		}
+
+		// 前缀之后包含分隔符的键合并为公共前缀
+		if delimiter != "" {
+			if i := strings.Index(entry.key[len(prefix):], delimiter); i >= 0 {
+				cp := entry.key[:len(prefix)+i+len(delimiter)]
+				if cp <= marker || cp == lastPrefix {
+					continue
+				}
+				if count == maxKeys {
+					result.IsTruncated = true
+					break
+				}
+				result.CommonPrefixes = append(result.CommonPrefixes, commonPrefix{Prefix: encode(cp)})
+				count, last, lastPrefix = count+1, cp, cp
+				continue
+			}
+		}
+		if entry.node.IsDir() {
+			continue
+		}
+
+		if count == maxKeys {
+			result.IsTruncated = true
+			break
+		}
+		info := vfs.NewFileInfo(entry.node)
+		result.Contents = append(result.Contents, object{
+			Key:          encode(entry.key),
+			LastModified: info.ModTime().UTC().Format(timeLayout),
+			ETag:         etag(entry.node.File, info.Size(), info.ModTime()),
+			Size:         info.Size(),
+			StorageClass: "STANDARD",
+		})
+		count, last = count+1, entry.key
+	}
+
+	if v2 {
+		result.KeyCount = &count
+		if result.IsTruncated {
+			result.NextContinuationToken = base64.RawURLEncoding.EncodeToString([]byte(last))
+		}
+	} else if result.IsTruncated {
+		result.NextMarker = encode(last)
+	}
+	writeXML(c, http.StatusOK, result)
+}
+
+// 收集目录树中键以prefix开头的文件和目录，不需要的子树不会展开
+func collect(fs *vfs.FS, dir *models.Directory, base, prefix, delimiter string, entries *[]listEntry) {
+	for _, child := range fs.Children(dir) {
+		key := base + child.Name
+		if !child.IsDir() {
+			if strings.HasPrefix(key, prefix) {
+				*entries = append(*entries, listEntry{key: key, node: child})
+			}
+			continue
+		}
+
+		key += "/"
+		switch {
+		case strings.HasPrefix(prefix, key):
+			// 前缀位于该目录之下
+			collect(fs, child.Dir, key, prefix, delimiter, entries)
+		case strings.HasPrefix(key, prefix):
+			// 目录本身可以作为公共前缀（包括空目录）；分隔符为/时其下的内容都会合并到该前缀中，不需要展开
+			if delimiter != "" {
+				*entries = append(*entries, listEntry{key: key, node: child})
+			}
+			if delimiter != "/" {
+				collect(fs, child.Dir, key, prefix, delimiter, entries)
+			}
+		}
+	}
+}
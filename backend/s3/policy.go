@@ -0,0 +1,36 @@
+package s3
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"fileshare/access"
+	"fileshare/common"
+	"fileshare/models"
+	"fileshare/vfs"
+)
+
+// S3接口只读（修改请求在入口已经返回NotImplemented）
+const errReadOnly = vfs.ForbiddenError("S3接口为只读")
+
+// 只包含访客可见的目录和文件，和共享端相同。S3客户端无法提供目录密码，
+// 有密码的目录只显示目录本身，不包含其中的内容
+type sharePolicy struct {
+	c *gin.Context
+}
+
+func (p *sharePolicy) ShowDir(dir *models.Directory) common.DirMode {
+	return access.ListMode(p.c, dir)
+}
+
+func (p *sharePolicy) OpenDir(dir *models.Directory) bool {
+	return access.HasDirectoryAccess(p.c, dir)
+}
+
+func (p *sharePolicy) ShowFile(f *models.File) bool {
+	return f.IsShared
+}
+
+func (p *sharePolicy) CanMkdir(*models.Directory) error             { return errReadOnly }
+func (p *sharePolicy) CanPut(*models.Directory, *models.File) error { return errReadOnly }
+func (p *sharePolicy) CanRemove(*vfs.Node) error                    { return errReadOnly }
+func (p *sharePolicy) CanMove(*vfs.Node, *models.Directory) error   { return errReadOnly }
@@ -0,0 +1,93 @@
+package s3
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/models"
+	"fileshare/vfs"
+)
+
+// 列出目录树中所有可见的路径
+func walk(fs *vfs.FS, dir *models.Directory, prefix string) []string {
+	paths := []string{}
+	for _, n := range fs.Children(dir) {
+		name := path.Join(prefix, n.Name)
+		if n.IsDir() {
+			paths = append(paths, name+"/")
+			paths = append(paths, walk(fs, n.Dir, name)...)
+		} else {
+			paths = append(paths, name)
+		}
+	}
+	return paths
+}
+
+func TestSharePolicy(t *testing.T) {
+	savedDirs, savedFiles := models.Directories, models.Files
+	defer func() { models.Directories, models.Files = savedDirs, savedFiles }()
+
+	// private（未共享）
+	//   ├─ docs（共享）
+	//   │   └─ hidden（取消共享）
+	//   └─ other（沿用，即未共享）
+	// public（共享）
+	//   └─ locked（有密码）
+	// office（共享，只允许10.1.0.0/16访问）
+	hidden := &models.Directory{ID: "hidden", Name: "hidden", ParentID: "docs"}
+	docs := &models.Directory{ID: "docs", Name: "docs", ParentID: "private", IsShared: true, Children: []*models.Directory{hidden}}
+	other := &models.Directory{ID: "other", Name: "other", ParentID: "private", Inherit: true}
+	locked := &models.Directory{ID: "locked", Name: "locked", ParentID: "public", Inherit: true, Password: "hash"}
+	models.Directories = []*models.Directory{
+		{ID: "private", Name: "private", Children: []*models.Directory{docs, other}},
+		{ID: "public", Name: "public", IsShared: true, Children: []*models.Directory{locked}},
+		{ID: "office", Name: "office", IsShared: true, IPFilter: &models.IPFilter{Allow: []string{"10.1.0.0/16"}}},
+	}
+	models.Files = []*models.File{
+		{ID: "1", Name: "private.txt", DirectoryID: "private", IsShared: true},
+		{ID: "2", Name: "report.pdf", DirectoryID: "docs", IsShared: true},
+		{ID: "3", Name: "draft.pdf", DirectoryID: "docs"},
+		{ID: "4", Name: "secret.txt", DirectoryID: "hidden", IsShared: true},
+		{ID: "5", Name: "other.txt", DirectoryID: "other", IsShared: true},
+		{ID: "6", Name: "readme.txt", DirectoryID: "public", IsShared: true},
+		{ID: "7", Name: "locked.txt", DirectoryID: "locked", IsShared: true},
+		{ID: "8", Name: "office.txt", DirectoryID: "office", IsShared: true},
+	}
+
+	tests := []struct {
+		name     string
+		clientIP string
+		want     []string
+	}{
+		{"外部网络", "192.0.2.1", []string{"docs/", "docs/report.pdf", "public/", "public/locked/", "public/readme.txt"}},
+		{"办公网络", "10.1.2.3", []string{"docs/", "docs/report.pdf", "office/", "office/office.txt", "public/", "public/locked/", "public/readme.txt"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			c.Request.RemoteAddr = tt.clientIP + ":12345"
+			fs := &vfs.FS{Policy: &sharePolicy{c: c}}
+
+			got := walk(fs, nil, "")
+			sort.Strings(got)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("paths = %v, want %v", got, tt.want)
+			}
+
+			// 不可见的文件不能直接按路径读取
+			for _, name := range []string{"private/private.txt", "docs/draft.pdf", "docs/hidden/secret.txt", "public/locked/locked.txt"} {
+				if _, _, err := fs.Lookup(name); err == nil {
+					t.Errorf("Lookup(%s) succeeded", name)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+// Package s3 提供S3兼容的只读接口，供aws-cli和各类SDK下载文件。
+//
+// 整个目录树作为一个桶（名称见s3.bucket配置），目录作为前缀，文件的键由目录名和文件名组成（见vfs包）。
+// 支持ListBuckets、HeadBucket、GetBucketLocation、ListObjects（V1和V2）、GetObject和HeadObject，
+// 请求需要使用AWS签名版本4签名（Authorization请求头或预签名URL）。
+// 访问密钥属于包含s3权限范围的API密钥（见apikey包），只有管理员可以授予该权限范围，
+// 密钥所属的账号不再是管理员时访问密钥同时失效。只提供共享的内容：可见的目录和文件和共享端相同，
+// 未共享的文件、访客不可见的目录和有密码的目录中的内容都不会出现。
+package s3
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/apikey"
+	"fileshare/config"
+	"fileshare/lockout"
+	"fileshare/models"
+	"fileshare/proxy"
+	"fileshare/stats"
+	"fileshare/user"
+	"fileshare/vfs"
+)
+
+// RoutePath 入口路径（相对于管理API的上下文路径）
+const RoutePath = "/s3"
+
+// Methods 需要为入口注册的请求方法，修改操作统一返回NotImplemented
+var Methods = []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodPost, http.MethodDelete}
+
+// 响应中的命名空间和时间格式
+const (
+	xmlNamespace = "http://s3.amazonaws.com/doc/2006-03-01/"
+	timeLayout   = "2006-01-02T15:04:05.000Z"
+)
+
+// 错误响应
+type apiError struct {
+	status  int
+	code    string
+	message string
+}
+
+var (
+	errAccessDenied     = &apiError{http.StatusForbidden, "AccessDenied", "Access Denied"}
+	errMalformedAuth    = &apiError{http.StatusBadRequest, "AuthorizationHeaderMalformed", "The authorization header or query parameters are malformed"}
+	errTimeSkewed       = &apiError{http.StatusForbidden, "RequestTimeTooSkewed", "The difference between the request time and the server's time is too large"}
+	errExpired          = &apiError{http.StatusForbidden, "AccessDenied", "Request has expired"}
+	errInvalidAccessKey = &apiError{http.StatusForbidden, "InvalidAccessKeyId", "The access key ID you provided does not exist in our records"}
+	errSignature        = &apiError{http.StatusForbidden, "SignatureDoesNotMatch", "The request signature we calculated does not match the signature you provided"}
+	errSlowDown         = &apiError{http.StatusServiceUnavailable, "SlowDown", "Too many failed attempts, please try again later"}
+	errNoSuchBucket     = &apiError{http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist"}
+	errNoSuchKey        = &apiError{http.StatusNotFound, "NoSuchKey", "The specified key does not exist"}
+	errNotImplemented   = &apiError{http.StatusNotImplemented, "NotImplemented", "Only read operations are supported"}
+)
+
+// 参数无效
+func invalidArgument(message string) *apiError {
+	return &apiError{http.StatusBadRequest, "InvalidArgument", message}
+}
+
+// 错误响应体
+type errorResponse struct {
+	XMLName  xml.Name `xml:"Error"`
+	Code     string
+	Message  string
+	Resource string
+}
+
+// 输出XML响应
+func writeXML(c *gin.Context, status int, v interface{}) {
+	c.Header("Content-Type", "application/xml")
+	c.Status(status)
+	if c.Request.Method == http.MethodHead {
+		return
+	}
+	c.Writer.WriteString(xml.Header)
+	xml.NewEncoder(c.Writer).Encode(v)
+}
+
+// 输出错误响应
+func writeError(c *gin.Context, err *apiError) {
+	writeXML(c, err.status, errorResponse{Code: err.code, Message: err.message, Resource: c.Request.URL.Path})
+}
+
+// 桶名称
+func bucketName() string {
+	return config.GetServerConfig().S3.Bucket
+}
+
+// Handler S3入口，routePath为入口相对于basePath的路径
+func Handler(routePath string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			writeError(c, errNotImplemented)
+			return
+		}
+
+		// 签名使用客户端看到的路径
+		u, apiErr := authenticate(c, proxy.Prefix(c)+routePath+c.Param("path"))
+		if apiErr != nil {
+			writeError(c, apiErr)
+			return
+		}
+
+		fs := &vfs.FS{Policy: &sharePolicy{c: c}}
+		bucket, key, _ := strings.Cut(strings.TrimPrefix(c.Param("path"), "/"), "/")
+		query := c.Request.URL.Query()
+
+		switch {
+		case bucket == "":
+			listBuckets(c, u)
+		case bucket != bucketName():
+			writeError(c, errNoSuchBucket)
+		case key != "":
+			getObject(c, fs, key)
+		case c.Request.Method == http.MethodHead:
+			c.Status(http.StatusOK)
+		case query.Has("location"):
+			writeXML(c, http.StatusOK, locationResponse{Xmlns: xmlNamespace})
+		case query.Get("list-type") == "2":
+			listObjects(c, fs, true)
+		case len(query) == 0 || query.Has("prefix") || query.Has("delimiter") || query.Has("marker") ||
+			query.Has("max-keys") || query.Has("encoding-type"):
+			listObjects(c, fs, false)
+		default:
+			writeError(c, errNotImplemented)
+		}
+	}
+}
+
+// 验证签名，返回访问密钥所属的用户。失败次数过多时按IP锁定
+func authenticate(c *gin.Context, signedPath string) (*models.User, *apiError) {
+	sig, apiErr := parseSignature(c)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	if sig == nil {
+		return nil, errAccessDenied
+	}
+
+	lockoutKey := lockout.IPKey(c)
+	if retryAfter := lockout.RetryAfter(lockoutKey); retryAfter > 0 {
+		c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+		return nil, errSlowDown
+	}
+
+	k, secret, valid := apikey.FindByAccessKey(sig.accessKeyID)
+	if !valid {
+		lockout.Fail(lockoutKey)
+		return nil, errInvalidAccessKey
+	}
+	if !sig.verify(c.Request, signedPath, secret) {
+		lockout.Fail(lockoutKey)
+		return nil, errSignature
+	}
+
+	owner := user.FindByID(k.UserID)
+	if owner == nil || owner.Disabled || !user.HasPermission(owner, user.PermAdmin) {
+		return nil, errAccessDenied
+	}
+	apikey.MarkUsed(k.ID, c.ClientIP())
+	return owner, nil
+}
+
+// 桶列表，只有一个桶
+type listBucketsResponse struct {
+	XMLName xml.Name `xml:"ListAllMyBucketsResult"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	Owner   struct {
+		ID          string
+		DisplayName string
+	}
+	Buckets []bucketInfo `xml:"Buckets>Bucket"`
+}
+
+type bucketInfo struct {
+	Name         string
+	CreationDate string
+}
+
+// 桶所在区域，为空表示默认区域
+type locationResponse struct {
+	XMLName xml.Name `xml:"LocationConstraint"`
+	Xmlns   string   `xml:"xmlns,attr"`
+}
+
+func listBuckets(c *gin.Context, u *models.User) {
+	resp := listBucketsResponse{Xmlns: xmlNamespace}
+	resp.Owner.ID = u.ID
+	resp.Owner.DisplayName = u.Username
+	resp.Buckets = []bucketInfo{{Name: bucketName(), CreationDate: startTime.Format(timeLayout)}}
+	writeXML(c, http.StatusOK, resp)
+}
+
+// 桶没有创建时间，使用服务启动时间
+var startTime = time.Now().UTC()
+
+// 下载或获取文件信息，支持Range和条件请求
+func getObject(c *gin.Context, fs *vfs.FS, key string) {
+	// 键必须是规范的路径，目录不是对象
+	if strings.Join(vfs.SplitPath(key), "/") != key {
+		writeError(c, errNoSuchKey)
+		return
+	}
+	n, _, err := fs.Lookup(key)
+	if err != nil || n.IsDir() {
+		writeError(c, errNoSuchKey)
+		return
+	}
+
+	f, err := os.Open(n.File.Path)
+	if err != nil {
+		writeError(c, errNoSuchKey)
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		writeError(c, errNoSuchKey)
+		return
+	}
+
+	c.Header("ETag", etag(n.File, info.Size(), info.ModTime()))
+	http.ServeContent(c.Writer, c.Request, n.Name, info.ModTime(), f)
+
+	// 和下载接口一样记录统计，没有发送到文件末尾视为未完成
+	if c.Request.Method == http.MethodGet && c.Writer.Size() >= 0 {
+		stats.RecordResponse(c, n.File.ID, info.Size())
+	}
+}
+
+// 文件的ETag，不是内容的MD5（使用分段上传的格式，客户端不会当作MD5校验）
+func etag(f *models.File, size int64, modTime time.Time) string {
+	sum := md5.Sum([]byte(f.ID + "\x00" + strconv.FormatInt(size, 10) + "\x00" + strconv.FormatInt(modTime.UnixNano(), 10)))
+	return `"` + hex.EncodeToString(sum[:]) + `-1"`
+}
@@ -0,0 +1,243 @@
+package s3
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AWS签名版本4（SigV4）
+const (
+	signAlgorithm   = "AWS4-HMAC-SHA256"
+	amzDateLayout   = "20060102T150405Z"
+	unsignedPayload = "UNSIGNED-PAYLOAD"
+	maxClockSkew    = 15 * time.Minute
+	maxPresignTTL   = 7 * 24 * time.Hour
+)
+
+// 空请求体的SHA256，请求头中没有x-amz-content-sha256时使用
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// 请求中的签名信息，来自Authorization请求头或预签名URL的查询参数
+type signature struct {
+	accessKeyID   string
+	scope         string // 日期/区域/服务/aws4_request
+	date          string // 凭据范围中的日期
+	region        string
+	service       string
+	signedHeaders []string
+	signature     string
+	amzDate       time.Time
+	presigned     bool
+}
+
+// 解析请求中的签名，没有签名时返回nil
+func parseSignature(c *gin.Context) (*signature, *apiError) {
+	query := c.Request.URL.Query()
+	if query.Get("X-Amz-Algorithm") != "" {
+		return parsePresigned(query)
+	}
+
+	header := c.GetHeader("Authorization")
+	if header == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(header, signAlgorithm+" ") {
+		return nil, errMalformedAuth
+	}
+
+	sig := &signature{}
+	for _, part := range strings.Split(strings.TrimPrefix(header, signAlgorithm+" "), ",") {
+		name, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch name {
+		case "Credential":
+			if !sig.parseCredential(value) {
+				return nil, errMalformedAuth
+			}
+		case "SignedHeaders":
+			sig.signedHeaders = strings.Split(value, ";")
+		case "Signature":
+			sig.signature = value
+		}
+	}
+
+	amzDate := c.GetHeader("X-Amz-Date")
+	if amzDate == "" {
+		amzDate = c.GetHeader("Date")
+	}
+	date, err := time.Parse(amzDateLayout, amzDate)
+	if err != nil {
+		if date, err = http.ParseTime(amzDate); err != nil {
+			return nil, errMalformedAuth
+		}
+	}
+	sig.amzDate = date.UTC()
+
+	if sig.accessKeyID == "" || sig.signature == "" || !sig.signsHost() {
+		return nil, errMalformedAuth
+	}
+	if d := time.Since(sig.amzDate); d > maxClockSkew || d < -maxClockSkew {
+		return nil, errTimeSkewed
+	}
+	return sig, nil
+}
+
+// 解析预签名URL中的签名，并检查是否过期
+func parsePresigned(query url.Values) (*signature, *apiError) {
+	if query.Get("X-Amz-Algorithm") != signAlgorithm {
+		return nil, errMalformedAuth
+	}
+
+	sig := &signature{presigned: true, signature: query.Get("X-Amz-Signature")}
+	if !sig.parseCredential(query.Get("X-Amz-Credential")) {
+		return nil, errMalformedAuth
+	}
+	sig.signedHeaders = strings.Split(query.Get("X-Amz-SignedHeaders"), ";")
+
+	date, err := time.Parse(amzDateLayout, query.Get("X-Amz-Date"))
+	if err != nil {
+		return nil, errMalformedAuth
+	}
+	sig.amzDate = date
+	expires, err := strconv.Atoi(query.Get("X-Amz-Expires"))
+	if err != nil || expires <= 0 || time.Duration(expires)*time.Second > maxPresignTTL {
+		return nil, errMalformedAuth
+	}
+
+	if sig.signature == "" || !sig.signsHost() {
+		return nil, errMalformedAuth
+	}
+	if time.Now().After(date.Add(time.Duration(expires) * time.Second)) {
+		return nil, errExpired
+	}
+	if time.Until(date) > maxClockSkew {
+		return nil, errTimeSkewed
+	}
+	return sig, nil
+}
+
+// 解析凭据：访问密钥ID/日期/区域/s3/aws4_request
+func (sig *signature) parseCredential(credential string) bool {
+	parts := strings.Split(credential, "/")
+	if len(parts) != 5 || parts[3] != "s3" || parts[4] != "aws4_request" {
+		return false
+	}
+	sig.accessKeyID = parts[0]
+	sig.date, sig.region, sig.service = parts[1], parts[2], parts[3]
+	sig.scope = strings.Join(parts[1:], "/")
+	return true
+}
+
+// 签名必须包含host请求头，否则签名可以在其他主机上重放
+func (sig *signature) signsHost() bool {
+	for _, name := range sig.signedHeaders {
+		if name == "host" {
+			return true
+		}
+	}
+	return false
+}
+
+// 使用私有访问密钥计算签名并和请求中的签名比较，signedPath为客户端请求的路径（未编码）
+func (sig *signature) verify(r *http.Request, signedPath, secret string) bool {
+	if sig.amzDate.Format("20060102") != sig.date {
+		return false
+	}
+	return hmac.Equal([]byte(sig.sign(r, signedPath, secret)), []byte(sig.signature))
+}
+
+// 按签名版本4计算请求的签名
+func (sig *signature) sign(r *http.Request, signedPath, secret string) string {
+	canonicalRequest := strings.Join([]string{
+		r.Method,
+		uriEncode(signedPath, false),
+		canonicalQuery(r.URL.Query()),
+		canonicalHeaders(r, sig.signedHeaders),
+		strings.Join(sig.signedHeaders, ";"),
+		payloadHash(r, sig.presigned),
+	}, "\n")
+
+	hash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		signAlgorithm,
+		sig.amzDate.Format(amzDateLayout),
+		sig.scope,
+		hex.EncodeToString(hash[:]),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secret), sig.date)
+	key = hmacSHA256(key, sig.region)
+	key = hmacSHA256(key, sig.service)
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// 按名称排序的查询参数，预签名URL中的签名参数除外
+func canonicalQuery(query url.Values) string {
+	params := []string{}
+	for name, values := range query {
+		if name == "X-Amz-Signature" {
+			continue
+		}
+		for _, value := range values {
+			params = append(params, uriEncode(name, true)+"="+uriEncode(value, true))
+		}
+	}
+	sort.Strings(params)
+	return strings.Join(params, "&")
+}
+
+// 参与签名的请求头，每行一个，以换行结尾
+func canonicalHeaders(r *http.Request, names []string) string {
+	var b strings.Builder
+	for _, name := range names {
+		var value string
+		if name == "host" {
+			value = r.Host
+		} else {
+			value = strings.Join(r.Header.Values(name), ",")
+		}
+		b.WriteString(name + ":" + strings.Join(strings.Fields(value), " ") + "\n")
+	}
+	return b.String()
+}
+
+// 请求体的哈希，GET和HEAD请求没有请求体
+func payloadHash(r *http.Request, presigned bool) string {
+	if presigned {
+		return unsignedPayload
+	}
+	if hash := r.Header.Get("X-Amz-Content-Sha256"); hash != "" {
+		return hash
+	}
+	return emptyPayloadHash
+}
+
+// 按AWS的规则编码：只保留字母、数字和-_.~，encodeSlash为false时保留路径中的/
+func uriEncode(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		if ('A' <= ch && ch <= 'Z') || ('a' <= ch && ch <= 'z') || ('0' <= ch && ch <= '9') ||
+			ch == '-' || ch == '_' || ch == '.' || ch == '~' || (ch == '/' && !encodeSlash) {
+			b.WriteByte(ch)
+			continue
+		}
+		b.WriteString("%" + strings.ToUpper(hex.EncodeToString([]byte{ch})))
+	}
+	return b.String()
+}
@@ -0,0 +1,207 @@
+package sftpd
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"time"
+
+	"fileshare/vfs"
+)
+
+// SFTP协议版本3（draft-ietf-secsh-filexfer-02），OpenSSH等常见客户端都支持
+const protocolVersion = 3
+
+// 数据包类型
+const (
+	fxpInit     = 1
+	fxpVersion  = 2
+	fxpOpen     = 3
+	fxpClose    = 4
+	fxpRead     = 5
+	fxpWrite    = 6
+	fxpLstat    = 7
+	fxpFstat    = 8
+	fxpSetstat  = 9
+	fxpFsetstat = 10
+	fxpOpendir  = 11
+	fxpReaddir  = 12
+	fxpRemove   = 13
+	fxpMkdir    = 14
+	fxpRmdir    = 15
+	fxpRealpath = 16
+	fxpStat     = 17
+	fxpRename   = 18
+	fxpStatus   = 101
+	fxpHandle   = 102
+	fxpData     = 103
+	fxpName     = 104
+	fxpAttrs    = 105
+)
+
+// 状态码
+const (
+	fxOK               = 0
+	fxEOF              = 1
+	fxNoSuchFile       = 2
+	fxPermissionDenied = 3
+	fxFailure          = 4
+	fxBadMessage       = 5
+	fxOpUnsupported    = 8
+)
+
+// 打开文件的标志
+const (
+	fxfRead   = 0x01
+	fxfWrite  = 0x02
+	fxfAppend = 0x04
+	fxfCreat  = 0x08
+	fxfTrunc  = 0x10
+	fxfExcl   = 0x20
+)
+
+// 文件属性中包含的字段
+const (
+	attrSize        = 0x01
+	attrPermissions = 0x04
+	attrACModTime   = 0x08
+)
+
+// 数据包的最大长度，客户端每次写入一般不超过256KB
+const maxPacketSize = 1 << 20
+
+// 每次READ最多返回的字节数
+const maxReadSize = 1 << 18
+
+// 数据包格式错误
+var errBadMessage = errors.New("bad message")
+
+// 读取一个数据包（不含长度）
+func readPacket(r io.Reader) ([]byte, error) {
+	var header [4]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+	length := binary.BigEndian.Uint32(header[:])
+	if length == 0 || length > maxPacketSize {
+		return nil, errBadMessage
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// 解析数据包中的字段，数据不足时记录错误并返回零值
+type decoder struct {
+	data []byte
+	err  error
+}
+
+func (d *decoder) uint32() uint32 {
+	if len(d.data) < 4 {
+		d.err = errBadMessage
+		return 0
+	}
+	v := binary.BigEndian.Uint32(d.data)
+	d.data = d.data[4:]
+	return v
+}
+
+func (d *decoder) uint64() uint64 {
+	if len(d.data) < 8 {
+		d.err = errBadMessage
+		return 0
+	}
+	v := binary.BigEndian.Uint64(d.data)
+	d.data = d.data[8:]
+	return v
+}
+
+func (d *decoder) bytes() []byte {
+	length := d.uint32()
+	if uint32(len(d.data)) < length {
+		d.err = errBadMessage
+		return nil
+	}
+	v := d.data[:length]
+	d.data = d.data[length:]
+	return v
+}
+
+func (d *decoder) string() string {
+	return string(d.bytes())
+}
+
+// 构造响应数据包，前4字节为长度
+type encoder struct {
+	data []byte
+}
+
+func newPacket(typ byte, id uint32) *encoder {
+	e := &encoder{data: make([]byte, 4, 64)}
+	e.byte(typ)
+	e.uint32(id)
+	return e
+}
+
+func (e *encoder) byte(v byte) {
+	e.data = append(e.data, v)
+}
+
+func (e *encoder) uint32(v uint32) {
+	e.data = binary.BigEndian.AppendUint32(e.data, v)
+}
+
+func (e *encoder) uint64(v uint64) {
+	e.data = binary.BigEndian.AppendUint64(e.data, v)
+}
+
+func (e *encoder) string(v string) {
+	e.uint32(uint32(len(v)))
+	e.data = append(e.data, v...)
+}
+
+func (e *encoder) bytes(v []byte) {
+	e.uint32(uint32(len(v)))
+	e.data = append(e.data, v...)
+}
+
+// 文件属性：大小、权限和修改时间
+func (e *encoder) attrs(info *vfs.FileInfo) {
+	e.uint32(attrSize | attrPermissions | attrACModTime)
+	e.uint64(uint64(info.Size()))
+	e.uint32(posixMode(info.Mode()))
+	mtime := uint32(info.ModTime().Unix())
+	e.uint32(mtime)
+	e.uint32(mtime)
+}
+
+// 完成数据包，写入长度
+func (e *encoder) finish() []byte {
+	binary.BigEndian.PutUint32(e.data, uint32(len(e.data)-4))
+	return e.data
+}
+
+// 转换为POSIX的文件类型和权限位
+func posixMode(mode fs.FileMode) uint32 {
+	perm := uint32(mode.Perm())
+	if mode.IsDir() {
+		return 0040000 | perm
+	}
+	return 0100000 | perm
+}
+
+// 类似ls -l的条目描述，部分客户端直接显示该内容
+func longName(info *vfs.FileInfo) string {
+	modTime := info.ModTime()
+	layout := "Jan _2 15:04"
+	if time.Since(modTime) > 180*24*time.Hour {
+		layout = "Jan _2  2006"
+	}
+	return fmt.Sprintf("%s    1 fileshare fileshare %12d %s %s", info.Mode(), info.Size(), modTime.Format(layout), info.Name())
+}
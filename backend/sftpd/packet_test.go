@@ -0,0 +1,169 @@
+package sftpd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// 构造请求数据包（不含长度）
+func request(typ byte, fields ...interface{}) []byte {
+	e := &encoder{}
+	e.byte(typ)
+	for _, field := range fields {
+		switch v := field.(type) {
+		case uint32:
+			e.uint32(v)
+		case uint64:
+			e.uint64(v)
+		case string:
+			e.string(v)
+		case []byte:
+			e.data = append(e.data, v...)
+		}
+	}
+	return e.data
+}
+
+func TestReadPacket(t *testing.T) {
+	withLength := func(length uint32, body []byte) []byte {
+		return append(binary.BigEndian.AppendUint32(nil, length), body...)
+	}
+
+	tests := []struct {
+		name    string
+		input   []byte
+		want    []byte
+		wantErr bool
+	}{
+		{"完整的数据包", withLength(3, []byte{1, 2, 3}), []byte{1, 2, 3}, false},
+		{"空输入", nil, nil, true},
+		{"长度不完整", []byte{0, 0}, nil, true},
+		{"长度为0", withLength(0, nil), nil, true},
+		{"超过最大长度", withLength(maxPacketSize+1, nil), nil, true},
+		{"内容不完整", withLength(5, []byte{1, 2}), nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := readPacket(bytes.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("readPacket() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("readPacket() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecoderTruncated(t *testing.T) {
+	full := request(0, uint32(7), uint64(1<<40), "name")[1:]
+
+	tests := []struct {
+		name string
+		data []byte
+		ok   bool
+	}{
+		{"完整", full, true},
+		{"空", nil, false},
+		{"uint32不完整", full[:3], false},
+		{"uint64不完整", full[:4+7], false},
+		{"字符串长度不完整", full[:4+8+2], false},
+		{"字符串内容不完整", full[:len(full)-1], false},
+		{"字符串长度超过剩余数据", append(append([]byte{}, full[:12]...), 0xff, 0xff, 0xff, 0xff, 'x'), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &decoder{data: tt.data}
+			n, offset, name := d.uint32(), d.uint64(), d.string()
+			if (d.err == nil) != tt.ok {
+				t.Fatalf("err = %v, want ok %v", d.err, tt.ok)
+			}
+			if tt.ok && (n != 7 || offset != 1<<40 || name != "name") {
+				t.Errorf("decoded %d %d %q", n, offset, name)
+			}
+		})
+	}
+}
+
+// 解析响应中的状态码
+func responseStatus(t *testing.T, out *bytes.Buffer) (typ byte, id, code uint32) {
+	t.Helper()
+	data, err := readPacket(out)
+	if err != nil {
+		t.Fatalf("read response: %v", err)
+	}
+	d := &decoder{data: data[1:]}
+	id = d.uint32()
+	if data[0] == fxpStatus {
+		code = d.uint32()
+	}
+	return data[0], id, code
+}
+
+func TestDispatchTruncated(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"OPEN缺少标志", request(fxpOpen, uint32(1), "/a")},
+		{"CLOSE缺少句柄", request(fxpClose, uint32(1))},
+		{"READ缺少长度", request(fxpRead, uint32(1), "h", uint64(0))},
+		{"WRITE数据不完整", request(fxpWrite, uint32(1), "h", uint64(0), uint32(10), []byte("abc"))},
+		{"STAT路径不完整", request(fxpStat, uint32(1), uint32(5), []byte("ab"))},
+		{"SETSTAT缺少路径", request(fxpSetstat, uint32(1))},
+		{"FSETSTAT缺少句柄", request(fxpFsetstat, uint32(1))},
+		{"OPENDIR缺少路径", request(fxpOpendir, uint32(1))},
+		{"READDIR缺少句柄", request(fxpReaddir, uint32(1))},
+		{"MKDIR缺少路径", request(fxpMkdir, uint32(1))},
+		{"RENAME缺少新路径", request(fxpRename, uint32(1), "/a")},
+		{"REALPATH缺少路径", request(fxpRealpath, uint32(1))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			s := newSession(out, nil)
+			if err := s.dispatch(tt.data); err != nil {
+				t.Fatalf("dispatch() error = %v", err)
+			}
+			typ, id, code := responseStatus(t, out)
+			if typ != fxpStatus || id != 1 || code != fxBadMessage {
+				t.Errorf("response = type %d id %d code %d, want BAD_MESSAGE", typ, id, code)
+			}
+		})
+	}
+}
+
+func TestDispatchMissingID(t *testing.T) {
+	s := newSession(&bytes.Buffer{}, nil)
+	if err := s.dispatch([]byte{fxpStat, 0, 0}); err == nil {
+		t.Error("dispatch() without request id should fail")
+	}
+}
+
+func TestHandleLimit(t *testing.T) {
+	out := &bytes.Buffer{}
+	s := newSession(out, nil)
+
+	for i := 0; i < maxHandles; i++ {
+		if err := s.addHandle(uint32(i), &handle{}); err != nil {
+			t.Fatal(err)
+		}
+		if typ, _, _ := responseStatus(t, out); typ != fxpHandle {
+			t.Fatalf("handle %d: response type %d, want HANDLE", i, typ)
+		}
+	}
+
+	if err := s.addHandle(99, &handle{}); err != nil {
+		t.Fatal(err)
+	}
+	if typ, id, code := responseStatus(t, out); typ != fxpStatus || id != 99 || code != fxFailure {
+		t.Errorf("response = type %d id %d code %d, want FAILURE", typ, id, code)
+	}
+	if len(s.handles) != maxHandles {
+		t.Errorf("open handles = %d, want %d", len(s.handles), maxHandles)
+	}
+}
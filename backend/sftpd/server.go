@@ -0,0 +1,254 @@
+// Package sftpd 提供内嵌的SFTP服务，把目录树映射为远程文件系统（见vfs包）。
+//
+// 使用管理端账号登录：密码可以是账号密码或API密钥，也可以使用账号中添加的SSH公钥；
+// 启用了两步验证的账号只能使用SSH公钥或API密钥。权限和管理端相同，上传的文件会保存为文件记录。
+package sftpd
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+
+	"fileshare/apikey"
+	"fileshare/config"
+	"fileshare/ipfilter"
+	"fileshare/lockout"
+	"fileshare/models"
+	"fileshare/user"
+)
+
+// 登录失败时返回给客户端的错误，不区分具体原因
+var errAuthFailed = errors.New("authentication failed")
+
+// 认证通过后在ssh.Permissions.Extensions中保存的账号信息，握手完成后从连接中读取
+const (
+	extUserID = "fileshare-user-id"
+	extKeyID  = "fileshare-key-id" // 使用API密钥登录时的密钥ID
+)
+
+// Server SFTP服务
+type Server struct {
+	config *ssh.ServerConfig
+	filter *models.IPFilter
+}
+
+// ListenAndServe 按配置启动SFTP服务，配置无效时启动失败
+func ListenAndServe() {
+	cfg := config.GetServerConfig()
+	filter, err := ipfilter.Normalize(&cfg.Security.ManageIPFilter)
+	if err != nil {
+		log.Fatalf("Invalid security.manageIpFilter: %v", err)
+	}
+	hostKey, err := loadHostKey(cfg.SFTP.HostKeyFile)
+	if err != nil {
+		log.Fatalf("Failed to load SFTP host key: %v", err)
+	}
+
+	s := &Server{filter: filter}
+	s.config = &ssh.ServerConfig{
+		PasswordCallback:  s.passwordCallback,
+		PublicKeyCallback: s.publicKeyCallback,
+		ServerVersion:     "SSH-2.0-FileShare",
+	}
+	s.config.AddHostKey(hostKey)
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.SFTP.Port))
+	if err != nil {
+		log.Fatalf("Failed to start SFTP server: %v", err)
+	}
+	log.Printf("SFTP服务已运行，端口：%d", cfg.SFTP.Port)
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			log.Printf("SFTP accept error: %v", err)
+			time.Sleep(time.Second)
+			continue
+		}
+		go s.handleConn(conn)
+	}
+}
+
+// 读取主机私钥，文件不存在时生成ed25519密钥并保存
+func loadHostKey(path string) (ssh.Signer, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		data, err = generateHostKey(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ssh.ParsePrivateKey(data)
+}
+
+// 生成主机私钥（PKCS#8 PEM）
+func generateHostKey(path string) ([]byte, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		return nil, err
+	}
+	log.Printf("Generated SFTP host key: %s", path)
+	return data, nil
+}
+
+// 客户端的IP地址
+func remoteIP(conn ssh.ConnMetadata) string {
+	host, _, err := net.SplitHostPort(conn.RemoteAddr().String())
+	if err != nil {
+		return conn.RemoteAddr().String()
+	}
+	return host
+}
+
+// 密码登录，密码可以是账号密码或API密钥（忽略用户名），失败次数过多时锁定
+func (s *Server) passwordCallback(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+	ip := remoteIP(conn)
+
+	if apikey.IsAPIKey(string(password)) {
+		k, valid := apikey.Authenticate(string(password), ip)
+		if !valid {
+			return nil, errAuthFailed
+		}
+		owner := user.FindByID(k.UserID)
+		if owner == nil || owner.Disabled {
+			return nil, errAuthFailed
+		}
+		return permissions(owner, k), nil
+	}
+
+	// 按IP以及IP和账号的组合锁定，账号本身的失败只会延迟登录
+	accountKey := lockout.AccountKey(conn.User())
+	lockoutKeys := []string{lockout.AddrKey(ip), lockout.ScopedAddrKey(accountKey, ip), accountKey}
+	if lockout.RetryAfter(lockoutKeys[:2]...) > 0 {
+		return nil, errors.New("too many failed attempts, please try again later")
+	}
+	lockout.Throttle(accountKey)
+	// 启用了两步验证的账号不能只凭密码登录，和密码错误返回相同的错误并计为失败，
+	// 避免通过响应判断密码是否正确（这类账号使用SSH公钥或API密钥登录）
+	u := user.Authenticate(conn.User(), string(password))
+	if u == nil || u.TOTPEnabled {
+		lockout.Fail(lockoutKeys...)
+		return nil, errAuthFailed
+	}
+	lockout.Succeed(lockoutKeys[1:]...)
+	if u.MustChangePassword {
+		return nil, errors.New("the default password must be changed first")
+	}
+	return permissions(u, nil), nil
+}
+
+// 公钥登录，公钥需要添加到对应账号中
+func (s *Server) publicKeyCallback(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+	u := user.FindBySSHKey(conn.User(), key)
+	if u == nil {
+		return nil, errAuthFailed
+	}
+	return permissions(u, nil), nil
+}
+
+// 认证结果，只保存账号和API密钥的ID，使用时重新查找
+func permissions(u *models.User, k *models.APIKey) *ssh.Permissions {
+	extensions := map[string]string{extUserID: u.ID}
+	if k != nil {
+		extensions[extKeyID] = k.ID
+	}
+	return &ssh.Permissions{Extensions: extensions}
+}
+
+// 根据连接的认证结果查找账号和API密钥，账号被删除、禁用或API密钥失效时返回false
+func resolveLogin(perms *ssh.Permissions) (*models.User, *models.APIKey, bool) {
+	if perms == nil {
+		return nil, nil, false
+	}
+	u := user.FindByID(perms.Extensions[extUserID])
+	if u == nil || u.Disabled {
+		return nil, nil, false
+	}
+
+	var k *models.APIKey
+	if id := perms.Extensions[extKeyID]; id != "" {
+		found, valid := apikey.FindByID(id)
+		if !valid || found.UserID != u.ID {
+			return nil, nil, false
+		}
+		k = found
+	}
+	return u, k, true
+}
+
+// 处理一个连接：检查IP限制、完成SSH握手，只接受sftp子系统
+func (s *Server) handleConn(netConn net.Conn) {
+	defer netConn.Close()
+
+	ip, _, _ := net.SplitHostPort(netConn.RemoteAddr().String())
+	if !ipfilter.Allows(s.filter, ip) {
+		return
+	}
+
+	conn, channels, requests, err := ssh.NewServerConn(netConn, s.config)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	go ssh.DiscardRequests(requests)
+
+	if _, _, ok := resolveLogin(conn.Permissions); !ok {
+		return
+	}
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		go s.handleSession(conn, channel, requests)
+	}
+}
+
+// 处理会话请求，只允许启动sftp子系统，不提供shell和命令执行
+func (s *Server) handleSession(conn *ssh.ServerConn, channel ssh.Channel, requests <-chan *ssh.Request) {
+	defer channel.Close()
+
+	for req := range requests {
+		if req.Type != "subsystem" || len(req.Payload) < 4 || string(req.Payload[4:]) != "sftp" {
+			req.Reply(false, nil)
+			continue
+		}
+		req.Reply(true, nil)
+		go ssh.DiscardRequests(requests)
+
+		// 账号被禁用或API密钥失效后会话结束，同时断开连接
+		if !newSession(channel, conn.Permissions).serve() {
+			conn.Close()
+			return
+		}
+		channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{0}))
+		return
+	}
+}
@@ -0,0 +1,474 @@
+package sftpd
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path"
+	"strconv"
+
+	"golang.org/x/crypto/ssh"
+
+	"fileshare/models"
+	"fileshare/stats"
+	"fileshare/vfs"
+)
+
+// 每个会话最多同时打开的文件和目录数
+const maxHandles = 64
+
+// 打开的文件或目录
+type handle struct {
+	node    *vfs.Node
+	file    *os.File    // 读取的文件
+	writer  *vfs.Writer // 上传中的文件
+	entries []*vfs.Node // 尚未返回的目录条目
+	listed  bool        // 目录条目是否已全部返回
+	read    int64       // 已读取的字节数，用于下载统计
+}
+
+// 一个SFTP会话，按顺序处理客户端的请求
+type session struct {
+	rw         io.ReadWriter
+	perms      *ssh.Permissions // 连接的认证结果（见resolveLogin）
+	fs         *vfs.FS
+	handles    map[string]*handle
+	nextHandle uint64
+}
+
+// 创建会话，权限和管理端相同
+func newSession(rw io.ReadWriter, perms *ssh.Permissions) *session {
+	return &session{
+		rw:      rw,
+		perms:   perms,
+		fs:      &vfs.FS{},
+		handles: make(map[string]*handle),
+	}
+}
+
+// 处理请求直到连接关闭，关闭时放弃未完成的上传。
+// 每个请求前重新检查账号和API密钥，账号被禁用、删除或API密钥失效时返回false
+func (s *session) serve() bool {
+	defer s.closeAll()
+
+	for {
+		data, err := readPacket(s.rw)
+		if err != nil {
+			return true
+		}
+
+		u, k, ok := resolveLogin(s.perms)
+		if !ok {
+			return false
+		}
+		s.fs.Policy = &vfs.ManagePolicy{User: u, Key: k}
+
+		if err := s.dispatch(data); err != nil {
+			return true
+		}
+	}
+}
+
+// 关闭所有打开的文件，未关闭的上传视为中断
+func (s *session) closeAll() {
+	for id, h := range s.handles {
+		if h.writer != nil {
+			h.writer.Abort()
+		}
+		if h.file != nil {
+			h.file.Close()
+		}
+		delete(s.handles, id)
+	}
+}
+
+// 发送响应
+func (s *session) send(e *encoder) error {
+	_, err := s.rw.Write(e.finish())
+	return err
+}
+
+// 发送状态响应
+func (s *session) sendStatus(id uint32, code uint32, message string) error {
+	e := newPacket(fxpStatus, id)
+	e.uint32(code)
+	e.string(message)
+	e.string("")
+	return s.send(e)
+}
+
+// 根据错误发送对应的状态
+func (s *session) sendError(id uint32, err error) error {
+	switch {
+	case err == nil:
+		return s.sendStatus(id, fxOK, "")
+	case errors.Is(err, os.ErrNotExist):
+		return s.sendStatus(id, fxNoSuchFile, "no such file")
+	case errors.Is(err, os.ErrPermission):
+		return s.sendStatus(id, fxPermissionDenied, err.Error())
+	case errors.Is(err, os.ErrExist):
+		return s.sendStatus(id, fxFailure, "file already exists")
+	default:
+		return s.sendStatus(id, fxFailure, err.Error())
+	}
+}
+
+// 处理一个请求
+func (s *session) dispatch(data []byte) error {
+	if data[0] == fxpInit {
+		return s.send(newPacket(fxpVersion, protocolVersion))
+	}
+
+	d := &decoder{data: data[1:]}
+	id := d.uint32()
+	if d.err != nil {
+		return d.err
+	}
+
+	var err error
+	switch data[0] {
+	case fxpOpen:
+		err = s.open(id, d)
+	case fxpClose:
+		err = s.close(id, d)
+	case fxpRead:
+		err = s.read(id, d)
+	case fxpWrite:
+		err = s.write(id, d)
+	case fxpStat, fxpLstat:
+		err = s.stat(id, d)
+	case fxpFstat:
+		err = s.fstat(id, d)
+	case fxpSetstat:
+		// 不支持修改权限和时间，忽略以免客户端上传后报错
+		name := d.string()
+		if d.err == nil {
+			_, _, lookupErr := s.fs.Lookup(name)
+			err = s.sendError(id, lookupErr)
+		}
+	case fxpFsetstat:
+		h := s.handles[d.string()]
+		if d.err == nil && h == nil {
+			err = s.sendStatus(id, fxFailure, "invalid handle")
+		} else if d.err == nil {
+			err = s.sendStatus(id, fxOK, "")
+		}
+	case fxpOpendir:
+		err = s.opendir(id, d)
+	case fxpReaddir:
+		err = s.readdir(id, d)
+	case fxpRemove:
+		err = s.remove(id, d)
+	case fxpMkdir:
+		name := d.string()
+		if d.err == nil {
+			err = s.sendError(id, s.fs.Mkdir(name))
+		}
+	case fxpRmdir:
+		err = s.rmdir(id, d)
+	case fxpRealpath:
+		err = s.realpath(id, d)
+	case fxpRename:
+		oldName, newName := d.string(), d.string()
+		if d.err == nil {
+			err = s.sendError(id, s.fs.Rename(oldName, newName))
+		}
+	default:
+		return s.sendStatus(id, fxOpUnsupported, "operation not supported")
+	}
+
+	if d.err != nil {
+		return s.sendStatus(id, fxBadMessage, "bad message")
+	}
+	return err
+}
+
+// 保存打开的文件或目录，返回句柄。打开的数量达到上限时关闭h并返回错误
+func (s *session) addHandle(id uint32, h *handle) error {
+	if len(s.handles) >= maxHandles {
+		if h.writer != nil {
+			h.writer.Abort()
+		}
+		if h.file != nil {
+			h.file.Close()
+		}
+		return s.sendStatus(id, fxFailure, "too many open handles")
+	}
+
+	s.nextHandle++
+	name := strconv.FormatUint(s.nextHandle, 10)
+	s.handles[name] = h
+
+	e := newPacket(fxpHandle, id)
+	e.string(name)
+	return s.send(e)
+}
+
+// 打开文件：读取时直接读取存储的文件，写入时上传为文件记录（只支持从头顺序写入）
+func (s *session) open(id uint32, d *decoder) error {
+	name := d.string()
+	pflags := d.uint32()
+	if d.err != nil {
+		return nil
+	}
+
+	if pflags&(fxfWrite|fxfAppend|fxfCreat|fxfTrunc) != 0 {
+		if pflags&fxfRead != 0 {
+			return s.sendStatus(id, fxOpUnsupported, "read-write access is not supported")
+		}
+		if pflags&fxfCreat == 0 {
+			if _, _, err := s.fs.Lookup(name); err != nil {
+				return s.sendError(id, err)
+			}
+		}
+		w, err := s.fs.Create(name, pflags&fxfTrunc != 0, pflags&fxfExcl != 0)
+		if err != nil {
+			return s.sendError(id, err)
+		}
+		return s.addHandle(id, &handle{writer: w})
+	}
+
+	n, _, err := s.fs.Lookup(name)
+	if err != nil {
+		return s.sendError(id, err)
+	}
+	if n.IsDir() {
+		return s.sendStatus(id, fxFailure, "is a directory")
+	}
+	f, err := os.Open(n.File.Path)
+	if err != nil {
+		return s.sendError(id, err)
+	}
+	return s.addHandle(id, &handle{node: n, file: f})
+}
+
+// 关闭文件或目录：完成上传时保存文件记录，读取文件时记录下载统计
+func (s *session) close(id uint32, d *decoder) error {
+	name := d.string()
+	h := s.handles[name]
+	if d.err != nil {
+		return nil
+	}
+	if h == nil {
+		return s.sendStatus(id, fxFailure, "invalid handle")
+	}
+	delete(s.handles, name)
+
+	switch {
+	case h.writer != nil:
+		return s.sendError(id, h.writer.Close())
+	case h.file != nil:
+		// 和下载接口一样记录统计，未读完视为未完成
+		if h.read > 0 {
+			completed := false
+			if info, err := h.file.Stat(); err == nil {
+				completed = h.read >= info.Size()
+			}
+			stats.RecordDownload(h.node.File.ID, h.read, completed)
+		}
+		h.file.Close()
+	}
+	return s.sendStatus(id, fxOK, "")
+}
+
+// 读取文件内容
+func (s *session) read(id uint32, d *decoder) error {
+	h := s.handles[d.string()]
+	offset := d.uint64()
+	length := d.uint32()
+	if d.err != nil {
+		return nil
+	}
+	if h == nil || h.file == nil {
+		return s.sendStatus(id, fxFailure, "invalid handle")
+	}
+
+	if length > maxReadSize {
+		length = maxReadSize
+	}
+	buf := make([]byte, length)
+	n, err := h.file.ReadAt(buf, int64(offset))
+	if n == 0 {
+		if err == io.EOF {
+			return s.sendStatus(id, fxEOF, "")
+		}
+		return s.sendError(id, err)
+	}
+	h.read += int64(n)
+
+	e := newPacket(fxpData, id)
+	e.bytes(buf[:n])
+	return s.send(e)
+}
+
+// 写入上传的内容，偏移必须和已写入的长度一致
+func (s *session) write(id uint32, d *decoder) error {
+	h := s.handles[d.string()]
+	offset := d.uint64()
+	data := d.bytes()
+	if d.err != nil {
+		return nil
+	}
+	if h == nil || h.writer == nil {
+		return s.sendStatus(id, fxFailure, "invalid handle")
+	}
+
+	if int64(offset) != h.writer.Written() {
+		return s.sendStatus(id, fxOpUnsupported, "只支持从头顺序写入")
+	}
+	if _, err := h.writer.Write(data); err != nil {
+		return s.sendError(id, err)
+	}
+	return s.sendStatus(id, fxOK, "")
+}
+
+// 发送文件属性
+func (s *session) sendAttrs(id uint32, info *vfs.FileInfo) error {
+	e := newPacket(fxpAttrs, id)
+	e.attrs(info)
+	return s.send(e)
+}
+
+// 获取路径的属性（没有符号链接，STAT和LSTAT相同）
+func (s *session) stat(id uint32, d *decoder) error {
+	name := d.string()
+	if d.err != nil {
+		return nil
+	}
+
+	info, err := s.fs.Stat(name)
+	if err != nil {
+		return s.sendError(id, err)
+	}
+	return s.sendAttrs(id, info)
+}
+
+// 获取打开的文件或目录的属性
+func (s *session) fstat(id uint32, d *decoder) error {
+	h := s.handles[d.string()]
+	if d.err != nil {
+		return nil
+	}
+
+	switch {
+	case h == nil:
+		return s.sendStatus(id, fxFailure, "invalid handle")
+	case h.writer != nil:
+		return s.sendAttrs(id, h.writer.Stat())
+	default:
+		return s.sendAttrs(id, vfs.NewFileInfo(h.node))
+	}
+}
+
+// 打开目录，列出的内容为打开时的快照
+func (s *session) opendir(id uint32, d *decoder) error {
+	name := d.string()
+	if d.err != nil {
+		return nil
+	}
+
+	n, _, err := s.fs.Lookup(name)
+	if err != nil {
+		return s.sendError(id, err)
+	}
+	if !n.IsDir() {
+		return s.sendStatus(id, fxFailure, "not a directory")
+	}
+	return s.addHandle(id, &handle{node: n, entries: s.fs.Children(n.Dir)})
+}
+
+// 返回目录中的条目，每次最多100个，全部返回后返回EOF
+func (s *session) readdir(id uint32, d *decoder) error {
+	h := s.handles[d.string()]
+	if d.err != nil {
+		return nil
+	}
+	if h == nil || h.node == nil || !h.node.IsDir() {
+		return s.sendStatus(id, fxFailure, "invalid handle")
+	}
+	if h.listed {
+		return s.sendStatus(id, fxEOF, "")
+	}
+
+	entries := h.entries
+	if len(entries) > 100 {
+		entries = entries[:100]
+	}
+	h.entries = h.entries[len(entries):]
+	h.listed = len(h.entries) == 0
+
+	e := newPacket(fxpName, id)
+	e.uint32(uint32(len(entries)))
+	for _, entry := range entries {
+		info := vfs.NewFileInfo(entry)
+		e.string(entry.Name)
+		e.string(longName(info))
+		e.attrs(info)
+	}
+	return s.send(e)
+}
+
+// 删除文件
+func (s *session) remove(id uint32, d *decoder) error {
+	name := d.string()
+	if d.err != nil {
+		return nil
+	}
+
+	n, _, err := s.fs.Lookup(name)
+	if err != nil {
+		return s.sendError(id, err)
+	}
+	if n.IsDir() {
+		return s.sendStatus(id, fxFailure, "is a directory")
+	}
+	return s.sendError(id, s.fs.Remove(name))
+}
+
+// 删除空目录，目录中有子目录或文件（包括看不到的）时失败
+func (s *session) rmdir(id uint32, d *decoder) error {
+	name := d.string()
+	if d.err != nil {
+		return nil
+	}
+
+	n, _, err := s.fs.Lookup(name)
+	if err != nil {
+		return s.sendError(id, err)
+	}
+	if !n.IsDir() {
+		return s.sendStatus(id, fxFailure, "not a directory")
+	}
+	if n.Dir != nil && !isEmpty(n.Dir) {
+		return s.sendStatus(id, fxFailure, "directory not empty")
+	}
+	return s.sendError(id, s.fs.Remove(name))
+}
+
+// 目录中是否没有子目录和文件
+func isEmpty(dir *models.Directory) bool {
+	if len(dir.Children) > 0 {
+		return false
+	}
+	for _, f := range models.Files {
+		if f.DirectoryID == dir.ID {
+			return false
+		}
+	}
+	return true
+}
+
+// 规范化路径，相对路径相对于根目录
+func (s *session) realpath(id uint32, d *decoder) error {
+	name := d.string()
+	if d.err != nil {
+		return nil
+	}
+
+	e := newPacket(fxpName, id)
+	e.uint32(1)
+	e.string(path.Clean("/" + name))
+	e.string("")
+	e.uint32(0)
+	return s.send(e)
+}
@@ -0,0 +1,676 @@
+package sharelink
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"fileshare/access"
+	"fileshare/acl"
+	"fileshare/common"
+	"fileshare/file"
+	"fileshare/ipfilter"
+	"fileshare/lockout"
+	"fileshare/models"
+	"fileshare/proxy"
+	"fileshare/user"
+	"fileshare/utils"
+)
+
+// 配置文件路径
+const (
+	ShareLinkConfigPath = "./config/config-sharelink.json"
+)
+
+// 分享目标类型
+const (
+	TargetFile      = "file"
+	TargetDirectory = "directory"
+)
+
+// 时间格式
+const timeLayout = "2006-01-02 15:04:05"
+
+// 分享链接访问令牌的Cookie名称前缀
+const linkCookiePrefix = "fs_link_"
+
+// LinkTokenHeader 分享链接访问令牌请求头
+const LinkTokenHeader = "X-Link-Token"
+
+// 分享链接的读写锁（下载计数可能并发更新）
+var linksMu sync.Mutex
+
+// 加载分享链接配置
+func LoadShareLinks() {
+	linksMu.Lock()
+	defer linksMu.Unlock()
+
+	data, err := os.ReadFile(ShareLinkConfigPath)
+	if err != nil {
+		models.ShareLinks = []*models.ShareLink{}
+		if !os.IsNotExist(err) {
+			log.Printf("Failed to read share link config: %v", err)
+		}
+		return
+	}
+
+	if err := json.Unmarshal(data, &models.ShareLinks); err != nil {
+		log.Printf("Failed to parse share link config: %v", err)
+		models.ShareLinks = []*models.ShareLink{}
+		return
+	}
+
+	// 将明文密码迁移为哈希
+	changed := false
+	for _, link := range models.ShareLinks {
+		if link.Password != "" && !utils.IsPasswordHash(link.Password) {
+			hash, err := utils.HashPassword(link.Password)
+			if err != nil {
+				log.Printf("Failed to hash password of share link %s: %v", link.ID, err)
+				continue
+			}
+			link.Password = hash
+			changed = true
+		}
+	}
+	if changed {
+		if err := saveShareLinks(); err != nil {
+			log.Printf("Failed to save migrated share link passwords: %v", err)
+		}
+	}
+}
+
+// 保存分享链接配置（调用方需持有锁）
+func saveShareLinks() error {
+	data, err := json.MarshalIndent(models.ShareLinks, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(ShareLinkConfigPath, data, 0644)
+}
+
+// 获取分享链接列表，可按目标过滤
+func GetShareLinks(c *gin.Context) {
+	targetID := c.Query("targetId")
+
+	linksMu.Lock()
+	defer linksMu.Unlock()
+
+	links := []*models.ShareLink{}
+	for _, link := range models.ShareLinks {
+		if (targetID == "" || link.TargetID == targetID) && canManageTarget(c, link.TargetType, link.TargetID) {
+			links = append(links, publicLink(link))
+		}
+	}
+
+	c.JSON(http.StatusOK, links)
+}
+
+// 创建分享链接
+func CreateShareLink(c *gin.Context) {
+	var req struct {
+		TargetType   string           `json:"targetType" binding:"required"`
+		TargetID     string           `json:"targetId" binding:"required"`
+		Password     string           `json:"password"`
+		ExpiresAt    string           `json:"expiresAt"`
+		MaxDownloads int              `json:"maxDownloads"`
+		IPFilter     *models.IPFilter `json:"ipFilter"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ipFilter, err := ipfilter.Normalize(req.IPFilter)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// 检查分享目标
+	switch req.TargetType {
+	case TargetFile:
+		if common.FindFile(req.TargetID) == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+			return
+		}
+	case TargetDirectory:
+		var targetDir *models.Directory
+		common.FindDirectory(models.Directories, req.TargetID, &targetDir)
+		if targetDir == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Directory not found"})
+			return
+		}
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid target type"})
+		return
+	}
+
+	if !canManageTarget(c, req.TargetType, req.TargetID) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "权限不足"})
+		return
+	}
+
+	if !validateLimits(c, req.ExpiresAt, req.MaxDownloads) {
+		return
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate share token"})
+		return
+	}
+
+	passwordHash, err := hashLinkPassword(req.Password)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash password"})
+		return
+	}
+
+	newLink := &models.ShareLink{
+		ID:           uuid.New().String(),
+		Token:        token,
+		TargetType:   req.TargetType,
+		TargetID:     req.TargetID,
+		Password:     passwordHash,
+		ExpiresAt:    req.ExpiresAt,
+		MaxDownloads: req.MaxDownloads,
+		CreatedAt:    time.Now().Format(timeLayout),
+		IPFilter:     ipFilter,
+	}
+
+	linksMu.Lock()
+	defer linksMu.Unlock()
+
+	models.ShareLinks = append(models.ShareLinks, newLink)
+
+	// 保存配置
+	if err := saveShareLinks(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save share link"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, publicLink(newLink))
+}
+
+// 更新分享链接的过期时间、下载次数限制、密码和IP访问限制
+func UpdateShareLink(c *gin.Context) {
+	id := c.Param("id")
+
+	var req struct {
+		Password     *string          `json:"password"`
+		ExpiresAt    *string          `json:"expiresAt"`
+		MaxDownloads *int             `json:"maxDownloads"`
+		IPFilter     *models.IPFilter `json:"ipFilter"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ipFilter, err := ipfilter.Normalize(req.IPFilter)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	linksMu.Lock()
+	defer linksMu.Unlock()
+
+	link := findLinkByID(id)
+	if link == nil || !canManageTarget(c, link.TargetType, link.TargetID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Share link not found"})
+		return
+	}
+
+	expiresAt, maxDownloads := link.ExpiresAt, link.MaxDownloads
+	if req.ExpiresAt != nil {
+		expiresAt = *req.ExpiresAt
+	}
+	if req.MaxDownloads != nil {
+		maxDownloads = *req.MaxDownloads
+	}
+	if !validateLimits(c, expiresAt, maxDownloads) {
+		return
+	}
+
+	if req.Password != nil {
+		passwordHash, err := hashLinkPassword(*req.Password)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash password"})
+			return
+		}
+		link.Password = passwordHash
+	}
+	link.ExpiresAt = expiresAt
+	link.MaxDownloads = maxDownloads
+	if req.IPFilter != nil {
+		link.IPFilter = ipFilter
+	}
+
+	// 保存配置
+	if err := saveShareLinks(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save share link"})
+		return
+	}
+
+	c.JSON(http.StatusOK, publicLink(link))
+}
+
+// 撤销分享链接
+func RevokeShareLink(c *gin.Context) {
+	id := c.Param("id")
+
+	linksMu.Lock()
+	defer linksMu.Unlock()
+
+	link := findLinkByID(id)
+	if link == nil || !canManageTarget(c, link.TargetType, link.TargetID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Share link not found"})
+		return
+	}
+
+	link.Revoked = true
+
+	// 保存配置
+	if err := saveShareLinks(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save share link"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Share link revoked successfully"})
+}
+
+// 删除分享链接
+func DeleteShareLink(c *gin.Context) {
+	id := c.Param("id")
+
+	linksMu.Lock()
+	defer linksMu.Unlock()
+
+	for i, link := range models.ShareLinks {
+		if link.ID == id && canManageTarget(c, link.TargetType, link.TargetID) {
+			models.ShareLinks = append(models.ShareLinks[:i], models.ShareLinks[i+1:]...)
+
+			// 保存配置
+			if err := saveShareLinks(); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save share link"})
+				return
+			}
+
+			c.JSON(http.StatusOK, gin.H{"message": "Share link deleted successfully"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusNotFound, gin.H{"error": "Share link not found"})
+}
+
+// 解析分享链接，返回分享的文件或目录内容
+func ResolveShareLink(c *gin.Context) {
+	link, ok := openLink(c)
+	if !ok {
+		return
+	}
+
+	result := gin.H{
+		"targetType":    link.TargetType,
+		"expiresAt":     link.ExpiresAt,
+		"maxDownloads":  link.MaxDownloads,
+		"downloadCount": link.DownloadCount,
+	}
+
+	if link.TargetType == TargetFile {
+		targetFile := common.FindFile(link.TargetID)
+		if targetFile == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+			return
+		}
+		result["file"] = targetFile
+		c.JSON(http.StatusOK, result)
+		return
+	}
+
+	targetDir := findLinkDirectory(c, link)
+	if targetDir == nil {
+		return
+	}
+
+	// 返回目录树及其中的文件
+	dirIDs := map[string]bool{}
+	collectDirectoryIDs(c, targetDir, dirIDs)
+	files := []*models.File{}
+	for _, f := range models.Files {
+		if dirIDs[f.DirectoryID] {
+			files = append(files, f)
+		}
+	}
+
+	result["directory"] = linkDirectoryTree(c, targetDir)
+	result["files"] = files
+	c.JSON(http.StatusOK, result)
+}
+
+// 通过分享链接下载：文件目标直接下载，目录目标下载归档
+func DownloadShareLink(c *gin.Context) {
+	link, ok := openLink(c)
+	if !ok {
+		return
+	}
+
+	if link.TargetType == TargetFile {
+		targetFile := common.FindFile(link.TargetID)
+		if targetFile == nil {
+			c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+			return
+		}
+		file.SendFileWith(c, targetFile, countDownload(c, link.ID))
+		return
+	}
+
+	targetDir := findLinkDirectory(c, link)
+	if targetDir == nil {
+		return
+	}
+	file.SendDirectoryArchive(c, targetDir, countDownload(c, link.ID))
+}
+
+// 通过目录分享链接下载其中的单个文件
+func DownloadShareLinkFile(c *gin.Context) {
+	link, ok := openLink(c)
+	if !ok {
+		return
+	}
+
+	if link.TargetType != TargetDirectory {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Share link does not target a directory"})
+		return
+	}
+
+	targetDir := findLinkDirectory(c, link)
+	if targetDir == nil {
+		return
+	}
+
+	// 文件必须位于分享目录树中
+	targetFile := common.FindFile(c.Param("fileId"))
+	dirIDs := map[string]bool{}
+	collectDirectoryIDs(c, targetDir, dirIDs)
+	if targetFile == nil || !dirIDs[targetFile.DirectoryID] {
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		return
+	}
+
+	file.SendFileWith(c, targetFile, countDownload(c, link.ID))
+}
+
+// 验证分享链接密码，签发访问令牌并写入Cookie，之后访问链接时携带令牌即可，
+// 密码不会出现在URL中（URL会被记录到访问日志）
+func VerifyShareLinkPassword(c *gin.Context) {
+	var req struct {
+		Password string `json:"password"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	link, ok := findOpenLink(c)
+	if !ok {
+		return
+	}
+	if link.Password == "" {
+		c.JSON(http.StatusOK, gin.H{"message": "Password verified successfully"})
+		return
+	}
+
+	// 按链接和客户端IP统计密码错误次数，多次错误后锁定，空密码不计入失败次数
+	lockoutKey := lockout.ScopedKey(c, "link:"+link.ID)
+	if !lockout.Guard(c, lockoutKey) {
+		return
+	}
+	if !utils.CheckPassword(link.Password, req.Password) {
+		if req.Password != "" {
+			lockout.Fail(lockoutKey)
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid password"})
+		return
+	}
+	lockout.Succeed(lockoutKey)
+
+	// 令牌绑定链接ID和当前密码，修改密码后旧令牌失效
+	token, expiresAt := utils.GenerateDirectoryToken(linkTokenScope(link), link.Password)
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(linkCookiePrefix+link.ID, token, int(utils.DirectoryTokenTTL.Seconds()), "/", "", proxy.IsHTTPS(c), true)
+	c.JSON(http.StatusOK, gin.H{
+		"message":   "Password verified successfully",
+		"token":     token,
+		"expiresAt": expiresAt.Format(timeLayout),
+	})
+}
+
+// 查找并检查分享链接，有密码的链接需要持有访问令牌，下载次数在开始发送时由countDownload计入
+func openLink(c *gin.Context) (*models.ShareLink, bool) {
+	link, ok := findOpenLink(c)
+	if !ok {
+		return nil, false
+	}
+
+	if link.Password != "" && !hasLinkAccess(c, link) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Share link password required", "requirePassword": true})
+		return nil, false
+	}
+	return link, true
+}
+
+// 查找并检查分享链接的有效期、IP限制和下载次数，不检查密码
+func findOpenLink(c *gin.Context) (*models.ShareLink, bool) {
+	token := c.Param("token")
+
+	linksMu.Lock()
+	defer linksMu.Unlock()
+
+	// 逐个比较全部链接，比较时间不依赖令牌内容
+	var link *models.ShareLink
+	for _, item := range models.ShareLinks {
+		if subtle.ConstantTimeCompare([]byte(item.Token), []byte(token)) == 1 {
+			link = item
+		}
+	}
+
+	if link == nil || link.Revoked {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Share link not found"})
+		return nil, false
+	}
+
+	if link.ExpiresAt != "" {
+		expiresAt, err := time.ParseInLocation(timeLayout, link.ExpiresAt, time.Local)
+		if err != nil || time.Now().After(expiresAt) {
+			c.JSON(http.StatusGone, gin.H{"error": "Share link has expired"})
+			return nil, false
+		}
+	}
+
+	// 同时检查链接和分享目标所在目录链的IP访问限制
+	if !ipfilter.Allows(link.IPFilter, c.ClientIP()) || !ipfilter.AllowsChain(linkTargetChain(link), c.ClientIP()) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Access from your network is not allowed"})
+		return nil, false
+	}
+
+	if link.MaxDownloads > 0 && link.DownloadCount >= link.MaxDownloads {
+		c.JSON(http.StatusGone, gin.H{"error": "Share link download limit reached"})
+		return nil, false
+	}
+
+	// 返回副本，避免在锁外读取时与其他请求冲突
+	copied := *link
+	return &copied, true
+}
+
+// 访问令牌的签名范围，和目录访问令牌区分
+func linkTokenScope(link *models.ShareLink) string {
+	return "link:" + link.ID
+}
+
+// 检查请求是否携带分享链接的访问令牌（Cookie、X-Link-Token请求头或linkToken查询参数）
+func hasLinkAccess(c *gin.Context, link *models.ShareLink) bool {
+	tokens := c.QueryArray("linkToken")
+	if cookie, err := c.Cookie(linkCookiePrefix + link.ID); err == nil {
+		tokens = append(tokens, cookie)
+	}
+	for _, header := range c.Request.Header.Values(LinkTokenHeader) {
+		tokens = append(tokens, strings.Split(header, ",")...)
+	}
+
+	for _, token := range tokens {
+		if utils.ValidateDirectoryToken(strings.TrimSpace(token), linkTokenScope(link), link.Password) {
+			return true
+		}
+	}
+	return false
+}
+
+// 返回在开始发送时计入下载次数的函数，达到下载次数限制（可能被并发的下载用完）时返回410
+func countDownload(c *gin.Context, linkID string) func() bool {
+	return func() bool {
+		linksMu.Lock()
+		defer linksMu.Unlock()
+
+		link := findLinkByID(linkID)
+		if link == nil || link.Revoked {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Share link not found"})
+			return false
+		}
+		if link.MaxDownloads > 0 && link.DownloadCount >= link.MaxDownloads {
+			c.JSON(http.StatusGone, gin.H{"error": "Share link download limit reached"})
+			return false
+		}
+
+		link.DownloadCount++
+		if err := saveShareLinks(); err != nil {
+			log.Printf("Failed to save share link: %v", err)
+		}
+		return true
+	}
+}
+
+// 查找分享链接指向的目录
+func findLinkDirectory(c *gin.Context, link *models.ShareLink) *models.Directory {
+	var targetDir *models.Directory
+	common.FindDirectory(models.Directories, link.TargetID, &targetDir)
+	if targetDir == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Directory not found"})
+	}
+	return targetDir
+}
+
+// 检查过期时间和下载次数限制
+func validateLimits(c *gin.Context, expiresAt string, maxDownloads int) bool {
+	if expiresAt != "" {
+		if _, err := time.ParseInLocation(timeLayout, expiresAt, time.Local); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid expiresAt, expected format 2006-01-02 15:04:05"})
+			return false
+		}
+	}
+	if maxDownloads < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "maxDownloads must not be negative"})
+		return false
+	}
+	return true
+}
+
+// 计算链接密码哈希，空密码表示不需要密码
+func hashLinkPassword(password string) (string, error) {
+	if password == "" {
+		return "", nil
+	}
+	return utils.HashPassword(password)
+}
+
+// 复制分享链接用于接口响应，去掉密码哈希
+func publicLink(link *models.ShareLink) *models.ShareLink {
+	copied := *link
+	copied.Password = ""
+	copied.HasPassword = link.Password != ""
+	return &copied
+}
+
+// 生成不可猜测的链接令牌
+func generateToken() (string, error) {
+	buf := make([]byte, 18)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// 根据ID查找分享链接（调用方需持有锁）
+func findLinkByID(id string) *models.ShareLink {
+	for _, link := range models.ShareLinks {
+		if link.ID == id {
+			return link
+		}
+	}
+	return nil
+}
+
+// 判断当前请求是否拥有分享目标所在目录的管理权限
+func canManageTarget(c *gin.Context, targetType, targetID string) bool {
+	dirID := targetID
+	if targetType == TargetFile {
+		f := common.FindFile(targetID)
+		if f == nil {
+			// 文件已被删除时按角色判断
+			return user.HasPermission(user.Current(c), user.PermManage)
+		}
+		dirID = f.DirectoryID
+	}
+	return acl.CanAccess(c, dirID, user.PermManage)
+}
+
+// 收集目录树中的所有目录ID，跳过不允许客户端IP访问的子目录
+func collectDirectoryIDs(c *gin.Context, dir *models.Directory, ids map[string]bool) {
+	ids[dir.ID] = true
+	for _, child := range dir.Children {
+		if access.IPAllowed(c, child) {
+			collectDirectoryIDs(c, child, ids)
+		}
+	}
+}
+
+// 复制分享的目录树用于接口响应，去掉不允许客户端IP访问的子目录
+func linkDirectoryTree(c *gin.Context, dir *models.Directory) *models.Directory {
+	copied := common.SanitizeDirectory(dir)
+	copied.Children = nil
+	for _, child := range dir.Children {
+		if access.IPAllowed(c, child) {
+			copied.Children = append(copied.Children, linkDirectoryTree(c, child))
+		}
+	}
+	return copied
+}
+
+// 获取分享目标所在的目录链，文件目标为文件所在目录的目录链
+func linkTargetChain(link *models.ShareLink) []*models.Directory {
+	dirID := link.TargetID
+	if link.TargetType == TargetFile {
+		f := common.FindFile(link.TargetID)
+		if f == nil {
+			return nil
+		}
+		dirID = f.DirectoryID
+	}
+	return common.FindDirectoryChain(models.Directories, dirID)
+}
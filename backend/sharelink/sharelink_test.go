@@ -0,0 +1,102 @@
+package sharelink
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/crypto/bcrypt"
+
+	"fileshare/models"
+)
+
+const testPassword = "secret"
+
+func testRouter() *gin.Engine {
+	r := gin.New()
+	r.GET("/links/:token", ResolveShareLink)
+	r.POST("/links/:token/verify", VerifyShareLinkPassword)
+	return r
+}
+
+// 发送请求，返回响应
+func serve(r *gin.Engine, method, target, body string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.RemoteAddr = "10.0.2.1:12345"
+	req.Header.Set("Content-Type", "application/json")
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestShareLinkPassword(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	savedDirs, savedFiles, savedLinks := models.Directories, models.Files, models.ShareLinks
+	defer func() { models.Directories, models.Files, models.ShareLinks = savedDirs, savedFiles, savedLinks }()
+	models.Directories = []*models.Directory{{ID: "dir", IsShared: true}}
+	models.Files = []*models.File{{ID: "file", DirectoryID: "dir", IsShared: true}}
+	models.ShareLinks = []*models.ShareLink{
+		{ID: "link1", Token: "token1", TargetType: TargetFile, TargetID: "file", Password: string(hash)},
+		{ID: "link2", Token: "token2", TargetType: TargetFile, TargetID: "file", Password: string(hash)},
+		{ID: "open", Token: "open", TargetType: TargetFile, TargetID: "file"},
+	}
+
+	r := testRouter()
+
+	// 验证密码获得访问令牌和Cookie
+	w := serve(r, http.MethodPost, "/links/token1/verify", `{"password":"`+testPassword+`"}`, nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("verify = %d, want 200: %s", w.Code, w.Body)
+	}
+	var verified struct {
+		Token string `json:"token"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &verified)
+	var cookie *http.Cookie
+	for _, item := range w.Result().Cookies() {
+		if item.Name == linkCookiePrefix+"link1" {
+			cookie = item
+		}
+	}
+	if verified.Token == "" || cookie == nil || cookie.Value != verified.Token || !cookie.HttpOnly {
+		t.Fatalf("token %q, cookie %+v", verified.Token, cookie)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		header http.Header
+		want   int
+	}{
+		{"没有令牌时需要密码", http.MethodGet, "/links/token1", "", nil, http.StatusForbidden},
+		{"不再接受查询参数中的密码", http.MethodGet, "/links/token1?password=" + testPassword, "", nil, http.StatusForbidden},
+		{"Cookie中的令牌", http.MethodGet, "/links/token1", "", http.Header{"Cookie": {cookie.String()}}, http.StatusOK},
+		{"请求头中的令牌", http.MethodGet, "/links/token1", "", http.Header{LinkTokenHeader: {verified.Token}}, http.StatusOK},
+		{"查询参数中的令牌", http.MethodGet, "/links/token1?linkToken=" + verified.Token, "", nil, http.StatusOK},
+		{"令牌只对签发的链接有效", http.MethodGet, "/links/token2", "", http.Header{LinkTokenHeader: {verified.Token}}, http.StatusForbidden},
+		{"没有密码的链接", http.MethodGet, "/links/open", "", nil, http.StatusOK},
+		{"空密码", http.MethodPost, "/links/token2/verify", `{"password":""}`, nil, http.StatusUnauthorized},
+		{"错误的密码", http.MethodPost, "/links/token2/verify", `{"password":"wrong"}`, nil, http.StatusUnauthorized},
+		{"不存在的链接", http.MethodPost, "/links/missing/verify", `{"password":"` + testPassword + `"}`, nil, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := serve(r, tt.method, tt.target, tt.body, tt.header); w.Code != tt.want {
+				t.Errorf("%s %s = %d, want %d: %s", tt.method, tt.target, w.Code, tt.want, w.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/acl"
+	"fileshare/apikey"
+	"fileshare/common"
+	"fileshare/models"
+	"fileshare/user"
+)
+
+// 配置文件路径
+const (
+	StatsConfigPath = "./config/config-stats.json"
+)
+
+// 下载统计的互斥锁（下载可能并发完成）
+var statsMu sync.Mutex
+
+// 统计变化后延迟保存，合并短时间内的多次下载，避免每次下载都重写整个文件
+const saveDelay = 5 * time.Second
+
+// 是否有未保存的变化，以及等待中的延迟保存
+var (
+	dirty     bool
+	saveTimer *time.Timer
+)
+
+// 目录统计结构
+type DirectoryStats struct {
+	DirectoryID        string              `json:"directoryId"`
+	FileCount          int                 `json:"fileCount"`
+	DownloadCount      int64               `json:"downloadCount"`
+	CompletedDownloads int64               `json:"completedDownloads"`
+	AbortedDownloads   int64               `json:"abortedDownloads"`
+	BytesServed        int64               `json:"bytesServed"`
+	LastDownloadTime   string              `json:"lastDownloadTime,omitempty"`
+	Files              []*models.FileStats `json:"files"`
+}
+
+// 加载下载统计
+func LoadStats() {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+
+	models.Stats = make(map[string]*models.FileStats)
+
+	data, err := os.ReadFile(StatsConfigPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Failed to read stats config: %v", err)
+		}
+		return
+	}
+
+	var list []*models.FileStats
+	if err := json.Unmarshal(data, &list); err != nil {
+		log.Printf("Failed to parse stats config: %v", err)
+		return
+	}
+
+	// 忽略已删除文件的统计
+	existing := make(map[string]bool, len(models.Files))
+	for _, file := range models.Files {
+		existing[file.ID] = true
+	}
+	for _, item := range list {
+		if existing[item.FileID] {
+			models.Stats[item.FileID] = item
+		}
+	}
+}
+
+// 保存下载统计（调用方需持有锁）
+func saveStats() error {
+	list := make([]*models.FileStats, 0, len(models.Stats))
+	for _, item := range models.Stats {
+		list = append(list, item)
+	}
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(StatsConfigPath, data, 0644)
+}
+
+// 延迟保存统计（调用方需持有锁）
+func scheduleSave() {
+	dirty = true
+	if saveTimer != nil {
+		return
+	}
+	saveTimer = time.AfterFunc(saveDelay, func() {
+		statsMu.Lock()
+		defer statsMu.Unlock()
+
+		saveTimer = nil
+		saveIfDirty()
+	})
+}
+
+// 有未保存的变化时保存统计（调用方需持有锁）
+func saveIfDirty() {
+	if !dirty {
+		return
+	}
+	dirty = false
+	if err := saveStats(); err != nil {
+		log.Printf("Failed to save stats: %v", err)
+	}
+}
+
+// Flush 立即保存尚未保存的统计，用于退出前。延迟保存可能已经触发但还在等待锁，
+// 因此不依赖计时器是否停止，只要有未保存的变化就保存
+func Flush() {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+
+	if saveTimer != nil {
+		saveTimer.Stop()
+		saveTimer = nil
+	}
+	saveIfDirty()
+}
+
+// 记录一次下载
+func RecordDownload(fileID string, bytesServed int64, completed bool) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+
+	if models.Stats == nil {
+		models.Stats = make(map[string]*models.FileStats)
+	}
+
+	item, exists := models.Stats[fileID]
+	if !exists {
+		item = &models.FileStats{FileID: fileID}
+		models.Stats[fileID] = item
+	}
+
+	item.DownloadCount++
+	item.BytesServed += bytesServed
+	item.LastDownloadTime = time.Now().Format("2006-01-02 15:04:05")
+	if completed {
+		item.CompletedDownloads++
+	} else {
+		item.AbortedDownloads++
+	}
+
+	scheduleSave()
+}
+
+// RecordResponse 根据已发送的下载响应记录统计。完整响应发送了全部内容，
+// 或Range响应发送到了文件末尾（断点续传的最后一段）时计为完整下载，其他视为中断
+func RecordResponse(c *gin.Context, fileID string, size int64) {
+	status := c.Writer.Status()
+	if status != http.StatusOK && status != http.StatusPartialContent {
+		return
+	}
+
+	written := max(int64(c.Writer.Size()), 0)
+	RecordDownload(fileID, written, isComplete(status, c.Writer.Header().Get("Content-Range"), written, size))
+}
+
+// 判断响应是否发送到了文件末尾，多段Range响应没有Content-Range头，视为未完成
+func isComplete(status int, contentRange string, written, size int64) bool {
+	switch status {
+	case http.StatusOK:
+		return written >= size
+	case http.StatusPartialContent:
+		var start, end, total int64
+		if _, err := fmt.Sscanf(contentRange, "bytes %d-%d/%d", &start, &end, &total); err != nil {
+			return false
+		}
+		return total == size && end == size-1 && written >= end-start+1
+	default:
+		return false
+	}
+}
+
+// 删除文件的下载统计
+func RemoveStats(fileIDs ...string) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+
+	changed := false
+	for _, fileID := range fileIDs {
+		if _, exists := models.Stats[fileID]; exists {
+			delete(models.Stats, fileID)
+			changed = true
+		}
+	}
+	if changed {
+		scheduleSave()
+	}
+}
+
+// 获取文件的下载统计
+func GetFileStats(c *gin.Context) {
+	id := c.Param("id")
+
+	fileFound := false
+	for _, file := range models.Files {
+		if file.ID == id {
+			fileFound = true
+			break
+		}
+	}
+
+	if !fileFound {
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		return
+	}
+
+	c.JSON(http.StatusOK, snapshot(id))
+}
+
+// 获取目录（包括子目录）的下载统计
+func GetDirectoryStats(c *gin.Context) {
+	id := c.Param("id")
+
+	var targetDir *models.Directory
+	common.FindDirectory(models.Directories, id, &targetDir)
+	if targetDir == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Directory not found"})
+		return
+	}
+
+	// 收集目录树中的所有目录ID
+	// 和目录列表相同，没有浏览权限的目录中的文件不计入，其下有浏览权限的子目录仍然计入（见acl.ListMode）
+	dirIDs := map[string]bool{}
+	u, k := user.Current(c), apikey.Current(c)
+	var collect func(dir *models.Directory)
+	collect = func(dir *models.Directory) {
+		switch acl.ListMode(u, k, dir, user.PermRead) {
+		case common.DirHidden:
+			return
+		case common.DirShown:
+			dirIDs[dir.ID] = true
+		}
+		for _, child := range dir.Children {
+			collect(child)
+		}
+	}
+	collect(targetDir)
+
+	result := DirectoryStats{DirectoryID: id, Files: []*models.FileStats{}}
+	for _, file := range models.Files {
+		if !dirIDs[file.DirectoryID] {
+			continue
+		}
+
+		item := snapshot(file.ID)
+		result.FileCount++
+		result.DownloadCount += item.DownloadCount
+		result.CompletedDownloads += item.CompletedDownloads
+		result.AbortedDownloads += item.AbortedDownloads
+		result.BytesServed += item.BytesServed
+		// 时间格式固定，可以直接按字符串比较
+		if item.LastDownloadTime > result.LastDownloadTime {
+			result.LastDownloadTime = item.LastDownloadTime
+		}
+		result.Files = append(result.Files, item)
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// 获取文件统计的副本，没有下载记录时返回空统计
+func snapshot(fileID string) *models.FileStats {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+
+	if item, exists := models.Stats[fileID]; exists {
+		copied := *item
+		return &copied
+	}
+	return &models.FileStats{FileID: fileID}
+}
@@ -0,0 +1,250 @@
+package stats
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/models"
+	"fileshare/user"
+)
+
+// 在临时目录中运行，统计保存到临时目录的config下
+func useTempDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "config"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+}
+
+// 清空统计和等待中的保存，测试结束后同样取消等待中的保存
+func resetStats(t *testing.T) {
+	reset := func() {
+		statsMu.Lock()
+		defer statsMu.Unlock()
+
+		if saveTimer != nil {
+			saveTimer.Stop()
+			saveTimer = nil
+		}
+		dirty = false
+		models.Stats = make(map[string]*models.FileStats)
+	}
+	reset()
+	t.Cleanup(reset)
+}
+
+func TestIsComplete(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		contentRange string
+		written      int64
+		want         bool
+	}{
+		{"完整响应", http.StatusOK, "", 10, true},
+		{"完整响应发送中断", http.StatusOK, "", 6, false},
+		{"续传到文件末尾", http.StatusPartialContent, "bytes 4-9/10", 6, true},
+		{"从头下载一段", http.StatusPartialContent, "bytes 0-4/10", 5, false},
+		{"续传到文件末尾但发送中断", http.StatusPartialContent, "bytes 4-9/10", 3, false},
+		{"只请求最后一个字节", http.StatusPartialContent, "bytes 9-9/10", 1, true},
+		{"多段响应没有Content-Range", http.StatusPartialContent, "", 10, false},
+		{"文件大小不一致", http.StatusPartialContent, "bytes 4-9/12", 6, false},
+		{"其他状态码", http.StatusNotModified, "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isComplete(tt.status, tt.contentRange, tt.written, 10); got != tt.want {
+				t.Errorf("isComplete(%d, %q, %d) = %v, want %v", tt.status, tt.contentRange, tt.written, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRecordResponse(t *testing.T) {
+	content := "0123456789"
+	tests := []struct {
+		name      string
+		rangeSpec string
+		completed int64
+		aborted   int64
+		bytes     int64
+	}{
+		{"完整下载", "", 1, 0, 10},
+		{"续传剩余部分", "bytes=4-", 1, 0, 6},
+		{"续传到指定的最后一个字节", "bytes=4-9", 1, 0, 6},
+		{"只下载开头", "bytes=0-4", 0, 1, 5},
+		{"下载最后几个字节", "bytes=-3", 1, 0, 3},
+		{"多段下载", "bytes=0-1,8-9", 0, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetStats(t)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.rangeSpec != "" {
+				c.Request.Header.Set("Range", tt.rangeSpec)
+			}
+			http.ServeContent(c.Writer, c.Request, "file.txt", time.Time{}, strings.NewReader(content))
+			RecordResponse(c, "file", int64(len(content)))
+
+			item := snapshot("file")
+			if item.DownloadCount != 1 || item.CompletedDownloads != tt.completed || item.AbortedDownloads != tt.aborted {
+				t.Errorf("stats = %+v, want completed %d aborted %d", item, tt.completed, tt.aborted)
+			}
+			// 多段响应包含分隔符，只检查单段响应的字节数
+			if tt.bytes > 0 && item.BytesServed != tt.bytes {
+				t.Errorf("BytesServed = %d, want %d", item.BytesServed, tt.bytes)
+			}
+		})
+	}
+}
+
+// 读取保存的统计
+func savedStats(t *testing.T) map[string]*models.FileStats {
+	data, err := os.ReadFile(StatsConfigPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var list []*models.FileStats
+	if err := json.Unmarshal(data, &list); err != nil {
+		t.Fatal(err)
+	}
+	result := map[string]*models.FileStats{}
+	for _, item := range list {
+		result[item.FileID] = item
+	}
+	return result
+}
+
+func TestFlush(t *testing.T) {
+	useTempDir(t)
+
+	tests := []struct {
+		name    string
+		prepare func()
+		saved   bool
+	}{
+		{"保存等待中的变化", func() {
+			RecordDownload("file", 10, true)
+		}, true},
+		{"延迟保存已触发但尚未保存", func() {
+			RecordDownload("file", 10, true)
+			// 计时器已经停止（Stop返回false），和计时器已触发、回调还在等待锁的情况相同
+			statsMu.Lock()
+			saveTimer.Stop()
+			statsMu.Unlock()
+		}, true},
+		{"没有变化时不保存", func() {}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resetStats(t)
+			os.Remove(StatsConfigPath)
+
+			tt.prepare()
+			Flush()
+
+			saved := savedStats(t)
+			if (saved != nil) != tt.saved {
+				t.Fatalf("saved = %v, want saved %v", saved, tt.saved)
+			}
+			if tt.saved && (saved["file"] == nil || saved["file"].CompletedDownloads != 1) {
+				t.Errorf("saved stats = %+v", saved["file"])
+			}
+		})
+	}
+}
+
+func TestGetDirectoryStats(t *testing.T) {
+	resetStats(t)
+
+	savedDirs, savedFiles := models.Directories, models.Files
+	defer func() { models.Directories, models.Files = savedDirs, savedFiles }()
+
+	// root
+	//   ├─ a（a.txt，只有bob可以浏览）
+	//   │   └─ a1（a1.txt，alice也可以浏览）
+	//   └─ b（b.txt）
+	a1 := &models.Directory{ID: "a1", ParentID: "a", ACL: []*models.ACLEntry{{Type: "user", Subject: "alice", Permissions: []string{"read"}}}}
+	a := &models.Directory{ID: "a", ParentID: "root", Children: []*models.Directory{a1},
+		ACL: []*models.ACLEntry{{Type: "user", Subject: "bob", Permissions: []string{"read"}}}}
+	b := &models.Directory{ID: "b", ParentID: "root"}
+	models.Directories = []*models.Directory{{ID: "root", Children: []*models.Directory{a, b}}}
+	models.Files = []*models.File{
+		{ID: "a.txt", DirectoryID: "a"},
+		{ID: "a1.txt", DirectoryID: "a1"},
+		{ID: "b.txt", DirectoryID: "b"},
+	}
+
+	RecordDownload("a.txt", 10, true)
+	RecordDownload("a.txt", 4, false)
+	RecordDownload("a1.txt", 20, true)
+	RecordDownload("b.txt", 30, true)
+
+	admin := &models.User{ID: "admin", Username: "admin", Role: user.RoleAdmin}
+	alice := &models.User{ID: "alice", Username: "alice", Role: user.RoleViewer}
+
+	tests := []struct {
+		name      string
+		user      *models.User
+		dirID     string
+		files     int
+		downloads int64
+		completed int64
+		aborted   int64
+		bytes     int64
+	}{
+		{"整个目录树", admin, "root", 3, 4, 3, 1, 64},
+		{"包含子目录", admin, "a", 2, 3, 2, 1, 34},
+		{"没有子目录", admin, "a1", 1, 1, 1, 0, 20},
+		{"其他目录", admin, "b", 1, 1, 1, 0, 30},
+		// 和目录列表相同，不能浏览的a不计入，其中可以浏览的a1仍然计入
+		{"跳过不能浏览的目录但计入其中可以浏览的子目录", alice, "root", 2, 2, 2, 0, 50},
+		{"不能浏览的目录本身", alice, "a", 1, 1, 1, 0, 20},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+			c.Params = gin.Params{{Key: "id", Value: tt.dirID}}
+			c.Set(user.ContextKey, tt.user)
+			GetDirectoryStats(c)
+
+			var got DirectoryStats
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.FileCount != tt.files || got.DownloadCount != tt.downloads || got.CompletedDownloads != tt.completed ||
+				got.AbortedDownloads != tt.aborted || got.BytesServed != tt.bytes {
+				t.Errorf("GetDirectoryStats(%s) = %+v", tt.dirID, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,254 @@
+package thumbnail
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+
+	"fileshare/config"
+	"fileshare/models"
+)
+
+// 缩略图尺寸档位（最长边像素）
+var Buckets = []int{64, 128, 256, 512, 1024}
+
+// 默认缩略图尺寸
+const DefaultSize = 256
+
+// 允许解码的最大像素数，防止解压炸弹
+const maxSourcePixels = 100 * 1000 * 1000
+
+// 缓存目录名称（位于文件存储路径下）
+const cacheDirName = ".thumbnails"
+
+// 支持生成缩略图的文件类型
+var supportedTypes = map[string]bool{
+	"jpg":  true,
+	"jpeg": true,
+	"png":  true,
+	"gif":  true,
+	"webp": true,
+}
+
+// ErrUnsupported 文件类型不支持生成缩略图
+var ErrUnsupported = errors.New("unsupported image type")
+
+// 生成中的缩略图，同一缩略图的并发请求等待第一个请求的结果，避免重复生成
+var (
+	inflightMu sync.Mutex
+	inflight   = map[string]*generation{}
+)
+
+// 一次进行中的缩略图生成
+type generation struct {
+	done chan struct{}
+	path string
+	err  error
+}
+
+// 生成缩略图，测试中可以替换
+var generateFunc = generate
+
+// IsSupported 判断文件是否支持生成缩略图
+func IsSupported(file *models.File) bool {
+	return supportedTypes[strings.ToLower(file.Type)]
+}
+
+// BucketFor 将请求的尺寸向上取整到最近的档位
+func BucketFor(size int) int {
+	if size <= 0 {
+		return DefaultSize
+	}
+	for _, bucket := range Buckets {
+		if size <= bucket {
+			return bucket
+		}
+	}
+	return Buckets[len(Buckets)-1]
+}
+
+// Get 获取文件的缩略图路径，缓存不存在时生成
+func Get(file *models.File, size int) (string, error) {
+	if !IsSupported(file) {
+		return "", ErrUnsupported
+	}
+
+	info, err := os.Stat(file.Path)
+	if err != nil {
+		return "", err
+	}
+
+	bucket := BucketFor(size)
+	cacheDir := CacheDir()
+	prefix := fmt.Sprintf("%s_%d_", file.ID, bucket)
+
+	// 缓存键包含文件路径、大小和修改时间，文件被替换后自动失效
+	sum := sha1.Sum([]byte(fmt.Sprintf("%s|%d|%d", file.Path, info.Size(), info.ModTime().UnixNano())))
+	key := prefix + hex.EncodeToString(sum[:8])
+
+	inflightMu.Lock()
+	if g, ok := inflight[key]; ok {
+		inflightMu.Unlock()
+		<-g.done
+		return g.path, g.err
+	}
+	g := &generation{done: make(chan struct{})}
+	inflight[key] = g
+	inflightMu.Unlock()
+
+	// 先从进行中的列表删除再通知等待者，之后的请求直接读取缓存或重新生成
+	defer func() {
+		inflightMu.Lock()
+		delete(inflight, key)
+		inflightMu.Unlock()
+		close(g.done)
+	}()
+
+	g.path, g.err = load(file.Path, cacheDir, prefix, key, bucket)
+	return g.path, g.err
+}
+
+// 读取缓存的缩略图，缓存不存在时清理同一档位的过期缩略图并生成
+func load(srcPath, cacheDir, prefix, key string, bucket int) (string, error) {
+	if cached := findCached(cacheDir, key); cached != "" {
+		return cached, nil
+	}
+
+	if err := os.MkdirAll(cacheDir, 0755); err != nil {
+		return "", err
+	}
+
+	// 清理同一档位的过期缩略图
+	removeMatching(filepath.Join(cacheDir, prefix+"*"))
+
+	return generateFunc(srcPath, filepath.Join(cacheDir, key), bucket)
+}
+
+// Invalidate 删除文件的所有缓存缩略图
+func Invalidate(fileID string) {
+	removeMatching(filepath.Join(CacheDir(), fileID+"_*"))
+}
+
+// CacheDir 缩略图缓存目录
+func CacheDir() string {
+	serverConfig := config.GetServerConfig()
+	return filepath.Join(serverConfig.Server.FilestorePath, cacheDirName)
+}
+
+// 查找已缓存的缩略图
+func findCached(cacheDir, key string) string {
+	for _, ext := range []string{".jpg", ".png"} {
+		path := filepath.Join(cacheDir, key+ext)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// 删除匹配的缓存文件
+func removeMatching(pattern string) {
+	matches, _ := filepath.Glob(pattern)
+	for _, match := range matches {
+		_ = os.Remove(match)
+	}
+}
+
+// 生成缩略图并写入缓存，返回缓存文件路径
+func generate(srcPath, dstBase string, bucket int) (string, error) {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	// 先读取图片尺寸，拒绝过大的图片
+	cfg, _, err := image.DecodeConfig(src)
+	if err != nil {
+		return "", ErrUnsupported
+	}
+	if cfg.Width*cfg.Height > maxSourcePixels {
+		return "", fmt.Errorf("image too large: %dx%d", cfg.Width, cfg.Height)
+	}
+	if _, err := src.Seek(0, 0); err != nil {
+		return "", err
+	}
+
+	img, _, err := image.Decode(src)
+	if err != nil {
+		return "", err
+	}
+
+	thumb := resize(img, bucket)
+
+	// 不透明图片使用JPEG，带透明通道的图片使用PNG
+	ext := ".jpg"
+	if !isOpaque(thumb) {
+		ext = ".png"
+	}
+	dstPath := dstBase + ext
+
+	tmp, err := os.CreateTemp(filepath.Dir(dstPath), ".tmp-*")
+	if err != nil {
+		return "", err
+	}
+	if ext == ".jpg" {
+		err = jpeg.Encode(tmp, thumb, &jpeg.Options{Quality: 82})
+	} else {
+		err = png.Encode(tmp, thumb)
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+
+	if err := os.Rename(tmp.Name(), dstPath); err != nil {
+		os.Remove(tmp.Name())
+		return "", err
+	}
+
+	return dstPath, nil
+}
+
+// 按最长边缩放图片，不放大
+func resize(img image.Image, bucket int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width <= bucket && height <= bucket {
+		return img
+	}
+
+	newWidth, newHeight := bucket, bucket
+	if width > height {
+		newHeight = max(1, height*bucket/width)
+	} else {
+		newWidth = max(1, width*bucket/height)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, newWidth, newHeight))
+	draw.BiLinear.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
+
+// 判断图片是否不透明
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	return false
+}
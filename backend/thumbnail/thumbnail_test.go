@@ -0,0 +1,199 @@
+package thumbnail
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"fileshare/models"
+)
+
+func TestBucketFor(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		want int
+	}{
+		{"未指定尺寸", 0, DefaultSize},
+		{"负数尺寸", -1, DefaultSize},
+		{"最小档位以下", 1, 64},
+		{"正好是档位", 128, 128},
+		{"向上取整", 129, 256},
+		{"最大档位", 1024, 1024},
+		{"超过最大档位", 5000, 1024},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BucketFor(tt.size); got != tt.want {
+				t.Errorf("BucketFor(%d) = %d, want %d", tt.size, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResize(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		bucket        int
+		wantW, wantH  int
+	}{
+		{"横向图片按宽度缩放", 1000, 500, 256, 256, 128},
+		{"纵向图片按高度缩放", 300, 1200, 64, 16, 64},
+		{"正方形图片", 512, 512, 128, 128, 128},
+		{"小图片不放大", 100, 50, 256, 100, 50},
+		{"正好等于档位不缩放", 256, 100, 256, 256, 100},
+		{"极窄的图片至少保留1像素", 10000, 2, 64, 64, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := image.NewRGBA(image.Rect(0, 0, tt.width, tt.height))
+			bounds := resize(img, tt.bucket).Bounds()
+			if bounds.Dx() != tt.wantW || bounds.Dy() != tt.wantH {
+				t.Errorf("resize(%dx%d, %d) = %dx%d, want %dx%d",
+					tt.width, tt.height, tt.bucket, bounds.Dx(), bounds.Dy(), tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+// 在临时目录中运行，缩略图缓存位于临时目录的static下
+func useTempDir(t *testing.T) string {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	return dir
+}
+
+// 写入测试图片
+func writeImage(t *testing.T, path string, width, height int, fill color.Color) {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, fill)
+		}
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if err := png.Encode(f, img); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// 统计生成次数，每次生成前等待一段时间，让并发的请求在生成期间到达
+func countGenerations(t *testing.T) *atomic.Int32 {
+	var count atomic.Int32
+	generateFunc = func(srcPath, dstBase string, bucket int) (string, error) {
+		count.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		return generate(srcPath, dstBase, bucket)
+	}
+	t.Cleanup(func() { generateFunc = generate })
+	return &count
+}
+
+func TestGet(t *testing.T) {
+	dir := useTempDir(t)
+
+	tests := []struct {
+		name    string
+		fill    color.Color
+		wantExt string
+	}{
+		{"不透明图片生成JPEG", color.RGBA{R: 255, A: 255}, ".jpg"},
+		{"透明图片生成PNG", color.RGBA{G: 128, A: 128}, ".png"},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count := countGenerations(t)
+			file := &models.File{ID: "file" + string(rune('a'+i)), Type: "png", Path: filepath.Join(dir, tt.name+".png")}
+			writeImage(t, file.Path, 800, 400, tt.fill)
+
+			// 并发请求同一缩略图只生成一次
+			const callers = 8
+			paths := make([]string, callers)
+			errs := make([]error, callers)
+			var wg sync.WaitGroup
+			for n := 0; n < callers; n++ {
+				wg.Add(1)
+				go func(n int) {
+					defer wg.Done()
+					paths[n], errs[n] = Get(file, 200)
+				}(n)
+			}
+			wg.Wait()
+
+			for n := 0; n < callers; n++ {
+				if errs[n] != nil || paths[n] != paths[0] {
+					t.Fatalf("Get() = %q, %v, want %q", paths[n], errs[n], paths[0])
+				}
+			}
+			if got := count.Load(); got != 1 {
+				t.Errorf("generated %d times, want 1", got)
+			}
+			if filepath.Ext(paths[0]) != tt.wantExt {
+				t.Errorf("thumbnail %s, want extension %s", paths[0], tt.wantExt)
+			}
+
+			// 之后的请求读取缓存
+			if path, err := Get(file, 256); err != nil || path != paths[0] || count.Load() != 1 {
+				t.Errorf("cached Get() = %q, %v after %d generations", path, err, count.Load())
+			}
+
+			// 文件被替换后重新生成并删除旧缩略图
+			later := time.Now().Add(time.Hour)
+			if err := os.Chtimes(file.Path, later, later); err != nil {
+				t.Fatal(err)
+			}
+			path, err := Get(file, 256)
+			if err != nil || path == paths[0] || count.Load() != 2 {
+				t.Errorf("Get() after change = %q, %v after %d generations", path, err, count.Load())
+			}
+			if _, err := os.Stat(paths[0]); !os.IsNotExist(err) {
+				t.Errorf("stale thumbnail %s not removed", paths[0])
+			}
+		})
+	}
+}
+
+func TestGetUnsupported(t *testing.T) {
+	dir := useTempDir(t)
+	path := filepath.Join(dir, "notes.txt")
+	if err := os.WriteFile(path, []byte("not an image"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		file *models.File
+	}{
+		{"不支持的文件类型", &models.File{ID: "txt", Type: "txt", Path: path}},
+		{"扩展名是图片但内容不是", &models.File{ID: "fake", Type: "png", Path: path}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Get(tt.file, 128); err != ErrUnsupported {
+				t.Errorf("Get() error = %v, want ErrUnsupported", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+// Package tlscert 负责HTTPS证书的加载、自签名证书的生成和证书热更新。
+//
+// 配置了证书文件时使用配置的证书；没有配置时在config目录下生成自签名证书，
+// 供局域网使用，重启后继续使用同一个证书（即将过期或本机名称、地址变化后重新生成）。
+// 证书文件被替换后，新的TLS连接会自动使用新证书，不需要重启服务。
+package tlscert
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 自签名证书文件路径
+const (
+	SelfSignedCertPath = "./config/tls-cert.pem"
+	SelfSignedKeyPath  = "./config/tls-key.pem"
+)
+
+// 自签名证书有效期，不超过825天（更长有效期的服务器证书不被macOS和iOS信任）
+const selfSignedValidity = 825 * 24 * time.Hour
+
+// 自签名证书在到期前多久重新生成
+const selfSignedRenewBefore = 30 * 24 * time.Hour
+
+// 检查证书文件是否变化的间隔
+const reloadCheckInterval = 10 * time.Second
+
+// Manager 管理当前使用的证书，证书文件变化后自动重新加载
+type Manager struct {
+	certFile   string
+	keyFile    string
+	selfSigned bool
+	hosts      []string
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	modTime   time.Time
+	lastCheck time.Time
+}
+
+// NewManager 创建证书管理器，certFile和keyFile都为空时使用自签名证书
+func NewManager(certFile, keyFile string, hosts []string) (*Manager, error) {
+	if (certFile == "") != (keyFile == "") {
+		return nil, errors.New("tls.certFile and tls.keyFile must be set together")
+	}
+
+	m := &Manager{certFile: certFile, keyFile: keyFile, hosts: hosts}
+	if certFile == "" {
+		m.certFile, m.keyFile, m.selfSigned = SelfSignedCertPath, SelfSignedKeyPath, true
+		if err := m.ensureSelfSigned(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := m.load(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// TLSConfig 返回使用该管理器提供证书的TLS配置
+func (m *Manager) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: m.GetCertificate,
+	}
+}
+
+// GetCertificate 返回当前证书，证书文件有变化时先重新加载
+func (m *Manager) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(m.lastCheck) >= reloadCheckInterval {
+		m.lastCheck = now
+		m.reloadIfChanged()
+	}
+	return m.cert, nil
+}
+
+// 证书文件变化时重新加载，自签名证书不再可用时重新生成（调用方需持有锁）
+func (m *Manager) reloadIfChanged() {
+	if m.selfSigned && m.cert != nil && m.cert.Leaf != nil && !selfSignedCurrent(m.cert.Leaf, certificateHosts(m.hosts), time.Now()) {
+		if err := m.generateSelfSigned(); err != nil {
+			log.Printf("Failed to renew self-signed certificate: %v", err)
+			return
+		}
+	}
+
+	modTime, err := latestModTime(m.certFile, m.keyFile)
+	if err != nil || !modTime.After(m.modTime) {
+		return
+	}
+	if err := m.loadLocked(); err != nil {
+		// 证书可能正在被替换，保留旧证书，下次检查时再加载
+		log.Printf("Failed to reload TLS certificate: %v", err)
+		return
+	}
+	log.Printf("TLS certificate reloaded from %s", m.certFile)
+}
+
+// 加载证书文件
+func (m *Manager) load() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.loadLocked()
+}
+
+// 加载证书文件（调用方需持有锁）
+func (m *Manager) loadLocked() error {
+	modTime, err := latestModTime(m.certFile, m.keyFile)
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(m.certFile, m.keyFile)
+	if err != nil {
+		return err
+	}
+	if cert.Leaf == nil {
+		if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+			return err
+		}
+	}
+
+	m.cert = &cert
+	m.modTime = modTime
+	m.lastCheck = time.Now()
+	return nil
+}
+
+// 自签名证书不存在、无法解析或不再可用时重新生成
+func (m *Manager) ensureSelfSigned() error {
+	cert, err := tls.LoadX509KeyPair(m.certFile, m.keyFile)
+	if err == nil {
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		if err == nil && selfSignedCurrent(leaf, certificateHosts(m.hosts), time.Now()) {
+			return nil
+		}
+	}
+	return m.generateSelfSigned()
+}
+
+// 自签名证书是否仍然可用：不在到期前的续期时间内、有效期不超过selfSignedValidity，
+// 并且包含的名称和地址与hosts完全一致
+func selfSignedCurrent(leaf *x509.Certificate, hosts []string, now time.Time) bool {
+	if now.Add(selfSignedRenewBefore).After(leaf.NotAfter) ||
+		leaf.NotAfter.Sub(leaf.NotBefore) > selfSignedValidity+time.Hour {
+		return false
+	}
+
+	names := map[string]bool{}
+	for _, name := range leaf.DNSNames {
+		names[strings.ToLower(name)] = true
+	}
+	for _, ip := range leaf.IPAddresses {
+		names[ip.String()] = true
+	}
+
+	expected := map[string]bool{}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			expected[ip.String()] = true
+		} else {
+			expected[strings.ToLower(host)] = true
+		}
+	}
+
+	if len(names) != len(expected) {
+		return false
+	}
+	for name := range expected {
+		if !names[name] {
+			return false
+		}
+	}
+	return true
+}
+
+// 生成自签名证书并保存到config目录
+func (m *Manager) generateSelfSigned() error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: "FileShare", Organization: []string{"FileShare self-signed"}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(selfSignedValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	for _, host := range certificateHosts(m.hosts) {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+
+	// 先写私钥再写证书，避免热更新时读到不匹配的证书和私钥
+	if err := os.WriteFile(m.keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(m.certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644); err != nil {
+		return err
+	}
+
+	log.Printf("Generated self-signed TLS certificate %s (valid until %s)", m.certFile, template.NotAfter.Format("2006-01-02"))
+	return nil
+}
+
+// 自签名证书包含的名称：本机名称、本机地址和配置的额外名称
+func certificateHosts(extra []string) []string {
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+	if hostname, err := os.Hostname(); err == nil && hostname != "" {
+		hosts = append(hosts, hostname)
+	}
+	if addrs, err := net.InterfaceAddrs(); err == nil {
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLoopback() && !ipNet.IP.IsLinkLocalUnicast() {
+				hosts = append(hosts, ipNet.IP.String())
+			}
+		}
+	}
+	hosts = append(hosts, extra...)
+
+	seen := map[string]bool{}
+	result := []string{}
+	for _, host := range hosts {
+		if host != "" && !seen[host] {
+			seen[host] = true
+			result = append(result, host)
+		}
+	}
+	return result
+}
+
+// 获取证书和私钥文件中较新的修改时间
+func latestModTime(files ...string) (time.Time, error) {
+	var latest time.Time
+	for _, name := range files {
+		info, err := os.Stat(name)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// RedirectHandler 返回把HTTP请求跳转到HTTPS端口的处理器
+func RedirectHandler(httpsPort int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(r.Host); err == nil {
+			host = h
+		}
+		host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+		if httpsPort != 443 {
+			host = net.JoinHostPort(host, strconv.Itoa(httpsPort))
+		} else if strings.Contains(host, ":") {
+			host = "[" + host + "]"
+		}
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusMovedPermanently)
+	})
+}
@@ -0,0 +1,76 @@
+package tlscert
+
+import (
+	"crypto/x509"
+	"net"
+	"testing"
+	"time"
+)
+
+func TestSelfSignedCurrent(t *testing.T) {
+	now := time.Now()
+	hosts := []string{"localhost", "127.0.0.1", "::1", "fileserver.lan", "192.168.1.10"}
+	leaf := func(validFor time.Duration, dnsNames []string, ips ...string) *x509.Certificate {
+		cert := &x509.Certificate{NotBefore: now.Add(-time.Hour), NotAfter: now.Add(validFor), DNSNames: dnsNames}
+		for _, ip := range ips {
+			cert.IPAddresses = append(cert.IPAddresses, net.ParseIP(ip))
+		}
+		return cert
+	}
+	dnsNames := []string{"localhost", "fileserver.lan"}
+
+	tests := []struct {
+		name string
+		leaf *x509.Certificate
+		want bool
+	}{
+		{"名称和地址一致", leaf(selfSignedValidity, dnsNames, "127.0.0.1", "::1", "192.168.1.10"), true},
+		{"名称不区分大小写和顺序", leaf(selfSignedValidity, []string{"FileServer.lan", "LOCALHOST"}, "192.168.1.10", "::1", "127.0.0.1"), true},
+		{"IPv4地址的不同写法", leaf(selfSignedValidity, dnsNames, "127.0.0.1", "0:0:0:0:0:0:0:1", "::ffff:192.168.1.10"), true},
+		{"本机地址变化", leaf(selfSignedValidity, dnsNames, "127.0.0.1", "::1", "192.168.1.11"), false},
+		{"缺少配置的名称", leaf(selfSignedValidity, []string{"localhost"}, "127.0.0.1", "::1", "192.168.1.10"), false},
+		{"多出不再使用的名称", leaf(selfSignedValidity, append(dnsNames, "old.lan"), "127.0.0.1", "::1", "192.168.1.10"), false},
+		{"已过期", leaf(-time.Minute, dnsNames, "127.0.0.1", "::1", "192.168.1.10"), false},
+		{"进入续期时间", leaf(selfSignedRenewBefore-time.Hour, dnsNames, "127.0.0.1", "::1", "192.168.1.10"), false},
+		{"有效期超过825天", leaf(10*365*24*time.Hour, dnsNames, "127.0.0.1", "::1", "192.168.1.10"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := selfSignedCurrent(tt.leaf, hosts, now); got != tt.want {
+				t.Errorf("selfSignedCurrent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateSelfSigned(t *testing.T) {
+	dir := t.TempDir()
+	m := &Manager{certFile: dir + "/cert.pem", keyFile: dir + "/key.pem", selfSigned: true, hosts: []string{"fileserver.lan"}}
+	if err := m.ensureSelfSigned(); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.load(); err != nil {
+		t.Fatal(err)
+	}
+
+	leaf := m.cert.Leaf
+	if validity := leaf.NotAfter.Sub(leaf.NotBefore); validity > 825*24*time.Hour+time.Hour {
+		t.Errorf("validity = %v, want at most 825 days", validity)
+	}
+	if !selfSignedCurrent(leaf, certificateHosts(m.hosts), time.Now()) {
+		t.Errorf("generated certificate is not current: %v %v", leaf.DNSNames, leaf.IPAddresses)
+	}
+
+	// hosts变化后重新生成
+	m.hosts = []string{"other.lan"}
+	if err := m.ensureSelfSigned(); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.load(); err != nil {
+		t.Fatal(err)
+	}
+	if m.cert.Leaf.SerialNumber.Cmp(leaf.SerialNumber) == 0 {
+		t.Error("certificate was not regenerated after hosts changed")
+	}
+}
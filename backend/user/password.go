@@ -0,0 +1,95 @@
+package user
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"unicode/utf8"
+
+	"fileshare/config"
+	"fileshare/models"
+	"fileshare/utils"
+)
+
+// MinPasswordLength 本地账号密码的最小长度（字符数）
+const MinPasswordLength = 8
+
+// ErrPasswordTooShort 新密码长度不足
+var ErrPasswordTooShort = fmt.Errorf("password must be at least %d characters", MinPasswordLength)
+
+// ValidatePassword 检查新密码是否满足长度要求
+func ValidatePassword(password string) error {
+	if utf8.RuneCountInString(password) < MinPasswordLength {
+		return ErrPasswordTooShort
+	}
+	return nil
+}
+
+// SetPassword 设置用户的新密码，并清除必须修改密码的标记
+func SetPassword(u *models.User, password string) error {
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	usersMu.Lock()
+	defer usersMu.Unlock()
+
+	u.Password = hash
+	u.MustChangePassword = false
+	return saveUsers()
+}
+
+// ResetPassword 重置用户密码（用于命令行恢复），密码为空时生成随机密码。
+// 重置后会启用该用户、注销其所有会话，并要求下次登录后修改密码
+func ResetPassword(username, password string) (string, error) {
+	if password == "" {
+		buf := make([]byte, 12)
+		if _, err := rand.Read(buf); err != nil {
+			return "", err
+		}
+		password = base64.RawURLEncoding.EncodeToString(buf)
+	}
+	if err := ValidatePassword(password); err != nil {
+		return "", err
+	}
+
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		return "", err
+	}
+
+	usersMu.Lock()
+	defer usersMu.Unlock()
+
+	// 用户名不区分大小写，和登录时一致
+	target := findByUsername(username)
+	if target == nil {
+		return "", fmt.Errorf("user %q not found", username)
+	}
+
+	target.Password = hash
+	target.Disabled = false
+	target.MustChangePassword = true
+	if err := saveUsers(); err != nil {
+		return "", err
+	}
+
+	utils.RevokeUserSessions(target.ID, "")
+	return password, nil
+}
+
+// 标记仍在使用默认密码的本地账号（调用方需持有锁），返回是否有变化
+func markDefaultPasswords() bool {
+	changed := false
+	for _, u := range models.Users {
+		if u.Provider != "" || u.MustChangePassword || u.Password == "" {
+			continue
+		}
+		if utils.CheckPassword(u.Password, config.DefaultManagePassword) {
+			u.MustChangePassword = true
+			changed = true
+		}
+	}
+	return changed
+}
@@ -0,0 +1,75 @@
+package user
+
+import (
+	"fileshare/models"
+)
+
+// 角色
+const (
+	RoleAdmin    = "admin"    // 管理员：所有权限，包括用户管理
+	RoleEditor   = "editor"   // 编辑：管理目录、文件和分享
+	RoleUploader = "uploader" // 上传者：浏览和上传文件
+	RoleViewer   = "viewer"   // 查看者：只能浏览和下载
+)
+
+// 权限
+const (
+	PermRead   = "read"   // 浏览和下载目录、文件
+	PermUpload = "upload" // 上传文件
+	PermManage = "manage" // 创建、修改、删除目录和文件，管理分享
+	PermAdmin  = "admin"  // 用户管理和系统管理
+)
+
+// 角色拥有的权限
+var rolePermissions = map[string][]string{
+	RoleAdmin:    {PermRead, PermUpload, PermManage, PermAdmin},
+	RoleEditor:   {PermRead, PermUpload, PermManage},
+	RoleUploader: {PermRead, PermUpload},
+	RoleViewer:   {PermRead},
+}
+
+// 角色从低到高的顺序
+var roleOrder = []string{RoleViewer, RoleUploader, RoleEditor, RoleAdmin}
+
+// HigherRole 返回两个角色中权限较高的一个，无效的角色视为最低
+func HigherRole(a, b string) string {
+	rank := func(role string) int {
+		for i, r := range roleOrder {
+			if r == role {
+				return i
+			}
+		}
+		return -1
+	}
+	if rank(b) > rank(a) {
+		return b
+	}
+	return a
+}
+
+// IsValidRole 判断角色是否有效
+func IsValidRole(role string) bool {
+	_, exists := rolePermissions[role]
+	return exists
+}
+
+// Permissions 获取用户拥有的权限
+func Permissions(u *models.User) []string {
+	if u == nil {
+		return []string{}
+	}
+	return append([]string{}, rolePermissions[u.Role]...)
+}
+
+// HasPermission 判断用户是否拥有指定权限
+func HasPermission(u *models.User, perm string) bool {
+	if u == nil {
+		return false
+	}
+	for _, p := range rolePermissions[u.Role] {
+		if p == perm {
+			return true
+		}
+	}
+	return false
+}
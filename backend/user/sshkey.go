@@ -0,0 +1,122 @@
+package user
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/ssh"
+
+	"fileshare/models"
+)
+
+// FindBySSHKey 根据用户名和SSH公钥查找用户，用户被禁用或公钥不属于该用户时返回nil
+func FindBySSHKey(username string, key ssh.PublicKey) *models.User {
+	u := FindByUsername(username)
+	if u == nil || u.Disabled {
+		return nil
+	}
+
+	usersMu.RLock()
+	defer usersMu.RUnlock()
+
+	marshaled := key.Marshal()
+	for _, k := range u.SSHKeys {
+		parsed, _, _, _, err := ssh.ParseAuthorizedKey([]byte(k.PublicKey))
+		if err == nil && bytes.Equal(parsed.Marshal(), marshaled) {
+			return u
+		}
+	}
+	return nil
+}
+
+// 获取当前用户的SSH公钥
+func GetSSHKeys(c *gin.Context) {
+	u := Current(c)
+
+	usersMu.RLock()
+	defer usersMu.RUnlock()
+
+	keys := u.SSHKeys
+	if keys == nil {
+		keys = []*models.SSHKey{}
+	}
+	c.JSON(http.StatusOK, keys)
+}
+
+// 为当前用户添加SSH公钥
+func AddSSHKey(c *gin.Context) {
+	var req struct {
+		Name      string `json:"name"`
+		PublicKey string `json:"publicKey" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	parsed, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(strings.TrimSpace(req.PublicKey)))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid SSH public key"})
+		return
+	}
+	if req.Name = strings.TrimSpace(req.Name); req.Name == "" {
+		req.Name = comment
+	}
+
+	u := Current(c)
+	fingerprint := ssh.FingerprintSHA256(parsed)
+
+	usersMu.Lock()
+	defer usersMu.Unlock()
+
+	for _, k := range u.SSHKeys {
+		if k.Fingerprint == fingerprint {
+			c.JSON(http.StatusConflict, gin.H{"error": "SSH public key already exists"})
+			return
+		}
+	}
+
+	newKey := &models.SSHKey{
+		ID:          uuid.New().String(),
+		Name:        req.Name,
+		PublicKey:   strings.TrimSpace(string(ssh.MarshalAuthorizedKey(parsed))),
+		Fingerprint: fingerprint,
+		CreatedAt:   time.Now().Format("2006-01-02 15:04:05"),
+	}
+	u.SSHKeys = append(u.SSHKeys, newKey)
+
+	if err := saveUsers(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save user"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, newKey)
+}
+
+// 删除当前用户的SSH公钥
+func DeleteSSHKey(c *gin.Context) {
+	id := c.Param("id")
+	u := Current(c)
+
+	usersMu.Lock()
+	defer usersMu.Unlock()
+
+	for i, k := range u.SSHKeys {
+		if k.ID == id {
+			u.SSHKeys = append(u.SSHKeys[:i], u.SSHKeys[i+1:]...)
+			if err := saveUsers(); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save user"})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"message": "SSH public key deleted successfully"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusNotFound, gin.H{"error": "SSH public key not found"})
+}
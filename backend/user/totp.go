@@ -0,0 +1,294 @@
+package user
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"fileshare/lockout"
+	"fileshare/models"
+	"fileshare/utils"
+)
+
+// 二维码中显示的签发者名称
+const totpIssuer = "FileShare"
+
+// 每次生成的恢复码数量
+const recoveryCodeCount = 10
+
+// 两步验证码请求
+type totpCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// VerifySecondFactor 校验用户的TOTP验证码或恢复码，恢复码使用后立即作废
+func VerifySecondFactor(u *models.User, code string) bool {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return false
+	}
+
+	usersMu.Lock()
+	if u.TOTPEnabled {
+		if step, ok := utils.ValidateTOTP(u.TOTPSecret, code, u.TOTPLastStep); ok {
+			u.TOTPLastStep = step
+			saveUsersLogged()
+			usersMu.Unlock()
+			return true
+		}
+	}
+	hashes := append([]string{}, u.RecoveryCodes...)
+	usersMu.Unlock()
+
+	// 恢复码使用bcrypt哈希，比较较慢，放在锁外进行
+	code = strings.ToLower(code)
+	for _, hash := range hashes {
+		if !utils.CheckPassword(hash, code) {
+			continue
+		}
+
+		usersMu.Lock()
+		defer usersMu.Unlock()
+		for i, existing := range u.RecoveryCodes {
+			if existing == hash {
+				u.RecoveryCodes = append(u.RecoveryCodes[:i], u.RecoveryCodes[i+1:]...)
+				saveUsersLogged()
+				return true
+			}
+		}
+		// 恢复码已被并发请求使用
+		return false
+	}
+
+	return false
+}
+
+// 保存用户配置并记录错误（调用方需持有锁）
+func saveUsersLogged() {
+	if err := saveUsers(); err != nil {
+		log.Printf("Failed to save user config: %v", err)
+	}
+}
+
+// 生成新的恢复码，返回明文和哈希
+func newRecoveryCodes() ([]string, []string, error) {
+	codes, err := utils.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hash, err := utils.HashPassword(code)
+		if err != nil {
+			return nil, nil, err
+		}
+		hashes = append(hashes, hash)
+	}
+	return codes, hashes, nil
+}
+
+// 开始启用两步验证，生成密钥和二维码URI，确认后才会生效
+func EnrollTOTP(c *gin.Context) {
+	u := Current(c)
+
+	if u.TOTPEnabled {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Two-factor authentication is already enabled"})
+		return
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate secret"})
+		return
+	}
+
+	usersMu.Lock()
+	defer usersMu.Unlock()
+
+	u.TOTPPending = secret
+	if err := saveUsers(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save user"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"secret": secret,
+		"uri":    utils.TOTPURI(totpIssuer, u.Username, secret),
+	})
+}
+
+// 确认启用两步验证，验证码正确后返回一次性恢复码
+func ConfirmTOTP(c *gin.Context) {
+	u := Current(c)
+
+	var req totpCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	lockoutKey := "totp:" + u.ID
+	if !lockout.Guard(c, lockoutKey) {
+		return
+	}
+
+	usersMu.RLock()
+	pending := u.TOTPPending
+	usersMu.RUnlock()
+
+	if pending == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Two-factor enrolment has not been started"})
+		return
+	}
+
+	step, ok := utils.ValidateTOTP(pending, req.Code, 0)
+	if !ok {
+		lockout.Fail(lockoutKey)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid verification code"})
+		return
+	}
+	lockout.Succeed(lockoutKey)
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate recovery codes"})
+		return
+	}
+
+	usersMu.Lock()
+	defer usersMu.Unlock()
+
+	u.TOTPEnabled = true
+	u.TOTPSecret = pending
+	u.TOTPPending = ""
+	u.TOTPLastStep = step
+	u.RecoveryCodes = hashes
+	if err := saveUsers(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save user"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "Two-factor authentication enabled",
+		"recoveryCodes": codes,
+	})
+}
+
+// 关闭两步验证，需要提供密码和验证码（或恢复码）
+func DisableTOTP(c *gin.Context) {
+	u := Current(c)
+
+	var req struct {
+		Password string `json:"password" binding:"required"`
+		Code     string `json:"code" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !u.TOTPEnabled {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Two-factor authentication is not enabled"})
+		return
+	}
+
+	lockoutKey := "totp:" + u.ID
+	if !lockout.Guard(c, lockoutKey) {
+		return
+	}
+	if !utils.CheckPassword(u.Password, req.Password) || !VerifySecondFactor(u, req.Code) {
+		lockout.Fail(lockoutKey)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid password or verification code"})
+		return
+	}
+	lockout.Succeed(lockoutKey)
+
+	usersMu.Lock()
+	defer usersMu.Unlock()
+
+	clearTOTP(u)
+	if err := saveUsers(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save user"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+}
+
+// 重新生成恢复码，原有恢复码全部作废
+func RegenerateRecoveryCodes(c *gin.Context) {
+	u := Current(c)
+
+	var req totpCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !u.TOTPEnabled {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Two-factor authentication is not enabled"})
+		return
+	}
+
+	lockoutKey := "totp:" + u.ID
+	if !lockout.Guard(c, lockoutKey) {
+		return
+	}
+	if !VerifySecondFactor(u, req.Code) {
+		lockout.Fail(lockoutKey)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid verification code"})
+		return
+	}
+	lockout.Succeed(lockoutKey)
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate recovery codes"})
+		return
+	}
+
+	usersMu.Lock()
+	defer usersMu.Unlock()
+
+	u.RecoveryCodes = hashes
+	if err := saveUsers(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save user"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"recoveryCodes": codes})
+}
+
+// 管理员重置用户的两步验证（用于丢失设备的情况）
+func ResetUserTOTP(c *gin.Context) {
+	id := c.Param("id")
+
+	usersMu.Lock()
+	defer usersMu.Unlock()
+
+	for _, u := range models.Users {
+		if u.ID == id {
+			clearTOTP(u)
+			if err := saveUsers(); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save user"})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication reset"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+}
+
+// 清除两步验证信息（调用方需持有锁）
+func clearTOTP(u *models.User) {
+	u.TOTPEnabled = false
+	u.TOTPSecret = ""
+	u.TOTPPending = ""
+	u.TOTPLastStep = 0
+	u.RecoveryCodes = nil
+}
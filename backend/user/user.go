@@ -0,0 +1,472 @@
+package user
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"fileshare/config"
+	"fileshare/models"
+	"fileshare/utils"
+)
+
+// 配置文件路径
+const (
+	UserConfigPath = "./config/config-user.json"
+)
+
+// 默认管理员用户名
+const DefaultAdminName = "admin"
+
+// 上下文中保存当前用户的键
+const ContextKey = "user"
+
+// 外部身份登录的错误
+var (
+	ErrUserDisabled  = errors.New("user is disabled")
+	ErrUsernameTaken = errors.New("username is already used by another account")
+)
+
+// 用户数据的读写锁
+var usersMu sync.RWMutex
+
+// 加载用户配置，没有用户时根据managePassword创建默认管理员
+func LoadUsers() {
+	usersMu.Lock()
+	defer usersMu.Unlock()
+
+	models.Users = []*models.User{}
+
+	data, err := os.ReadFile(UserConfigPath)
+	if err == nil {
+		if err := json.Unmarshal(data, &models.Users); err != nil {
+			log.Printf("Failed to parse user config: %v", err)
+			models.Users = []*models.User{}
+		}
+	} else if !os.IsNotExist(err) {
+		log.Printf("Failed to read user config: %v", err)
+	}
+
+	if len(models.Users) > 0 {
+		// 仍在使用默认密码的账号需要修改密码
+		if markDefaultPasswords() {
+			if err := saveUsers(); err != nil {
+				log.Printf("Failed to save user config: %v", err)
+			}
+		}
+		return
+	}
+
+	// 从单一管理密码迁移为默认管理员账号
+	password := string(config.GetServerConfig().Server.ManagePassword)
+	if password == "" {
+		password = config.DefaultManagePassword
+	}
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		log.Printf("Failed to create default admin: %v", err)
+		return
+	}
+	models.Users = append(models.Users, &models.User{
+		ID:        uuid.New().String(),
+		Username:  DefaultAdminName,
+		Password:  hash,
+		Role:      RoleAdmin,
+		CreatedAt: time.Now().Format("2006-01-02 15:04:05"),
+
+		MustChangePassword: password == config.DefaultManagePassword,
+	})
+
+	if err := saveUsers(); err != nil {
+		log.Printf("Failed to save user config: %v", err)
+	}
+}
+
+// 保存用户配置（调用方需持有锁）
+func saveUsers() error {
+	data, err := json.MarshalIndent(models.Users, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(UserConfigPath, data, 0600)
+}
+
+// Authenticate 校验用户名和密码，成功时返回用户
+func Authenticate(username, password string) *models.User {
+	u := FindByUsername(username)
+	if u == nil || u.Disabled || !utils.CheckPassword(u.Password, password) {
+		return nil
+	}
+	return u
+}
+
+// SyncExternalUser 根据外部身份查找或创建用户，并同步身份提供方映射的角色和用户组
+func SyncExternalUser(provider, subject, username, role string, groups []string) (*models.User, error) {
+	usersMu.Lock()
+	defer usersMu.Unlock()
+
+	groups = normalizeGroups(groups)
+	for _, u := range models.Users {
+		if u.Provider == provider && u.Subject == subject {
+			if u.Disabled {
+				return nil, ErrUserDisabled
+			}
+			// 角色或用户组变化后注销之前的会话，之前签发的会话不应保留旧的身份
+			if u.Role != role || !sameGroups(u.Groups, groups) {
+				utils.RevokeUserSessions(u.ID, "")
+			}
+			u.Role = role
+			u.Groups = groups
+			if err := saveUsers(); err != nil {
+				return nil, err
+			}
+			return u, nil
+		}
+	}
+
+	// 不自动关联同名的本地账号，避免外部身份接管本地账号
+	for _, u := range models.Users {
+		if strings.EqualFold(u.Username, username) {
+			return nil, ErrUsernameTaken
+		}
+	}
+
+	newUser := &models.User{
+		ID:        uuid.New().String(),
+		Username:  username,
+		Role:      role,
+		Groups:    groups,
+		CreatedAt: time.Now().Format("2006-01-02 15:04:05"),
+		Provider:  provider,
+		Subject:   subject,
+	}
+	models.Users = append(models.Users, newUser)
+
+	if err := saveUsers(); err != nil {
+		return nil, err
+	}
+	return newUser, nil
+}
+
+// FindByUsername 根据用户名查找用户
+func FindByUsername(username string) *models.User {
+	usersMu.RLock()
+	defer usersMu.RUnlock()
+
+	return findByUsername(username)
+}
+
+// 根据用户名查找用户，不区分大小写（调用方需持有锁）
+func findByUsername(username string) *models.User {
+	for _, u := range models.Users {
+		if strings.EqualFold(u.Username, username) {
+			return u
+		}
+	}
+	return nil
+}
+
+// FindByID 根据ID查找用户
+func FindByID(id string) *models.User {
+	usersMu.RLock()
+	defer usersMu.RUnlock()
+
+	for _, u := range models.Users {
+		if u.ID == id {
+			return u
+		}
+	}
+	return nil
+}
+
+// Current 获取当前请求的登录用户
+func Current(c *gin.Context) *models.User {
+	if value, exists := c.Get(ContextKey); exists {
+		if u, ok := value.(*models.User); ok {
+			return u
+		}
+	}
+	return nil
+}
+
+// Public 复制用户信息用于接口响应，去掉密码哈希
+func Public(u *models.User) *models.User {
+	copied := *u
+	copied.Password = ""
+	copied.TOTPSecret = ""
+	copied.TOTPPending = ""
+	copied.TOTPLastStep = 0
+	copied.RecoveryCodes = nil
+	return &copied
+}
+
+// 获取当前登录用户信息
+func GetCurrentUser(c *gin.Context) {
+	u := Current(c)
+	usersMu.RLock()
+	recoveryCodes := len(u.RecoveryCodes)
+	usersMu.RUnlock()
+
+	c.JSON(http.StatusOK, gin.H{
+		"user":          Public(u),
+		"permissions":   Permissions(u),
+		"recoveryCodes": recoveryCodes, // 剩余恢复码数量
+	})
+}
+
+// 获取用户列表
+func GetUsers(c *gin.Context) {
+	usersMu.RLock()
+	defer usersMu.RUnlock()
+
+	users := make([]*models.User, 0, len(models.Users))
+	for _, u := range models.Users {
+		users = append(users, Public(u))
+	}
+
+	c.JSON(http.StatusOK, users)
+}
+
+// 创建用户
+func CreateUser(c *gin.Context) {
+	var req struct {
+		Username string   `json:"username" binding:"required"`
+		Password string   `json:"password" binding:"required"`
+		Role     string   `json:"role" binding:"required"`
+		Groups   []string `json:"groups"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	req.Username = strings.TrimSpace(req.Username)
+	if req.Username == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Username is required"})
+		return
+	}
+	if !IsValidRole(req.Role) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role"})
+		return
+	}
+	if err := ValidatePassword(req.Password); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if FindByUsername(req.Username) != nil {
+		c.JSON(http.StatusConflict, gin.H{"error": "Username already exists"})
+		return
+	}
+
+	hash, err := utils.HashPassword(req.Password)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash password"})
+		return
+	}
+
+	newUser := &models.User{
+		ID:        uuid.New().String(),
+		Username:  req.Username,
+		Password:  hash,
+		Role:      req.Role,
+		Groups:    normalizeGroups(req.Groups),
+		CreatedAt: time.Now().Format("2006-01-02 15:04:05"),
+	}
+
+	usersMu.Lock()
+	defer usersMu.Unlock()
+
+	models.Users = append(models.Users, newUser)
+
+	// 保存配置
+	if err := saveUsers(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save user"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, Public(newUser))
+}
+
+// 更新用户的角色、用户组、密码和禁用状态
+func UpdateUser(c *gin.Context) {
+	id := c.Param("id")
+
+	var req struct {
+		Password *string   `json:"password"`
+		Role     *string   `json:"role"`
+		Disabled *bool     `json:"disabled"`
+		Groups   *[]string `json:"groups"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.Role != nil && !IsValidRole(*req.Role) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role"})
+		return
+	}
+
+	var passwordHash string
+	if req.Password != nil {
+		if err := ValidatePassword(*req.Password); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		hash, err := utils.HashPassword(*req.Password)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash password"})
+			return
+		}
+		passwordHash = hash
+	}
+
+	usersMu.Lock()
+	defer usersMu.Unlock()
+
+	var target *models.User
+	for _, u := range models.Users {
+		if u.ID == id {
+			target = u
+			break
+		}
+	}
+
+	if target == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	}
+
+	// 不能移除最后一个可用的管理员
+	demoted := req.Role != nil && *req.Role != RoleAdmin
+	disabled := req.Disabled != nil && *req.Disabled
+	if target.Role == RoleAdmin && !target.Disabled && (demoted || disabled) && activeAdminCount() <= 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot remove the last administrator"})
+		return
+	}
+
+	identityChanged := false
+	if req.Role != nil {
+		identityChanged = target.Role != *req.Role
+		target.Role = *req.Role
+	}
+	if req.Disabled != nil {
+		target.Disabled = *req.Disabled
+	}
+	if req.Groups != nil {
+		groups := normalizeGroups(*req.Groups)
+		identityChanged = identityChanged || !sameGroups(target.Groups, groups)
+		target.Groups = groups
+	}
+	if passwordHash != "" {
+		target.Password = passwordHash
+		// 管理员重置他人的密码后，该用户下次登录需要修改密码
+		if current := Current(c); current == nil || current.ID != target.ID {
+			target.MustChangePassword = true
+		}
+	}
+
+	// 修改密码、角色、用户组或禁用后注销该用户的所有会话
+	if passwordHash != "" || target.Disabled || identityChanged {
+		utils.RevokeUserSessions(target.ID, "")
+	}
+
+	// 保存配置
+	if err := saveUsers(); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save user"})
+		return
+	}
+
+	c.JSON(http.StatusOK, Public(target))
+}
+
+// 删除用户
+func DeleteUser(c *gin.Context) {
+	id := c.Param("id")
+
+	if current := Current(c); current != nil && current.ID == id {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot delete the current user"})
+		return
+	}
+
+	usersMu.Lock()
+	defer usersMu.Unlock()
+
+	for i, u := range models.Users {
+		if u.ID == id {
+			if u.Role == RoleAdmin && !u.Disabled && activeAdminCount() <= 1 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Cannot remove the last administrator"})
+				return
+			}
+
+			models.Users = append(models.Users[:i], models.Users[i+1:]...)
+			utils.RevokeUserSessions(id, "")
+
+			// 保存配置
+			if err := saveUsers(); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save user"})
+				return
+			}
+
+			c.JSON(http.StatusOK, gin.H{"message": "User deleted successfully"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+}
+
+// 统计可用的管理员数量（调用方需持有锁）
+func activeAdminCount() int {
+	count := 0
+	for _, u := range models.Users {
+		if u.Role == RoleAdmin && !u.Disabled {
+			count++
+		}
+	}
+	return count
+}
+
+// 去掉空白和重复的用户组
+func normalizeGroups(groups []string) []string {
+	result := []string{}
+	seen := map[string]bool{}
+	for _, group := range groups {
+		group = strings.TrimSpace(group)
+		if group != "" && !seen[group] {
+			seen[group] = true
+			result = append(result, group)
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// 判断两组用户组是否相同（不考虑顺序）
+func sameGroups(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	set := map[string]bool{}
+	for _, group := range a {
+		set[group] = true
+	}
+	for _, group := range b {
+		if !set[group] {
+			return false
+		}
+	}
+	return true
+}
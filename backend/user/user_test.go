@@ -0,0 +1,45 @@
+package user
+
+import "testing"
+
+func TestSameGroups(t *testing.T) {
+	tests := []struct {
+		a, b []string
+		want bool
+	}{
+		{nil, nil, true},
+		{nil, []string{}, true},
+		{[]string{"dev"}, []string{"dev"}, true},
+		{[]string{"dev", "ops"}, []string{"ops", "dev"}, true},
+		{[]string{"dev"}, nil, false},
+		{[]string{"dev"}, []string{"ops"}, false},
+		{[]string{"dev", "ops"}, []string{"dev"}, false},
+		{[]string{"Dev"}, []string{"dev"}, false},
+	}
+
+	for _, tt := range tests {
+		if got := sameGroups(tt.a, tt.b); got != tt.want {
+			t.Errorf("sameGroups(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestValidatePassword(t *testing.T) {
+	tests := []struct {
+		password string
+		valid    bool
+	}{
+		{"", false},
+		{"123456", false},
+		{"1234567", false},
+		{"12345678", true},
+		{"密码密码密码密码", true},
+		{"密码密码密码", false},
+	}
+
+	for _, tt := range tests {
+		if err := ValidatePassword(tt.password); (err == nil) != tt.valid {
+			t.Errorf("ValidatePassword(%q) = %v, want valid %v", tt.password, err, tt.valid)
+		}
+	}
+}
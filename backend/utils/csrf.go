@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// CSRFToken 生成登录会话对应的CSRF令牌，令牌由会话ID签名得到，会话失效后随之失效
+func CSRFToken(sessionID string) string {
+	mac := hmac.New(sha256.New, Secret())
+	mac.Write([]byte("csrf"))
+	mac.Write([]byte{0})
+	mac.Write([]byte(sessionID))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// ValidateCSRFToken 验证CSRF令牌是否属于登录会话
+func ValidateCSRFToken(sessionID, token string) bool {
+	return token != "" && hmac.Equal([]byte(token), []byte(CSRFToken(sessionID)))
+}
+
+// SessionCSRFToken 获取会话token对应的CSRF令牌，token无效时返回空字符串
+func SessionCSRFToken(token string) string {
+	sessionsMu.Lock()
+	s, exists := sessions[hashToken(token)]
+	sessionsMu.Unlock()
+
+	if !exists {
+		return ""
+	}
+	return CSRFToken(s.ID)
+}
@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 目录访问令牌有效期
+const DirectoryTokenTTL = 12 * time.Hour
+
+// GenerateDirectoryToken 生成目录访问令牌，令牌绑定目录ID和当前密码，修改密码后旧令牌失效
+func GenerateDirectoryToken(dirID, password string) (string, time.Time) {
+	expiresAt := time.Now().Add(DirectoryTokenTTL)
+	payload := base64.RawURLEncoding.EncodeToString([]byte(dirID)) + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	return payload + "." + signDirectoryToken(payload, password), expiresAt
+}
+
+// DirectoryTokenID 获取令牌所属的目录ID
+func DirectoryTokenID(token string) string {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	dirID, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return ""
+	}
+	return string(dirID)
+}
+
+// ValidateDirectoryToken 验证目录访问令牌
+func ValidateDirectoryToken(token, dirID, password string) bool {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || DirectoryTokenID(token) != dirID {
+		return false
+	}
+
+	expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return false
+	}
+
+	expected := signDirectoryToken(parts[0]+"."+parts[1], password)
+	return hmac.Equal([]byte(parts[2]), []byte(expected))
+}
+
+// 计算令牌签名
+func signDirectoryToken(payload, password string) string {
+	mac := hmac.New(sha256.New, Secret())
+	mac.Write([]byte(payload))
+	mac.Write([]byte{0})
+	mac.Write([]byte(password))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,96 @@
+package utils
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"sync"
+)
+
+// 服务器密钥文件路径
+const SecretPath = "./config/secret.key"
+
+var (
+	serverSecret     []byte
+	serverSecretOnce sync.Once
+)
+
+// Secret 获取服务器签名密钥，首次使用时生成并持久化，保证重启后签名仍然有效
+func Secret() []byte {
+	serverSecretOnce.Do(func() {
+		data, err := os.ReadFile(SecretPath)
+		if err == nil {
+			if secret, err := hex.DecodeString(strings.TrimSpace(string(data))); err == nil && len(secret) >= 32 {
+				serverSecret = secret
+				return
+			}
+		}
+
+		serverSecret = make([]byte, 32)
+		if _, err := rand.Read(serverSecret); err != nil {
+			log.Fatalf("Failed to generate server secret: %v", err)
+		}
+		if err := os.WriteFile(SecretPath, []byte(hex.EncodeToString(serverSecret)), 0600); err != nil {
+			log.Printf("Failed to save server secret: %v", err)
+		}
+	})
+
+	return serverSecret
+}
+
+// 加密使用的密钥，由服务器密钥派生，和签名使用的密钥分开
+func encryptionKey() []byte {
+	mac := hmac.New(sha256.New, Secret())
+	mac.Write([]byte("encrypt"))
+	return mac.Sum(nil)
+}
+
+// 创建AES-GCM加密器
+func newGCM() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(encryptionKey())
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Encrypt 使用服务器密钥加密需要保存明文的凭据（如S3私有访问密钥），返回base64编码的密文
+func Encrypt(plaintext string) (string, error) {
+	gcm, err := newGCM()
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(plaintext), nil)), nil
+}
+
+// Decrypt 解密Encrypt生成的密文，服务器密钥变化或密文被修改时返回错误
+func Decrypt(ciphertext string) (string, error) {
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := newGCM()
+	if err != nil {
+		return "", err
+	}
+	if len(data) < gcm.NonceSize() {
+		return "", errors.New("ciphertext too short")
+	}
+	plaintext, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,47 @@
+package utils
+
+import "testing"
+
+func TestEncryptDecrypt(t *testing.T) {
+	tests := []string{"", "secret", "9a8b7c6d5e4f3a2b1c0d9e8f7a6b5c4d3e2f1a0b", "中文"}
+
+	for _, plaintext := range tests {
+		encrypted, err := Encrypt(plaintext)
+		if err != nil {
+			t.Fatalf("Encrypt(%q) error = %v", plaintext, err)
+		}
+		if plaintext != "" && encrypted == plaintext {
+			t.Errorf("Encrypt(%q) returned the plaintext", plaintext)
+		}
+		got, err := Decrypt(encrypted)
+		if err != nil || got != plaintext {
+			t.Errorf("Decrypt(Encrypt(%q)) = %q, %v", plaintext, got, err)
+		}
+	}
+}
+
+func TestDecryptInvalid(t *testing.T) {
+	encrypted, err := Encrypt("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := []byte(encrypted)
+	tampered[len(tampered)/2] ^= 1
+
+	tests := []struct {
+		name       string
+		ciphertext string
+	}{
+		{"不是base64", "!!!"},
+		{"长度不足", "AAAA"},
+		{"被修改", string(tampered)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Decrypt(tt.ciphertext); err == nil {
+				t.Error("Decrypt() error = nil")
+			}
+		})
+	}
+}
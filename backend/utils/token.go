@@ -1,53 +1,288 @@
 package utils
 
 import (
-	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
+	"log"
+	"os"
+	"sort"
+	"sync"
 	"time"
 
 	"fileshare/config"
 )
 
-// 存储有效的token
-var validTokens = make(map[string]time.Time)
+// 会话配置文件路径
+const SessionConfigPath = "./config/config-session.json"
 
-// GenerateToken 生成管理员token
-func GenerateToken() string {
-	// 获取当前时间戳
-	timestamp := time.Now().Unix()
+// 默认会话有效期
+const defaultSessionTTL = 24 * time.Hour
 
-	// 获取管理密码
-	serverConfig := config.GetServerConfig()
-	password := serverConfig.Server.ManagePassword
+// 最近使用时间的持久化间隔，避免每个请求都写文件
+const lastUsedSaveInterval = time.Minute
 
-	// 生成token (密码 + 时间戳的MD5)
-	data := []byte(string(password) + string(timestamp))
-	hash := md5.Sum(data)
-	token := hex.EncodeToString(hash[:])
+// Session 登录会话，只保存token的哈希，不保存token本身
+type Session struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"userId"`
+	TokenHash  string    `json:"tokenHash"`
+	ClientIP   string    `json:"clientIp"`
+	UserAgent  string    `json:"userAgent"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastUsedAt time.Time `json:"lastUsedAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+// 存储有效的会话，键为token哈希
+var (
+	sessions   = make(map[string]*Session)
+	sessionsMu sync.Mutex
+	lastSaved  time.Time
+)
+
+// SessionTTL 获取会话有效期，可通过server.json的sessionTtlHours配置
+func SessionTTL() time.Duration {
+	hours := config.GetServerConfig().Server.SessionTTLHours
+	if hours <= 0 {
+		return defaultSessionTTL
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// LoadTokens 加载持久化的会话，丢弃已过期的会话
+func LoadTokens() {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+
+	sessions = make(map[string]*Session)
+
+	data, err := os.ReadFile(SessionConfigPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Failed to read session config: %v", err)
+		}
+		return
+	}
+
+	var list []*Session
+	if err := json.Unmarshal(data, &list); err != nil {
+		log.Printf("Failed to parse session config: %v", err)
+		return
+	}
+
+	now := time.Now()
+	for _, s := range list {
+		if s.TokenHash != "" && now.Before(s.ExpiresAt) {
+			sessions[s.TokenHash] = s
+		}
+	}
+
+	if len(sessions) != len(list) {
+		saveSessions()
+	}
+}
+
+// 保存会话（调用方需持有锁）
+func saveSessions() {
+	list := make([]*Session, 0, len(sessions))
+	for _, s := range sessions {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].CreatedAt.Before(list[j].CreatedAt)
+	})
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err == nil {
+		err = os.WriteFile(SessionConfigPath, data, 0600)
+	}
+	if err != nil {
+		log.Printf("Failed to save session config: %v", err)
+	}
+	lastSaved = time.Now()
+}
+
+// 计算token的哈希
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// 生成指定字节数的随机字符串
+func randomString(n int) string {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		log.Fatalf("Failed to generate random token: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf)
+}
+
+// 清理过期会话（调用方需持有锁），返回是否有会话被清理
+func cleanupSessions(now time.Time) bool {
+	removed := false
+	for hash, s := range sessions {
+		if now.After(s.ExpiresAt) {
+			delete(sessions, hash)
+			removed = true
+		}
+	}
+	return removed
+}
+
+// GenerateToken 为用户生成随机的管理token并保存会话
+func GenerateToken(userID, clientIP, userAgent string) string {
+	token := randomString(32)
+	now := time.Now()
+
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
 
-	// 存储token，有效期24小时
-	validTokens[token] = time.Now().Add(24 * time.Hour)
+	cleanupSessions(now)
+	sessions[hashToken(token)] = &Session{
+		ID:         randomString(12),
+		UserID:     userID,
+		TokenHash:  hashToken(token),
+		ClientIP:   clientIP,
+		UserAgent:  userAgent,
+		CreatedAt:  now,
+		LastUsedAt: now,
+		ExpiresAt:  now.Add(SessionTTL()),
+	}
+	saveSessions()
 
 	return token
 }
 
-// ValidateToken 验证token是否有效
-func ValidateToken(token string) bool {
-	expiry, exists := validTokens[token]
+// ValidateToken 验证token是否有效，返回token对应的会话
+func ValidateToken(token string) (Session, bool) {
+	hash := hashToken(token)
+	now := time.Now()
+
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+
+	s, exists := sessions[hash]
 	if !exists {
-		return false
+		return Session{}, false
 	}
 
 	// 检查token是否过期
-	if time.Now().After(expiry) {
-		delete(validTokens, token)
-		return false
+	if now.After(s.ExpiresAt) {
+		delete(sessions, hash)
+		saveSessions()
+		return Session{}, false
+	}
+
+	s.LastUsedAt = now
+	if now.Sub(lastSaved) > lastUsedSaveInterval {
+		saveSessions()
 	}
 
-	return true
+	return *s, true
+}
+
+// RefreshToken 用有效的token换取新token，旧token立即失效
+func RefreshToken(token string) (string, time.Time, bool) {
+	hash := hashToken(token)
+	now := time.Now()
+
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+
+	s, exists := sessions[hash]
+	if !exists || now.After(s.ExpiresAt) {
+		return "", time.Time{}, false
+	}
+
+	newToken := randomString(32)
+	delete(sessions, hash)
+	s.TokenHash = hashToken(newToken)
+	s.LastUsedAt = now
+	s.ExpiresAt = now.Add(SessionTTL())
+	sessions[s.TokenHash] = s
+	saveSessions()
+
+	return newToken, s.ExpiresAt, true
 }
 
 // InvalidateToken 使token失效
 func InvalidateToken(token string) {
-	delete(validTokens, token)
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+
+	hash := hashToken(token)
+	if _, exists := sessions[hash]; exists {
+		delete(sessions, hash)
+		saveSessions()
+	}
+}
+
+// ListSessions 获取会话列表，userID为空时返回所有用户的会话
+func ListSessions(userID string) []Session {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+
+	if cleanupSessions(time.Now()) {
+		saveSessions()
+	}
+
+	list := []Session{}
+	for _, s := range sessions {
+		if userID == "" || s.UserID == userID {
+			list = append(list, *s)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].LastUsedAt.After(list[j].LastUsedAt)
+	})
+	return list
+}
+
+// FindSession 根据会话ID查找会话
+func FindSession(id string) (Session, bool) {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+
+	for _, s := range sessions {
+		if s.ID == id {
+			return *s, true
+		}
+	}
+	return Session{}, false
+}
+
+// RevokeSession 根据会话ID注销会话
+func RevokeSession(id string) bool {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+
+	for hash, s := range sessions {
+		if s.ID == id {
+			delete(sessions, hash)
+			saveSessions()
+			return true
+		}
+	}
+	return false
+}
+
+// RevokeUserSessions 注销用户的所有会话，exceptID不为空时保留该会话
+func RevokeUserSessions(userID, exceptID string) int {
+	sessionsMu.Lock()
+	defer sessionsMu.Unlock()
+
+	count := 0
+	for hash, s := range sessions {
+		if s.UserID == userID && s.ID != exceptID {
+			delete(sessions, hash)
+			count++
+		}
+	}
+	if count > 0 {
+		saveSessions()
+	}
+	return count
 }
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+// RFC 6238附录B的SHA1测试向量（取8位验证码的后6位）
+func TestTOTPCodeRFC6238(t *testing.T) {
+	key := []byte("12345678901234567890")
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tt := range tests {
+		if got := totpCode(key, tt.unix/totpPeriod); got != tt.want {
+			t.Errorf("totpCode(T=%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	key := []byte("12345678901234567890")
+	secret := totpEncoding.EncodeToString(key)
+	current := time.Now().Unix() / totpPeriod
+
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		lastStep int64
+		want     bool
+	}{
+		{"当前窗口", secret, totpCode(key, current), 0, true},
+		{"前一个窗口", secret, totpCode(key, current-1), 0, true},
+		{"后一个窗口", secret, totpCode(key, current+1), 0, true},
+		{"超出允许的偏差", secret, totpCode(key, current-3), 0, false},
+		{"前后带空格", secret, " " + totpCode(key, current) + " ", 0, true},
+		{"小写密钥", "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", totpCode(key, current), 0, true},
+		{"重放已使用的窗口", secret, totpCode(key, current-1), current + 1, false},
+		{"位数不对", secret, totpCode(key, current)[:5], 0, false},
+		{"无效的密钥", "not base32!", totpCode(key, current), 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := ValidateTOTP(tt.secret, tt.code, tt.lastStep)
+			if ok != tt.want {
+				t.Fatalf("ValidateTOTP() = %v, want %v", ok, tt.want)
+			}
+			if ok && step <= tt.lastStep {
+				t.Errorf("step %d not after lastStep %d", step, tt.lastStep)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package vfs
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"time"
+
+	"fileshare/file"
+	"fileshare/models"
+)
+
+// 目录没有修改时间，使用服务启动时间
+var startTime = time.Now()
+
+// ErrAborted 上传被放弃（例如客户端中断）
+var ErrAborted = errors.New("upload aborted")
+
+// FileInfo 目录或文件的信息，实现os.FileInfo
+type FileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	dir     bool
+}
+
+// NewFileInfo 根据节点生成文件信息，文件使用实际的大小和修改时间
+func NewFileInfo(n *Node) *FileInfo {
+	if n.IsDir() {
+		return &FileInfo{name: n.Name, modTime: startTime, dir: true}
+	}
+
+	info := &FileInfo{name: n.Name, size: n.File.Size, modTime: startTime}
+	if stat, err := os.Stat(n.File.Path); err == nil {
+		info.size, info.modTime = stat.Size(), stat.ModTime()
+	} else if addTime, err := time.ParseInLocation("2006-01-02 15:04:05", n.File.AddTime, time.Local); err == nil {
+		info.modTime = addTime
+	}
+	return info
+}
+
+func (fi *FileInfo) Name() string       { return fi.name }
+func (fi *FileInfo) Size() int64        { return fi.size }
+func (fi *FileInfo) ModTime() time.Time { return fi.modTime }
+func (fi *FileInfo) IsDir() bool        { return fi.dir }
+func (fi *FileInfo) Sys() interface{}   { return nil }
+
+func (fi *FileInfo) Mode() fs.FileMode {
+	if fi.dir {
+		return fs.ModeDir | 0755
+	}
+	return 0644
+}
+
+// Writer 上传中的文件，写入的内容通过管道交给文件存储，Close时保存文件记录
+type Writer struct {
+	info    *FileInfo
+	pw      *io.PipeWriter
+	done    chan error
+	written int64
+}
+
+// 开始上传文件，existing不为nil时替换已有文件的内容
+func newWriter(parent *models.Directory, name string, existing *models.File) *Writer {
+	pr, pw := io.Pipe()
+	w := &Writer{
+		info: &FileInfo{name: name, modTime: time.Now()},
+		pw:   pw,
+		done: make(chan error, 1),
+	}
+
+	go func() {
+		var err error
+		if existing != nil {
+			err = file.ReplaceFile(existing, pr)
+		} else {
+			_, err = file.StoreFile(parent.ID, name, pr)
+		}
+		// 保存失败时让后续的写入返回错误
+		pr.CloseWithError(err)
+		w.done <- err
+	}()
+
+	return w
+}
+
+func (w *Writer) Write(p []byte) (int, error) {
+	n, err := w.pw.Write(p)
+	w.written += int64(n)
+	return n, err
+}
+
+// Written 已写入的字节数
+func (w *Writer) Written() int64 {
+	return w.written
+}
+
+// Stat 获取上传中文件的信息
+func (w *Writer) Stat() *FileInfo {
+	info := *w.info
+	info.size = w.written
+	return &info
+}
+
+// Close 结束上传，保存文件和文件记录
+func (w *Writer) Close() error {
+	w.pw.Close()
+	if err := <-w.done; err != nil {
+		return err
+	}
+	return file.SaveFiles()
+}
+
+// Abort 放弃上传，已写入的内容会被删除
+func (w *Writer) Abort() {
+	w.pw.CloseWithError(ErrAborted)
+	<-w.done
+}
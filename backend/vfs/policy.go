@@ -0,0 +1,93 @@
+package vfs
+
+import (
+	"fileshare/acl"
+	"fileshare/common"
+	"fileshare/models"
+	"fileshare/user"
+)
+
+// ErrNoPermission 权限不足
+const ErrNoPermission = ForbiddenError("权限不足")
+
+// ManagePolicy 管理端：按目录访问控制检查权限（见acl包），和管理API相同
+type ManagePolicy struct {
+	User *models.User
+	Key  *models.APIKey // 使用API密钥登录时的密钥
+}
+
+// ShowDir 显示有浏览权限的目录，其他目录中可以浏览的子目录提升到上级目录中（和管理API相同）
+func (p *ManagePolicy) ShowDir(dir *models.Directory) common.DirMode {
+	return acl.ListMode(p.User, p.Key, dir, user.PermRead)
+}
+
+func (p *ManagePolicy) OpenDir(*models.Directory) bool {
+	return true
+}
+
+func (p *ManagePolicy) ShowFile(f *models.File) bool {
+	return acl.Check(p.User, p.Key, f.DirectoryID, user.PermRead)
+}
+
+// CanMkdir 创建目录需要上级目录的管理权限，根目录按角色检查
+func (p *ManagePolicy) CanMkdir(parent *models.Directory) error {
+	allowed := false
+	if parent == nil {
+		allowed = acl.CheckRole(p.User, p.Key, user.PermManage)
+	} else {
+		allowed = acl.Check(p.User, p.Key, parent.ID, user.PermManage)
+	}
+	if !allowed {
+		return ErrNoPermission
+	}
+	return nil
+}
+
+// CanPut 上传需要上传权限，覆盖已有文件需要管理权限
+func (p *ManagePolicy) CanPut(parent *models.Directory, existing *models.File) error {
+	if parent.DirType == "link" {
+		return ForbiddenError("链接型目录不能直接上传文件")
+	}
+
+	perm := user.PermUpload
+	if existing != nil {
+		perm = user.PermManage
+	}
+	if !acl.Check(p.User, p.Key, parent.ID, perm) {
+		return ErrNoPermission
+	}
+	return nil
+}
+
+// CanRemove 删除文件需要所在目录的管理权限，删除目录需要整个子树的管理权限
+func (p *ManagePolicy) CanRemove(n *Node) error {
+	allowed := false
+	if n.IsDir() {
+		allowed = acl.CheckTree(p.User, p.Key, n.Dir, user.PermManage)
+	} else {
+		allowed = acl.Check(p.User, p.Key, n.File.DirectoryID, user.PermManage)
+	}
+	if !allowed {
+		return ErrNoPermission
+	}
+	return nil
+}
+
+// CanMove 移动需要删除原位置的权限，以及在新位置创建的权限
+func (p *ManagePolicy) CanMove(n *Node, parent *models.Directory) error {
+	if err := p.CanRemove(n); err != nil {
+		return err
+	}
+	if n.IsDir() {
+		return p.CanMkdir(parent)
+	}
+
+	// 链接型目录中的文件指向外部路径，不能和存储型目录互相移动
+	if (n.Parent.DirType == "link") != (parent.DirType == "link") {
+		return ForbiddenError("不能在链接型目录和存储型目录之间移动文件")
+	}
+	if !acl.Check(p.User, p.Key, parent.ID, user.PermUpload) {
+		return ErrNoPermission
+	}
+	return nil
+}
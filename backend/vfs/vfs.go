@@ -0,0 +1,294 @@
+// Package vfs 把目录树和文件记录映射为按路径访问的虚拟文件系统，供WebDAV和SFTP使用。
+//
+// 路径由目录名和文件名组成，同一目录下重名的目录或文件会在名称后加上序号区分。
+// 根目录下只有目录；可以看到哪些目录和文件、是否允许修改由Policy决定。
+// 不显示的目录中可以显示的子目录提升到上级目录中，和管理API、共享API返回的目录树一致。
+// 创建目录、上传、删除和管理API使用相同的存储逻辑（见directory和file包）。
+package vfs
+
+import (
+	"os"
+	"path"
+	"strings"
+
+	"fileshare/common"
+	"fileshare/directory"
+	"fileshare/file"
+	"fileshare/models"
+)
+
+// Node 路径对应的目录或文件，根目录的Dir和File都为nil
+type Node struct {
+	Name   string
+	Dir    *models.Directory
+	File   *models.File
+	Parent *models.Directory // 所在的目录，位于根目录下时为nil
+}
+
+// IsDir 是否为目录（包括根目录）
+func (n *Node) IsDir() bool {
+	return n.File == nil
+}
+
+// IsRoot 是否为根目录
+func (n *Node) IsRoot() bool {
+	return n.Dir == nil && n.File == nil
+}
+
+// Policy 决定可以看到哪些目录和文件，以及是否允许修改
+type Policy interface {
+	// ShowDir 目录在上级目录列表中的显示方式
+	ShowDir(dir *models.Directory) common.DirMode
+	// OpenDir 是否可以查看目录中的内容
+	OpenDir(dir *models.Directory) bool
+	// ShowFile 文件是否出现在目录的列表中
+	ShowFile(f *models.File) bool
+
+	// 修改操作的权限检查，返回nil表示允许，parent为nil表示根目录
+	CanMkdir(parent *models.Directory) error
+	CanPut(parent *models.Directory, existing *models.File) error
+	CanRemove(n *Node) error
+	CanMove(n *Node, parent *models.Directory) error
+}
+
+// ForbiddenError 不允许操作时返回的错误，errors.Is(err, os.ErrPermission)为true
+type ForbiddenError string
+
+func (e ForbiddenError) Error() string {
+	return string(e)
+}
+
+func (e ForbiddenError) Is(target error) bool {
+	return target == os.ErrPermission
+}
+
+// 根目录的限制
+const (
+	ErrRootFiles    = ForbiddenError("根目录下不能存放文件")
+	ErrRootReadOnly = ForbiddenError("不能删除或移动根目录")
+)
+
+// FS 按Policy访问目录树，通常每个请求或连接创建一个
+type FS struct {
+	Policy Policy
+}
+
+// Children 列出目录中的子目录和文件，dir为nil表示根目录（根目录下只有目录）
+func (fs *FS) Children(dir *models.Directory) []*Node {
+	nodes := []*Node{}
+	usedNames := map[string]bool{}
+
+	subdirs := models.Directories
+	if dir != nil {
+		if !fs.Policy.OpenDir(dir) {
+			return nodes
+		}
+		subdirs = dir.Children
+	}
+
+	for _, child := range subdirs {
+		nodes = fs.appendDir(nodes, usedNames, child, dir)
+	}
+
+	if dir != nil {
+		for _, f := range models.Files {
+			if f.DirectoryID == dir.ID && fs.Policy.ShowFile(f) {
+				nodes = append(nodes, &Node{Name: file.UniqueName(usedNames, f.Name), File: f, Parent: dir})
+			}
+		}
+	}
+
+	return nodes
+}
+
+// 按ShowDir把子目录添加到parent的列表中。不显示的目录可以查看内容时，
+// 其中显示的子目录提升到parent中（Node.Parent为parent，而不是实际的上级目录）
+func (fs *FS) appendDir(nodes []*Node, usedNames map[string]bool, dir, parent *models.Directory) []*Node {
+	switch fs.Policy.ShowDir(dir) {
+	case common.DirShown:
+		nodes = append(nodes, &Node{Name: file.UniqueName(usedNames, dir.Name), Dir: dir, Parent: parent})
+	case common.DirElided:
+		if fs.Policy.OpenDir(dir) {
+			for _, child := range dir.Children {
+				nodes = fs.appendDir(nodes, usedNames, child, parent)
+			}
+		}
+	}
+	return nodes
+}
+
+// Lookup 根据路径查找目录或文件，同时返回从根目录到目标的目录链。
+// 路径不存在时返回os.ErrNotExist，此时目录链为能找到的最深一级目录
+func (fs *FS) Lookup(name string) (*Node, []*models.Directory, error) {
+	current := &Node{Name: "/"}
+	chain := []*models.Directory{}
+
+	for _, part := range SplitPath(name) {
+		if !current.IsDir() {
+			return nil, chain, os.ErrNotExist
+		}
+
+		var next *Node
+		for _, child := range fs.Children(current.Dir) {
+			if child.Name == part {
+				next = child
+				break
+			}
+		}
+		if next == nil {
+			return nil, chain, os.ErrNotExist
+		}
+
+		current = next
+		if current.Dir != nil {
+			chain = append(chain, current.Dir)
+		}
+	}
+
+	return current, chain, nil
+}
+
+// LookupParent 查找路径的上级目录，返回上级目录（根目录时为nil）和最后一级名称
+func (fs *FS) LookupParent(name string) (*models.Directory, string, error) {
+	parts := SplitPath(name)
+	if len(parts) == 0 {
+		return nil, "", os.ErrInvalid
+	}
+
+	parent, _, err := fs.Lookup(strings.Join(parts[:len(parts)-1], "/"))
+	if err != nil {
+		return nil, "", err
+	}
+	if !parent.IsDir() {
+		return nil, "", os.ErrNotExist
+	}
+	return parent.Dir, parts[len(parts)-1], nil
+}
+
+// SplitPath 把路径拆分为各级名称
+func SplitPath(name string) []string {
+	name = strings.Trim(path.Clean("/"+name), "/")
+	if name == "" {
+		return nil
+	}
+	return strings.Split(name, "/")
+}
+
+// Mkdir 在上级目录中创建存储型目录
+func (fs *FS) Mkdir(name string) error {
+	parent, dirName, err := fs.LookupParent(name)
+	if err != nil {
+		return err
+	}
+	if _, _, err := fs.Lookup(name); err == nil {
+		return os.ErrExist
+	}
+	if err := fs.Policy.CanMkdir(parent); err != nil {
+		return err
+	}
+
+	parentID := ""
+	if parent != nil {
+		parentID = parent.ID
+	}
+	_, err = directory.NewDirectory(dirName, parentID, "")
+	return err
+}
+
+// Create 开始上传文件，文件已存在时替换内容（需要truncate），exclusive为true时文件已存在返回os.ErrExist
+func (fs *FS) Create(name string, truncate, exclusive bool) (*Writer, error) {
+	parent, fileName, err := fs.LookupParent(name)
+	if err != nil {
+		return nil, err
+	}
+	if parent == nil {
+		return nil, ErrRootFiles
+	}
+
+	var existing *models.File
+	if n, _, err := fs.Lookup(name); err == nil {
+		if n.IsDir() || exclusive {
+			return nil, os.ErrExist
+		}
+		existing = n.File
+	}
+
+	// 只支持整体上传，不支持追加或修改部分内容
+	if existing != nil && !truncate {
+		return nil, ForbiddenError("只支持覆盖整个文件")
+	}
+	if err := fs.Policy.CanPut(parent, existing); err != nil {
+		return nil, err
+	}
+
+	return newWriter(parent, fileName, existing), nil
+}
+
+// Remove 删除目录（连同子目录和文件）或文件
+func (fs *FS) Remove(name string) error {
+	n, _, err := fs.Lookup(name)
+	if err != nil {
+		return err
+	}
+	if n.IsRoot() {
+		return ErrRootReadOnly
+	}
+	if err := fs.Policy.CanRemove(n); err != nil {
+		return err
+	}
+
+	if n.IsDir() {
+		return directory.RemoveDirectory(n.Dir.ID)
+	}
+	file.RemoveFile(n.File)
+	return file.SaveFiles()
+}
+
+// Rename 重命名或移动目录和文件，目标已存在时返回os.ErrExist
+func (fs *FS) Rename(oldName, newName string) error {
+	n, _, err := fs.Lookup(oldName)
+	if err != nil {
+		return err
+	}
+	if n.IsRoot() {
+		return ErrRootReadOnly
+	}
+	parent, newBase, err := fs.LookupParent(newName)
+	if err != nil {
+		return err
+	}
+	if _, _, err := fs.Lookup(newName); err == nil {
+		return os.ErrExist
+	}
+	if !n.IsDir() && parent == nil {
+		return ErrRootFiles
+	}
+	if err := fs.Policy.CanMove(n, parent); err != nil {
+		return err
+	}
+
+	if n.IsDir() {
+		parentID := ""
+		if parent != nil {
+			parentID = parent.ID
+		}
+		// 提升显示的目录在原位置重命名时保留实际的上级目录
+		if parent == n.Parent {
+			parentID = n.Dir.ParentID
+		}
+		return directory.MoveDirectory(n.Dir.ID, parentID, newBase)
+	}
+
+	n.File.Name = newBase
+	n.File.DirectoryID = parent.ID
+	return file.SaveFiles()
+}
+
+// Stat 获取目录或文件的信息
+func (fs *FS) Stat(name string) (*FileInfo, error) {
+	n, _, err := fs.Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+	return NewFileInfo(n), nil
+}
@@ -0,0 +1,72 @@
+package vfs
+
+import (
+	"reflect"
+	"testing"
+
+	"fileshare/acl"
+	"fileshare/models"
+	"fileshare/user"
+)
+
+func TestManageChildren(t *testing.T) {
+	savedDirs, savedFiles := models.Directories, models.Files
+	t.Cleanup(func() { models.Directories, models.Files = savedDirs, savedFiles })
+
+	// private（只有ops组可以浏览其中的shared）
+	//   ├─ shared
+	//   └─ secret
+	// public
+	readOps := []*models.ACLEntry{{Type: acl.SubjectGroup, Subject: "ops", Permissions: []string{user.PermRead}}}
+	readNobody := []*models.ACLEntry{{Type: acl.SubjectGroup, Subject: "nobody", Permissions: []string{user.PermRead}}}
+	models.Directories = []*models.Directory{
+		{ID: "private", Name: "private", ACL: readNobody, Children: []*models.Directory{
+			{ID: "shared", Name: "shared", ParentID: "private", ACL: readOps},
+			{ID: "secret", Name: "secret", ParentID: "private"},
+		}},
+		{ID: "public", Name: "public"},
+	}
+	models.Files = []*models.File{
+		{ID: "f1", Name: "hidden.txt", DirectoryID: "private"},
+		{ID: "f2", Name: "visible.txt", DirectoryID: "shared"},
+	}
+
+	ops := &models.User{Username: "olga", Role: user.RoleViewer, Groups: []string{"ops"}}
+	viewer := &models.User{Username: "bob", Role: user.RoleViewer}
+
+	tests := []struct {
+		name string
+		user *models.User
+		path string
+		want []string
+	}{
+		{"没有权限的目录不显示，有权限的子目录提升到根目录", ops, "/", []string{"shared", "public"}},
+		{"提升的目录可以进入", ops, "/shared", []string{"visible.txt"}},
+		{"没有权限的目录不能按原路径访问", ops, "/private", nil},
+		{"没有可浏览的子目录时整个目录不显示", viewer, "/", []string{"public"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := &FS{Policy: &ManagePolicy{User: tt.user}}
+			n, _, err := fs.Lookup(tt.path)
+			if tt.want == nil {
+				if err == nil {
+					t.Fatalf("Lookup(%s) succeeded, want error", tt.path)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Lookup(%s): %v", tt.path, err)
+			}
+
+			got := []string{}
+			for _, child := range fs.Children(n.Dir) {
+				got = append(got, child.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Children(%s) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
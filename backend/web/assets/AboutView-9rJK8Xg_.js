@@ -1 +1 @@
-import{d,c as e,b as t,m as n,j as i,F as _,x as f,y as p,h as o,z as u,t as m,_ as v}from"./index-B_Y7wqn5.js";const x={class:"about"},b={class:"about-content"},h={class:"about-title"},k={class:"about-description"},w={class:"welcome-text"},y={class:"solution-text"},B={class:"feature-container"},V={class:"contact-info"},g=d({__name:"AboutView",setup(C){const c=[{text:"快速传输",icon:"fas fa-bolt"},{text:"安全可靠",icon:"fas fa-shield-alt"},{text:"简单易用",icon:"fas fa-magic"},{text:"多平台支持",icon:"fas fa-desktop"}];return(D,s)=>{const a=p("animate-on-scroll");return o(),e("div",x,[t("div",b,[n((o(),e("h1",h,s[0]||(s[0]=[i("使用说明")]))),[[a]]),t("div",k,[n((o(),e("p",w,s[1]||(s[1]=[i("本系统是对外进行文件共享的工具，在server.json可配置服务端口及管理密码(默认：123456)，管理维护页面左侧是分类树，可以在上级节点上右键添加子节点、修改名称、目录共享、设置目录密码等。右侧文件列表可点添加或拖拽文件进来添加。删除也是虚拟删除。")]))),[[a]]),n((o(),e("p",y,s[2]||(s[2]=[i("本系统提供文件存储和本机文件引用共享两个功能，分别是存储型目录和链接型目录，存储型目录下上传的文件都会存储到服务器上，链接型类似引用功能(快捷方式)，只共享链接指定的文件，不会再次进行存储。")]))),[[a]]),n((o(),e("div",B,[(o(),e(_,null,f(c,(l,r)=>t("div",{class:"feature-card",key:r},[t("i",{class:u(l.icon)},null,2),t("span",null,m(l.text),1)])),64))])),[[a]])]),n((o(),e("div",V,s[3]||(s[3]=[t("p",null,"Create By 刘秀君",-1),t("p",{class:"email"},[t("i",{class:"fas fa-envelope"}),i("文件共享系统")],-1)]))),[[a]])])])}}}),z=v(g,[["__scopeId","data-v-2274f863"]]);export{z as default};
+import{d,c as e,b as t,m as n,j as i,F as _,x as f,y as p,h as o,z as u,t as m,_ as v}from"./index-jic-H99L.js";const x={class:"about"},b={class:"about-content"},h={class:"about-title"},k={class:"about-description"},w={class:"welcome-text"},y={class:"solution-text"},B={class:"feature-container"},V={class:"contact-info"},g=d({__name:"AboutView",setup(C){const c=[{text:"快速传输",icon:"fas fa-bolt"},{text:"安全可靠",icon:"fas fa-shield-alt"},{text:"简单易用",icon:"fas fa-magic"},{text:"多平台支持",icon:"fas fa-desktop"}];return(D,s)=>{const a=p("animate-on-scroll");return o(),e("div",x,[t("div",b,[n((o(),e("h1",h,s[0]||(s[0]=[i("使用说明")]))),[[a]]),t("div",k,[n((o(),e("p",w,s[1]||(s[1]=[i("本系统是对外进行文件共享的工具，在server.json可配置服务端口及管理密码(默认：123456)，管理维护页面左侧是分类树，可以在上级节点上右键添加子节点、修改名称、目录共享、设置目录密码等。右侧文件列表可点添加或拖拽文件进来添加。删除也是虚拟删除。")]))),[[a]]),n((o(),e("p",y,s[2]||(s[2]=[i("本系统提供文件存储和本机文件引用共享两个功能，分别是存储型目录和链接型目录，存储型目录下上传的文件都会存储到服务器上，链接型类似引用功能(快捷方式)，只共享链接指定的文件，不会再次进行存储。")]))),[[a]]),n((o(),e("div",B,[(o(),e(_,null,f(c,(l,r)=>t("div",{class:"feature-card",key:r},[t("i",{class:u(l.icon)},null,2),t("span",null,m(l.text),1)])),64))])),[[a]])]),n((o(),e("div",V,s[3]||(s[3]=[t("p",null,"Create By 刘秀君",-1),t("p",{class:"email"},[t("i",{class:"fas fa-envelope"}),i("文件共享系统")],-1)]))),[[a]])])])}}}),z=v(g,[["__scopeId","data-v-2274f863"]]);export{z as default};
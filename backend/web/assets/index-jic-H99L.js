@@ -1,5 +1,5 @@
-const __vite__mapDeps=(i,m=__vite__mapDeps,d=(m.f||(m.f=["fileserver/assets/ShareView-DW4xIDsm.js","fileserver/assets/ShareView-CGhs5Tte.css","fileserver/assets/ManageView-qaCSx95K.js","fileserver/assets/ManageView-BZlSAR3h.css","fileserver/assets/AboutView-Dr_wGXAT.js","fileserver/assets/AboutView-9oYHn4_m.css"])))=>i.map(i=>d[i]);
-(function(){const t=document.createElement("link").relList;if(t&&t.supports&&t.supports("modulepreload"))return;for(const o of document.querySelectorAll('link[rel="modulepreload"]'))a(o);new MutationObserver(o=>{for(const l of o)if(l.type==="childList")for(const r of l.addedNodes)r.tagName==="LINK"&&r.rel==="modulepreload"&&a(r)}).observe(document,{childList:!0,subtree:!0});function n(o){const l={};return o.integrity&&(l.integrity=o.integrity),o.referrerPolicy&&(l.referrerPolicy=o.referrerPolicy),o.crossOrigin==="use-credentials"?l.credentials="include":o.crossOrigin==="anonymous"?l.credentials="omit":l.credentials="same-origin",l}function a(o){if(o.ep)return;o.ep=!0;const l=n(o);fetch(o.href,l)}})();/**
+const __vite__mapDeps=(i,m=__vite__mapDeps,d=(m.f||(m.f=[window.__fileshareAsset("assets/ShareView-YzdVJs69.js"),window.__fileshareAsset("assets/ShareView-CGhs5Tte.css"),window.__fileshareAsset("assets/ManageView-lRc_G3F8.js"),window.__fileshareAsset("assets/ManageView-BZlSAR3h.css"),window.__fileshareAsset("assets/AboutView-9rJK8Xg_.js"),window.__fileshareAsset("assets/AboutView-9oYHn4_m.css")])))=>i.map(i=>d[i]);
+(function(){const t=document.createElement("link").relList;if(t&&t.supports&&t.supports("modulepreload"))return;for(const o of document.querySelectorAll('link[rel="modulepreload"]'))a(o);new MutationObserver(o=>{for(const l of o)if(l.type==="childList")for(const r of l.addedNodes)r.tagName==="LINK"&&r.rel==="modulepreload"&&a(r)}).observe(document,{childList:!0,subtree:!0});function n(o){const l={};return o.integrity&&(l.integrity=o.integrity),o.referrerPolicy&&(l.referrerPolicy=o.referrerPolicy),o.crossOrigin==="use-credentials"?l.credentials="include":o.crossOrigin==="anonymous"?l.credentials="omit":l.credentials="same-origin",l}function a(o){if(o.ep)return;o.ep=!0;const l=n(o);fetch(o.href,l)}})();const Yre=()=>{const e=document.querySelector('meta[name="fileshare-config"]');try{return JSON.parse((e==null?void 0:e.getAttribute("content"))||"{}")}catch{return{}}},Zre=Yre(),Hre=Zre.basePath??"",Xre=Zre.uiPath??"/fileserver",Wre=Zre.manageApi??"/fileshare/api",Qre=Zre.shareApi??"/filesharePreview/api";window.__fileshareAsset=e=>`${Hre}${Xre}/${e}`;/**
 * @vue/shared v3.5.13
 * (c) 2018-present Yuxi (Evan) You and Vue contributors
 * @license MIT
@@ -55,4 +55,4 @@ const __vite__mapDeps=(i,m=__vite__mapDeps,d=(m.f||(m.f=["fileserver/assets/Shar
 * @internal
 * @license Modernizr 3.0.0pre (Custom Build) | MIT
 */const cQ=function(e,t){if(e&&e.addEventListener){const n=function(a){const o=uQ(a);t&&Reflect.apply(t,this,[a,o])};e.addEventListener("wheel",n,{passive:!0})}},dQ={beforeMount(e,t){cQ(e,t.value)}};let fQ=1;const pQ=I({name:"ElTable",directives:{Mousewheel:dQ},components:{TableHeader:DJ,TableBody:GJ,TableFooter:JJ,ElScrollbar:oo,hColgroup:$y},props:nQ,emits:["select","select-all","selection-change","cell-mouse-enter","cell-mouse-leave","cell-contextmenu","cell-click","cell-dblclick","row-click","row-contextmenu","row-dblclick","header-click","header-contextmenu","sort-change","filter-change","current-change","header-dragend","expand-change","scroll"],setup(e){const{t}=gt(),n=ge("table"),a=lt();ft(Ha,a);const o=IJ(a,e);a.store=o;const l=new NJ({store:a.store,table:a,fit:e.fit,showHeader:e.showHeader});a.layout=l;const r=S(()=>(o.states.data.value||[]).length===0),{setCurrentRow:i,getSelectionRows:u,toggleRowSelection:c,clearSelection:d,clearFilter:f,toggleAllSelection:p,toggleRowExpansion:v,clearSort:m,sort:h,updateKeyChildren:y}=QJ(o),{isHidden:g,renderExpanded:k,setDragVisible:w,isGroup:_,handleMouseLeave:C,handleHeaderFooterMousewheel:E,tableSize:$,emptyBlockStyle:A,handleFixedMousewheel:O,resizeProxyVisible:N,bodyWidth:L,resizeState:R,doLayout:K,tableBodyStyles:X,tableLayout:F,scrollbarViewStyle:P,scrollbarStyle:H}=eQ(e,l,o,a),{scrollBarRef:z,scrollTo:B,setScrollLeft:j,setScrollTop:G}=aQ(),te=Qn(K,50),Y=`${n.namespace.value}-table_${fQ++}`;a.tableId=Y,a.state={isGroup:_,resizeState:R,doLayout:K,debouncedUpdateLayout:te};const ie=S(()=>{var W;return(W=e.sumText)!=null?W:t("el.table.sumText")}),ce=S(()=>{var W;return(W=e.emptyText)!=null?W:t("el.table.emptyText")}),re=S(()=>Ty(o.states.originColumns.value)[0]);return tQ(a),At(()=>{te.cancel()}),{ns:n,layout:l,store:o,columns:re,handleHeaderFooterMousewheel:E,handleMouseLeave:C,tableId:Y,tableSize:$,isHidden:g,isEmpty:r,renderExpanded:k,resizeProxyVisible:N,resizeState:R,isGroup:_,bodyWidth:L,tableBodyStyles:X,emptyBlockStyle:A,debouncedUpdateLayout:te,handleFixedMousewheel:O,setCurrentRow:i,getSelectionRows:u,toggleRowSelection:c,clearSelection:d,clearFilter:f,toggleAllSelection:p,toggleRowExpansion:v,clearSort:m,doLayout:K,sort:h,updateKeyChildren:y,t,setDragVisible:w,context:a,computedSumText:ie,computedEmptyText:ce,tableLayout:F,scrollbarViewStyle:P,scrollbarStyle:H,scrollBarRef:z,scrollTo:B,setScrollLeft:j,setScrollTop:G,allowDragLastColumn:e.allowDragLastColumn}}});function vQ(e,t,n,a,o,l){const r=ut("hColgroup"),i=ut("table-header"),u=ut("table-body"),c=ut("table-footer"),d=ut("el-scrollbar"),f=Oc("mousewheel");return b(),M("div",{ref:"tableWrapper",class:T([{[e.ns.m("fit")]:e.fit,[e.ns.m("striped")]:e.stripe,[e.ns.m("border")]:e.border||e.isGroup,[e.ns.m("hidden")]:e.isHidden,[e.ns.m("group")]:e.isGroup,[e.ns.m("fluid-height")]:e.maxHeight,[e.ns.m("scrollable-x")]:e.layout.scrollX.value,[e.ns.m("scrollable-y")]:e.layout.scrollY.value,[e.ns.m("enable-row-hover")]:!e.store.states.isComplex.value,[e.ns.m("enable-row-transition")]:(e.store.states.data.value||[]).length!==0&&(e.store.states.data.value||[]).length<100,"has-footer":e.showSummary},e.ns.m(e.tableSize),e.className,e.ns.b(),e.ns.m(`layout-${e.tableLayout}`)]),style:Fe(e.style),"data-prefix":e.ns.namespace.value,onMouseleave:e.handleMouseLeave},[x("div",{class:T(e.ns.e("inner-wrapper"))},[x("div",{ref:"hiddenColumns",class:"hidden-columns"},[ne(e.$slots,"default")],512),e.showHeader&&e.tableLayout==="fixed"?Qe((b(),M("div",{key:0,ref:"headerWrapper",class:T(e.ns.e("header-wrapper"))},[x("table",{ref:"tableHeader",class:T(e.ns.e("header")),style:Fe(e.tableBodyStyles),border:"0",cellpadding:"0",cellspacing:"0"},[U(r,{columns:e.store.states.columns.value,"table-layout":e.tableLayout},null,8,["columns","table-layout"]),U(i,{ref:"tableHeaderRef",border:e.border,"default-sort":e.defaultSort,store:e.store,"append-filter-panel-to":e.appendFilterPanelTo,"allow-drag-last-column":e.allowDragLastColumn,onSetDragVisible:e.setDragVisible},null,8,["border","default-sort","store","append-filter-panel-to","allow-drag-last-column","onSetDragVisible"])],6)],2)),[[f,e.handleHeaderFooterMousewheel]]):le("v-if",!0),x("div",{ref:"bodyWrapper",class:T(e.ns.e("body-wrapper"))},[U(d,{ref:"scrollBarRef","view-style":e.scrollbarViewStyle,"wrap-style":e.scrollbarStyle,always:e.scrollbarAlwaysOn,tabindex:e.scrollbarTabindex,onScroll:p=>e.$emit("scroll",p)},{default:J(()=>[x("table",{ref:"tableBody",class:T(e.ns.e("body")),cellspacing:"0",cellpadding:"0",border:"0",style:Fe({width:e.bodyWidth,tableLayout:e.tableLayout})},[U(r,{columns:e.store.states.columns.value,"table-layout":e.tableLayout},null,8,["columns","table-layout"]),e.showHeader&&e.tableLayout==="auto"?(b(),ue(i,{key:0,ref:"tableHeaderRef",class:T(e.ns.e("body-header")),border:e.border,"default-sort":e.defaultSort,store:e.store,"append-filter-panel-to":e.appendFilterPanelTo,onSetDragVisible:e.setDragVisible},null,8,["class","border","default-sort","store","append-filter-panel-to","onSetDragVisible"])):le("v-if",!0),U(u,{context:e.context,highlight:e.highlightCurrentRow,"row-class-name":e.rowClassName,"tooltip-effect":e.tooltipEffect,"tooltip-options":e.tooltipOptions,"row-style":e.rowStyle,store:e.store,stripe:e.stripe},null,8,["context","highlight","row-class-name","tooltip-effect","tooltip-options","row-style","store","stripe"]),e.showSummary&&e.tableLayout==="auto"?(b(),ue(c,{key:1,class:T(e.ns.e("body-footer")),border:e.border,"default-sort":e.defaultSort,store:e.store,"sum-text":e.computedSumText,"summary-method":e.summaryMethod},null,8,["class","border","default-sort","store","sum-text","summary-method"])):le("v-if",!0)],6),e.isEmpty?(b(),M("div",{key:0,ref:"emptyBlock",style:Fe(e.emptyBlockStyle),class:T(e.ns.e("empty-block"))},[x("span",{class:T(e.ns.e("empty-text"))},[ne(e.$slots,"empty",{},()=>[ct(Te(e.computedEmptyText),1)])],2)],6)):le("v-if",!0),e.$slots.append?(b(),M("div",{key:1,ref:"appendWrapper",class:T(e.ns.e("append-wrapper"))},[ne(e.$slots,"append")],2)):le("v-if",!0)]),_:3},8,["view-style","wrap-style","always","tabindex","onScroll"])],2),e.showSummary&&e.tableLayout==="fixed"?Qe((b(),M("div",{key:1,ref:"footerWrapper",class:T(e.ns.e("footer-wrapper"))},[x("table",{class:T(e.ns.e("footer")),cellspacing:"0",cellpadding:"0",border:"0",style:Fe(e.tableBodyStyles)},[U(r,{columns:e.store.states.columns.value,"table-layout":e.tableLayout},null,8,["columns","table-layout"]),U(c,{border:e.border,"default-sort":e.defaultSort,store:e.store,"sum-text":e.computedSumText,"summary-method":e.summaryMethod},null,8,["border","default-sort","store","sum-text","summary-method"])],6)],2)),[[_t,!e.isEmpty],[f,e.handleHeaderFooterMousewheel]]):le("v-if",!0),e.border||e.isGroup?(b(),M("div",{key:2,class:T(e.ns.e("border-left-patch"))},null,2)):le("v-if",!0)],2),Qe(x("div",{ref:"resizeProxy",class:T(e.ns.e("column-resize-proxy"))},null,2),[[_t,e.resizeProxyVisible]])],46,["data-prefix","onMouseleave"])}var hQ=Me(pQ,[["render",vQ],["__file","table.vue"]]);const mQ={selection:"table-column--selection",expand:"table__expand-column"},gQ={default:{order:""},selection:{width:48,minWidth:48,realWidth:48,order:""},expand:{width:48,minWidth:48,realWidth:48,order:""},index:{width:48,minWidth:48,realWidth:48,order:""}},yQ=e=>mQ[e]||"",_Q={selection:{renderHeader({store:e,column:t}){function n(){return e.states.data.value&&e.states.data.value.length===0}return We(ba,{disabled:n(),size:e.states.tableSize.value,indeterminate:e.states.selection.value.length>0&&!e.states.isAllSelected.value,"onUpdate:modelValue":e.toggleAllSelection,modelValue:e.states.isAllSelected.value,ariaLabel:t.label})},renderCell({row:e,column:t,store:n,$index:a}){return We(ba,{disabled:t.selectable?!t.selectable.call(null,e,a):!1,size:n.states.tableSize.value,onChange:()=>{n.commit("rowSelectedChanged",e)},onClick:o=>o.stopPropagation(),modelValue:n.isSelected(e),ariaLabel:t.label})},sortable:!1,resizable:!1},index:{renderHeader({column:e}){return e.label||"#"},renderCell({column:e,$index:t}){let n=t+1;const a=e.index;return Ke(a)?n=t+a:ze(a)&&(n=a(t)),We("div",{},[n])},sortable:!1},expand:{renderHeader({column:e}){return e.label||""},renderCell({row:e,store:t,expanded:n}){const{ns:a}=t,o=[a.e("expand-icon")];return n&&o.push(a.em("expand-icon","expanded")),We("div",{class:o,onClick:function(r){r.stopPropagation(),t.toggleRowExpansion(e)}},{default:()=>[We(Le,null,{default:()=>[We(Pn)]})]})},sortable:!1,resizable:!1}};function bQ({row:e,column:t,$index:n}){var a;const o=t.property,l=o&&As(e,o).value;return t&&t.formatter?t.formatter(e,t,l,n):((a=l==null?void 0:l.toString)==null?void 0:a.call(l))||""}function wQ({row:e,treeNode:t,store:n},a=!1){const{ns:o}=n;if(!t)return a?[We("span",{class:o.e("placeholder")})]:null;const l=[],r=function(i){i.stopPropagation(),!t.loading&&n.loadOrToggle(e)};if(t.indent&&l.push(We("span",{class:o.e("indent"),style:{"padding-left":`${t.indent}px`}})),It(t.expanded)&&!t.noLazyChildren){const i=[o.e("expand-icon"),t.expanded?o.em("expand-icon","expanded"):""];let u=Pn;t.loading&&(u=Na),l.push(We("div",{class:i,onClick:r},{default:()=>[We(Le,{class:{[o.is("loading")]:t.loading}},{default:()=>[We(u)]})]}))}else l.push(We("span",{class:o.e("placeholder")}));return l}function gm(e,t){return e.reduce((n,a)=>(n[a]=a,n),t)}function CQ(e,t){const n=lt();return{registerComplexWatchers:()=>{const l=["fixed"],r={realWidth:"width",realMinWidth:"minWidth"},i=gm(l,r);Object.keys(i).forEach(u=>{const c=r[u];yt(t,c)&&pe(()=>t[c],d=>{let f=d;c==="width"&&u==="realWidth"&&(f=b2(d)),c==="minWidth"&&u==="realMinWidth"&&(f=Cy(d)),n.columnConfig.value[c]=f,n.columnConfig.value[u]=f;const p=c==="fixed";e.value.store.scheduleLayout(p)})})},registerNormalWatchers:()=>{const l=["label","filters","filterMultiple","filteredValue","sortable","index","formatter","className","labelClassName","filterClassName","showOverflowTooltip","tooltipFormatter"],r={property:"prop",align:"realAlign",headerAlign:"realHeaderAlign"},i=gm(l,r);Object.keys(i).forEach(u=>{const c=r[u];yt(t,c)&&pe(()=>t[c],d=>{n.columnConfig.value[u]=d})})}}}function SQ(e,t,n){const a=lt(),o=V(""),l=V(!1),r=V(),i=V(),u=ge("table");wn(()=>{r.value=e.align?`is-${e.align}`:null,r.value}),wn(()=>{i.value=e.headerAlign?`is-${e.headerAlign}`:r.value,i.value});const c=S(()=>{let _=a.vnode.vParent||a.parent;for(;_&&!_.tableId&&!_.columnId;)_=_.vnode.vParent||_.parent;return _}),d=S(()=>{const{store:_}=a.parent;if(!_)return!1;const{treeData:C}=_.states,E=C.value;return E&&Object.keys(E).length>0}),f=V(b2(e.width)),p=V(Cy(e.minWidth)),v=_=>(f.value&&(_.width=f.value),p.value&&(_.minWidth=p.value),!f.value&&p.value&&(_.width=void 0),_.minWidth||(_.minWidth=80),_.realWidth=Number(bt(_.width)?_.minWidth:_.width),_),m=_=>{const C=_.type,E=_Q[C]||{};Object.keys(E).forEach(A=>{const O=E[A];A!=="className"&&!bt(O)&&(_[A]=O)});const $=yQ(C);if($){const A=`${s(u.namespace)}-${$}`;_.className=_.className?`${_.className} ${A}`:A}return _},h=_=>{Se(_)?_.forEach(E=>C(E)):C(_);function C(E){var $;(($=E==null?void 0:E.type)==null?void 0:$.name)==="ElTableColumn"&&(E.vParent=a)}};return{columnId:o,realAlign:r,isSubColumn:l,realHeaderAlign:i,columnOrTableParent:c,setColumnWidth:v,setColumnForcedProps:m,setColumnRenders:_=>{e.renderHeader||_.type!=="selection"&&(_.renderHeader=E=>(a.columnConfig.value.label,ne(t,"header",E,()=>[_.label]))),t["filter-icon"]&&(_.renderFilterIcon=E=>ne(t,"filter-icon",E));let C=_.renderCell;return _.type==="expand"?(_.renderCell=E=>We("div",{class:"cell"},[C(E)]),n.value.renderExpanded=E=>t.default?t.default(E):t.default):(C=C||bQ,_.renderCell=E=>{let $=null;if(t.default){const K=t.default(E);$=K.some(X=>X.type!==sn)?K:C(E)}else $=C(E);const{columns:A}=n.value.store.states,O=A.value.findIndex(K=>K.type==="default"),N=d.value&&E.cellIndex===O,L=wQ(E,N),R={class:"cell",style:{}};return _.showOverflowTooltip&&(R.class=`${R.class} ${s(u.namespace)}-tooltip`,R.style={width:`${(E.column.realWidth||Number(E.column.width))-1}px`}),h($),We("div",R,[L,$])}),_},getPropsData:(..._)=>_.reduce((C,E)=>(Se(E)&&E.forEach($=>{C[$]=e[$]}),C),{}),getColumnElIndex:(_,C)=>Array.prototype.indexOf.call(_,C),updateColumnOrder:()=>{n.value.store.commit("updateColumnOrder",a.columnConfig.value)}}}var kQ={type:{type:String,default:"default"},label:String,className:String,labelClassName:String,property:String,prop:String,width:{type:[String,Number],default:""},minWidth:{type:[String,Number],default:""},renderHeader:Function,sortable:{type:[Boolean,String],default:!1},sortMethod:Function,sortBy:[String,Function,Array],resizable:{type:Boolean,default:!0},columnKey:String,align:String,headerAlign:String,showOverflowTooltip:{type:[Boolean,Object],default:void 0},tooltipFormatter:Function,fixed:[Boolean,String],formatter:Function,selectable:Function,reserveSelection:Boolean,filterMethod:Function,filteredValue:Array,filters:Array,filterPlacement:String,filterMultiple:{type:Boolean,default:!0},filterClassName:String,index:[Number,Function],sortOrders:{type:Array,default:()=>["ascending","descending",null],validator:e=>e.every(t=>["ascending","descending",null].includes(t))}};let EQ=1;var Vy=I({name:"ElTableColumn",components:{ElCheckbox:ba},props:kQ,setup(e,{slots:t}){const n=lt(),a=V({}),o=S(()=>{let w=n.parent;for(;w&&!w.tableId;)w=w.parent;return w}),{registerNormalWatchers:l,registerComplexWatchers:r}=CQ(o,e),{columnId:i,isSubColumn:u,realHeaderAlign:c,columnOrTableParent:d,setColumnWidth:f,setColumnForcedProps:p,setColumnRenders:v,getPropsData:m,getColumnElIndex:h,realAlign:y,updateColumnOrder:g}=SQ(e,t,o),k=d.value;i.value=`${k.tableId||k.columnId}_column_${EQ++}`,$c(()=>{u.value=o.value!==k;const w=e.type||"default",_=e.sortable===""?!0:e.sortable,C=w==="selection"?!1:bt(e.showOverflowTooltip)?k.props.showOverflowTooltip:e.showOverflowTooltip,E=bt(e.tooltipFormatter)?k.props.tooltipFormatter:e.tooltipFormatter,$={...gQ[w],id:i.value,type:w,property:e.prop||e.property,align:y,headerAlign:c,showOverflowTooltip:C,tooltipFormatter:E,filterable:e.filters||e.filterMethod,filteredValue:[],filterPlacement:"",filterClassName:"",isColumnGroup:!1,isSubColumn:!1,filterOpened:!1,sortable:_,index:e.index,rawColumnKey:n.vnode.key};let R=m(["columnKey","label","className","labelClassName","type","renderHeader","formatter","fixed","resizable"],["sortMethod","sortBy","sortOrders"],["selectable","reserveSelection"],["filterMethod","filters","filterMultiple","filterOpened","filteredValue","filterPlacement","filterClassName"]);R=_J($,R),R=wJ(v,f,p)(R),a.value=R,l(),r()}),at(()=>{var w;const _=d.value,C=u.value?_.vnode.el.children:(w=_.refs.hiddenColumns)==null?void 0:w.children,E=()=>h(C||[],n.vnode.el);a.value.getColumnIndex=E,E()>-1&&o.value.store.commit("insertColumn",a.value,u.value?_.columnConfig.value:null,g)}),At(()=>{const w=a.value.getColumnIndex;(w?w():-1)>-1&&o.value.store.commit("removeColumn",a.value,u.value?k.columnConfig.value:null,g)}),n.columnId=i.value,n.columnConfig=a},render(){var e,t,n;try{const a=(t=(e=this.$slots).default)==null?void 0:t.call(e,{row:{},column:{},$index:-1}),o=[];if(Se(a))for(const r of a)((n=r.type)==null?void 0:n.name)==="ElTableColumn"||r.shapeFlag&2?o.push(r):r.type===He&&Se(r.children)&&r.children.forEach(i=>{(i==null?void 0:i.patchFlag)!==1024&&!Ve(i==null?void 0:i.children)&&o.push(i)});return We("div",o)}catch{return We("div",[])}}});const xQ=Ze(hQ,{TableColumn:Vy}),MQ=Kt(Vy);var di=(e=>(e.ASC="asc",e.DESC="desc",e))(di||{}),fi=(e=>(e.CENTER="center",e.RIGHT="right",e))(fi||{}),By=(e=>(e.LEFT="left",e.RIGHT="right",e))(By||{});const b0={asc:"desc",desc:"asc"},pi=Symbol("placeholder"),TQ=(e,t,n)=>{var a;const o={flexGrow:0,flexShrink:0,...n?{}:{flexGrow:e.flexGrow||0,flexShrink:e.flexShrink||1}};n||(o.flexShrink=1);const l={...(a=e.style)!=null?a:{},...o,flexBasis:"auto",width:e.width};return t||(e.maxWidth&&(l.maxWidth=e.maxWidth),e.minWidth&&(l.minWidth=e.minWidth)),l};function $Q(e,t,n){const a=S(()=>s(t).map((y,g)=>{var k,w;return{...y,key:(w=(k=y.key)!=null?k:y.dataKey)!=null?w:g}})),o=S(()=>s(a).filter(y=>!y.hidden)),l=S(()=>s(o).filter(y=>y.fixed==="left"||y.fixed===!0)),r=S(()=>s(o).filter(y=>y.fixed==="right")),i=S(()=>s(o).filter(y=>!y.fixed)),u=S(()=>{const y=[];return s(l).forEach(g=>{y.push({...g,placeholderSign:pi})}),s(i).forEach(g=>{y.push(g)}),s(r).forEach(g=>{y.push({...g,placeholderSign:pi})}),y}),c=S(()=>s(l).length||s(r).length),d=S(()=>s(a).reduce((y,g)=>(y[g.key]=TQ(g,s(n),e.fixed),y),{})),f=S(()=>s(o).reduce((y,g)=>y+g.width,0)),p=y=>s(a).find(g=>g.key===y),v=y=>s(d)[y],m=(y,g)=>{y.width=g};function h(y){var g;const{key:k}=y.currentTarget.dataset;if(!k)return;const{sortState:w,sortBy:_}=e;let C=di.ASC;nt(w)?C=b0[w[k]]:C=b0[_.order],(g=e.onColumnSort)==null||g.call(e,{column:p(k),key:k,order:C})}return{columns:a,columnsStyles:d,columnsTotalWidth:f,fixedColumnsOnLeft:l,fixedColumnsOnRight:r,hasFixedColumns:c,mainColumns:u,normalColumns:i,visibleColumns:o,getColumn:p,getColumnStyle:v,updateColumnWidth:m,onColumnSorted:h}}const OQ=(e,{mainTableRef:t,leftTableRef:n,rightTableRef:a,onMaybeEndReached:o})=>{const l=V({scrollLeft:0,scrollTop:0});function r(v){var m,h,y;const{scrollTop:g}=v;(m=t.value)==null||m.scrollTo(v),(h=n.value)==null||h.scrollToTop(g),(y=a.value)==null||y.scrollToTop(g)}function i(v){l.value=v,r(v)}function u(v){l.value.scrollTop=v,r(s(l))}function c(v){var m,h;l.value.scrollLeft=v,(h=(m=t.value)==null?void 0:m.scrollTo)==null||h.call(m,s(l))}function d(v){var m;i(v),(m=e.onScroll)==null||m.call(e,v)}function f({scrollTop:v}){const{scrollTop:m}=s(l);v!==m&&u(v)}function p(v,m="auto"){var h;(h=t.value)==null||h.scrollToRow(v,m)}return pe(()=>s(l).scrollTop,(v,m)=>{v>m&&o()}),{scrollPos:l,scrollTo:i,scrollToLeft:c,scrollToTop:u,scrollToRow:p,onScroll:d,onVerticalScroll:f}},IQ=(e,{mainTableRef:t,leftTableRef:n,rightTableRef:a,tableInstance:o,ns:l,isScrolling:r})=>{const i=lt(),{emit:u}=i,c=Pt(!1),d=V(e.defaultExpandedRowKeys||[]),f=V(-1),p=Pt(null),v=V({}),m=V({}),h=Pt({}),y=Pt({}),g=Pt({}),k=S(()=>Ke(e.estimatedRowHeight));function w(N){var L;(L=e.onRowsRendered)==null||L.call(e,N),N.rowCacheEnd>s(f)&&(f.value=N.rowCacheEnd)}function _({hovered:N,rowKey:L}){if(r.value)return;o.vnode.el.querySelectorAll(`[rowkey="${String(L)}"]`).forEach(X=>{N?X.classList.add(l.is("hovered")):X.classList.remove(l.is("hovered"))})}function C({expanded:N,rowData:L,rowIndex:R,rowKey:K}){var X,F;const P=[...s(d)],H=P.indexOf(K);N?H===-1&&P.push(K):H>-1&&P.splice(H,1),d.value=P,u("update:expandedRowKeys",P),(X=e.onRowExpand)==null||X.call(e,{expanded:N,rowData:L,rowIndex:R,rowKey:K}),(F=e.onExpandedRowsChange)==null||F.call(e,P)}const E=Qn(()=>{var N,L,R,K;c.value=!0,v.value={...s(v),...s(m)},$(s(p),!1),m.value={},p.value=null,(N=t.value)==null||N.forceUpdate(),(L=n.value)==null||L.forceUpdate(),(R=a.value)==null||R.forceUpdate(),(K=i.proxy)==null||K.$forceUpdate(),c.value=!1},0);function $(N,L=!1){s(k)&&[t,n,a].forEach(R=>{const K=s(R);K&&K.resetAfterRowIndex(N,L)})}function A(N,L,R){const K=s(p);(K===null||K>R)&&(p.value=R),m.value[N]=L}function O({rowKey:N,height:L,rowIndex:R},K){K?K===By.RIGHT?g.value[N]=L:h.value[N]=L:y.value[N]=L;const X=Math.max(...[h,g,y].map(F=>F.value[N]||0));s(v)[N]!==X&&(A(N,X,R),E())}return{expandedRowKeys:d,lastRenderedRowIndex:f,isDynamic:k,isResetting:c,rowHeights:v,resetAfterIndex:$,onRowExpanded:C,onRowHovered:_,onRowsRendered:w,onRowHeightChange:O}},AQ=(e,{expandedRowKeys:t,lastRenderedRowIndex:n,resetAfterIndex:a})=>{const o=V({}),l=S(()=>{const i={},{data:u,rowKey:c}=e,d=s(t);if(!d||!d.length)return u;const f=[],p=new Set;d.forEach(m=>p.add(m));let v=u.slice();for(v.forEach(m=>i[m[c]]=0);v.length>0;){const m=v.shift();f.push(m),p.has(m[c])&&Se(m.children)&&m.children.length>0&&(v=[...m.children,...v],m.children.forEach(h=>i[h[c]]=i[m[c]]+1))}return o.value=i,f}),r=S(()=>{const{data:i,expandColumnKey:u}=e;return u?s(l):i});return pe(r,(i,u)=>{i!==u&&(n.value=-1,a(0,!0))}),{data:r,depthMap:o}},NQ=(e,t)=>e+t,Nu=e=>Se(e)?e.reduce(NQ,0):e,Bl=(e,t,n={})=>ze(e)?e(t):e??n,Yo=e=>(["width","maxWidth","minWidth","height"].forEach(t=>{e[t]=qt(e[t])}),e),zy=e=>Bt(e)?t=>We(e,t):e,PQ=(e,{columnsTotalWidth:t,rowsHeight:n,fixedColumnsOnLeft:a,fixedColumnsOnRight:o})=>{const l=S(()=>{const{fixed:k,width:w,vScrollbarSize:_}=e,C=w-_;return k?Math.max(Math.round(s(t)),C):C}),r=S(()=>s(l)+e.vScrollbarSize),i=S(()=>{const{height:k=0,maxHeight:w=0,footerHeight:_,hScrollbarSize:C}=e;if(w>0){const E=s(v),$=s(n),O=s(p)+E+$+C;return Math.min(O,w-_)}return k-_}),u=S(()=>{const{maxHeight:k}=e,w=s(i);if(Ke(k)&&k>0)return w;const _=s(n)+s(p)+s(v);return Math.min(w,_)}),c=k=>k.width,d=S(()=>Nu(s(a).map(c))),f=S(()=>Nu(s(o).map(c))),p=S(()=>Nu(e.headerHeight)),v=S(()=>{var k;return(((k=e.fixedData)==null?void 0:k.length)||0)*e.rowHeight}),m=S(()=>s(i)-s(p)-s(v)),h=S(()=>{const{style:k={},height:w,width:_}=e;return Yo({...k,height:w,width:_})}),y=S(()=>Yo({height:e.footerHeight})),g=S(()=>({top:qt(s(p)),bottom:qt(e.footerHeight),width:qt(e.width)}));return{bodyWidth:l,fixedTableHeight:u,mainTableHeight:i,leftTableWidth:d,rightTableWidth:f,headerWidth:r,windowHeight:m,footerHeight:y,emptyStyle:g,rootStyle:h,headerHeight:p}};function RQ(e){const t=V(),n=V(),a=V(),{columns:o,columnsStyles:l,columnsTotalWidth:r,fixedColumnsOnLeft:i,fixedColumnsOnRight:u,hasFixedColumns:c,mainColumns:d,onColumnSorted:f}=$Q(e,Et(e,"columns"),Et(e,"fixed")),{scrollTo:p,scrollToLeft:v,scrollToTop:m,scrollToRow:h,onScroll:y,onVerticalScroll:g,scrollPos:k}=OQ(e,{mainTableRef:t,leftTableRef:n,rightTableRef:a,onMaybeEndReached:q}),w=ge("table-v2"),_=lt(),C=Pt(!1),{expandedRowKeys:E,lastRenderedRowIndex:$,isDynamic:A,isResetting:O,rowHeights:N,resetAfterIndex:L,onRowExpanded:R,onRowHeightChange:K,onRowHovered:X,onRowsRendered:F}=IQ(e,{mainTableRef:t,leftTableRef:n,rightTableRef:a,tableInstance:_,ns:w,isScrolling:C}),{data:P,depthMap:H}=AQ(e,{expandedRowKeys:E,lastRenderedRowIndex:$,resetAfterIndex:L}),z=S(()=>{const{estimatedRowHeight:se,rowHeight:ve}=e,be=s(P);return Ke(se)?Object.values(s(N)).reduce((de,Ce)=>de+Ce,0):be.length*ve}),{bodyWidth:B,fixedTableHeight:j,mainTableHeight:G,leftTableWidth:te,rightTableWidth:Y,headerWidth:ie,windowHeight:ce,footerHeight:re,emptyStyle:W,rootStyle:oe,headerHeight:ae}=PQ(e,{columnsTotalWidth:r,fixedColumnsOnLeft:i,fixedColumnsOnRight:u,rowsHeight:z}),we=V(),me=S(()=>{const se=s(P).length===0;return Se(e.fixedData)?e.fixedData.length===0&&se:se});function D(se){const{estimatedRowHeight:ve,rowHeight:be,rowKey:de}=e;return ve?s(N)[s(P)[se][de]]||ve:be}function q(){const{onEndReached:se}=e;if(!se)return;const{scrollTop:ve}=s(k),be=s(z),de=s(ce),Ce=be-(ve+de)+e.hScrollbarSize;s($)>=0&&be===ve+s(G)-s(ae)&&se(Ce)}return pe(()=>e.expandedRowKeys,se=>E.value=se,{deep:!0}),{columns:o,containerRef:we,mainTableRef:t,leftTableRef:n,rightTableRef:a,isDynamic:A,isResetting:O,isScrolling:C,hasFixedColumns:c,columnsStyles:l,columnsTotalWidth:r,data:P,expandedRowKeys:E,depthMap:H,fixedColumnsOnLeft:i,fixedColumnsOnRight:u,mainColumns:d,bodyWidth:B,emptyStyle:W,rootStyle:oe,headerWidth:ie,footerHeight:re,mainTableHeight:G,fixedTableHeight:j,leftTableWidth:te,rightTableWidth:Y,showEmpty:me,getRowHeight:D,onColumnSorted:f,onRowHovered:X,onRowExpanded:R,onRowsRendered:F,onRowHeightChange:K,scrollTo:p,scrollToLeft:v,scrollToTop:m,scrollToRow:h,onScroll:y,onVerticalScroll:g}}const k2=Symbol("tableV2"),Hy=String,Ai={type:Q(Array),required:!0},E2={type:Q(Array)},Dy={...E2,required:!0},LQ=String,ym={type:Q(Array),default:()=>Ft([])},wl={type:Number,required:!0},Fy={type:Q([String,Number,Symbol]),default:"id"},_m={type:Q(Object)},El=Ee({class:String,columns:Ai,columnsStyles:{type:Q(Object),required:!0},depth:Number,expandColumnKey:LQ,estimatedRowHeight:{...Ol.estimatedRowHeight,default:void 0},isScrolling:Boolean,onRowExpand:{type:Q(Function)},onRowHover:{type:Q(Function)},onRowHeightChange:{type:Q(Function)},rowData:{type:Q(Object),required:!0},rowEventHandlers:{type:Q(Object)},rowIndex:{type:Number,required:!0},rowKey:Fy,style:{type:Q(Object)}}),tf={type:Number,required:!0},x2=Ee({class:String,columns:Ai,fixedHeaderData:{type:Q(Array)},headerData:{type:Q(Array),required:!0},headerHeight:{type:Q([Number,Array]),default:50},rowWidth:tf,rowHeight:{type:Number,default:50},height:tf,width:tf}),Pu=Ee({columns:Ai,data:Dy,fixedData:E2,estimatedRowHeight:El.estimatedRowHeight,width:wl,height:wl,headerWidth:wl,headerHeight:x2.headerHeight,bodyWidth:wl,rowHeight:wl,cache:uy.cache,useIsScrolling:Boolean,scrollbarAlwaysOn:Ol.scrollbarAlwaysOn,scrollbarStartGap:Ol.scrollbarStartGap,scrollbarEndGap:Ol.scrollbarEndGap,class:Hy,style:_m,containerStyle:_m,getRowHeight:{type:Q(Function),required:!0},rowKey:El.rowKey,onRowsRendered:{type:Q(Function)},onScroll:{type:Q(Function)}}),VQ=Ee({cache:Pu.cache,estimatedRowHeight:El.estimatedRowHeight,rowKey:Fy,headerClass:{type:Q([String,Function])},headerProps:{type:Q([Object,Function])},headerCellProps:{type:Q([Object,Function])},headerHeight:x2.headerHeight,footerHeight:{type:Number,default:0},rowClass:{type:Q([String,Function])},rowProps:{type:Q([Object,Function])},rowHeight:{type:Number,default:50},cellProps:{type:Q([Object,Function])},columns:Ai,data:Dy,dataGetter:{type:Q(Function)},fixedData:E2,expandColumnKey:El.expandColumnKey,expandedRowKeys:ym,defaultExpandedRowKeys:ym,class:Hy,fixed:Boolean,style:{type:Q(Object)},width:wl,height:wl,maxHeight:Number,useIsScrolling:Boolean,indentSize:{type:Number,default:12},iconSize:{type:Number,default:12},hScrollbarSize:Ol.hScrollbarSize,vScrollbarSize:Ol.vScrollbarSize,scrollbarAlwaysOn:fy.alwaysOn,sortBy:{type:Q(Object),default:()=>({})},sortState:{type:Q(Object),default:void 0},onColumnSort:{type:Q(Function)},onExpandedRowsChange:{type:Q(Function)},onEndReached:{type:Q(Function)},onRowExpand:El.onRowExpand,onScroll:Pu.onScroll,onRowsRendered:Pu.onRowsRendered,rowEventHandlers:El.rowEventHandlers}),BQ="ElTableV2Header",zQ=I({name:BQ,props:x2,setup(e,{slots:t,expose:n}){const a=ge("table-v2"),o=Pe("tableV2GridScrollLeft"),l=V(),r=S(()=>Yo({width:e.width,height:e.height})),i=S(()=>Yo({width:e.rowWidth,height:e.height})),u=S(()=>On(s(e.headerHeight))),c=p=>{const v=s(l);Be(()=>{v!=null&&v.scroll&&v.scroll({left:p})})},d=()=>{const p=a.e("fixed-header-row"),{columns:v,fixedHeaderData:m,rowHeight:h}=e;return m==null?void 0:m.map((y,g)=>{var k;const w=Yo({height:h,width:"100%"});return(k=t.fixed)==null?void 0:k.call(t,{class:p,columns:v,rowData:y,rowIndex:-(g+1),style:w})})},f=()=>{const p=a.e("dynamic-header-row"),{columns:v}=e;return s(u).map((m,h)=>{var y;const g=Yo({width:"100%",height:m});return(y=t.dynamic)==null?void 0:y.call(t,{class:p,columns:v,headerIndex:h,style:g})})};return La(()=>{o!=null&&o.value&&c(o.value)}),n({scrollToLeft:c}),()=>{if(!(e.height<=0))return U("div",{ref:l,class:e.class,style:s(r),role:"rowgroup"},[U("div",{style:s(i),class:a.e("header")},[f(),d()])])}}});var HQ=zQ;const DQ=({atXEndEdge:e,atXStartEdge:t,atYEndEdge:n,atYStartEdge:a},o)=>{let l=null,r=0,i=0;const u=(d,f)=>{const p=d<=0&&t.value||d>=0&&e.value,v=f<=0&&a.value||f>=0&&n.value;return p&&v};return{hasReachedEdge:u,onWheel:d=>{Ll(l);let f=d.deltaX,p=d.deltaY;Math.abs(f)>Math.abs(p)?p=0:f=0,d.shiftKey&&p!==0&&(f=p,p=0),!(u(r,i)&&u(r+f,i+p))&&(r+=f,i+=p,d.preventDefault(),l=tl(()=>{o(r,i),r=0,i=0}))}}},Ky=({name:e,clearCache:t,getColumnPosition:n,getColumnStartIndexForOffset:a,getColumnStopIndexForStartIndex:o,getEstimatedTotalHeight:l,getEstimatedTotalWidth:r,getColumnOffset:i,getRowOffset:u,getRowPosition:c,getRowStartIndexForOffset:d,getRowStopIndexForStartIndex:f,initCache:p,injectToInstance:v,validateProps:m})=>I({name:e??"ElVirtualList",props:Ol,emits:[sc,ic],setup(h,{emit:y,expose:g,slots:k}){const w=ge("vl");m(h);const _=lt(),C=V(p(h,_));v==null||v(_,C);const E=V(),$=V(),A=V(),O=V(null),N=V({isScrolling:!1,scrollLeft:Ke(h.initScrollLeft)?h.initScrollLeft:0,scrollTop:Ke(h.initScrollTop)?h.initScrollTop:0,updateRequested:!1,xAxisScrollDir:sr,yAxisScrollDir:sr}),L=ry(),R=S(()=>Number.parseInt(`${h.height}`,10)),K=S(()=>Number.parseInt(`${h.width}`,10)),X=S(()=>{const{totalColumn:de,totalRow:Ce,columnCache:he}=h,{isScrolling:ke,xAxisScrollDir:xe,scrollLeft:De}=s(N);if(de===0||Ce===0)return[0,0,0,0];const Ne=a(h,De,s(C)),ye=o(h,Ne,De,s(C)),Re=!ke||xe===uc?Math.max(1,he):1,Ye=!ke||xe===sr?Math.max(1,he):1;return[Math.max(0,Ne-Re),Math.max(0,Math.min(de-1,ye+Ye)),Ne,ye]}),F=S(()=>{const{totalColumn:de,totalRow:Ce,rowCache:he}=h,{isScrolling:ke,yAxisScrollDir:xe,scrollTop:De}=s(N);if(de===0||Ce===0)return[0,0,0,0];const Ne=d(h,De,s(C)),ye=f(h,Ne,De,s(C)),Re=!ke||xe===uc?Math.max(1,he):1,Ye=!ke||xe===sr?Math.max(1,he):1;return[Math.max(0,Ne-Re),Math.max(0,Math.min(Ce-1,ye+Ye)),Ne,ye]}),P=S(()=>l(h,s(C))),H=S(()=>r(h,s(C))),z=S(()=>{var de;return[{position:"relative",overflow:"hidden",WebkitOverflowScrolling:"touch",willChange:"transform"},{direction:h.direction,height:Ke(h.height)?`${h.height}px`:h.height,width:Ke(h.width)?`${h.width}px`:h.width},(de=h.style)!=null?de:{}]}),B=S(()=>{const de=`${s(H)}px`;return{height:`${s(P)}px`,pointerEvents:s(N).isScrolling?"none":void 0,width:de}}),j=()=>{const{totalColumn:de,totalRow:Ce}=h;if(de>0&&Ce>0){const[ye,Re,Ye,dt]=s(X),[ot,St,vt,Wt]=s(F);y(sc,{columnCacheStart:ye,columnCacheEnd:Re,rowCacheStart:ot,rowCacheEnd:St,columnVisibleStart:Ye,columnVisibleEnd:dt,rowVisibleStart:vt,rowVisibleEnd:Wt})}const{scrollLeft:he,scrollTop:ke,updateRequested:xe,xAxisScrollDir:De,yAxisScrollDir:Ne}=s(N);y(ic,{xAxisScrollDir:De,scrollLeft:he,yAxisScrollDir:Ne,scrollTop:ke,updateRequested:xe})},G=de=>{const{clientHeight:Ce,clientWidth:he,scrollHeight:ke,scrollLeft:xe,scrollTop:De,scrollWidth:Ne}=de.currentTarget,ye=s(N);if(ye.scrollTop===De&&ye.scrollLeft===xe)return;let Re=xe;if(am(h.direction))switch(dc()){case ui:Re=-xe;break;case _2:Re=Ne-he-xe;break}N.value={...ye,isScrolling:!0,scrollLeft:Re,scrollTop:Math.max(0,Math.min(De,ke-Ce)),updateRequested:!0,xAxisScrollDir:kl(ye.scrollLeft,Re),yAxisScrollDir:kl(ye.scrollTop,De)},Be(()=>oe()),ae(),j()},te=(de,Ce)=>{const he=s(R),ke=(P.value-he)/Ce*de;ce({scrollTop:Math.min(P.value-he,ke)})},Y=(de,Ce)=>{const he=s(K),ke=(H.value-he)/Ce*de;ce({scrollLeft:Math.min(H.value-he,ke)})},{onWheel:ie}=DQ({atXStartEdge:S(()=>N.value.scrollLeft<=0),atXEndEdge:S(()=>N.value.scrollLeft>=H.value-s(K)),atYStartEdge:S(()=>N.value.scrollTop<=0),atYEndEdge:S(()=>N.value.scrollTop>=P.value-s(R))},(de,Ce)=>{var he,ke,xe,De;(ke=(he=$.value)==null?void 0:he.onMouseUp)==null||ke.call(he),(De=(xe=A.value)==null?void 0:xe.onMouseUp)==null||De.call(xe);const Ne=s(K),ye=s(R);ce({scrollLeft:Math.min(N.value.scrollLeft+de,H.value-Ne),scrollTop:Math.min(N.value.scrollTop+Ce,P.value-ye)})});Mt(E,"wheel",ie,{passive:!1});const ce=({scrollLeft:de=N.value.scrollLeft,scrollTop:Ce=N.value.scrollTop})=>{de=Math.max(de,0),Ce=Math.max(Ce,0);const he=s(N);Ce===he.scrollTop&&de===he.scrollLeft||(N.value={...he,xAxisScrollDir:kl(he.scrollLeft,de),yAxisScrollDir:kl(he.scrollTop,Ce),scrollLeft:de,scrollTop:Ce,updateRequested:!0},Be(()=>oe()),ae(),j())},re=(de=0,Ce=0,he=pa)=>{const ke=s(N);Ce=Math.max(0,Math.min(Ce,h.totalColumn-1)),de=Math.max(0,Math.min(de,h.totalRow-1));const xe=L6(w.namespace.value),De=s(C),Ne=l(h,De),ye=r(h,De);ce({scrollLeft:i(h,Ce,he,ke.scrollLeft,De,ye>h.width?xe:0),scrollTop:u(h,de,he,ke.scrollTop,De,Ne>h.height?xe:0)})},W=(de,Ce)=>{const{columnWidth:he,direction:ke,rowHeight:xe}=h,De=L.value(t&&he,t&&xe,t&&ke),Ne=`${de},${Ce}`;if(yt(De,Ne))return De[Ne];{const[,ye]=n(h,Ce,s(C)),Re=s(C),Ye=am(ke),[dt,ot]=c(h,de,Re),[St]=n(h,Ce,Re);return De[Ne]={position:"absolute",left:Ye?void 0:`${ye}px`,right:Ye?`${ye}px`:void 0,top:`${ot}px`,height:`${dt}px`,width:`${St}px`},De[Ne]}},oe=()=>{N.value.isScrolling=!1,Be(()=>{L.value(-1,null,null)})};at(()=>{if(!mt)return;const{initScrollLeft:de,initScrollTop:Ce}=h,he=s(E);he&&(Ke(de)&&(he.scrollLeft=de),Ke(Ce)&&(he.scrollTop=Ce)),j()});const ae=()=>{const{direction:de}=h,{scrollLeft:Ce,scrollTop:he,updateRequested:ke}=s(N),xe=s(E);if(ke&&xe){if(de===yr)switch(dc()){case ui:{xe.scrollLeft=-Ce;break}case y2:{xe.scrollLeft=Ce;break}default:{const{clientWidth:De,scrollWidth:Ne}=xe;xe.scrollLeft=Ne-De-Ce;break}}else xe.scrollLeft=Math.max(0,Ce);xe.scrollTop=Math.max(0,he)}},{resetAfterColumnIndex:we,resetAfterRowIndex:me,resetAfter:D}=_.proxy;g({windowRef:E,innerRef:O,getItemStyleCache:L,scrollTo:ce,scrollToItem:re,states:N,resetAfterColumnIndex:we,resetAfterRowIndex:me,resetAfter:D});const q=()=>{const{scrollbarAlwaysOn:de,scrollbarStartGap:Ce,scrollbarEndGap:he,totalColumn:ke,totalRow:xe}=h,De=s(K),Ne=s(R),ye=s(H),Re=s(P),{scrollLeft:Ye,scrollTop:dt}=s(N),ot=We(u0,{ref:$,alwaysOn:de,startGap:Ce,endGap:he,class:w.e("horizontal"),clientSize:De,layout:"horizontal",onScroll:Y,ratio:De*100/ye,scrollFrom:Ye/(ye-De),total:xe,visible:!0}),St=We(u0,{ref:A,alwaysOn:de,startGap:Ce,endGap:he,class:w.e("vertical"),clientSize:Ne,layout:"vertical",onScroll:te,ratio:Ne*100/Re,scrollFrom:dt/(Re-Ne),total:ke,visible:!0});return{horizontalScrollbar:ot,verticalScrollbar:St}},se=()=>{var de;const[Ce,he]=s(X),[ke,xe]=s(F),{data:De,totalColumn:Ne,totalRow:ye,useIsScrolling:Re,itemKey:Ye}=h,dt=[];if(ye>0&&Ne>0)for(let ot=ke;ot<=xe;ot++)for(let St=Ce;St<=he;St++){const vt=Ye({columnIndex:St,data:De,rowIndex:ot});dt.push(We(He,{key:vt},(de=k.default)==null?void 0:de.call(k,{columnIndex:St,data:De,isScrolling:Re?s(N).isScrolling:void 0,style:W(ot,St),rowIndex:ot})))}return dt},ve=()=>{const de=rt(h.innerElement),Ce=se();return[We(de,{style:s(B),ref:O},Ve(de)?Ce:{default:()=>Ce})]};return()=>{const de=rt(h.containerElement),{horizontalScrollbar:Ce,verticalScrollbar:he}=q(),ke=ve();return We("div",{key:0,class:w.e("wrapper"),role:h.role},[We(de,{class:h.className,style:s(z),onScroll:G,ref:E},Ve(de)?ke:{default:()=>ke}),Ce,he])}}}),{max:vc,min:Wy,floor:jy}=Math,FQ={column:"columnWidth",row:"rowHeight"},w0={column:"lastVisitedColumnIndex",row:"lastVisitedRowIndex"},fo=(e,t,n,a)=>{const[o,l,r]=[n[a],e[FQ[a]],n[w0[a]]];if(t>r){let i=0;if(r>=0){const u=o[r];i=u.offset+u.size}for(let u=r+1;u<=t;u++){const c=l(u);o[u]={offset:i,size:c},i+=c}n[w0[a]]=t}return o[t]},qy=(e,t,n,a,o,l)=>{for(;n<=a;){const r=n+jy((a-n)/2),i=fo(e,r,t,l).offset;if(i===o)return r;i<o?n=r+1:a=r-1}return vc(0,n-1)},KQ=(e,t,n,a,o)=>{const l=o==="column"?e.totalColumn:e.totalRow;let r=1;for(;n<l&&fo(e,n,t,o).offset<a;)n+=r,r*=2;return qy(e,t,jy(n/2),Wy(n,l-1),a,o)},bm=(e,t,n,a)=>{const[o,l]=[t[a],t[w0[a]]];return(l>0?o[l].offset:0)>=n?qy(e,t,0,l,n,a):KQ(e,t,vc(0,l),n,a)},Uy=({totalRow:e},{estimatedRowHeight:t,lastVisitedRowIndex:n,row:a})=>{let o=0;if(n>=e&&(n=e-1),n>=0){const i=a[n];o=i.offset+i.size}const r=(e-n-1)*t;return o+r},Yy=({totalColumn:e},{column:t,estimatedColumnWidth:n,lastVisitedColumnIndex:a})=>{let o=0;if(a>e&&(a=e-1),a>=0){const i=t[a];o=i.offset+i.size}const r=(e-a-1)*n;return o+r},WQ={column:Yy,row:Uy},wm=(e,t,n,a,o,l,r)=>{const[i,u]=[l==="row"?e.height:e.width,WQ[l]],c=fo(e,t,o,l),d=u(e,o),f=vc(0,Wy(d-i,c.offset)),p=vc(0,c.offset-i+r+c.size);switch(n===rd&&(a>=p-i&&a<=f+i?n=pa:n=Ua),n){case si:return f;case ii:return p;case Ua:return Math.round(p+(f-p)/2);case pa:default:return a>=p&&a<=f?a:p>f||a<p?p:f}},jQ=Ky({name:"ElDynamicSizeGrid",getColumnPosition:(e,t,n)=>{const a=fo(e,t,n,"column");return[a.size,a.offset]},getRowPosition:(e,t,n)=>{const a=fo(e,t,n,"row");return[a.size,a.offset]},getColumnOffset:(e,t,n,a,o,l)=>wm(e,t,n,a,o,"column",l),getRowOffset:(e,t,n,a,o,l)=>wm(e,t,n,a,o,"row",l),getColumnStartIndexForOffset:(e,t,n)=>bm(e,n,t,"column"),getColumnStopIndexForStartIndex:(e,t,n,a)=>{const o=fo(e,t,a,"column"),l=n+e.width;let r=o.offset+o.size,i=t;for(;i<e.totalColumn-1&&r<l;)i++,r+=fo(e,t,a,"column").size;return i},getEstimatedTotalHeight:Uy,getEstimatedTotalWidth:Yy,getRowStartIndexForOffset:(e,t,n)=>bm(e,n,t,"row"),getRowStopIndexForStartIndex:(e,t,n,a)=>{const{totalRow:o,height:l}=e,r=fo(e,t,a,"row"),i=n+l;let u=r.size+r.offset,c=t;for(;c<o-1&&u<i;)c++,u+=fo(e,c,a,"row").size;return c},injectToInstance:(e,t)=>{const n=({columnIndex:l,rowIndex:r},i)=>{var u,c;i=bt(i)?!0:i,Ke(l)&&(t.value.lastVisitedColumnIndex=Math.min(t.value.lastVisitedColumnIndex,l-1)),Ke(r)&&(t.value.lastVisitedRowIndex=Math.min(t.value.lastVisitedRowIndex,r-1)),(u=e.exposed)==null||u.getItemStyleCache.value(-1,null,null),i&&((c=e.proxy)==null||c.$forceUpdate())},a=(l,r)=>{n({columnIndex:l},r)},o=(l,r)=>{n({rowIndex:l},r)};Object.assign(e.proxy,{resetAfterColumnIndex:a,resetAfterRowIndex:o,resetAfter:n})},initCache:({estimatedColumnWidth:e=a0,estimatedRowHeight:t=a0})=>({column:{},estimatedColumnWidth:e,estimatedRowHeight:t,lastVisitedColumnIndex:-1,lastVisitedRowIndex:-1,row:{}}),clearCache:!1,validateProps:({columnWidth:e,rowHeight:t})=>{}}),qQ=Ky({name:"ElFixedSizeGrid",getColumnPosition:({columnWidth:e},t)=>[e,t*e],getRowPosition:({rowHeight:e},t)=>[e,t*e],getEstimatedTotalHeight:({totalRow:e,rowHeight:t})=>t*e,getEstimatedTotalWidth:({totalColumn:e,columnWidth:t})=>t*e,getColumnOffset:({totalColumn:e,columnWidth:t,width:n},a,o,l,r,i)=>{n=Number(n);const u=Math.max(0,e*t-n),c=Math.min(u,a*t),d=Math.max(0,a*t-n+i+t);switch(o==="smart"&&(l>=d-n&&l<=c+n?o=pa:o=Ua),o){case si:return c;case ii:return d;case Ua:{const f=Math.round(d+(c-d)/2);return f<Math.ceil(n/2)?0:f>u+Math.floor(n/2)?u:f}case pa:default:return l>=d&&l<=c?l:d>c||l<d?d:c}},getRowOffset:({rowHeight:e,height:t,totalRow:n},a,o,l,r,i)=>{t=Number(t);const u=Math.max(0,n*e-t),c=Math.min(u,a*e),d=Math.max(0,a*e-t+i+e);switch(o===rd&&(l>=d-t&&l<=c+t?o=pa:o=Ua),o){case si:return c;case ii:return d;case Ua:{const f=Math.round(d+(c-d)/2);return f<Math.ceil(t/2)?0:f>u+Math.floor(t/2)?u:f}case pa:default:return l>=d&&l<=c?l:d>c||l<d?d:c}},getColumnStartIndexForOffset:({columnWidth:e,totalColumn:t},n)=>Math.max(0,Math.min(t-1,Math.floor(n/e))),getColumnStopIndexForStartIndex:({columnWidth:e,totalColumn:t,width:n},a,o)=>{const l=a*e,r=Math.ceil((n+o-l)/e);return Math.max(0,Math.min(t-1,a+r-1))},getRowStartIndexForOffset:({rowHeight:e,totalRow:t},n)=>Math.max(0,Math.min(t-1,Math.floor(n/e))),getRowStopIndexForStartIndex:({rowHeight:e,totalRow:t,height:n},a,o)=>{const l=a*e,r=Math.ceil((n+o-l)/e);return Math.max(0,Math.min(t-1,a+r-1))},initCache:()=>{},clearCache:!0,validateProps:({columnWidth:e,rowHeight:t})=>{}}),UQ="ElTableV2Grid",YQ=e=>{const t=V(),n=V(),a=V(0),o=S(()=>{const{data:y,rowHeight:g,estimatedRowHeight:k}=e;if(!k)return y.length*g}),l=S(()=>{const{fixedData:y,rowHeight:g}=e;return((y==null?void 0:y.length)||0)*g}),r=S(()=>Nu(e.headerHeight)),i=S(()=>{const{height:y}=e;return Math.max(0,y-s(r)-s(l))}),u=S(()=>s(r)+s(l)>0),c=({data:y,rowIndex:g})=>y[g][e.rowKey];function d({rowCacheStart:y,rowCacheEnd:g,rowVisibleStart:k,rowVisibleEnd:w}){var _;(_=e.onRowsRendered)==null||_.call(e,{rowCacheStart:y,rowCacheEnd:g,rowVisibleStart:k,rowVisibleEnd:w})}function f(y,g){var k;(k=n.value)==null||k.resetAfterRowIndex(y,g)}function p(y,g){const k=s(t),w=s(n);nt(y)?(k==null||k.scrollToLeft(y.scrollLeft),a.value=y.scrollLeft,w==null||w.scrollTo(y)):(k==null||k.scrollToLeft(y),a.value=y,w==null||w.scrollTo({scrollLeft:y,scrollTop:g}))}function v(y){var g;(g=s(n))==null||g.scrollTo({scrollTop:y})}function m(y,g){var k;(k=s(n))==null||k.scrollToItem(y,1,g)}function h(){var y,g;(y=s(n))==null||y.$forceUpdate(),(g=s(t))==null||g.$forceUpdate()}return pe(()=>e.bodyWidth,()=>{var y;Ke(e.estimatedRowHeight)&&((y=n.value)==null||y.resetAfter({columnIndex:0},!1))}),{bodyRef:n,forceUpdate:h,fixedRowHeight:l,gridHeight:i,hasHeader:u,headerHeight:r,headerRef:t,totalHeight:o,itemKey:c,onItemRendered:d,resetAfterRowIndex:f,scrollTo:p,scrollToTop:v,scrollToRow:m,scrollLeft:a}},GQ=I({name:UQ,props:Pu,setup(e,{slots:t,expose:n}){const{ns:a}=Pe(k2),{bodyRef:o,fixedRowHeight:l,gridHeight:r,hasHeader:i,headerRef:u,headerHeight:c,totalHeight:d,forceUpdate:f,itemKey:p,onItemRendered:v,resetAfterRowIndex:m,scrollTo:h,scrollToTop:y,scrollToRow:g,scrollLeft:k}=YQ(e);ft("tableV2GridScrollLeft",k),n({forceUpdate:f,totalHeight:d,scrollTo:h,scrollToTop:y,scrollToRow:g,resetAfterRowIndex:m});const w=()=>e.bodyWidth;return()=>{const{cache:_,columns:C,data:E,fixedData:$,useIsScrolling:A,scrollbarAlwaysOn:O,scrollbarEndGap:N,scrollbarStartGap:L,style:R,rowHeight:K,bodyWidth:X,estimatedRowHeight:F,headerWidth:P,height:H,width:z,getRowHeight:B,onScroll:j}=e,G=Ke(F),te=G?jQ:qQ,Y=s(c);return U("div",{role:"table",class:[a.e("table"),e.class],style:R},[U(te,{ref:o,data:E,useIsScrolling:A,itemKey:p,columnCache:0,columnWidth:G?w:X,totalColumn:1,totalRow:E.length,rowCache:_,rowHeight:G?B:K,width:z,height:s(r),class:a.e("body"),role:"rowgroup",scrollbarStartGap:L,scrollbarEndGap:N,scrollbarAlwaysOn:O,onScroll:j,onItemRendered:v,perfMode:!1},{default:ie=>{var ce;const re=E[ie.rowIndex];return(ce=t.row)==null?void 0:ce.call(t,{...ie,columns:C,rowData:re})}}),s(i)&&U(HQ,{ref:u,class:a.e("header-wrapper"),columns:C,headerData:E,headerHeight:e.headerHeight,fixedHeaderData:$,rowWidth:P,rowHeight:K,width:z,height:Math.min(Y+s(l),H)},{dynamic:t.header,fixed:t.row})])}}});var M2=GQ;function XQ(e){return typeof e=="function"||Object.prototype.toString.call(e)==="[object Object]"&&!Bt(e)}const ZQ=(e,{slots:t})=>{const{mainTableRef:n,...a}=e;return U(M2,st({ref:n},a),XQ(t)?t:{default:()=>[t]})};var JQ=ZQ;function QQ(e){return typeof e=="function"||Object.prototype.toString.call(e)==="[object Object]"&&!Bt(e)}const eee=(e,{slots:t})=>{if(!e.columns.length)return;const{leftTableRef:n,...a}=e;return U(M2,st({ref:n},a),QQ(t)?t:{default:()=>[t]})};var tee=eee;function nee(e){return typeof e=="function"||Object.prototype.toString.call(e)==="[object Object]"&&!Bt(e)}const aee=(e,{slots:t})=>{if(!e.columns.length)return;const{rightTableRef:n,...a}=e;return U(M2,st({ref:n},a),nee(t)?t:{default:()=>[t]})};var oee=aee;const lee=e=>{const{isScrolling:t}=Pe(k2),n=V(!1),a=V(),o=S(()=>Ke(e.estimatedRowHeight)&&e.rowIndex>=0),l=(u=!1)=>{const c=s(a);if(!c)return;const{columns:d,onRowHeightChange:f,rowKey:p,rowIndex:v,style:m}=e,{height:h}=c.getBoundingClientRect();n.value=!0,Be(()=>{if(u||h!==Number.parseInt(m.height)){const y=d[0],g=(y==null?void 0:y.placeholderSign)===pi;f==null||f({rowKey:p,height:h,rowIndex:v},y&&!g&&y.fixed)}})},r=S(()=>{const{rowData:u,rowIndex:c,rowKey:d,onRowHover:f}=e,p=e.rowEventHandlers||{},v={};return Object.entries(p).forEach(([m,h])=>{ze(h)&&(v[m]=y=>{h({event:y,rowData:u,rowIndex:c,rowKey:d})})}),f&&[{name:"onMouseleave",hovered:!1},{name:"onMouseenter",hovered:!0}].forEach(({name:m,hovered:h})=>{const y=v[m];v[m]=g=>{f({event:g,hovered:h,rowData:u,rowIndex:c,rowKey:d}),y==null||y(g)}}),v}),i=u=>{const{onRowExpand:c,rowData:d,rowIndex:f,rowKey:p}=e;c==null||c({expanded:u,rowData:d,rowIndex:f,rowKey:p})};return at(()=>{s(o)&&l(!0)}),{isScrolling:t,measurable:o,measured:n,rowRef:a,eventHandlers:r,onExpand:i}},ree="ElTableV2TableRow",see=I({name:ree,props:El,setup(e,{expose:t,slots:n,attrs:a}){const{eventHandlers:o,isScrolling:l,measurable:r,measured:i,rowRef:u,onExpand:c}=lee(e);return t({onExpand:c}),()=>{const{columns:d,columnsStyles:f,expandColumnKey:p,depth:v,rowData:m,rowIndex:h,style:y}=e;let g=d.map((k,w)=>{const _=Se(m.children)&&m.children.length>0&&k.key===p;return n.cell({column:k,columns:d,columnIndex:w,depth:v,style:f[k.key],rowData:m,rowIndex:h,isScrolling:s(l),expandIconProps:_?{rowData:m,rowIndex:h,onExpand:c}:void 0})});if(n.row&&(g=n.row({cells:g.map(k=>Se(k)&&k.length===1?k[0]:k),style:y,columns:d,depth:v,rowData:m,rowIndex:h,isScrolling:s(l)})),s(r)){const{height:k,...w}=y||{},_=s(i);return U("div",st({ref:u,class:e.class,style:_?y:w,role:"row"},a,s(o)),[g])}return U("div",st(a,{ref:u,class:e.class,style:y,role:"row"},s(o)),[g])}}});var iee=see;function uee(e){return typeof e=="function"||Object.prototype.toString.call(e)==="[object Object]"&&!Bt(e)}const cee=(e,{slots:t})=>{const{columns:n,columnsStyles:a,depthMap:o,expandColumnKey:l,expandedRowKeys:r,estimatedRowHeight:i,hasFixedColumns:u,rowData:c,rowIndex:d,style:f,isScrolling:p,rowProps:v,rowClass:m,rowKey:h,rowEventHandlers:y,ns:g,onRowHovered:k,onRowExpanded:w}=e,_=Bl(m,{columns:n,rowData:c,rowIndex:d},""),C=Bl(v,{columns:n,rowData:c,rowIndex:d}),E=c[h],$=o[E]||0,A=!!l,O=d<0,N=[g.e("row"),_,{[g.e(`row-depth-${$}`)]:A&&d>=0,[g.is("expanded")]:A&&r.includes(E),[g.is("fixed")]:!$&&O,[g.is("customized")]:!!t.row}],L=u?k:void 0,R={...C,columns:n,columnsStyles:a,class:N,depth:$,expandColumnKey:l,estimatedRowHeight:O?void 0:i,isScrolling:p,rowIndex:d,rowData:c,rowKey:E,rowEventHandlers:y,style:f};return U(iee,st(R,{onRowExpand:w,onMouseenter:F=>{L==null||L({hovered:!0,rowKey:E,event:F,rowData:c,rowIndex:d})},onMouseleave:F=>{L==null||L({hovered:!1,rowKey:E,event:F,rowData:c,rowIndex:d})},rowkey:E}),uee(t)?t:{default:()=>[t]})};var dee=cee;const T2=(e,{slots:t})=>{var n;const{cellData:a,style:o}=e,l=((n=a==null?void 0:a.toString)==null?void 0:n.call(a))||"",r=ne(t,"default",e,()=>[l]);return U("div",{class:e.class,title:l,style:o},[r])};T2.displayName="ElTableV2Cell";T2.inheritAttrs=!1;var fee=T2;const pee=e=>{const{expanded:t,expandable:n,onExpand:a,style:o,size:l}=e,r={onClick:n?()=>a(!t):void 0,class:e.class};return U(Le,st(r,{size:l,style:o}),{default:()=>[U(Pn,null,null)]})};var vee=pee;const Gy=({columns:e,column:t,columnIndex:n,depth:a,expandIconProps:o,isScrolling:l,rowData:r,rowIndex:i,style:u,expandedRowKeys:c,ns:d,cellProps:f,expandColumnKey:p,indentSize:v,iconSize:m,rowKey:h},{slots:y})=>{const g=Yo(u);if(t.placeholderSign===pi)return U("div",{class:d.em("row-cell","placeholder"),style:g},null);const{cellRenderer:k,dataKey:w,dataGetter:_}=t,C=ze(_)?_({columns:e,column:t,columnIndex:n,rowData:r,rowIndex:i}):Zt(r,w??""),E=Bl(f,{cellData:C,columns:e,column:t,columnIndex:n,rowIndex:i,rowData:r}),$={class:d.e("cell-text"),columns:e,column:t,columnIndex:n,cellData:C,isScrolling:l,rowData:r,rowIndex:i},A=zy(k),O=A?A($):ne(y,"default",$,()=>[U(fee,$,null)]),N=[d.e("row-cell"),t.class,t.align===fi.CENTER&&d.is("align-center"),t.align===fi.RIGHT&&d.is("align-right")],L=i>=0&&p&&t.key===p,R=i>=0&&c.includes(r[h]);let K;const X=`margin-inline-start: ${a*v}px;`;return L&&(nt(o)?K=U(vee,st(o,{class:[d.e("expand-icon"),d.is("expanded",R)],size:m,expanded:R,style:X,expandable:!0}),null):K=U("div",{style:[X,`width: ${m}px; height: ${m}px;`].join(" ")},null)),U("div",st({class:N,style:g},E,{role:"cell"}),[K,O])};Gy.inheritAttrs=!1;var Cm=Gy;const hee=Ee({class:String,columns:Ai,columnsStyles:{type:Q(Object),required:!0},headerIndex:Number,style:{type:Q(Object)}}),mee=I({name:"ElTableV2HeaderRow",props:hee,setup(e,{slots:t}){return()=>{const{columns:n,columnsStyles:a,headerIndex:o,style:l}=e;let r=n.map((i,u)=>t.cell({columns:n,column:i,columnIndex:u,headerIndex:o,style:a[i.key]}));return t.header&&(r=t.header({cells:r.map(i=>Se(i)&&i.length===1?i[0]:i),columns:n,headerIndex:o})),U("div",{class:e.class,style:l,role:"row"},[r])}}});var gee=mee;function yee(e){return typeof e=="function"||Object.prototype.toString.call(e)==="[object Object]"&&!Bt(e)}const _ee=({columns:e,columnsStyles:t,headerIndex:n,style:a,headerClass:o,headerProps:l,ns:r},{slots:i})=>{const u={columns:e,headerIndex:n},c=[r.e("header-row"),Bl(o,u,""),{[r.is("customized")]:!!i.header}],d={...Bl(l,u),columnsStyles:t,class:c,columns:e,headerIndex:n,style:a};return U(gee,d,yee(i)?i:{default:()=>[i]})};var bee=_ee;const $2=(e,{slots:t})=>ne(t,"default",e,()=>{var n,a;return[U("div",{class:e.class,title:(n=e.column)==null?void 0:n.title},[(a=e.column)==null?void 0:a.title])]});$2.displayName="ElTableV2HeaderCell";$2.inheritAttrs=!1;var wee=$2;const Cee=e=>{const{sortOrder:t}=e;return U(Le,{size:14,class:e.class},{default:()=>[t===di.ASC?U(n3,null,null):U(t3,null,null)]})};var See=Cee;const kee=(e,{slots:t})=>{const{column:n,ns:a,style:o,onColumnSorted:l}=e,r=Yo(o);if(n.placeholderSign===pi)return U("div",{class:a.em("header-row-cell","placeholder"),style:r},null);const{headerCellRenderer:i,headerClass:u,sortable:c}=n,d={...e,class:a.e("header-cell-text")},f=zy(i),p=f?f(d):ne(t,"default",d,()=>[U(wee,d,null)]),{sortBy:v,sortState:m,headerCellProps:h}=e;let y,g;if(m){const _=m[n.key];y=!!b0[_],g=y?_:di.ASC}else y=n.key===v.key,g=y?v.order:di.ASC;const k=[a.e("header-cell"),Bl(u,e,""),n.align===fi.CENTER&&a.is("align-center"),n.align===fi.RIGHT&&a.is("align-right"),c&&a.is("sortable")],w={...Bl(h,e),onClick:n.sortable?l:void 0,class:k,style:r,"data-key":n.key};return U("div",st(w,{role:"columnheader"}),[p,c&&U(See,{class:[a.e("sort-icon"),y&&a.is("sorting")],sortOrder:g},null)])};var Sm=kee;const Xy=(e,{slots:t})=>{var n;return U("div",{class:e.class,style:e.style},[(n=t.default)==null?void 0:n.call(t)])};Xy.displayName="ElTableV2Footer";var Eee=Xy;const Zy=(e,{slots:t})=>{const n=ne(t,"default",{},()=>[U(j8,null,null)]);return U("div",{class:e.class,style:e.style},[n])};Zy.displayName="ElTableV2Empty";var xee=Zy;const Jy=(e,{slots:t})=>{var n;return U("div",{class:e.class,style:e.style},[(n=t.default)==null?void 0:n.call(t)])};Jy.displayName="ElTableV2Overlay";var Mee=Jy;function hs(e){return typeof e=="function"||Object.prototype.toString.call(e)==="[object Object]"&&!Bt(e)}const Tee="ElTableV2",$ee=I({name:Tee,props:VQ,setup(e,{slots:t,expose:n}){const a=ge("table-v2"),{columnsStyles:o,fixedColumnsOnLeft:l,fixedColumnsOnRight:r,mainColumns:i,mainTableHeight:u,fixedTableHeight:c,leftTableWidth:d,rightTableWidth:f,data:p,depthMap:v,expandedRowKeys:m,hasFixedColumns:h,mainTableRef:y,leftTableRef:g,rightTableRef:k,isDynamic:w,isResetting:_,isScrolling:C,bodyWidth:E,emptyStyle:$,rootStyle:A,headerWidth:O,footerHeight:N,showEmpty:L,scrollTo:R,scrollToLeft:K,scrollToTop:X,scrollToRow:F,getRowHeight:P,onColumnSorted:H,onRowHeightChange:z,onRowHovered:B,onRowExpanded:j,onRowsRendered:G,onScroll:te,onVerticalScroll:Y}=RQ(e);return n({scrollTo:R,scrollToLeft:K,scrollToTop:X,scrollToRow:F}),ft(k2,{ns:a,isResetting:_,isScrolling:C}),()=>{const{cache:ie,cellProps:ce,estimatedRowHeight:re,expandColumnKey:W,fixedData:oe,headerHeight:ae,headerClass:we,headerProps:me,headerCellProps:D,sortBy:q,sortState:se,rowHeight:ve,rowClass:be,rowEventHandlers:de,rowKey:Ce,rowProps:he,scrollbarAlwaysOn:ke,indentSize:xe,iconSize:De,useIsScrolling:Ne,vScrollbarSize:ye,width:Re}=e,Ye=s(p),dt={cache:ie,class:a.e("main"),columns:s(i),data:Ye,fixedData:oe,estimatedRowHeight:re,bodyWidth:s(E)+ye,headerHeight:ae,headerWidth:s(O),height:s(u),mainTableRef:y,rowKey:Ce,rowHeight:ve,scrollbarAlwaysOn:ke,scrollbarStartGap:2,scrollbarEndGap:ye,useIsScrolling:Ne,width:Re,getRowHeight:P,onRowsRendered:G,onScroll:te},ot=s(d),St=s(c),vt={cache:ie,class:a.e("left"),columns:s(l),data:Ye,fixedData:oe,estimatedRowHeight:re,leftTableRef:g,rowHeight:ve,bodyWidth:ot,headerWidth:ot,headerHeight:ae,height:St,rowKey:Ce,scrollbarAlwaysOn:ke,scrollbarStartGap:2,scrollbarEndGap:ye,useIsScrolling:Ne,width:ot,getRowHeight:P,onScroll:Y},je=s(f)+ye,_e={cache:ie,class:a.e("right"),columns:s(r),data:Ye,fixedData:oe,estimatedRowHeight:re,rightTableRef:k,rowHeight:ve,bodyWidth:je,headerWidth:je,headerHeight:ae,height:St,rowKey:Ce,scrollbarAlwaysOn:ke,scrollbarStartGap:2,scrollbarEndGap:ye,width:je,style:`--${s(a.namespace)}-table-scrollbar-size: ${ye}px`,useIsScrolling:Ne,getRowHeight:P,onScroll:Y},ee=s(o),fe={ns:a,depthMap:s(v),columnsStyles:ee,expandColumnKey:W,expandedRowKeys:s(m),estimatedRowHeight:re,hasFixedColumns:s(h),rowProps:he,rowClass:be,rowKey:Ce,rowEventHandlers:de,onRowHovered:B,onRowExpanded:j,onRowHeightChange:z},Z={cellProps:ce,expandColumnKey:W,indentSize:xe,iconSize:De,rowKey:Ce,expandedRowKeys:s(m),ns:a},Ie={ns:a,headerClass:we,headerProps:me,columnsStyles:ee},et={ns:a,sortBy:q,sortState:se,headerCellProps:D,onColumnSorted:H},tt={row:en=>U(dee,st(en,fe),{row:t.row,cell:ln=>{let zt;return t.cell?U(Cm,st(ln,Z,{style:ee[ln.column.key]}),hs(zt=t.cell(ln))?zt:{default:()=>[zt]}):U(Cm,st(ln,Z,{style:ee[ln.column.key]}),null)}}),header:en=>U(bee,st(en,Ie),{header:t.header,cell:ln=>{let zt;return t["header-cell"]?U(Sm,st(ln,et,{style:ee[ln.column.key]}),hs(zt=t["header-cell"](ln))?zt:{default:()=>[zt]}):U(Sm,st(ln,et,{style:ee[ln.column.key]}),null)}})},Lt=[e.class,a.b(),a.e("root"),{[a.is("dynamic")]:s(w)}],ia={class:a.e("footer"),style:s(N)};return U("div",{class:Lt,style:s(A)},[U(JQ,dt,hs(tt)?tt:{default:()=>[tt]}),U(tee,vt,hs(tt)?tt:{default:()=>[tt]}),U(oee,_e,hs(tt)?tt:{default:()=>[tt]}),t.footer&&U(Eee,ia,{default:t.footer}),s(L)&&U(xee,{class:a.e("empty"),style:s($)},{default:t.empty}),t.overlay&&U(Mee,{class:a.e("overlay")},{default:t.overlay})])}}});var Oee=$ee;const Iee=Ee({disableWidth:Boolean,disableHeight:Boolean,onResize:{type:Q(Function)}}),Aee=e=>{const t=V(),n=V(0),a=V(0);let o;return at(()=>{o=Dt(t,([l])=>{const{width:r,height:i}=l.contentRect,{paddingLeft:u,paddingRight:c,paddingTop:d,paddingBottom:f}=getComputedStyle(l.target),p=Number.parseInt(u)||0,v=Number.parseInt(c)||0,m=Number.parseInt(d)||0,h=Number.parseInt(f)||0;n.value=r-p-v,a.value=i-m-h}).stop}),At(()=>{o==null||o()}),pe([n,a],([l,r])=>{var i;(i=e.onResize)==null||i.call(e,{width:l,height:r})}),{sizer:t,width:n,height:a}},Nee=I({name:"ElAutoResizer",props:Iee,setup(e,{slots:t}){const n=ge("auto-resizer"),{height:a,width:o,sizer:l}=Aee(e),r={width:"100%",height:"100%"};return()=>{var i;return U("div",{ref:l,class:n.b(),style:r},[(i=t.default)==null?void 0:i.call(t,{height:a.value,width:o.value})])}}});var Pee=Nee;const Ree=Ze(Oee),Lee=Ze(Pee),sd=Symbol("tabsRootContextKey"),Vee=Ee({tabs:{type:Q(Array),default:()=>Ft([])}}),Qy="ElTabBar",Bee=I({name:Qy}),zee=I({...Bee,props:Vee,setup(e,{expose:t}){const n=e,a=lt(),o=Pe(sd);o||nn(Qy,"<el-tabs><el-tab-bar /></el-tabs>");const l=ge("tabs"),r=V(),i=V(),u=()=>{let v=0,m=0;const h=["top","bottom"].includes(o.props.tabPosition)?"width":"height",y=h==="width"?"x":"y",g=y==="x"?"left":"top";return n.tabs.every(k=>{var w,_;const C=(_=(w=a.parent)==null?void 0:w.refs)==null?void 0:_[`tab-${k.uid}`];if(!C)return!1;if(!k.active)return!0;v=C[`offset${qa(g)}`],m=C[`client${qa(h)}`];const E=window.getComputedStyle(C);return h==="width"&&(m-=Number.parseFloat(E.paddingLeft)+Number.parseFloat(E.paddingRight),v+=Number.parseFloat(E.paddingLeft)),!1}),{[h]:`${m}px`,transform:`translate${qa(y)}(${v}px)`}},c=()=>i.value=u(),d=[],f=()=>{var v;d.forEach(h=>h.stop()),d.length=0;const m=(v=a.parent)==null?void 0:v.refs;if(m){for(const h in m)if(h.startsWith("tab-")){const y=m[h];y&&d.push(Dt(y,c))}}};pe(()=>n.tabs,async()=>{await Be(),c(),f()},{immediate:!0});const p=Dt(r,()=>c());return At(()=>{d.forEach(v=>v.stop()),d.length=0,p.stop()}),t({ref:r,update:c}),(v,m)=>(b(),M("div",{ref_key:"barRef",ref:r,class:T([s(l).e("active-bar"),s(l).is(s(o).props.tabPosition)]),style:Fe(i.value)},null,6))}});var Hee=Me(zee,[["__file","tab-bar.vue"]]);const Dee=Ee({panes:{type:Q(Array),default:()=>Ft([])},currentName:{type:[String,Number],default:""},editable:Boolean,type:{type:String,values:["card","border-card",""],default:""},stretch:Boolean}),Fee={tabClick:(e,t,n)=>n instanceof Event,tabRemove:(e,t)=>t instanceof Event},km="ElTabNav",Kee=I({name:km,props:Dee,emits:Fee,setup(e,{expose:t,emit:n}){const a=Pe(sd);a||nn(km,"<el-tabs><tab-nav /></el-tabs>");const o=ge("tabs"),l=sx(),r=_x(),i=V(),u=V(),c=V(),d=V(),f=V(!1),p=V(0),v=V(!1),m=V(!0),h=S(()=>["top","bottom"].includes(a.props.tabPosition)?"width":"height"),y=S(()=>({transform:`translate${h.value==="width"?"X":"Y"}(-${p.value}px)`})),g=()=>{if(!i.value)return;const A=i.value[`offset${qa(h.value)}`],O=p.value;if(!O)return;const N=O>A?O-A:0;p.value=N},k=()=>{if(!i.value||!u.value)return;const A=u.value[`offset${qa(h.value)}`],O=i.value[`offset${qa(h.value)}`],N=p.value;if(A-N<=O)return;const L=A-N>O*2?N+O:A-O;p.value=L},w=async()=>{const A=u.value;if(!f.value||!c.value||!i.value||!A)return;await Be();const O=c.value.querySelector(".is-active");if(!O)return;const N=i.value,L=["top","bottom"].includes(a.props.tabPosition),R=O.getBoundingClientRect(),K=N.getBoundingClientRect(),X=L?A.offsetWidth-K.width:A.offsetHeight-K.height,F=p.value;let P=F;L?(R.left<K.left&&(P=F-(K.left-R.left)),R.right>K.right&&(P=F+R.right-K.right)):(R.top<K.top&&(P=F-(K.top-R.top)),R.bottom>K.bottom&&(P=F+(R.bottom-K.bottom))),P=Math.max(P,0),p.value=Math.min(P,X)},_=()=>{var A;if(!u.value||!i.value)return;e.stretch&&((A=d.value)==null||A.update());const O=u.value[`offset${qa(h.value)}`],N=i.value[`offset${qa(h.value)}`],L=p.value;N<O?(f.value=f.value||{},f.value.prev=L,f.value.next=L+N<O,O-L<N&&(p.value=O-N)):(f.value=!1,L>0&&(p.value=0))},C=A=>{let O=0;switch(A.code){case Ae.left:case Ae.up:O=-1;break;case Ae.right:case Ae.down:O=1;break;default:return}const N=Array.from(A.currentTarget.querySelectorAll("[role=tab]:not(.is-disabled)"));let R=N.indexOf(A.target)+O;R<0?R=N.length-1:R>=N.length&&(R=0),N[R].focus({preventScroll:!0}),N[R].click(),E()},E=()=>{m.value&&(v.value=!0)},$=()=>v.value=!1;return pe(l,A=>{A==="hidden"?m.value=!1:A==="visible"&&setTimeout(()=>m.value=!0,50)}),pe(r,A=>{A?setTimeout(()=>m.value=!0,50):m.value=!1}),Dt(c,_),at(()=>setTimeout(()=>w(),0)),La(()=>_()),t({scrollToActiveTab:w,removeFocus:$}),()=>{const A=f.value?[U("span",{class:[o.e("nav-prev"),o.is("disabled",!f.value.prev)],onClick:g},[U(Le,null,{default:()=>[U(wo,null,null)]})]),U("span",{class:[o.e("nav-next"),o.is("disabled",!f.value.next)],onClick:k},[U(Le,null,{default:()=>[U(Pn,null,null)]})])]:null,O=e.panes.map((N,L)=>{var R,K,X,F;const P=N.uid,H=N.props.disabled,z=(K=(R=N.props.name)!=null?R:N.index)!=null?K:`${L}`,B=!H&&(N.isClosable||e.editable);N.index=`${L}`;const j=B?U(Le,{class:"is-icon-close",onClick:Y=>n("tabRemove",N,Y)},{default:()=>[U(ga,null,null)]}):null,G=((F=(X=N.slots).label)==null?void 0:F.call(X))||N.props.label,te=!H&&N.active?0:-1;return U("div",{ref:`tab-${P}`,class:[o.e("item"),o.is(a.props.tabPosition),o.is("active",N.active),o.is("disabled",H),o.is("closable",B),o.is("focus",v.value)],id:`tab-${z}`,key:`tab-${P}`,"aria-controls":`pane-${z}`,role:"tab","aria-selected":N.active,tabindex:te,onFocus:()=>E(),onBlur:()=>$(),onClick:Y=>{$(),n("tabClick",N,z,Y)},onKeydown:Y=>{B&&(Y.code===Ae.delete||Y.code===Ae.backspace)&&n("tabRemove",N,Y)}},[G,j])});return U("div",{ref:c,class:[o.e("nav-wrap"),o.is("scrollable",!!f.value),o.is(a.props.tabPosition)]},[A,U("div",{class:o.e("nav-scroll"),ref:i},[U("div",{class:[o.e("nav"),o.is(a.props.tabPosition),o.is("stretch",e.stretch&&["top","bottom"].includes(a.props.tabPosition))],ref:u,style:y.value,role:"tablist",onKeydown:C},[e.type?null:U(Hee,{ref:d,tabs:[...e.panes]},null),O])])])}}}),Wee=Ee({type:{type:String,values:["card","border-card",""],default:""},closable:Boolean,addable:Boolean,modelValue:{type:[String,Number]},editable:Boolean,tabPosition:{type:String,values:["top","right","bottom","left"],default:"top"},beforeLeave:{type:Q(Function),default:()=>!0},stretch:Boolean}),nf=e=>Ve(e)||Ke(e),jee={[Ue]:e=>nf(e),tabClick:(e,t)=>t instanceof Event,tabChange:e=>nf(e),edit:(e,t)=>["remove","add"].includes(t),tabRemove:e=>nf(e),tabAdd:()=>!0},qee=I({name:"ElTabs",props:Wee,emits:jee,setup(e,{emit:t,slots:n,expose:a}){var o;const l=ge("tabs"),r=S(()=>["left","right"].includes(e.tabPosition)),{children:i,addChild:u,removeChild:c}=Yp(lt(),"ElTabPane"),d=V(),f=V((o=e.modelValue)!=null?o:"0"),p=async(g,k=!1)=>{var w,_;if(!(f.value===g||bt(g)))try{let C;if(e.beforeLeave){const E=e.beforeLeave(g,f.value);C=E instanceof Promise?await E:E}else C=!0;C!==!1&&(f.value=g,k&&(t(Ue,g),t("tabChange",g)),(_=(w=d.value)==null?void 0:w.removeFocus)==null||_.call(w))}catch{}},v=(g,k,w)=>{g.props.disabled||(p(k,!0),t("tabClick",g,w))},m=(g,k)=>{g.props.disabled||bt(g.props.name)||(k.stopPropagation(),t("edit",g.props.name,"remove"),t("tabRemove",g.props.name))},h=()=>{t("edit",void 0,"add"),t("tabAdd")};pe(()=>e.modelValue,g=>p(g)),pe(f,async()=>{var g;await Be(),(g=d.value)==null||g.scrollToActiveTab()}),ft(sd,{props:e,currentName:f,registerPane:g=>{i.value.push(g)},sortPane:u,unregisterPane:c}),a({currentName:f});const y=({render:g})=>g();return()=>{const g=n["add-icon"],k=e.editable||e.addable?U("div",{class:[l.e("new-tab"),r.value&&l.e("new-tab-vertical")],tabindex:"0",onClick:h,onKeydown:C=>{[Ae.enter,Ae.numpadEnter].includes(C.code)&&h()}},[g?ne(n,"add-icon"):U(Le,{class:l.is("icon-plus")},{default:()=>[U(Tp,null,null)]})]):null,w=U("div",{class:[l.e("header"),r.value&&l.e("header-vertical"),l.is(e.tabPosition)]},[U(y,{render:()=>{const C=i.value.some(E=>E.slots.label);return U(Kee,{ref:d,currentName:f.value,editable:e.editable,type:e.type,panes:i.value,stretch:e.stretch,onTabClick:v,onTabRemove:m},{$stable:!C})}},null),k]),_=U("div",{class:l.e("content")},[ne(n,"default")]);return U("div",{class:[l.b(),l.m(e.tabPosition),{[l.m("card")]:e.type==="card",[l.m("border-card")]:e.type==="border-card"}]},[_,w])}}});var Uee=qee;const Yee=Ee({label:{type:String,default:""},name:{type:[String,Number]},closable:Boolean,disabled:Boolean,lazy:Boolean}),e_="ElTabPane",Gee=I({name:e_}),Xee=I({...Gee,props:Yee,setup(e){const t=e,n=lt(),a=an(),o=Pe(sd);o||nn(e_,"usage: <el-tabs><el-tab-pane /></el-tabs/>");const l=ge("tab-pane"),r=V(),i=S(()=>t.closable||o.props.closable),u=Af(()=>{var v;return o.currentName.value===((v=t.name)!=null?v:r.value)}),c=V(u.value),d=S(()=>{var v;return(v=t.name)!=null?v:r.value}),f=Af(()=>!t.lazy||c.value||u.value);pe(u,v=>{v&&(c.value=!0)});const p=Ct({uid:n.uid,slots:a,props:t,paneName:d,active:u,index:r,isClosable:i});return o.registerPane(p),at(()=>{o.sortPane(p)}),rl(()=>{o.unregisterPane(p.uid)}),(v,m)=>s(f)?Qe((b(),M("div",{key:0,id:`pane-${s(d)}`,class:T(s(l).b()),role:"tabpanel","aria-hidden":!s(u),"aria-labelledby":`tab-${s(d)}`},[ne(v.$slots,"default")],10,["id","aria-hidden","aria-labelledby"])),[[_t,s(u)]]):le("v-if",!0)}});var t_=Me(Xee,[["__file","tab-pane.vue"]]);const Zee=Ze(Uee,{TabPane:t_}),Jee=Kt(t_),Qee=Ee({type:{type:String,values:["primary","success","info","warning","danger",""],default:""},size:{type:String,values:to,default:""},truncated:Boolean,lineClamp:{type:[String,Number]},tag:{type:String,default:"span"}}),ete=I({name:"ElText"}),tte=I({...ete,props:Qee,setup(e){const t=e,n=V(),a=Jt(),o=ge("text"),l=S(()=>[o.b(),o.m(t.type),o.m(a.value),o.is("truncated",t.truncated),o.is("line-clamp",!bt(t.lineClamp))]),r=$o().title,i=()=>{var u,c,d,f,p;if(r)return;let v=!1;const m=((u=n.value)==null?void 0:u.textContent)||"";if(t.truncated){const h=(c=n.value)==null?void 0:c.offsetWidth,y=(d=n.value)==null?void 0:d.scrollWidth;h&&y&&y>h&&(v=!0)}else if(!bt(t.lineClamp)){const h=(f=n.value)==null?void 0:f.offsetHeight,y=(p=n.value)==null?void 0:p.scrollHeight;h&&y&&y>h&&(v=!0)}v?n.value.setAttribute("title",m):n.value.removeAttribute("title")};return at(i),La(i),(u,c)=>(b(),ue(rt(u.tag),{ref_key:"textRef",ref:n,class:T(s(l)),style:Fe({"-webkit-line-clamp":u.lineClamp})},{default:J(()=>[ne(u.$slots,"default")]),_:3},8,["class","style"]))}});var nte=Me(tte,[["__file","text.vue"]]);const ate=Ze(nte),ote=Ee({format:{type:String,default:"HH:mm"},modelValue:String,disabled:Boolean,editable:{type:Boolean,default:!0},effect:{type:Q(String),default:"light"},clearable:{type:Boolean,default:!0},size:Qt,placeholder:String,start:{type:String,default:"09:00"},end:{type:String,default:"18:00"},step:{type:String,default:"00:30"},minTime:String,maxTime:String,includeEndTime:{type:Boolean,default:!1},name:String,prefixIcon:{type:Q([String,Object]),default:()=>Mp},clearIcon:{type:Q([String,Object]),default:()=>ao},...Zr}),mo=e=>{const t=(e||"").split(":");if(t.length>=2){let n=Number.parseInt(t[0],10);const a=Number.parseInt(t[1],10),o=e.toUpperCase();return o.includes("AM")&&n===12?n=0:o.includes("PM")&&n!==12&&(n+=12),{hours:n,minutes:a}}return null},af=(e,t)=>{const n=mo(e);if(!n)return-1;const a=mo(t);if(!a)return-1;const o=n.minutes+n.hours*60,l=a.minutes+a.hours*60;return o===l?0:o>l?1:-1},Em=e=>`${e}`.padStart(2,"0"),or=e=>`${Em(e.hours)}:${Em(e.minutes)}`,lte=(e,t)=>{const n=mo(e);if(!n)return"";const a=mo(t);if(!a)return"";const o={hours:n.hours,minutes:n.minutes};return o.minutes+=a.minutes,o.hours+=a.hours,o.hours+=Math.floor(o.minutes/60),o.minutes=o.minutes%60,or(o)},rte=I({name:"ElTimeSelect"}),ste=I({...rte,props:ote,emits:[it,"blur","focus","clear",Ue],setup(e,{expose:t}){const n=e;Je.extend(Jp);const{Option:a}=Vl,o=ge("input"),l=V(),r=En(),{lang:i}=gt(),u=S(()=>n.modelValue),c=S(()=>{const g=mo(n.start);return g?or(g):null}),d=S(()=>{const g=mo(n.end);return g?or(g):null}),f=S(()=>{const g=mo(n.step);return g?or(g):null}),p=S(()=>{const g=mo(n.minTime||"");return g?or(g):null}),v=S(()=>{const g=mo(n.maxTime||"");return g?or(g):null}),m=S(()=>{var g;const k=[],w=(_,C)=>{k.push({value:_,disabled:af(C,p.value||"-1:-1")<=0||af(C,v.value||"100:100")>=0})};if(n.start&&n.end&&n.step){let _=c.value,C;for(;_&&d.value&&af(_,d.value)<=0;)C=Je(_,"HH:mm").locale(i.value).format(n.format),w(C,_),_=lte(_,f.value);if(n.includeEndTime&&d.value&&((g=k[k.length-1])==null?void 0:g.value)!==d.value){const E=Je(d.value,"HH:mm").locale(i.value).format(n.format);w(E,d.value)}}return k});return t({blur:()=>{var g,k;(k=(g=l.value)==null?void 0:g.blur)==null||k.call(g)},focus:()=>{var g,k;(k=(g=l.value)==null?void 0:g.focus)==null||k.call(g)}}),(g,k)=>(b(),ue(s(Vl),{ref_key:"select",ref:l,"model-value":s(u),disabled:s(r),clearable:g.clearable,"clear-icon":g.clearIcon,size:g.size,effect:g.effect,placeholder:g.placeholder,"default-first-option":"",filterable:g.editable,"empty-values":g.emptyValues,"value-on-clear":g.valueOnClear,"onUpdate:modelValue":w=>g.$emit(s(Ue),w),onChange:w=>g.$emit(s(it),w),onBlur:w=>g.$emit("blur",w),onFocus:w=>g.$emit("focus",w),onClear:()=>g.$emit("clear")},{prefix:J(()=>[g.prefixIcon?(b(),ue(s(Le),{key:0,class:T(s(o).e("prefix-icon"))},{default:J(()=>[(b(),ue(rt(g.prefixIcon)))]),_:1},8,["class"])):le("v-if",!0)]),default:J(()=>[(b(!0),M(He,null,pt(s(m),w=>(b(),ue(s(a),{key:w.value,label:w.value,value:w.value,disabled:w.disabled},null,8,["label","value","disabled"]))),128))]),_:1},8,["model-value","disabled","clearable","clear-icon","size","effect","placeholder","filterable","empty-values","value-on-clear","onUpdate:modelValue","onChange","onBlur","onFocus","onClear"]))}});var ite=Me(ste,[["__file","time-select.vue"]]);const ute=Ze(ite),cte=I({name:"ElTimeline",setup(e,{slots:t}){const n=ge("timeline");return ft("timeline",t),()=>We("ul",{class:[n.b()]},[ne(t,"default")])}}),dte=Ee({timestamp:{type:String,default:""},hideTimestamp:Boolean,center:Boolean,placement:{type:String,values:["top","bottom"],default:"bottom"},type:{type:String,values:["primary","success","warning","danger","info"],default:""},color:{type:String,default:""},size:{type:String,values:["normal","large"],default:"normal"},icon:{type:Tt},hollow:Boolean}),fte=I({name:"ElTimelineItem"}),pte=I({...fte,props:dte,setup(e){const t=e,n=ge("timeline-item"),a=S(()=>[n.e("node"),n.em("node",t.size||""),n.em("node",t.type||""),n.is("hollow",t.hollow)]);return(o,l)=>(b(),M("li",{class:T([s(n).b(),{[s(n).e("center")]:o.center}])},[x("div",{class:T(s(n).e("tail"))},null,2),o.$slots.dot?le("v-if",!0):(b(),M("div",{key:0,class:T(s(a)),style:Fe({backgroundColor:o.color})},[o.icon?(b(),ue(s(Le),{key:0,class:T(s(n).e("icon"))},{default:J(()=>[(b(),ue(rt(o.icon)))]),_:1},8,["class"])):le("v-if",!0)],6)),o.$slots.dot?(b(),M("div",{key:1,class:T(s(n).e("dot"))},[ne(o.$slots,"dot")],2)):le("v-if",!0),x("div",{class:T(s(n).e("wrapper"))},[!o.hideTimestamp&&o.placement==="top"?(b(),M("div",{key:0,class:T([s(n).e("timestamp"),s(n).is("top")])},Te(o.timestamp),3)):le("v-if",!0),x("div",{class:T(s(n).e("content"))},[ne(o.$slots,"default")],2),!o.hideTimestamp&&o.placement==="bottom"?(b(),M("div",{key:1,class:T([s(n).e("timestamp"),s(n).is("bottom")])},Te(o.timestamp),3)):le("v-if",!0)],2)],2))}});var n_=Me(pte,[["__file","timeline-item.vue"]]);const vte=Ze(cte,{TimelineItem:n_}),hte=Kt(n_),a_=Ee({nowrap:Boolean});var o_=(e=>(e.top="top",e.bottom="bottom",e.left="left",e.right="right",e))(o_||{});const mte=Object.values(o_),O2=Ee({width:{type:Number,default:10},height:{type:Number,default:10},style:{type:Q(Object),default:null}}),gte=Ee({side:{type:Q(String),values:mte,required:!0}}),yte=["absolute","fixed"],_te=["top-start","top-end","top","bottom-start","bottom-end","bottom","left-start","left-end","left","right-start","right-end","right"],I2=Ee({arrowPadding:{type:Q(Number),default:5},effect:{type:Q(String),default:"light"},contentClass:String,placement:{type:Q(String),values:_te,default:"bottom"},reference:{type:Q(Object),default:null},offset:{type:Number,default:8},strategy:{type:Q(String),values:yte,default:"absolute"},showArrow:Boolean,...yn(["ariaLabel"])}),A2=Ee({delayDuration:{type:Number,default:300},defaultOpen:Boolean,open:{type:Boolean,default:void 0},onOpenChange:{type:Q(Function)},"onUpdate:open":{type:Q(Function)}}),Jl={type:Q(Function)},N2=Ee({onBlur:Jl,onClick:Jl,onFocus:Jl,onMouseDown:Jl,onMouseEnter:Jl,onMouseLeave:Jl}),bte=Ee({...A2,...O2,...N2,...I2,alwaysOn:Boolean,fullTransition:Boolean,transitionProps:{type:Q(Object),default:null},teleported:Boolean,to:{type:Q(String),default:"body"}}),id=Symbol("tooltipV2"),l_=Symbol("tooltipV2Content"),of="tooltip_v2.open",wte=I({name:"ElTooltipV2Root"}),Cte=I({...wte,props:A2,setup(e,{expose:t}){const n=e,a=V(n.defaultOpen),o=V(null),l=S({get:()=>$a(n.open)?a.value:n.open,set:y=>{var g;a.value=y,(g=n["onUpdate:open"])==null||g.call(n,y)}}),r=S(()=>Ke(n.delayDuration)&&n.delayDuration>0),{start:i,stop:u}=Rl(()=>{l.value=!0},S(()=>n.delayDuration),{immediate:!1}),c=ge("tooltip-v2"),d=gn(),f=()=>{u(),l.value=!0},p=()=>{s(r)?i():f()},v=f,m=()=>{u(),l.value=!1};return pe(l,y=>{var g;y&&(document.dispatchEvent(new CustomEvent(of)),v()),(g=n.onOpenChange)==null||g.call(n,y)}),at(()=>{document.addEventListener(of,m)}),At(()=>{u(),document.removeEventListener(of,m)}),ft(id,{contentId:d,triggerRef:o,ns:c,onClose:m,onDelayOpen:p,onOpen:v}),t({onOpen:v,onClose:m}),(y,g)=>ne(y.$slots,"default",{open:s(l)})}});var Ste=Me(Cte,[["__file","root.vue"]]);const kte=I({name:"ElTooltipV2Arrow"}),Ete=I({...kte,props:{...O2,...gte},setup(e){const t=e,{ns:n}=Pe(id),{arrowRef:a}=Pe(l_),o=S(()=>{const{style:l,width:r,height:i}=t,u=n.namespace.value;return{[`--${u}-tooltip-v2-arrow-width`]:`${r}px`,[`--${u}-tooltip-v2-arrow-height`]:`${i}px`,[`--${u}-tooltip-v2-arrow-border-width`]:`${r/2}px`,[`--${u}-tooltip-v2-arrow-cover-width`]:r/2-1,...l||{}}});return(l,r)=>(b(),M("span",{ref_key:"arrowRef",ref:a,style:Fe(s(o)),class:T(s(n).e("arrow"))},null,6))}});var xm=Me(Ete,[["__file","arrow.vue"]]);const zr=Math.min,Il=Math.max,hc=Math.round,lu=Math.floor,Ga=e=>({x:e,y:e}),xte={left:"right",right:"left",bottom:"top",top:"bottom"},Mte={start:"end",end:"start"};function C0(e,t,n){return Il(e,zr(t,n))}function Ni(e,t){return typeof e=="function"?e(t):e}function zl(e){return e.split("-")[0]}function Pi(e){return e.split("-")[1]}function r_(e){return e==="x"?"y":"x"}function P2(e){return e==="y"?"height":"width"}function Hr(e){return["top","bottom"].includes(zl(e))?"y":"x"}function R2(e){return r_(Hr(e))}function Tte(e,t,n){n===void 0&&(n=!1);const a=Pi(e),o=R2(e),l=P2(o);let r=o==="x"?a===(n?"end":"start")?"right":"left":a==="start"?"bottom":"top";return t.reference[l]>t.floating[l]&&(r=mc(r)),[r,mc(r)]}function $te(e){const t=mc(e);return[S0(e),t,S0(t)]}function S0(e){return e.replace(/start|end/g,t=>Mte[t])}function Ote(e,t,n){const a=["left","right"],o=["right","left"],l=["top","bottom"],r=["bottom","top"];switch(e){case"top":case"bottom":return n?t?o:a:t?a:o;case"left":case"right":return t?l:r;default:return[]}}function Ite(e,t,n,a){const o=Pi(e);let l=Ote(zl(e),n==="start",a);return o&&(l=l.map(r=>r+"-"+o),t&&(l=l.concat(l.map(S0)))),l}function mc(e){return e.replace(/left|right|bottom|top/g,t=>xte[t])}function Ate(e){return{top:0,right:0,bottom:0,left:0,...e}}function s_(e){return typeof e!="number"?Ate(e):{top:e,right:e,bottom:e,left:e}}function gc(e){const{x:t,y:n,width:a,height:o}=e;return{width:a,height:o,top:n,left:t,right:t+a,bottom:n+o,x:t,y:n}}function Mm(e,t,n){let{reference:a,floating:o}=e;const l=Hr(t),r=R2(t),i=P2(r),u=zl(t),c=l==="y",d=a.x+a.width/2-o.width/2,f=a.y+a.height/2-o.height/2,p=a[i]/2-o[i]/2;let v;switch(u){case"top":v={x:d,y:a.y-o.height};break;case"bottom":v={x:d,y:a.y+a.height};break;case"right":v={x:a.x+a.width,y:f};break;case"left":v={x:a.x-o.width,y:f};break;default:v={x:a.x,y:a.y}}switch(Pi(t)){case"start":v[r]-=p*(n&&c?-1:1);break;case"end":v[r]+=p*(n&&c?-1:1);break}return v}const Nte=async(e,t,n)=>{const{placement:a="bottom",strategy:o="absolute",middleware:l=[],platform:r}=n,i=l.filter(Boolean),u=await(r.isRTL==null?void 0:r.isRTL(t));let c=await r.getElementRects({reference:e,floating:t,strategy:o}),{x:d,y:f}=Mm(c,a,u),p=a,v={},m=0;for(let h=0;h<i.length;h++){const{name:y,fn:g}=i[h],{x:k,y:w,data:_,reset:C}=await g({x:d,y:f,initialPlacement:a,placement:p,strategy:o,middlewareData:v,rects:c,platform:r,elements:{reference:e,floating:t}});d=k??d,f=w??f,v={...v,[y]:{...v[y],..._}},C&&m<=50&&(m++,typeof C=="object"&&(C.placement&&(p=C.placement),C.rects&&(c=C.rects===!0?await r.getElementRects({reference:e,floating:t,strategy:o}):C.rects),{x:d,y:f}=Mm(c,p,u)),h=-1)}return{x:d,y:f,placement:p,strategy:o,middlewareData:v}};async function L2(e,t){var n;t===void 0&&(t={});const{x:a,y:o,platform:l,rects:r,elements:i,strategy:u}=e,{boundary:c="clippingAncestors",rootBoundary:d="viewport",elementContext:f="floating",altBoundary:p=!1,padding:v=0}=Ni(t,e),m=s_(v),y=i[p?f==="floating"?"reference":"floating":f],g=gc(await l.getClippingRect({element:(n=await(l.isElement==null?void 0:l.isElement(y)))==null||n?y:y.contextElement||await(l.getDocumentElement==null?void 0:l.getDocumentElement(i.floating)),boundary:c,rootBoundary:d,strategy:u})),k=f==="floating"?{x:a,y:o,width:r.floating.width,height:r.floating.height}:r.reference,w=await(l.getOffsetParent==null?void 0:l.getOffsetParent(i.floating)),_=await(l.isElement==null?void 0:l.isElement(w))?await(l.getScale==null?void 0:l.getScale(w))||{x:1,y:1}:{x:1,y:1},C=gc(l.convertOffsetParentRelativeRectToViewportRelativeRect?await l.convertOffsetParentRelativeRectToViewportRelativeRect({elements:i,rect:k,offsetParent:w,strategy:u}):k);return{top:(g.top-C.top+m.top)/_.y,bottom:(C.bottom-g.bottom+m.bottom)/_.y,left:(g.left-C.left+m.left)/_.x,right:(C.right-g.right+m.right)/_.x}}const Pte=e=>({name:"arrow",options:e,async fn(t){const{x:n,y:a,placement:o,rects:l,platform:r,elements:i,middlewareData:u}=t,{element:c,padding:d=0}=Ni(e,t)||{};if(c==null)return{};const f=s_(d),p={x:n,y:a},v=R2(o),m=P2(v),h=await r.getDimensions(c),y=v==="y",g=y?"top":"left",k=y?"bottom":"right",w=y?"clientHeight":"clientWidth",_=l.reference[m]+l.reference[v]-p[v]-l.floating[m],C=p[v]-l.reference[v],E=await(r.getOffsetParent==null?void 0:r.getOffsetParent(c));let $=E?E[w]:0;(!$||!await(r.isElement==null?void 0:r.isElement(E)))&&($=i.floating[w]||l.floating[m]);const A=_/2-C/2,O=$/2-h[m]/2-1,N=zr(f[g],O),L=zr(f[k],O),R=N,K=$-h[m]-L,X=$/2-h[m]/2+A,F=C0(R,X,K),P=!u.arrow&&Pi(o)!=null&&X!==F&&l.reference[m]/2-(X<R?N:L)-h[m]/2<0,H=P?X<R?X-R:X-K:0;return{[v]:p[v]+H,data:{[v]:F,centerOffset:X-F-H,...P&&{alignmentOffset:H}},reset:P}}}),Rte=function(e){return e===void 0&&(e={}),{name:"flip",options:e,async fn(t){var n,a;const{placement:o,middlewareData:l,rects:r,initialPlacement:i,platform:u,elements:c}=t,{mainAxis:d=!0,crossAxis:f=!0,fallbackPlacements:p,fallbackStrategy:v="bestFit",fallbackAxisSideDirection:m="none",flipAlignment:h=!0,...y}=Ni(e,t);if((n=l.arrow)!=null&&n.alignmentOffset)return{};const g=zl(o),k=Hr(i),w=zl(i)===i,_=await(u.isRTL==null?void 0:u.isRTL(c.floating)),C=p||(w||!h?[mc(i)]:$te(i)),E=m!=="none";!p&&E&&C.push(...Ite(i,h,m,_));const $=[i,...C],A=await L2(t,y),O=[];let N=((a=l.flip)==null?void 0:a.overflows)||[];if(d&&O.push(A[g]),f){const X=Tte(o,r,_);O.push(A[X[0]],A[X[1]])}if(N=[...N,{placement:o,overflows:O}],!O.every(X=>X<=0)){var L,R;const X=(((L=l.flip)==null?void 0:L.index)||0)+1,F=$[X];if(F)return{data:{index:X,overflows:N},reset:{placement:F}};let P=(R=N.filter(H=>H.overflows[0]<=0).sort((H,z)=>H.overflows[1]-z.overflows[1])[0])==null?void 0:R.placement;if(!P)switch(v){case"bestFit":{var K;const H=(K=N.filter(z=>{if(E){const B=Hr(z.placement);return B===k||B==="y"}return!0}).map(z=>[z.placement,z.overflows.filter(B=>B>0).reduce((B,j)=>B+j,0)]).sort((z,B)=>z[1]-B[1])[0])==null?void 0:K[0];H&&(P=H);break}case"initialPlacement":P=i;break}if(o!==P)return{reset:{placement:P}}}return{}}}};async function Lte(e,t){const{placement:n,platform:a,elements:o}=e,l=await(a.isRTL==null?void 0:a.isRTL(o.floating)),r=zl(n),i=Pi(n),u=Hr(n)==="y",c=["left","top"].includes(r)?-1:1,d=l&&u?-1:1,f=Ni(t,e);let{mainAxis:p,crossAxis:v,alignmentAxis:m}=typeof f=="number"?{mainAxis:f,crossAxis:0,alignmentAxis:null}:{mainAxis:f.mainAxis||0,crossAxis:f.crossAxis||0,alignmentAxis:f.alignmentAxis};return i&&typeof m=="number"&&(v=i==="end"?m*-1:m),u?{x:v*d,y:p*c}:{x:p*c,y:v*d}}const Vte=function(e){return e===void 0&&(e=0),{name:"offset",options:e,async fn(t){var n,a;const{x:o,y:l,placement:r,middlewareData:i}=t,u=await Lte(t,e);return r===((n=i.offset)==null?void 0:n.placement)&&(a=i.arrow)!=null&&a.alignmentOffset?{}:{x:o+u.x,y:l+u.y,data:{...u,placement:r}}}}},Bte=function(e){return e===void 0&&(e={}),{name:"shift",options:e,async fn(t){const{x:n,y:a,placement:o}=t,{mainAxis:l=!0,crossAxis:r=!1,limiter:i={fn:y=>{let{x:g,y:k}=y;return{x:g,y:k}}},...u}=Ni(e,t),c={x:n,y:a},d=await L2(t,u),f=Hr(zl(o)),p=r_(f);let v=c[p],m=c[f];if(l){const y=p==="y"?"top":"left",g=p==="y"?"bottom":"right",k=v+d[y],w=v-d[g];v=C0(k,v,w)}if(r){const y=f==="y"?"top":"left",g=f==="y"?"bottom":"right",k=m+d[y],w=m-d[g];m=C0(k,m,w)}const h=i.fn({...t,[p]:v,[f]:m});return{...h,data:{x:h.x-n,y:h.y-a,enabled:{[p]:l,[f]:r}}}}}};function ud(){return typeof window<"u"}function as(e){return i_(e)?(e.nodeName||"").toLowerCase():"#document"}function ra(e){var t;return(e==null||(t=e.ownerDocument)==null?void 0:t.defaultView)||window}function lo(e){var t;return(t=(i_(e)?e.ownerDocument:e.document)||window.document)==null?void 0:t.documentElement}function i_(e){return ud()?e instanceof Node||e instanceof ra(e).Node:!1}function Pa(e){return ud()?e instanceof Element||e instanceof ra(e).Element:!1}function eo(e){return ud()?e instanceof HTMLElement||e instanceof ra(e).HTMLElement:!1}function Tm(e){return!ud()||typeof ShadowRoot>"u"?!1:e instanceof ShadowRoot||e instanceof ra(e).ShadowRoot}function Ri(e){const{overflow:t,overflowX:n,overflowY:a,display:o}=Ra(e);return/auto|scroll|overlay|hidden|clip/.test(t+a+n)&&!["inline","contents"].includes(o)}function zte(e){return["table","td","th"].includes(as(e))}function cd(e){return[":popover-open",":modal"].some(t=>{try{return e.matches(t)}catch{return!1}})}function V2(e){const t=B2(),n=Pa(e)?Ra(e):e;return["transform","translate","scale","rotate","perspective"].some(a=>n[a]?n[a]!=="none":!1)||(n.containerType?n.containerType!=="normal":!1)||!t&&(n.backdropFilter?n.backdropFilter!=="none":!1)||!t&&(n.filter?n.filter!=="none":!1)||["transform","translate","scale","rotate","perspective","filter"].some(a=>(n.willChange||"").includes(a))||["paint","layout","strict","content"].some(a=>(n.contain||"").includes(a))}function Hte(e){let t=al(e);for(;eo(t)&&!Dr(t);){if(V2(t))return t;if(cd(t))return null;t=al(t)}return null}function B2(){return typeof CSS>"u"||!CSS.supports?!1:CSS.supports("-webkit-backdrop-filter","none")}function Dr(e){return["html","body","#document"].includes(as(e))}function Ra(e){return ra(e).getComputedStyle(e)}function dd(e){return Pa(e)?{scrollLeft:e.scrollLeft,scrollTop:e.scrollTop}:{scrollLeft:e.scrollX,scrollTop:e.scrollY}}function al(e){if(as(e)==="html")return e;const t=e.assignedSlot||e.parentNode||Tm(e)&&e.host||lo(e);return Tm(t)?t.host:t}function u_(e){const t=al(e);return Dr(t)?e.ownerDocument?e.ownerDocument.body:e.body:eo(t)&&Ri(t)?t:u_(t)}function vi(e,t,n){var a;t===void 0&&(t=[]),n===void 0&&(n=!0);const o=u_(e),l=o===((a=e.ownerDocument)==null?void 0:a.body),r=ra(o);if(l){const i=k0(r);return t.concat(r,r.visualViewport||[],Ri(o)?o:[],i&&n?vi(i):[])}return t.concat(o,vi(o,[],n))}function k0(e){return e.parent&&Object.getPrototypeOf(e.parent)?e.frameElement:null}function c_(e){const t=Ra(e);let n=parseFloat(t.width)||0,a=parseFloat(t.height)||0;const o=eo(e),l=o?e.offsetWidth:n,r=o?e.offsetHeight:a,i=hc(n)!==l||hc(a)!==r;return i&&(n=l,a=r),{width:n,height:a,$:i}}function z2(e){return Pa(e)?e:e.contextElement}function _r(e){const t=z2(e);if(!eo(t))return Ga(1);const n=t.getBoundingClientRect(),{width:a,height:o,$:l}=c_(t);let r=(l?hc(n.width):n.width)/a,i=(l?hc(n.height):n.height)/o;return(!r||!Number.isFinite(r))&&(r=1),(!i||!Number.isFinite(i))&&(i=1),{x:r,y:i}}const Dte=Ga(0);function d_(e){const t=ra(e);return!B2()||!t.visualViewport?Dte:{x:t.visualViewport.offsetLeft,y:t.visualViewport.offsetTop}}function Fte(e,t,n){return t===void 0&&(t=!1),!n||t&&n!==ra(e)?!1:t}function Hl(e,t,n,a){t===void 0&&(t=!1),n===void 0&&(n=!1);const o=e.getBoundingClientRect(),l=z2(e);let r=Ga(1);t&&(a?Pa(a)&&(r=_r(a)):r=_r(e));const i=Fte(l,n,a)?d_(l):Ga(0);let u=(o.left+i.x)/r.x,c=(o.top+i.y)/r.y,d=o.width/r.x,f=o.height/r.y;if(l){const p=ra(l),v=a&&Pa(a)?ra(a):a;let m=p,h=k0(m);for(;h&&a&&v!==m;){const y=_r(h),g=h.getBoundingClientRect(),k=Ra(h),w=g.left+(h.clientLeft+parseFloat(k.paddingLeft))*y.x,_=g.top+(h.clientTop+parseFloat(k.paddingTop))*y.y;u*=y.x,c*=y.y,d*=y.x,f*=y.y,u+=w,c+=_,m=ra(h),h=k0(m)}}return gc({width:d,height:f,x:u,y:c})}function H2(e,t){const n=dd(e).scrollLeft;return t?t.left+n:Hl(lo(e)).left+n}function f_(e,t,n){n===void 0&&(n=!1);const a=e.getBoundingClientRect(),o=a.left+t.scrollLeft-(n?0:H2(e,a)),l=a.top+t.scrollTop;return{x:o,y:l}}function Kte(e){let{elements:t,rect:n,offsetParent:a,strategy:o}=e;const l=o==="fixed",r=lo(a),i=t?cd(t.floating):!1;if(a===r||i&&l)return n;let u={scrollLeft:0,scrollTop:0},c=Ga(1);const d=Ga(0),f=eo(a);if((f||!f&&!l)&&((as(a)!=="body"||Ri(r))&&(u=dd(a)),eo(a))){const v=Hl(a);c=_r(a),d.x=v.x+a.clientLeft,d.y=v.y+a.clientTop}const p=r&&!f&&!l?f_(r,u,!0):Ga(0);return{width:n.width*c.x,height:n.height*c.y,x:n.x*c.x-u.scrollLeft*c.x+d.x+p.x,y:n.y*c.y-u.scrollTop*c.y+d.y+p.y}}function Wte(e){return Array.from(e.getClientRects())}function jte(e){const t=lo(e),n=dd(e),a=e.ownerDocument.body,o=Il(t.scrollWidth,t.clientWidth,a.scrollWidth,a.clientWidth),l=Il(t.scrollHeight,t.clientHeight,a.scrollHeight,a.clientHeight);let r=-n.scrollLeft+H2(e);const i=-n.scrollTop;return Ra(a).direction==="rtl"&&(r+=Il(t.clientWidth,a.clientWidth)-o),{width:o,height:l,x:r,y:i}}function qte(e,t){const n=ra(e),a=lo(e),o=n.visualViewport;let l=a.clientWidth,r=a.clientHeight,i=0,u=0;if(o){l=o.width,r=o.height;const c=B2();(!c||c&&t==="fixed")&&(i=o.offsetLeft,u=o.offsetTop)}return{width:l,height:r,x:i,y:u}}function Ute(e,t){const n=Hl(e,!0,t==="fixed"),a=n.top+e.clientTop,o=n.left+e.clientLeft,l=eo(e)?_r(e):Ga(1),r=e.clientWidth*l.x,i=e.clientHeight*l.y,u=o*l.x,c=a*l.y;return{width:r,height:i,x:u,y:c}}function $m(e,t,n){let a;if(t==="viewport")a=qte(e,n);else if(t==="document")a=jte(lo(e));else if(Pa(t))a=Ute(t,n);else{const o=d_(e);a={x:t.x-o.x,y:t.y-o.y,width:t.width,height:t.height}}return gc(a)}function p_(e,t){const n=al(e);return n===t||!Pa(n)||Dr(n)?!1:Ra(n).position==="fixed"||p_(n,t)}function Yte(e,t){const n=t.get(e);if(n)return n;let a=vi(e,[],!1).filter(i=>Pa(i)&&as(i)!=="body"),o=null;const l=Ra(e).position==="fixed";let r=l?al(e):e;for(;Pa(r)&&!Dr(r);){const i=Ra(r),u=V2(r);!u&&i.position==="fixed"&&(o=null),(l?!u&&!o:!u&&i.position==="static"&&!!o&&["absolute","fixed"].includes(o.position)||Ri(r)&&!u&&p_(e,r))?a=a.filter(d=>d!==r):o=i,r=al(r)}return t.set(e,a),a}function Gte(e){let{element:t,boundary:n,rootBoundary:a,strategy:o}=e;const r=[...n==="clippingAncestors"?cd(t)?[]:Yte(t,this._c):[].concat(n),a],i=r[0],u=r.reduce((c,d)=>{const f=$m(t,d,o);return c.top=Il(f.top,c.top),c.right=zr(f.right,c.right),c.bottom=zr(f.bottom,c.bottom),c.left=Il(f.left,c.left),c},$m(t,i,o));return{width:u.right-u.left,height:u.bottom-u.top,x:u.left,y:u.top}}function Xte(e){const{width:t,height:n}=c_(e);return{width:t,height:n}}function Zte(e,t,n){const a=eo(t),o=lo(t),l=n==="fixed",r=Hl(e,!0,l,t);let i={scrollLeft:0,scrollTop:0};const u=Ga(0);if(a||!a&&!l)if((as(t)!=="body"||Ri(o))&&(i=dd(t)),a){const p=Hl(t,!0,l,t);u.x=p.x+t.clientLeft,u.y=p.y+t.clientTop}else o&&(u.x=H2(o));const c=o&&!a&&!l?f_(o,i):Ga(0),d=r.left+i.scrollLeft-u.x-c.x,f=r.top+i.scrollTop-u.y-c.y;return{x:d,y:f,width:r.width,height:r.height}}function lf(e){return Ra(e).position==="static"}function Om(e,t){if(!eo(e)||Ra(e).position==="fixed")return null;if(t)return t(e);let n=e.offsetParent;return lo(e)===n&&(n=n.ownerDocument.body),n}function v_(e,t){const n=ra(e);if(cd(e))return n;if(!eo(e)){let o=al(e);for(;o&&!Dr(o);){if(Pa(o)&&!lf(o))return o;o=al(o)}return n}let a=Om(e,t);for(;a&&zte(a)&&lf(a);)a=Om(a,t);return a&&Dr(a)&&lf(a)&&!V2(a)?n:a||Hte(e)||n}const Jte=async function(e){const t=this.getOffsetParent||v_,n=this.getDimensions,a=await n(e.floating);return{reference:Zte(e.reference,await t(e.floating),e.strategy),floating:{x:0,y:0,width:a.width,height:a.height}}};function Qte(e){return Ra(e).direction==="rtl"}const ene={convertOffsetParentRelativeRectToViewportRelativeRect:Kte,getDocumentElement:lo,getClippingRect:Gte,getOffsetParent:v_,getElementRects:Jte,getClientRects:Wte,getDimensions:Xte,getScale:_r,isElement:Pa,isRTL:Qte};function h_(e,t){return e.x===t.x&&e.y===t.y&&e.width===t.width&&e.height===t.height}function tne(e,t){let n=null,a;const o=lo(e);function l(){var i;clearTimeout(a),(i=n)==null||i.disconnect(),n=null}function r(i,u){i===void 0&&(i=!1),u===void 0&&(u=1),l();const c=e.getBoundingClientRect(),{left:d,top:f,width:p,height:v}=c;if(i||t(),!p||!v)return;const m=lu(f),h=lu(o.clientWidth-(d+p)),y=lu(o.clientHeight-(f+v)),g=lu(d),w={rootMargin:-m+"px "+-h+"px "+-y+"px "+-g+"px",threshold:Il(0,zr(1,u))||1};let _=!0;function C(E){const $=E[0].intersectionRatio;if($!==u){if(!_)return r();$?r(!1,$):a=setTimeout(()=>{r(!1,1e-7)},1e3)}$===1&&!h_(c,e.getBoundingClientRect())&&r(),_=!1}try{n=new IntersectionObserver(C,{...w,root:o.ownerDocument})}catch{n=new IntersectionObserver(C,w)}n.observe(e)}return r(!0),l}function nne(e,t,n,a){a===void 0&&(a={});const{ancestorScroll:o=!0,ancestorResize:l=!0,elementResize:r=typeof ResizeObserver=="function",layoutShift:i=typeof IntersectionObserver=="function",animationFrame:u=!1}=a,c=z2(e),d=o||l?[...c?vi(c):[],...vi(t)]:[];d.forEach(g=>{o&&g.addEventListener("scroll",n,{passive:!0}),l&&g.addEventListener("resize",n)});const f=c&&i?tne(c,n):null;let p=-1,v=null;r&&(v=new ResizeObserver(g=>{let[k]=g;k&&k.target===c&&v&&(v.unobserve(t),cancelAnimationFrame(p),p=requestAnimationFrame(()=>{var w;(w=v)==null||w.observe(t)})),n()}),c&&!u&&v.observe(c),v.observe(t));let m,h=u?Hl(e):null;u&&y();function y(){const g=Hl(e);h&&!h_(h,g)&&n(),h=g,m=requestAnimationFrame(y)}return n(),()=>{var g;d.forEach(k=>{o&&k.removeEventListener("scroll",n),l&&k.removeEventListener("resize",n)}),f==null||f(),(g=v)==null||g.disconnect(),v=null,u&&cancelAnimationFrame(m)}}const ane=L2,m_=Vte,one=Bte,lne=Rte,g_=Pte,y_=(e,t,n)=>{const a=new Map,o={platform:ene,...n},l={...o.platform,_c:a};return Nte(e,t,{...o,platform:l})},rne=Ee({style:{type:Q([String,Object,Array]),default:()=>({})}}),sne=I({name:"ElVisuallyHidden"}),ine=I({...sne,props:rne,setup(e){const t=e,n=S(()=>[t.style,{position:"absolute",border:0,width:1,height:1,padding:0,margin:-1,overflow:"hidden",clip:"rect(0, 0, 0, 0)",whiteSpace:"nowrap",wordWrap:"normal"}]);return(a,o)=>(b(),M("span",st(a.$attrs,{style:s(n)}),[ne(a.$slots,"default")],16))}});var une=Me(ine,[["__file","visual-hidden.vue"]]);Ee({});const cne=e=>{if(!mt)return;if(!e)return e;const t=Fn(e);return t||(Rt(e)?t:e)},dne=({middleware:e,placement:t,strategy:n})=>{const a=V(),o=V(),l=V(),r=V(),i=V({}),u={x:l,y:r,placement:t,strategy:n,middlewareData:i},c=async()=>{if(!mt)return;const d=cne(a),f=Fn(o);if(!d||!f)return;const p=await y_(d,f,{placement:s(t),strategy:s(n),middleware:s(e)});Tr(u).forEach(v=>{u[v].value=p[v]})};return at(()=>{wn(()=>{c()})}),{...u,update:c,referenceRef:a,contentRef:o}},fne=({arrowRef:e,padding:t})=>({name:"arrow",options:{element:e,padding:t},fn(n){const a=s(e);return a?g_({element:a,padding:t}).fn(n):{}}}),pne=I({name:"ElTooltipV2Content"}),vne=I({...pne,props:{...I2,...a_},setup(e){const t=e,{triggerRef:n,contentId:a}=Pe(id),o=V(t.placement),l=V(t.strategy),r=V(null),{referenceRef:i,contentRef:u,middlewareData:c,x:d,y:f,update:p}=dne({placement:o,strategy:l,middleware:S(()=>{const w=[m_(t.offset)];return t.showArrow&&w.push(fne({arrowRef:r})),w})}),v=Xr().nextZIndex(),m=ge("tooltip-v2"),h=S(()=>o.value.split("-")[0]),y=S(()=>({position:s(l),top:`${s(f)||0}px`,left:`${s(d)||0}px`,zIndex:v})),g=S(()=>{if(!t.showArrow)return{};const{arrow:w}=s(c);return{[`--${m.namespace.value}-tooltip-v2-arrow-x`]:`${w==null?void 0:w.x}px`||"",[`--${m.namespace.value}-tooltip-v2-arrow-y`]:`${w==null?void 0:w.y}px`||""}}),k=S(()=>[m.e("content"),m.is("dark",t.effect==="dark"),m.is(s(l)),t.contentClass]);return pe(r,()=>p()),pe(()=>t.placement,w=>o.value=w),at(()=>{pe(()=>t.reference||n.value,w=>{i.value=w||void 0},{immediate:!0})}),ft(l_,{arrowRef:r}),(w,_)=>(b(),M("div",{ref_key:"contentRef",ref:u,style:Fe(s(y)),"data-tooltip-v2-root":""},[w.nowrap?le("v-if",!0):(b(),M("div",{key:0,"data-side":s(h),class:T(s(k))},[ne(w.$slots,"default",{contentStyle:s(y),contentClass:s(k)}),U(s(une),{id:s(a),role:"tooltip"},{default:J(()=>[w.ariaLabel?(b(),M(He,{key:0},[ct(Te(w.ariaLabel),1)],64)):ne(w.$slots,"default",{key:1})]),_:3},8,["id"]),ne(w.$slots,"arrow",{style:Fe(s(g)),side:s(h)})],10,["data-side"]))],4))}});var Im=Me(vne,[["__file","content.vue"]]);const hne=Ee({setRef:{type:Q(Function),required:!0},onlyChild:Boolean});var mne=I({props:hne,setup(e,{slots:t}){const n=V(),a=ad(n,o=>{o?e.setRef(o.nextElementSibling):e.setRef(null)});return()=>{var o;const[l]=((o=t.default)==null?void 0:o.call(t))||[],r=e.onlyChild?MD(l.children):l.children;return U(He,{ref:a},[r])}}});const gne=I({name:"ElTooltipV2Trigger"}),yne=I({...gne,props:{...a_,...N2},setup(e){const t=e,{onClose:n,onOpen:a,onDelayOpen:o,triggerRef:l,contentId:r}=Pe(id);let i=!1;const u=k=>{l.value=k},c=()=>{i=!1},d=Gt(t.onMouseEnter,o),f=Gt(t.onMouseLeave,n),p=Gt(t.onMouseDown,()=>{n(),i=!0,document.addEventListener("mouseup",c,{once:!0})}),v=Gt(t.onFocus,()=>{i||a()}),m=Gt(t.onBlur,n),h=Gt(t.onClick,k=>{k.detail===0&&n()}),y={blur:m,click:h,focus:v,mousedown:p,mouseenter:d,mouseleave:f},g=(k,w,_)=>{k&&Object.entries(w).forEach(([C,E])=>{k[_](C,E)})};return pe(l,(k,w)=>{g(k,y,"addEventListener"),g(w,y,"removeEventListener"),k&&k.setAttribute("aria-describedby",r.value)}),At(()=>{g(l.value,y,"removeEventListener"),document.removeEventListener("mouseup",c)}),(k,w)=>k.nowrap?(b(),ue(s(mne),{key:0,"set-ref":u,"only-child":""},{default:J(()=>[ne(k.$slots,"default")]),_:3})):(b(),M("button",st({key:1,ref_key:"triggerRef",ref:l},k.$attrs),[ne(k.$slots,"default")],16))}});var _ne=Me(yne,[["__file","trigger.vue"]]);const bne=I({name:"ElTooltipV2"}),wne=I({...bne,props:bte,setup(e){const n=pn(e),a=Ct(Ea(n,Object.keys(O2))),o=Ct(Ea(n,Object.keys(I2))),l=Ct(Ea(n,Object.keys(A2))),r=Ct(Ea(n,Object.keys(N2)));return(i,u)=>(b(),ue(Ste,aa(Xa(l)),{default:J(({open:c})=>[U(_ne,st(r,{nowrap:""}),{default:J(()=>[ne(i.$slots,"trigger")]),_:3},16),U(s(es),{to:i.to,disabled:!i.teleported},{default:J(()=>[i.fullTransition?(b(),ue(vn,aa(st({key:0},i.transitionProps)),{default:J(()=>[i.alwaysOn||c?(b(),ue(Im,aa(st({key:0},o)),{arrow:J(({style:d,side:f})=>[i.showArrow?(b(),ue(xm,st({key:0},a,{style:d,side:f}),null,16,["style","side"])):le("v-if",!0)]),default:J(()=>[ne(i.$slots,"default")]),_:3},16)):le("v-if",!0)]),_:2},1040)):(b(),M(He,{key:1},[i.alwaysOn||c?(b(),ue(Im,aa(st({key:0},o)),{arrow:J(({style:d,side:f})=>[i.showArrow?(b(),ue(xm,st({key:0},a,{style:d,side:f}),null,16,["style","side"])):le("v-if",!0)]),default:J(()=>[ne(i.$slots,"default")]),_:3},16)):le("v-if",!0)],64))]),_:2},1032,["to","disabled"])]),_:3},16))}});var Cne=Me(wne,[["__file","tooltip.vue"]]);const Sne=Ze(Cne),__="left-check-change",b_="right-check-change",lr=Ee({data:{type:Q(Array),default:()=>[]},titles:{type:Q(Array),default:()=>[]},buttonTexts:{type:Q(Array),default:()=>[]},filterPlaceholder:String,filterMethod:{type:Q(Function)},leftDefaultChecked:{type:Q(Array),default:()=>[]},rightDefaultChecked:{type:Q(Array),default:()=>[]},renderContent:{type:Q(Function)},modelValue:{type:Q(Array),default:()=>[]},format:{type:Q(Object),default:()=>({})},filterable:Boolean,props:{type:Q(Object),default:()=>Ft({label:"label",key:"key",disabled:"disabled"})},targetOrder:{type:String,values:["original","push","unshift"],default:"original"},validateEvent:{type:Boolean,default:!0}}),E0=(e,t)=>[e,t].every(Se)||Se(e)&&on(t),kne={[it]:(e,t,n)=>[e,n].every(Se)&&["left","right"].includes(t),[Ue]:e=>Se(e),[__]:E0,[b_]:E0},x0="checked-change",Ene=Ee({data:lr.data,optionRender:{type:Q(Function)},placeholder:String,title:String,filterable:Boolean,format:lr.format,filterMethod:lr.filterMethod,defaultChecked:lr.leftDefaultChecked,props:lr.props}),xne={[x0]:E0},Li=e=>{const t={label:"label",key:"key",disabled:"disabled"};return S(()=>({...t,...e.props}))},Mne=(e,t,n)=>{const a=Li(e),o=S(()=>e.data.filter(d=>ze(e.filterMethod)?e.filterMethod(t.query,d):String(d[a.value.label]||d[a.value.key]).toLowerCase().includes(t.query.toLowerCase()))),l=S(()=>o.value.filter(d=>!d[a.value.disabled])),r=S(()=>{const d=t.checked.length,f=e.data.length,{noChecked:p,hasChecked:v}=e.format;return p&&v?d>0?v.replace(/\${checked}/g,d.toString()).replace(/\${total}/g,f.toString()):p.replace(/\${total}/g,f.toString()):`${d}/${f}`}),i=S(()=>{const d=t.checked.length;return d>0&&d<l.value.length}),u=()=>{const d=l.value.map(f=>f[a.value.key]);t.allChecked=d.length>0&&d.every(f=>t.checked.includes(f))},c=d=>{t.checked=d?l.value.map(f=>f[a.value.key]):[]};return pe(()=>t.checked,(d,f)=>{if(u(),t.checkChangeByUser){const p=d.concat(f).filter(v=>!d.includes(v)||!f.includes(v));n(x0,d,p)}else n(x0,d),t.checkChangeByUser=!0}),pe(l,()=>{u()}),pe(()=>e.data,()=>{const d=[],f=o.value.map(p=>p[a.value.key]);t.checked.forEach(p=>{f.includes(p)&&d.push(p)}),t.checkChangeByUser=!1,t.checked=d}),pe(()=>e.defaultChecked,(d,f)=>{if(f&&d.length===f.length&&d.every(m=>f.includes(m)))return;const p=[],v=l.value.map(m=>m[a.value.key]);d.forEach(m=>{v.includes(m)&&p.push(m)}),t.checkChangeByUser=!1,t.checked=p},{immediate:!0}),{filteredData:o,checkableData:l,checkedSummary:r,isIndeterminate:i,updateAllChecked:u,handleAllCheckedChange:c}},Tne=I({name:"ElTransferPanel"}),$ne=I({...Tne,props:Ene,emits:xne,setup(e,{expose:t,emit:n}){const a=e,o=an(),l=({option:w})=>w,{t:r}=gt(),i=ge("transfer"),u=Ct({checked:[],allChecked:!1,query:"",checkChangeByUser:!0}),c=Li(a),{filteredData:d,checkedSummary:f,isIndeterminate:p,handleAllCheckedChange:v}=Mne(a,u,n),m=S(()=>!na(u.query)&&na(d.value)),h=S(()=>!na(o.default()[0].children)),{checked:y,allChecked:g,query:k}=pn(u);return t({query:k}),(w,_)=>(b(),M("div",{class:T(s(i).b("panel"))},[x("p",{class:T(s(i).be("panel","header"))},[U(s(ba),{modelValue:s(g),"onUpdate:modelValue":C=>Rt(g)?g.value=C:null,indeterminate:s(p),"validate-event":!1,onChange:s(v)},{default:J(()=>[ct(Te(w.title)+" ",1),x("span",null,Te(s(f)),1)]),_:1},8,["modelValue","onUpdate:modelValue","indeterminate","onChange"])],2),x("div",{class:T([s(i).be("panel","body"),s(i).is("with-footer",s(h))])},[w.filterable?(b(),ue(s(An),{key:0,modelValue:s(k),"onUpdate:modelValue":C=>Rt(k)?k.value=C:null,class:T(s(i).be("panel","filter")),size:"default",placeholder:w.placeholder,"prefix-icon":s(e3),clearable:"","validate-event":!1},null,8,["modelValue","onUpdate:modelValue","class","placeholder","prefix-icon"])):le("v-if",!0),Qe(U(s(Q3),{modelValue:s(y),"onUpdate:modelValue":C=>Rt(y)?y.value=C:null,"validate-event":!1,class:T([s(i).is("filterable",w.filterable),s(i).be("panel","list")])},{default:J(()=>[(b(!0),M(He,null,pt(s(d),C=>(b(),ue(s(ba),{key:C[s(c).key],class:T(s(i).be("panel","item")),value:C[s(c).key],disabled:C[s(c).disabled],"validate-event":!1},{default:J(()=>{var E;return[U(l,{option:(E=w.optionRender)==null?void 0:E.call(w,C)},null,8,["option"])]}),_:2},1032,["class","value","disabled"]))),128))]),_:1},8,["modelValue","onUpdate:modelValue","class"]),[[_t,!s(m)&&!s(na)(w.data)]]),Qe(x("div",{class:T(s(i).be("panel","empty"))},[ne(w.$slots,"empty",{},()=>[ct(Te(s(m)?s(r)("el.transfer.noMatch"):s(r)("el.transfer.noData")),1)])],2),[[_t,s(m)||s(na)(w.data)]])],2),s(h)?(b(),M("p",{key:0,class:T(s(i).be("panel","footer"))},[ne(w.$slots,"default")],2)):le("v-if",!0)],2))}});var Am=Me($ne,[["__file","transfer-panel.vue"]]);const One=e=>{const t=Li(e),n=S(()=>e.data.reduce((l,r)=>(l[r[t.value.key]]=r)&&l,{})),a=S(()=>e.data.filter(l=>!e.modelValue.includes(l[t.value.key]))),o=S(()=>e.targetOrder==="original"?e.data.filter(l=>e.modelValue.includes(l[t.value.key])):e.modelValue.reduce((l,r)=>{const i=n.value[r];return i&&l.push(i),l},[]));return{sourceData:a,targetData:o}},Ine=(e,t,n)=>{const a=Li(e),o=(i,u,c)=>{n(Ue,i),n(it,i,u,c)};return{addToLeft:()=>{const i=e.modelValue.slice();t.rightChecked.forEach(u=>{const c=i.indexOf(u);c>-1&&i.splice(c,1)}),o(i,"left",t.rightChecked)},addToRight:()=>{let i=e.modelValue.slice();const u=e.data.filter(c=>{const d=c[a.value.key];return t.leftChecked.includes(d)&&!e.modelValue.includes(d)}).map(c=>c[a.value.key]);i=e.targetOrder==="unshift"?u.concat(i):i.concat(u),e.targetOrder==="original"&&(i=e.data.filter(c=>i.includes(c[a.value.key])).map(c=>c[a.value.key])),o(i,"right",t.leftChecked)}}},Ane=(e,t)=>({onSourceCheckedChange:(o,l)=>{e.leftChecked=o,l&&t(__,o,l)},onTargetCheckedChange:(o,l)=>{e.rightChecked=o,l&&t(b_,o,l)}}),Nne=I({name:"ElTransfer"}),Pne=I({...Nne,props:lr,emits:kne,setup(e,{expose:t,emit:n}){const a=e,o=an(),{t:l}=gt(),r=ge("transfer"),{formItem:i}=kn(),u=Ct({leftChecked:[],rightChecked:[]}),c=Li(a),{sourceData:d,targetData:f}=One(a),{onSourceCheckedChange:p,onTargetCheckedChange:v}=Ane(u,n),{addToLeft:m,addToRight:h}=Ine(a,u,n),y=V(),g=V(),k=A=>{switch(A){case"left":y.value.query="";break;case"right":g.value.query="";break}},w=S(()=>a.buttonTexts.length===2),_=S(()=>a.titles[0]||l("el.transfer.titles.0")),C=S(()=>a.titles[1]||l("el.transfer.titles.1")),E=S(()=>a.filterPlaceholder||l("el.transfer.filterPlaceholder"));pe(()=>a.modelValue,()=>{var A;a.validateEvent&&((A=i==null?void 0:i.validate)==null||A.call(i,"change").catch(O=>void 0))});const $=S(()=>A=>{var O;if(a.renderContent)return a.renderContent(We,A);const N=(((O=o.default)==null?void 0:O.call(o,{option:A}))||[]).filter(L=>L.type!==sn);return N.length?N:We("span",A[c.value.label]||A[c.value.key])});return t({clearQuery:k,leftPanel:y,rightPanel:g}),(A,O)=>(b(),M("div",{class:T(s(r).b())},[U(Am,{ref_key:"leftPanel",ref:y,data:s(d),"option-render":s($),placeholder:s(E),title:s(_),filterable:A.filterable,format:A.format,"filter-method":A.filterMethod,"default-checked":A.leftDefaultChecked,props:a.props,onCheckedChange:s(p)},{empty:J(()=>[ne(A.$slots,"left-empty")]),default:J(()=>[ne(A.$slots,"left-footer")]),_:3},8,["data","option-render","placeholder","title","filterable","format","filter-method","default-checked","props","onCheckedChange"]),x("div",{class:T(s(r).e("buttons"))},[U(s(dn),{type:"primary",class:T([s(r).e("button"),s(r).is("with-texts",s(w))]),disabled:s(na)(u.rightChecked),onClick:s(m)},{default:J(()=>[U(s(Le),null,{default:J(()=>[U(s(wo))]),_:1}),s(bt)(A.buttonTexts[0])?le("v-if",!0):(b(),M("span",{key:0},Te(A.buttonTexts[0]),1))]),_:1},8,["class","disabled","onClick"]),U(s(dn),{type:"primary",class:T([s(r).e("button"),s(r).is("with-texts",s(w))]),disabled:s(na)(u.leftChecked),onClick:s(h)},{default:J(()=>[s(bt)(A.buttonTexts[1])?le("v-if",!0):(b(),M("span",{key:0},Te(A.buttonTexts[1]),1)),U(s(Le),null,{default:J(()=>[U(s(Pn))]),_:1})]),_:1},8,["class","disabled","onClick"])],2),U(Am,{ref_key:"rightPanel",ref:g,data:s(f),"option-render":s($),placeholder:s(E),filterable:A.filterable,format:A.format,"filter-method":A.filterMethod,title:s(C),"default-checked":A.rightDefaultChecked,props:a.props,onCheckedChange:s(v)},{empty:J(()=>[ne(A.$slots,"right-empty")]),default:J(()=>[ne(A.$slots,"right-footer")]),_:3},8,["data","option-render","placeholder","filterable","format","filter-method","title","default-checked","props","onCheckedChange"])],2))}});var Rne=Me(Pne,[["__file","transfer.vue"]]);const Lne=Ze(Rne),br="$treeNodeId",Nm=function(e,t){!t||t[br]||Object.defineProperty(t,br,{value:e.id,enumerable:!1,configurable:!1,writable:!1})},D2=(e,t)=>t==null?void 0:t[e||br],M0=(e,t,n)=>{const a=e.value.currentNode;n();const o=e.value.currentNode;a!==o&&t("current-change",o?o.data:null,o)},T0=e=>{let t=!0,n=!0,a=!0;for(let o=0,l=e.length;o<l;o++){const r=e[o];(r.checked!==!0||r.indeterminate)&&(t=!1,r.disabled||(a=!1)),(r.checked!==!1||r.indeterminate)&&(n=!1)}return{all:t,none:n,allWithoutDisable:a,half:!t&&!n}},_s=function(e){if(e.childNodes.length===0||e.loading)return;const{all:t,none:n,half:a}=T0(e.childNodes);t?(e.checked=!0,e.indeterminate=!1):a?(e.checked=!1,e.indeterminate=!0):n&&(e.checked=!1,e.indeterminate=!1);const o=e.parent;!o||o.level===0||e.store.checkStrictly||_s(o)},ru=function(e,t){const n=e.store.props,a=e.data||{},o=n[t];if(ze(o))return o(a,e);if(Ve(o))return a[o];if(bt(o)){const l=a[t];return l===void 0?"":l}};let Vne=0,$0=class Ru{constructor(t){this.id=Vne++,this.text=null,this.checked=!1,this.indeterminate=!1,this.data=null,this.expanded=!1,this.parent=null,this.visible=!0,this.isCurrent=!1,this.canFocus=!1;for(const n in t)yt(t,n)&&(this[n]=t[n]);this.level=0,this.loaded=!1,this.childNodes=[],this.loading=!1,this.parent&&(this.level=this.parent.level+1)}initialize(){const t=this.store;if(!t)throw new Error("[Node]store is required!");t.registerNode(this);const n=t.props;if(n&&typeof n.isLeaf<"u"){const l=ru(this,"isLeaf");It(l)&&(this.isLeafByUser=l)}if(t.lazy!==!0&&this.data?(this.setData(this.data),t.defaultExpandAll&&(this.expanded=!0,this.canFocus=!0)):this.level>0&&t.lazy&&t.defaultExpandAll&&!this.isLeafByUser&&this.expand(),Se(this.data)||Nm(this,this.data),!this.data)return;const a=t.defaultExpandedKeys,o=t.key;o&&a&&a.includes(this.key)&&this.expand(null,t.autoExpandParent),o&&t.currentNodeKey!==void 0&&this.key===t.currentNodeKey&&(t.currentNode=this,t.currentNode.isCurrent=!0),t.lazy&&t._initDefaultCheckedNode(this),this.updateLeafState(),this.parent&&(this.level===1||this.parent.expanded===!0)&&(this.canFocus=!0)}setData(t){Se(t)||Nm(this,t),this.data=t,this.childNodes=[];let n;this.level===0&&Se(this.data)?n=this.data:n=ru(this,"children")||[];for(let a=0,o=n.length;a<o;a++)this.insertChild({data:n[a]})}get label(){return ru(this,"label")}get key(){const t=this.store.key;return this.data?this.data[t]:null}get disabled(){return ru(this,"disabled")}get nextSibling(){const t=this.parent;if(t){const n=t.childNodes.indexOf(this);if(n>-1)return t.childNodes[n+1]}return null}get previousSibling(){const t=this.parent;if(t){const n=t.childNodes.indexOf(this);if(n>-1)return n>0?t.childNodes[n-1]:null}return null}contains(t,n=!0){return(this.childNodes||[]).some(a=>a===t||n&&a.contains(t))}remove(){const t=this.parent;t&&t.removeChild(this)}insertChild(t,n,a){if(!t)throw new Error("InsertChild error: child is required.");if(!(t instanceof Ru)){if(!a){const o=this.getChildren(!0);o.includes(t.data)||(bt(n)||n<0?o.push(t.data):o.splice(n,0,t.data))}Object.assign(t,{parent:this,store:this.store}),t=Ct(new Ru(t)),t instanceof Ru&&t.initialize()}t.level=this.level+1,bt(n)||n<0?this.childNodes.push(t):this.childNodes.splice(n,0,t),this.updateLeafState()}insertBefore(t,n){let a;n&&(a=this.childNodes.indexOf(n)),this.insertChild(t,a)}insertAfter(t,n){let a;n&&(a=this.childNodes.indexOf(n),a!==-1&&(a+=1)),this.insertChild(t,a)}removeChild(t){const n=this.getChildren()||[],a=n.indexOf(t.data);a>-1&&n.splice(a,1);const o=this.childNodes.indexOf(t);o>-1&&(this.store&&this.store.deregisterNode(t),t.parent=null,this.childNodes.splice(o,1)),this.updateLeafState()}removeChildByData(t){let n=null;for(let a=0;a<this.childNodes.length;a++)if(this.childNodes[a].data===t){n=this.childNodes[a];break}n&&this.removeChild(n)}expand(t,n){const a=()=>{if(n){let o=this.parent;for(;o.level>0;)o.expanded=!0,o=o.parent}this.expanded=!0,t&&t(),this.childNodes.forEach(o=>{o.canFocus=!0})};this.shouldLoadData()?this.loadData(o=>{Se(o)&&(this.checked?this.setChecked(!0,!0):this.store.checkStrictly||_s(this),a())}):a()}doCreateChildren(t,n={}){t.forEach(a=>{this.insertChild(Object.assign({data:a},n),void 0,!0)})}collapse(){this.expanded=!1,this.childNodes.forEach(t=>{t.canFocus=!1})}shouldLoadData(){return this.store.lazy===!0&&this.store.load&&!this.loaded}updateLeafState(){if(this.store.lazy===!0&&this.loaded!==!0&&typeof this.isLeafByUser<"u"){this.isLeaf=this.isLeafByUser;return}const t=this.childNodes;if(!this.store.lazy||this.store.lazy===!0&&this.loaded===!0){this.isLeaf=!t||t.length===0;return}this.isLeaf=!1}setChecked(t,n,a,o){if(this.indeterminate=t==="half",this.checked=t===!0,this.store.checkStrictly)return;if(!(this.shouldLoadData()&&!this.store.checkDescendants)){const{all:r,allWithoutDisable:i}=T0(this.childNodes);!this.isLeaf&&!r&&i&&(this.checked=!1,t=!1);const u=()=>{if(n){const c=this.childNodes;for(let p=0,v=c.length;p<v;p++){const m=c[p];o=o||t!==!1;const h=m.disabled?m.checked:o;m.setChecked(h,n,!0,o)}const{half:d,all:f}=T0(c);f||(this.checked=f,this.indeterminate=d)}};if(this.shouldLoadData()){this.loadData(()=>{u(),_s(this)},{checked:t!==!1});return}else u()}const l=this.parent;!l||l.level===0||a||_s(l)}getChildren(t=!1){if(this.level===0)return this.data;const n=this.data;if(!n)return null;const a=this.store.props;let o="children";return a&&(o=a.children||"children"),n[o]===void 0&&(n[o]=null),t&&!n[o]&&(n[o]=[]),n[o]}updateChildren(){const t=this.getChildren()||[],n=this.childNodes.map(l=>l.data),a={},o=[];t.forEach((l,r)=>{const i=l[br];!!i&&n.findIndex(c=>c[br]===i)>=0?a[i]={index:r,data:l}:o.push({index:r,data:l})}),this.store.lazy||n.forEach(l=>{a[l[br]]||this.removeChildByData(l)}),o.forEach(({index:l,data:r})=>{this.insertChild({data:r},l)}),this.updateLeafState()}loadData(t,n={}){if(this.store.lazy===!0&&this.store.load&&!this.loaded&&(!this.loading||Object.keys(n).length)){this.loading=!0;const a=l=>{this.childNodes=[],this.doCreateChildren(l,n),this.loaded=!0,this.loading=!1,this.updateLeafState(),t&&t.call(this,l)},o=()=>{this.loading=!1};this.store.load(this,a,o)}else t&&t.call(this)}eachNode(t){const n=[this];for(;n.length;){const a=n.shift();n.unshift(...a.childNodes),t(a)}}reInitChecked(){this.store.checkStrictly||_s(this)}};class Bne{constructor(t){this.currentNode=null,this.currentNodeKey=null;for(const n in t)yt(t,n)&&(this[n]=t[n]);this.nodesMap={}}initialize(){if(this.root=new $0({data:this.data,store:this}),this.root.initialize(),this.lazy&&this.load){const t=this.load;t(this.root,n=>{this.root.doCreateChildren(n),this._initDefaultCheckedNodes()})}else this._initDefaultCheckedNodes()}filter(t){const n=this.filterNodeMethod,a=this.lazy,o=async function(l){const r=l.root?l.root.childNodes:l.childNodes;for(const[i,u]of r.entries())u.visible=n.call(u,t,u.data,u),i%80===0&&i>0&&await Be(),o(u);if(!l.visible&&r.length){let i=!0;i=!r.some(u=>u.visible),l.root?l.root.visible=i===!1:l.visible=i===!1}t&&l.visible&&!l.isLeaf&&(!a||l.loaded)&&l.expand()};o(this)}setData(t){t!==this.root.data?(this.nodesMap={},this.root.setData(t),this._initDefaultCheckedNodes(),this.setCurrentNodeKey(this.currentNodeKey)):this.root.updateChildren()}getNode(t){if(t instanceof $0)return t;const n=nt(t)?D2(this.key,t):t;return this.nodesMap[n]||null}insertBefore(t,n){const a=this.getNode(n);a.parent.insertBefore({data:t},a)}insertAfter(t,n){const a=this.getNode(n);a.parent.insertAfter({data:t},a)}remove(t){const n=this.getNode(t);n&&n.parent&&(n===this.currentNode&&(this.currentNode=null),n.parent.removeChild(n))}append(t,n){const a=$a(n)?this.root:this.getNode(n);a&&a.insertChild({data:t})}_initDefaultCheckedNodes(){const t=this.defaultCheckedKeys||[],n=this.nodesMap;t.forEach(a=>{const o=n[a];o&&o.setChecked(!0,!this.checkStrictly)})}_initDefaultCheckedNode(t){(this.defaultCheckedKeys||[]).includes(t.key)&&t.setChecked(!0,!this.checkStrictly)}setDefaultCheckedKey(t){t!==this.defaultCheckedKeys&&(this.defaultCheckedKeys=t,this._initDefaultCheckedNodes())}registerNode(t){const n=this.key;!t||!t.data||(n?t.key!==void 0&&(this.nodesMap[t.key]=t):this.nodesMap[t.id]=t)}deregisterNode(t){!this.key||!t||!t.data||(t.childNodes.forEach(a=>{this.deregisterNode(a)}),delete this.nodesMap[t.key])}getCheckedNodes(t=!1,n=!1){const a=[],o=function(l){(l.root?l.root.childNodes:l.childNodes).forEach(i=>{(i.checked||n&&i.indeterminate)&&(!t||t&&i.isLeaf)&&a.push(i.data),o(i)})};return o(this),a}getCheckedKeys(t=!1){return this.getCheckedNodes(t).map(n=>(n||{})[this.key])}getHalfCheckedNodes(){const t=[],n=function(a){(a.root?a.root.childNodes:a.childNodes).forEach(l=>{l.indeterminate&&t.push(l.data),n(l)})};return n(this),t}getHalfCheckedKeys(){return this.getHalfCheckedNodes().map(t=>(t||{})[this.key])}_getAllNodes(){const t=[],n=this.nodesMap;for(const a in n)yt(n,a)&&t.push(n[a]);return t}updateChildren(t,n){const a=this.nodesMap[t];if(!a)return;const o=a.childNodes;for(let l=o.length-1;l>=0;l--){const r=o[l];this.remove(r.data)}for(let l=0,r=n.length;l<r;l++){const i=n[l];this.append(i,a.data)}}_setCheckedKeys(t,n=!1,a){const o=this._getAllNodes().sort((u,c)=>u.level-c.level),l=Object.create(null),r=Object.keys(a);o.forEach(u=>u.setChecked(!1,!1));const i=u=>{u.childNodes.forEach(c=>{var d;l[c.data[t]]=!0,(d=c.childNodes)!=null&&d.length&&i(c)})};for(let u=0,c=o.length;u<c;u++){const d=o[u],f=d.data[t].toString();if(!r.includes(f)){d.checked&&!l[f]&&d.setChecked(!1,!1);continue}if(d.childNodes.length&&i(d),d.isLeaf||this.checkStrictly){d.setChecked(!0,!1);continue}if(d.setChecked(!0,!0),n){d.setChecked(!1,!1);const v=function(m){m.childNodes.forEach(y=>{y.isLeaf||y.setChecked(!1,!1),v(y)})};v(d)}}}setCheckedNodes(t,n=!1){const a=this.key,o={};t.forEach(l=>{o[(l||{})[a]]=!0}),this._setCheckedKeys(a,n,o)}setCheckedKeys(t,n=!1){this.defaultCheckedKeys=t;const a=this.key,o={};t.forEach(l=>{o[l]=!0}),this._setCheckedKeys(a,n,o)}setDefaultExpandedKeys(t){t=t||[],this.defaultExpandedKeys=t,t.forEach(n=>{const a=this.getNode(n);a&&a.expand(null,this.autoExpandParent)})}setChecked(t,n,a){const o=this.getNode(t);o&&o.setChecked(!!n,a)}getCurrentNode(){return this.currentNode}setCurrentNode(t){const n=this.currentNode;n&&(n.isCurrent=!1),this.currentNode=t,this.currentNode.isCurrent=!0}setUserCurrentNode(t,n=!0){const a=t[this.key],o=this.nodesMap[a];this.setCurrentNode(o),n&&this.currentNode.level>1&&this.currentNode.parent.expand(null,!0)}setCurrentNodeKey(t,n=!0){if(this.currentNodeKey=t,t==null){this.currentNode&&(this.currentNode.isCurrent=!1),this.currentNode=null;return}const a=this.getNode(t);a&&(this.setCurrentNode(a),n&&this.currentNode.level>1&&this.currentNode.parent.expand(null,!0))}}const zne=I({name:"ElTreeNodeContent",props:{node:{type:Object,required:!0},renderContent:Function},setup(e){const t=ge("tree"),n=Pe("NodeInstance"),a=Pe("RootTree");return()=>{const o=e.node,{data:l,store:r}=o;return e.renderContent?e.renderContent(We,{_self:n,node:o,data:l,store:r}):ne(a.ctx.slots,"default",{node:o,data:l},()=>[We("span",{class:t.be("node","label")},[o.label])])}}});var Hne=Me(zne,[["__file","tree-node-content.vue"]]);function w_(e){const t=Pe("TreeNodeMap",null),n={treeNodeExpand:a=>{e.node!==a&&e.node.collapse()},children:[]};return t&&t.children.push(n),ft("TreeNodeMap",n),{broadcastExpanded:a=>{if(e.accordion)for(const o of n.children)o.treeNodeExpand(a)}}}const C_=Symbol("dragEvents");function Dne({props:e,ctx:t,el$:n,dropIndicator$:a,store:o}){const l=ge("tree"),r=V({showDropIndicator:!1,draggingNode:null,dropNode:null,allowDrop:!0,dropType:null});return ft(C_,{treeNodeDragStart:({event:d,treeNode:f})=>{if(ze(e.allowDrag)&&!e.allowDrag(f.node))return d.preventDefault(),!1;d.dataTransfer.effectAllowed="move";try{d.dataTransfer.setData("text/plain","")}catch{}r.value.draggingNode=f,t.emit("node-drag-start",f.node,d)},treeNodeDragOver:({event:d,treeNode:f})=>{const p=f,v=r.value.dropNode;v&&v.node.id!==p.node.id&&Nn(v.$el,l.is("drop-inner"));const m=r.value.draggingNode;if(!m||!p)return;let h=!0,y=!0,g=!0,k=!0;ze(e.allowDrop)&&(h=e.allowDrop(m.node,p.node,"prev"),k=y=e.allowDrop(m.node,p.node,"inner"),g=e.allowDrop(m.node,p.node,"next")),d.dataTransfer.dropEffect=y||h||g?"move":"none",(h||y||g)&&(v==null?void 0:v.node.id)!==p.node.id&&(v&&t.emit("node-drag-leave",m.node,v.node,d),t.emit("node-drag-enter",m.node,p.node,d)),h||y||g?r.value.dropNode=p:r.value.dropNode=null,p.node.nextSibling===m.node&&(g=!1),p.node.previousSibling===m.node&&(h=!1),p.node.contains(m.node,!1)&&(y=!1),(m.node===p.node||m.node.contains(p.node))&&(h=!1,y=!1,g=!1);const w=p.$el.querySelector(`.${l.be("node","content")}`).getBoundingClientRect(),_=n.value.getBoundingClientRect();let C;const E=h?y?.25:g?.45:1:-1,$=g?y?.75:h?.55:0:1;let A=-9999;const O=d.clientY-w.top;O<w.height*E?C="before":O>w.height*$?C="after":y?C="inner":C="none";const N=p.$el.querySelector(`.${l.be("node","expand-icon")}`).getBoundingClientRect(),L=a.value;C==="before"?A=N.top-_.top:C==="after"&&(A=N.bottom-_.top),L.style.top=`${A}px`,L.style.left=`${N.right-_.left}px`,C==="inner"?fa(p.$el,l.is("drop-inner")):Nn(p.$el,l.is("drop-inner")),r.value.showDropIndicator=C==="before"||C==="after",r.value.allowDrop=r.value.showDropIndicator||k,r.value.dropType=C,t.emit("node-drag-over",m.node,p.node,d)},treeNodeDragEnd:d=>{const{draggingNode:f,dropType:p,dropNode:v}=r.value;if(d.preventDefault(),d.dataTransfer&&(d.dataTransfer.dropEffect="move"),f&&v){const m={data:f.node.data};p!=="none"&&f.node.remove(),p==="before"?v.node.parent.insertBefore(m,v.node):p==="after"?v.node.parent.insertAfter(m,v.node):p==="inner"&&v.node.insertChild(m),p!=="none"&&(o.value.registerNode(m),o.value.key&&f.node.eachNode(h=>{var y;(y=o.value.nodesMap[h.data[o.value.key]])==null||y.setChecked(h.checked,!o.value.checkStrictly)})),Nn(v.$el,l.is("drop-inner")),t.emit("node-drag-end",f.node,v.node,p,d),p!=="none"&&t.emit("node-drop",f.node,v.node,p,d)}f&&!v&&t.emit("node-drag-end",f.node,null,p,d),r.value.showDropIndicator=!1,r.value.draggingNode=null,r.value.dropNode=null,r.value.allowDrop=!0}}),{dragState:r}}const Fne=I({name:"ElTreeNode",components:{ElCollapseTransition:td,ElCheckbox:ba,NodeContent:Hne,ElIcon:Le,Loading:Na},props:{node:{type:$0,default:()=>({})},props:{type:Object,default:()=>({})},accordion:Boolean,renderContent:Function,renderAfterExpand:Boolean,showCheckbox:{type:Boolean,default:!1}},emits:["node-expand"],setup(e,t){const n=ge("tree"),{broadcastExpanded:a}=w_(e),o=Pe("RootTree"),l=V(!1),r=V(!1),i=V(),u=V(),c=V(),d=Pe(C_),f=lt();ft("NodeInstance",f),e.node.expanded&&(l.value=!0,r.value=!0);const p=o.props.props.children||"children";pe(()=>{var O;const N=(O=e.node.data)==null?void 0:O[p];return N&&[...N]},()=>{e.node.updateChildren()}),pe(()=>e.node.indeterminate,O=>{h(e.node.checked,O)}),pe(()=>e.node.checked,O=>{h(O,e.node.indeterminate)}),pe(()=>e.node.childNodes.length,()=>e.node.reInitChecked()),pe(()=>e.node.expanded,O=>{Be(()=>l.value=O),O&&(r.value=!0)});const v=O=>D2(o.props.nodeKey,O.data),m=O=>{const N=e.props.class;if(!N)return{};let L;if(ze(N)){const{data:R}=O;L=N(R,O)}else L=N;return Ve(L)?{[L]:!0}:L},h=(O,N)=>{(i.value!==O||u.value!==N)&&o.ctx.emit("check-change",e.node.data,O,N),i.value=O,u.value=N},y=O=>{M0(o.store,o.ctx.emit,()=>{var N;if((N=o==null?void 0:o.props)==null?void 0:N.nodeKey){const R=v(e.node);o.store.value.setCurrentNodeKey(R)}else o.store.value.setCurrentNode(e.node)}),o.currentNode.value=e.node,o.props.expandOnClickNode&&k(),(o.props.checkOnClickNode||e.node.isLeaf&&o.props.checkOnClickLeaf)&&!e.node.disabled&&w(!e.node.checked),o.ctx.emit("node-click",e.node.data,e.node,f,O)},g=O=>{var N;(N=o.instance.vnode.props)!=null&&N.onNodeContextmenu&&(O.stopPropagation(),O.preventDefault()),o.ctx.emit("node-contextmenu",O,e.node.data,e.node,f)},k=()=>{e.node.isLeaf||(l.value?(o.ctx.emit("node-collapse",e.node.data,e.node,f),e.node.collapse()):e.node.expand(()=>{t.emit("node-expand",e.node.data,e.node,f)}))},w=O=>{e.node.setChecked(O,!(o!=null&&o.props.checkStrictly)),Be(()=>{const N=o.store.value;o.ctx.emit("check",e.node.data,{checkedNodes:N.getCheckedNodes(),checkedKeys:N.getCheckedKeys(),halfCheckedNodes:N.getHalfCheckedNodes(),halfCheckedKeys:N.getHalfCheckedKeys()})})};return{ns:n,node$:c,tree:o,expanded:l,childNodeRendered:r,oldChecked:i,oldIndeterminate:u,getNodeKey:v,getNodeClass:m,handleSelectChange:h,handleClick:y,handleContextMenu:g,handleExpandIconClick:k,handleCheckChange:w,handleChildNodeExpand:(O,N,L)=>{a(N),o.ctx.emit("node-expand",O,N,L)},handleDragStart:O=>{o.props.draggable&&d.treeNodeDragStart({event:O,treeNode:e})},handleDragOver:O=>{O.preventDefault(),o.props.draggable&&d.treeNodeDragOver({event:O,treeNode:{$el:c.value,node:e.node}})},handleDrop:O=>{O.preventDefault()},handleDragEnd:O=>{o.props.draggable&&d.treeNodeDragEnd(O)},CaretRight:xp}}});function Kne(e,t,n,a,o,l){const r=ut("el-icon"),i=ut("el-checkbox"),u=ut("loading"),c=ut("node-content"),d=ut("el-tree-node"),f=ut("el-collapse-transition");return Qe((b(),M("div",{ref:"node$",class:T([e.ns.b("node"),e.ns.is("expanded",e.expanded),e.ns.is("current",e.node.isCurrent),e.ns.is("hidden",!e.node.visible),e.ns.is("focusable",!e.node.disabled),e.ns.is("checked",!e.node.disabled&&e.node.checked),e.getNodeClass(e.node)]),role:"treeitem",tabindex:"-1","aria-expanded":e.expanded,"aria-disabled":e.node.disabled,"aria-checked":e.node.checked,draggable:e.tree.props.draggable,"data-key":e.getNodeKey(e.node),onClick:qe(e.handleClick,["stop"]),onContextmenu:e.handleContextMenu,onDragstart:qe(e.handleDragStart,["stop"]),onDragover:qe(e.handleDragOver,["stop"]),onDragend:qe(e.handleDragEnd,["stop"]),onDrop:qe(e.handleDrop,["stop"])},[x("div",{class:T(e.ns.be("node","content")),style:Fe({paddingLeft:(e.node.level-1)*e.tree.props.indent+"px"})},[e.tree.props.icon||e.CaretRight?(b(),ue(r,{key:0,class:T([e.ns.be("node","expand-icon"),e.ns.is("leaf",e.node.isLeaf),{expanded:!e.node.isLeaf&&e.expanded}]),onClick:qe(e.handleExpandIconClick,["stop"])},{default:J(()=>[(b(),ue(rt(e.tree.props.icon||e.CaretRight)))]),_:1},8,["class","onClick"])):le("v-if",!0),e.showCheckbox?(b(),ue(i,{key:1,"model-value":e.node.checked,indeterminate:e.node.indeterminate,disabled:!!e.node.disabled,onClick:qe(()=>{},["stop"]),onChange:e.handleCheckChange},null,8,["model-value","indeterminate","disabled","onClick","onChange"])):le("v-if",!0),e.node.loading?(b(),ue(r,{key:2,class:T([e.ns.be("node","loading-icon"),e.ns.is("loading")])},{default:J(()=>[U(u)]),_:1},8,["class"])):le("v-if",!0),U(c,{node:e.node,"render-content":e.renderContent},null,8,["node","render-content"])],6),U(f,null,{default:J(()=>[!e.renderAfterExpand||e.childNodeRendered?Qe((b(),M("div",{key:0,class:T(e.ns.be("node","children")),role:"group","aria-expanded":e.expanded},[(b(!0),M(He,null,pt(e.node.childNodes,p=>(b(),ue(d,{key:e.getNodeKey(p),"render-content":e.renderContent,"render-after-expand":e.renderAfterExpand,"show-checkbox":e.showCheckbox,node:p,accordion:e.accordion,props:e.props,onNodeExpand:e.handleChildNodeExpand},null,8,["render-content","render-after-expand","show-checkbox","node","accordion","props","onNodeExpand"]))),128))],10,["aria-expanded"])),[[_t,e.expanded]]):le("v-if",!0)]),_:1})],42,["aria-expanded","aria-disabled","aria-checked","draggable","data-key","onClick","onContextmenu","onDragstart","onDragover","onDragend","onDrop"])),[[_t,e.node.visible]])}var Wne=Me(Fne,[["render",Kne],["__file","tree-node.vue"]]);function jne({el$:e},t){const n=ge("tree"),a=Pt([]),o=Pt([]);at(()=>{r()}),La(()=>{a.value=Array.from(e.value.querySelectorAll("[role=treeitem]")),o.value=Array.from(e.value.querySelectorAll("input[type=checkbox]"))}),pe(o,i=>{i.forEach(u=>{u.setAttribute("tabindex","-1")})}),Mt(e,"keydown",i=>{const u=i.target;if(!u.className.includes(n.b("node")))return;const c=i.code;a.value=Array.from(e.value.querySelectorAll(`.${n.is("focusable")}[role=treeitem]`));const d=a.value.indexOf(u);let f;if([Ae.up,Ae.down].includes(c)){if(i.preventDefault(),c===Ae.up){f=d===-1?0:d!==0?d-1:a.value.length-1;const v=f;for(;!t.value.getNode(a.value[f].dataset.key).canFocus;){if(f--,f===v){f=-1;break}f<0&&(f=a.value.length-1)}}else{f=d===-1?0:d<a.value.length-1?d+1:0;const v=f;for(;!t.value.getNode(a.value[f].dataset.key).canFocus;){if(f++,f===v){f=-1;break}f>=a.value.length&&(f=0)}}f!==-1&&a.value[f].focus()}[Ae.left,Ae.right].includes(c)&&(i.preventDefault(),u.click());const p=u.querySelector('[type="checkbox"]');[Ae.enter,Ae.numpadEnter,Ae.space].includes(c)&&p&&(i.preventDefault(),p.click())});const r=()=>{var i;a.value=Array.from(e.value.querySelectorAll(`.${n.is("focusable")}[role=treeitem]`)),o.value=Array.from(e.value.querySelectorAll("input[type=checkbox]"));const u=e.value.querySelectorAll(`.${n.is("checked")}[role=treeitem]`);if(u.length){u[0].setAttribute("tabindex","0");return}(i=a.value[0])==null||i.setAttribute("tabindex","0")}}const qne=I({name:"ElTree",components:{ElTreeNode:Wne},props:{data:{type:Array,default:()=>[]},emptyText:{type:String},renderAfterExpand:{type:Boolean,default:!0},nodeKey:String,checkStrictly:Boolean,defaultExpandAll:Boolean,expandOnClickNode:{type:Boolean,default:!0},checkOnClickNode:Boolean,checkOnClickLeaf:{type:Boolean,default:!0},checkDescendants:{type:Boolean,default:!1},autoExpandParent:{type:Boolean,default:!0},defaultCheckedKeys:Array,defaultExpandedKeys:Array,currentNodeKey:[String,Number],renderContent:Function,showCheckbox:{type:Boolean,default:!1},draggable:{type:Boolean,default:!1},allowDrag:Function,allowDrop:Function,props:{type:Object,default:()=>({children:"children",label:"label",disabled:"disabled"})},lazy:{type:Boolean,default:!1},highlightCurrent:Boolean,load:Function,filterNodeMethod:Function,accordion:Boolean,indent:{type:Number,default:18},icon:{type:Tt}},emits:["check-change","current-change","node-click","node-contextmenu","node-collapse","node-expand","check","node-drag-start","node-drag-end","node-drop","node-drag-leave","node-drag-enter","node-drag-over"],setup(e,t){const{t:n}=gt(),a=ge("tree"),o=Pe(ns,null),l=V(new Bne({key:e.nodeKey,data:e.data,lazy:e.lazy,props:e.props,load:e.load,currentNodeKey:e.currentNodeKey,checkStrictly:e.checkStrictly,checkDescendants:e.checkDescendants,defaultCheckedKeys:e.defaultCheckedKeys,defaultExpandedKeys:e.defaultExpandedKeys,autoExpandParent:e.autoExpandParent,defaultExpandAll:e.defaultExpandAll,filterNodeMethod:e.filterNodeMethod}));l.value.initialize();const r=V(l.value.root),i=V(null),u=V(null),c=V(null),{broadcastExpanded:d}=w_(e),{dragState:f}=Dne({props:e,ctx:t,el$:u,dropIndicator$:c,store:l});jne({el$:u},l);const p=S(()=>{const{childNodes:z}=r.value,B=o?o.hasFilteredOptions!==0:!1;return(!z||z.length===0||z.every(({visible:j})=>!j))&&!B});pe(()=>e.currentNodeKey,z=>{l.value.setCurrentNodeKey(z)}),pe(()=>e.defaultCheckedKeys,z=>{l.value.setDefaultCheckedKey(z)}),pe(()=>e.defaultExpandedKeys,z=>{l.value.setDefaultExpandedKeys(z)}),pe(()=>e.data,z=>{l.value.setData(z)},{deep:!0}),pe(()=>e.checkStrictly,z=>{l.value.checkStrictly=z});const v=z=>{if(!e.filterNodeMethod)throw new Error("[Tree] filterNodeMethod is required when filter");l.value.filter(z)},m=z=>D2(e.nodeKey,z.data),h=z=>{if(!e.nodeKey)throw new Error("[Tree] nodeKey is required in getNodePath");const B=l.value.getNode(z);if(!B)return[];const j=[B.data];let G=B.parent;for(;G&&G!==r.value;)j.push(G.data),G=G.parent;return j.reverse()},y=(z,B)=>l.value.getCheckedNodes(z,B),g=z=>l.value.getCheckedKeys(z),k=()=>{const z=l.value.getCurrentNode();return z?z.data:null},w=()=>{if(!e.nodeKey)throw new Error("[Tree] nodeKey is required in getCurrentKey");const z=k();return z?z[e.nodeKey]:null},_=(z,B)=>{if(!e.nodeKey)throw new Error("[Tree] nodeKey is required in setCheckedNodes");l.value.setCheckedNodes(z,B)},C=(z,B)=>{if(!e.nodeKey)throw new Error("[Tree] nodeKey is required in setCheckedKeys");l.value.setCheckedKeys(z,B)},E=(z,B,j)=>{l.value.setChecked(z,B,j)},$=()=>l.value.getHalfCheckedNodes(),A=()=>l.value.getHalfCheckedKeys(),O=(z,B=!0)=>{if(!e.nodeKey)throw new Error("[Tree] nodeKey is required in setCurrentNode");M0(l,t.emit,()=>{d(z),l.value.setUserCurrentNode(z,B)})},N=(z,B=!0)=>{if(!e.nodeKey)throw new Error("[Tree] nodeKey is required in setCurrentKey");M0(l,t.emit,()=>{d(),l.value.setCurrentNodeKey(z,B)})},L=z=>l.value.getNode(z),R=z=>{l.value.remove(z)},K=(z,B)=>{l.value.append(z,B)},X=(z,B)=>{l.value.insertBefore(z,B)},F=(z,B)=>{l.value.insertAfter(z,B)},P=(z,B,j)=>{d(B),t.emit("node-expand",z,B,j)},H=(z,B)=>{if(!e.nodeKey)throw new Error("[Tree] nodeKey is required in updateKeyChild");l.value.updateChildren(z,B)};return ft("RootTree",{ctx:t,props:e,store:l,root:r,currentNode:i,instance:lt()}),ft(Ja,void 0),{ns:a,store:l,root:r,currentNode:i,dragState:f,el$:u,dropIndicator$:c,isEmpty:p,filter:v,getNodeKey:m,getNodePath:h,getCheckedNodes:y,getCheckedKeys:g,getCurrentNode:k,getCurrentKey:w,setCheckedNodes:_,setCheckedKeys:C,setChecked:E,getHalfCheckedNodes:$,getHalfCheckedKeys:A,setCurrentNode:O,setCurrentKey:N,t:n,getNode:L,remove:R,append:K,insertBefore:X,insertAfter:F,handleNodeExpand:P,updateKeyChildren:H}}});function Une(e,t,n,a,o,l){const r=ut("el-tree-node");return b(),M("div",{ref:"el$",class:T([e.ns.b(),e.ns.is("dragging",!!e.dragState.draggingNode),e.ns.is("drop-not-allow",!e.dragState.allowDrop),e.ns.is("drop-inner",e.dragState.dropType==="inner"),{[e.ns.m("highlight-current")]:e.highlightCurrent}]),role:"tree"},[(b(!0),M(He,null,pt(e.root.childNodes,i=>(b(),ue(r,{key:e.getNodeKey(i),node:i,props:e.props,accordion:e.accordion,"render-after-expand":e.renderAfterExpand,"show-checkbox":e.showCheckbox,"render-content":e.renderContent,onNodeExpand:e.handleNodeExpand},null,8,["node","props","accordion","render-after-expand","show-checkbox","render-content","onNodeExpand"]))),128)),e.isEmpty?(b(),M("div",{key:0,class:T(e.ns.e("empty-block"))},[ne(e.$slots,"empty",{},()=>{var i;return[x("span",{class:T(e.ns.e("empty-text"))},Te((i=e.emptyText)!=null?i:e.t("el.tree.emptyText")),3)]})],2)):le("v-if",!0),Qe(x("div",{ref:"dropIndicator$",class:T(e.ns.e("drop-indicator"))},null,2),[[_t,e.dragState.showDropIndicator]])],2)}var Yne=Me(qne,[["render",Une],["__file","tree.vue"]]);const yc=Ze(Yne),Gne=(e,{attrs:t,emit:n},{select:a,tree:o,key:l})=>{const r=ge("tree-select");return pe(()=>e.data,()=>{e.filterable&&Be(()=>{var u,c;(c=o.value)==null||c.filter((u=a.value)==null?void 0:u.states.inputValue)})},{flush:"post"}),{...Ea(pn(e),Object.keys(Vl.props)),...t,class:S(()=>t.class),style:S(()=>t.style),"onUpdate:modelValue":u=>n(Ue,u),valueKey:l,popperClass:S(()=>{const u=[r.e("popper")];return e.popperClass&&u.push(e.popperClass),u.join(" ")}),filterMethod:(u="")=>{var c;e.filterMethod?e.filterMethod(u):e.remoteMethod?e.remoteMethod(u):(c=o.value)==null||c.filter(u)}}},Xne=I({extends:rc,setup(e,t){const n=rc.setup(e,t);delete n.selectOptionClick;const a=lt().proxy;return Be(()=>{n.select.states.cachedOptions.get(a.value)||n.select.onOptionCreate(a)}),pe(()=>t.attrs.visible,o=>{Be(()=>{n.states.visible=o})},{immediate:!0}),n},methods:{selectOptionClick(){this.$el.parentElement.click()}}});function O0(e){return e||e===0}function F2(e){return Se(e)&&e.length}function Ql(e){return Se(e)?e:O0(e)?[e]:[]}function Lu(e,t,n,a,o){for(let l=0;l<e.length;l++){const r=e[l];if(t(r,l,e,o))return a?a(r,l,e,o):r;{const i=n(r);if(F2(i)){const u=Lu(i,t,n,a,r);if(u)return u}}}}function Vu(e,t,n,a){for(let o=0;o<e.length;o++){const l=e[o];t(l,o,e,a);const r=n(l);F2(r)&&Vu(r,t,n,l)}}const Zne=(e,{attrs:t,slots:n,emit:a},{select:o,tree:l,key:r})=>{pe(()=>e.modelValue,()=>{e.showCheckbox&&Be(()=>{const p=l.value;p&&!un(p.getCheckedKeys(),Ql(e.modelValue))&&p.setCheckedKeys(Ql(e.modelValue))})},{immediate:!0,deep:!0});const i=S(()=>({value:r.value,label:"label",children:"children",disabled:"disabled",isLeaf:"isLeaf",...e.props})),u=(p,v)=>{var m;const h=i.value[p];return ze(h)?h(v,(m=l.value)==null?void 0:m.getNode(u("value",v))):v[h]},c=Ql(e.modelValue).map(p=>Lu(e.data||[],v=>u("value",v)===p,v=>u("children",v),(v,m,h,y)=>y&&u("value",y))).filter(p=>O0(p)),d=S(()=>{if(!e.renderAfterExpand&&!e.lazy)return[];const p=[];return Vu(e.data.concat(e.cacheData),v=>{const m=u("value",v);p.push({value:m,currentLabel:u("label",v),isDisabled:u("disabled",v)})},v=>u("children",v)),p}),f=()=>{var p;return(p=l.value)==null?void 0:p.getCheckedKeys().filter(v=>{var m;const h=(m=l.value)==null?void 0:m.getNode(v);return!on(h)&&na(h.childNodes)})};return{...Ea(pn(e),Object.keys(yc.props)),...t,nodeKey:r,expandOnClickNode:S(()=>!e.checkStrictly&&e.expandOnClickNode),defaultExpandedKeys:S(()=>e.defaultExpandedKeys?e.defaultExpandedKeys.concat(c):c),renderContent:(p,{node:v,data:m,store:h})=>p(Xne,{value:u("value",m),label:u("label",m),disabled:u("disabled",m),visible:v.visible},e.renderContent?()=>e.renderContent(p,{node:v,data:m,store:h}):n.default?()=>n.default({node:v,data:m,store:h}):void 0),filterNodeMethod:(p,v,m)=>e.filterNodeMethod?e.filterNodeMethod(p,v,m):p?new RegExp(Xp(p),"i").test(u("label",v)||""):!0,onNodeClick:(p,v,m)=>{var h,y,g,k;if((h=t.onNodeClick)==null||h.call(t,p,v,m),!(e.showCheckbox&&e.checkOnClickNode)){if(!e.showCheckbox&&(e.checkStrictly||v.isLeaf)){if(!u("disabled",p)){const w=(y=o.value)==null?void 0:y.states.options.get(u("value",p));(g=o.value)==null||g.handleOptionSelect(w)}}else e.expandOnClickNode&&m.proxy.handleExpandIconClick();(k=o.value)==null||k.focus()}},onCheck:(p,v)=>{var m;if(!e.showCheckbox)return;const h=u("value",p),y={};Vu([l.value.store.root],_=>y[_.key]=_,_=>_.childNodes);const g=v.checkedKeys,k=e.multiple?Ql(e.modelValue).filter(_=>!(_ in y)&&!g.includes(_)):[],w=k.concat(g);if(e.checkStrictly)a(Ue,e.multiple?w:w.includes(h)?h:void 0);else if(e.multiple){const _=f();a(Ue,k.concat(_))}else{const _=Lu([p],$=>!F2(u("children",$))&&!u("disabled",$),$=>u("children",$)),C=_?u("value",_):void 0,E=O0(e.modelValue)&&!!Lu([p],$=>u("value",$)===e.modelValue,$=>u("children",$));a(Ue,C===e.modelValue||E?void 0:C)}Be(()=>{var _;const C=Ql(e.modelValue);l.value.setCheckedKeys(C),(_=t.onCheck)==null||_.call(t,p,{checkedKeys:l.value.getCheckedKeys(),checkedNodes:l.value.getCheckedNodes(),halfCheckedKeys:l.value.getHalfCheckedKeys(),halfCheckedNodes:l.value.getHalfCheckedNodes()})}),(m=o.value)==null||m.focus()},onNodeExpand:(p,v,m)=>{var h;(h=t.onNodeExpand)==null||h.call(t,p,v,m),Be(()=>{if(!e.checkStrictly&&e.lazy&&e.multiple&&v.checked){const y={},g=l.value.getCheckedKeys();Vu([l.value.store.root],_=>y[_.key]=_,_=>_.childNodes);const k=Ql(e.modelValue).filter(_=>!(_ in y)&&!g.includes(_)),w=f();a(Ue,k.concat(w))}})},cacheOptions:d}};var Jne=I({props:{data:{type:Array,default:()=>[]}},setup(e){const t=Pe(ns);return pe(()=>e.data,()=>{var n;e.data.forEach(o=>{t.states.cachedOptions.has(o.value)||t.states.cachedOptions.set(o.value,o)});const a=((n=t.selectRef)==null?void 0:n.querySelectorAll("input"))||[];mt&&!Array.from(a).includes(document.activeElement)&&t.setSelected()},{flush:"post",immediate:!0}),()=>{}}});const Qne=I({name:"ElTreeSelect",inheritAttrs:!1,props:{...Vl.props,...yc.props,cacheData:{type:Array,default:()=>[]}},setup(e,t){const{slots:n,expose:a}=t,o=V(),l=V(),r=S(()=>e.nodeKey||e.valueKey||"value"),i=Gne(e,t,{select:o,tree:l,key:r}),{cacheOptions:u,...c}=Zne(e,t,{select:o,tree:l,key:r}),d=Ct({});return a(d),at(()=>{Object.assign(d,{...Ea(l.value,["filter","updateKeyChildren","getCheckedNodes","setCheckedNodes","getCheckedKeys","setCheckedKeys","setChecked","getHalfCheckedNodes","getHalfCheckedKeys","getCurrentKey","getCurrentNode","setCurrentKey","setCurrentNode","getNode","remove","append","insertBefore","insertAfter"]),...Ea(o.value,["focus","blur","selectedLabel"])})}),()=>We(Vl,Ct({...i,ref:f=>o.value=f}),{...n,default:()=>[We(Jne,{data:u.value}),We(yc,Ct({...c,ref:f=>l.value=f}))]})}});var eae=Me(Qne,[["__file","tree-select.vue"]]);const tae=Ze(eae),K2=Symbol(),nae={key:-1,level:-1,data:{}};var bs=(e=>(e.KEY="id",e.LABEL="label",e.CHILDREN="children",e.DISABLED="disabled",e.CLASS="",e))(bs||{}),I0=(e=>(e.ADD="add",e.DELETE="delete",e))(I0||{});const S_={type:Number,default:26},aae=Ee({data:{type:Q(Array),default:()=>Ft([])},emptyText:{type:String},height:{type:Number,default:200},props:{type:Q(Object),default:()=>Ft({children:"children",label:"label",disabled:"disabled",value:"id",class:""})},highlightCurrent:{type:Boolean,default:!1},showCheckbox:{type:Boolean,default:!1},defaultCheckedKeys:{type:Q(Array),default:()=>Ft([])},checkStrictly:{type:Boolean,default:!1},defaultExpandedKeys:{type:Q(Array),default:()=>Ft([])},indent:{type:Number,default:16},itemSize:S_,icon:{type:Tt},expandOnClickNode:{type:Boolean,default:!0},checkOnClickNode:{type:Boolean,default:!1},checkOnClickLeaf:{type:Boolean,default:!0},currentNodeKey:{type:Q([String,Number])},accordion:{type:Boolean,default:!1},filterMethod:{type:Q(Function)},perfMode:{type:Boolean,default:!0}}),oae=Ee({node:{type:Q(Object),default:()=>Ft(nae)},expanded:{type:Boolean,default:!1},checked:{type:Boolean,default:!1},indeterminate:{type:Boolean,default:!1},showCheckbox:{type:Boolean,default:!1},disabled:{type:Boolean,default:!1},current:{type:Boolean,default:!1},hiddenExpandIcon:{type:Boolean,default:!1},itemSize:S_}),lae=Ee({node:{type:Q(Object),required:!0}}),k_="node-click",E_="node-drop",x_="node-expand",M_="node-collapse",T_="current-change",$_="check",O_="check-change",I_="node-contextmenu",rae={[k_]:(e,t,n)=>e&&t&&n,[E_]:(e,t,n)=>e&&t&&n,[x_]:(e,t)=>e&&t,[M_]:(e,t)=>e&&t,[T_]:(e,t)=>e&&t,[$_]:(e,t)=>e&&t,[O_]:(e,t)=>e&&It(t),[I_]:(e,t,n)=>e&&t&&n},sae={click:(e,t)=>!!(e&&t),drop:(e,t)=>!!(e&&t),toggle:e=>!!e,check:(e,t)=>e&&It(t)};function iae(e,t){const n=V(new Set),a=V(new Set),{emit:o}=lt();pe([()=>t.value,()=>e.defaultCheckedKeys],()=>Be(()=>{k(e.defaultCheckedKeys)}),{immediate:!0});const l=()=>{if(!t.value||!e.showCheckbox||e.checkStrictly)return;const{levelTreeNodeMap:w,maxLevel:_}=t.value,C=n.value,E=new Set;for(let $=_-1;$>=1;--$){const A=w.get($);A&&A.forEach(O=>{const N=O.children;if(N){let L=!0,R=!1;for(const K of N){const X=K.key;if(C.has(X))R=!0;else if(E.has(X)){L=!1,R=!0;break}else L=!1}L?C.add(O.key):R?(E.add(O.key),C.delete(O.key)):(C.delete(O.key),E.delete(O.key))}})}a.value=E},r=w=>n.value.has(w.key),i=w=>a.value.has(w.key),u=(w,_,C=!0,E=!0)=>{const $=n.value,A=(O,N)=>{$[N?I0.ADD:I0.DELETE](O.key);const L=O.children;!e.checkStrictly&&L&&L.forEach(R=>{R.disabled||A(R,N)})};A(w,_),E&&l(),C&&c(w,_)},c=(w,_)=>{const{checkedNodes:C,checkedKeys:E}=m(),{halfCheckedNodes:$,halfCheckedKeys:A}=h();o($_,w.data,{checkedKeys:E,checkedNodes:C,halfCheckedKeys:A,halfCheckedNodes:$}),o(O_,w.data,_)};function d(w=!1){return m(w).checkedKeys}function f(w=!1){return m(w).checkedNodes}function p(){return h().halfCheckedKeys}function v(){return h().halfCheckedNodes}function m(w=!1){const _=[],C=[];if(t!=null&&t.value&&e.showCheckbox){const{treeNodeMap:E}=t.value;n.value.forEach($=>{const A=E.get($);A&&(!w||w&&A.isLeaf)&&(C.push($),_.push(A.data))})}return{checkedKeys:C,checkedNodes:_}}function h(){const w=[],_=[];if(t!=null&&t.value&&e.showCheckbox){const{treeNodeMap:C}=t.value;a.value.forEach(E=>{const $=C.get(E);$&&(_.push(E),w.push($.data))})}return{halfCheckedNodes:w,halfCheckedKeys:_}}function y(w){n.value.clear(),a.value.clear(),Be(()=>{k(w)})}function g(w,_){if(t!=null&&t.value&&e.showCheckbox){const C=t.value.treeNodeMap.get(w);C&&u(C,_,!1)}}function k(w){if(t!=null&&t.value){const{treeNodeMap:_}=t.value;if(e.showCheckbox&&_&&(w==null?void 0:w.length)>0){for(const C of w){const E=_.get(C);E&&!r(E)&&u(E,!0,!1,!1)}l()}}}return{updateCheckedKeys:l,toggleCheckbox:u,isChecked:r,isIndeterminate:i,getCheckedKeys:d,getCheckedNodes:f,getHalfCheckedKeys:p,getHalfCheckedNodes:v,setChecked:g,setCheckedKeys:y}}function uae(e,t){const n=V(new Set([])),a=V(new Set([])),o=S(()=>ze(e.filterMethod));function l(i){var u;if(!o.value)return;const c=new Set,d=a.value,f=n.value,p=[],v=((u=t.value)==null?void 0:u.treeNodes)||[],m=e.filterMethod;f.clear();function h(y){y.forEach(g=>{p.push(g),m!=null&&m(i,g.data,g)?p.forEach(w=>{c.add(w.key)}):g.isLeaf&&f.add(g.key);const k=g.children;if(k&&h(k),!g.isLeaf){if(!c.has(g.key))f.add(g.key);else if(k){let w=!0;for(const _ of k)if(!f.has(_.key)){w=!1;break}w?d.add(g.key):d.delete(g.key)}}p.pop()})}return h(v),c}function r(i){return a.value.has(i.key)}return{hiddenExpandIconKeySet:a,hiddenNodeKeySet:n,doFilter:l,isForceHiddenExpandIcon:r}}function cae(e,t){const n=V(new Set(e.defaultExpandedKeys)),a=V(),o=Pt(),l=V();pe(()=>e.currentNodeKey,D=>{a.value=D},{immediate:!0}),pe(()=>e.data,D=>{oe(D)},{immediate:!0});const{isIndeterminate:r,isChecked:i,toggleCheckbox:u,getCheckedKeys:c,getCheckedNodes:d,getHalfCheckedKeys:f,getHalfCheckedNodes:p,setChecked:v,setCheckedKeys:m}=iae(e,o),{doFilter:h,hiddenNodeKeySet:y,isForceHiddenExpandIcon:g}=uae(e,o),k=S(()=>{var D;return((D=e.props)==null?void 0:D.value)||bs.KEY}),w=S(()=>{var D;return((D=e.props)==null?void 0:D.children)||bs.CHILDREN}),_=S(()=>{var D;return((D=e.props)==null?void 0:D.disabled)||bs.DISABLED}),C=S(()=>{var D;return((D=e.props)==null?void 0:D.label)||bs.LABEL}),E=S(()=>{var D;const q=n.value,se=y.value,ve=[],be=((D=o.value)==null?void 0:D.treeNodes)||[],de=[];for(let Ce=be.length-1;Ce>=0;--Ce)de.push(be[Ce]);for(;de.length;){const Ce=de.pop();if(!se.has(Ce.key)&&(ve.push(Ce),Ce.children&&q.has(Ce.key)))for(let he=Ce.children.length-1;he>=0;--he)de.push(Ce.children[he])}return ve}),$=S(()=>E.value.length>0);function A(D){const q=new Map,se=new Map;let ve=1;function be(Ce,he=1,ke=void 0){var xe;const De=[];for(const Ne of Ce){const ye=L(Ne),Re={level:he,key:ye,data:Ne};Re.label=K(Ne),Re.parent=ke;const Ye=N(Ne);Re.disabled=R(Ne),Re.isLeaf=!Ye||Ye.length===0,Ye&&Ye.length&&(Re.children=be(Ye,he+1,Re)),De.push(Re),q.set(ye,Re),se.has(he)||se.set(he,[]),(xe=se.get(he))==null||xe.push(Re)}return he>ve&&(ve=he),De}const de=be(D);return{treeNodeMap:q,levelTreeNodeMap:se,maxLevel:ve,treeNodes:de}}function O(D){const q=h(D);q&&(n.value=q)}function N(D){return D[w.value]}function L(D){return D?D[k.value]:""}function R(D){return D[_.value]}function K(D){return D[C.value]}function X(D){n.value.has(D.key)?G(D):j(D)}function F(D){const q=new Set,se=o.value.treeNodeMap;D.forEach(ve=>{let be=se.get(ve);for(;be&&!q.has(be.key);)q.add(be.key),be=be.parent}),n.value=q}function P(D,q){t(k_,D.data,D,q),z(D),e.expandOnClickNode&&X(D),e.showCheckbox&&(e.checkOnClickNode||D.isLeaf&&e.checkOnClickLeaf)&&!D.disabled&&u(D,!i(D),!0)}function H(D,q){t(E_,D.data,D,q)}function z(D){ie(D)||(a.value=D.key,t(T_,D.data,D))}function B(D,q){u(D,q)}function j(D){const q=n.value;if(o.value&&e.accordion){const{treeNodeMap:se}=o.value;q.forEach(ve=>{const be=se.get(ve);D&&D.level===(be==null?void 0:be.level)&&q.delete(ve)})}q.add(D.key),t(x_,D.data,D)}function G(D){n.value.delete(D.key),t(M_,D.data,D)}function te(D){return n.value.has(D.key)}function Y(D){return!!D.disabled}function ie(D){const q=a.value;return q!==void 0&&q===D.key}function ce(){var D,q;if(a.value)return(q=(D=o.value)==null?void 0:D.treeNodeMap.get(a.value))==null?void 0:q.data}function re(){return a.value}function W(D){a.value=D}function oe(D){Be(()=>o.value=A(D))}function ae(D){var q;const se=nt(D)?L(D):D;return(q=o.value)==null?void 0:q.treeNodeMap.get(se)}function we(D,q="auto"){const se=ae(D);se&&l.value&&l.value.scrollToItem(E.value.indexOf(se),q)}function me(D){var q;(q=l.value)==null||q.scrollTo(D)}return{tree:o,flattenTree:E,isNotEmpty:$,listRef:l,getKey:L,getChildren:N,toggleExpand:X,toggleCheckbox:u,isExpanded:te,isChecked:i,isIndeterminate:r,isDisabled:Y,isCurrent:ie,isForceHiddenExpandIcon:g,handleNodeClick:P,handleNodeDrop:H,handleNodeCheck:B,getCurrentNode:ce,getCurrentKey:re,setCurrentKey:W,getCheckedKeys:c,getCheckedNodes:d,getHalfCheckedKeys:f,getHalfCheckedNodes:p,setChecked:v,setCheckedKeys:m,filter:O,setData:oe,getNode:ae,expandNode:j,collapseNode:G,setExpandedKeys:F,scrollToNode:we,scrollTo:me}}var dae=I({name:"ElTreeNodeContent",props:lae,setup(e){const t=Pe(K2),n=ge("tree");return()=>{const a=e.node,{data:o}=a;return t!=null&&t.ctx.slots.default?t.ctx.slots.default({node:a,data:o}):We("span",{class:n.be("node","label")},[a==null?void 0:a.label])}}});const fae=I({name:"ElTreeNode"}),pae=I({...fae,props:oae,emits:sae,setup(e,{emit:t}){const n=e,a=Pe(K2),o=ge("tree"),l=S(()=>{var v;return(v=a==null?void 0:a.props.indent)!=null?v:16}),r=S(()=>{var v;return(v=a==null?void 0:a.props.icon)!=null?v:xp}),i=v=>{const m=a==null?void 0:a.props.props.class;if(!m)return{};let h;if(ze(m)){const{data:y}=v;h=m(y,v)}else h=m;return Ve(h)?{[h]:!0}:h},u=v=>{t("click",n.node,v)},c=v=>{t("drop",n.node,v)},d=()=>{t("toggle",n.node)},f=v=>{t("check",n.node,v)},p=v=>{var m,h,y,g;(y=(h=(m=a==null?void 0:a.instance)==null?void 0:m.vnode)==null?void 0:h.props)!=null&&y.onNodeContextmenu&&(v.stopPropagation(),v.preventDefault()),a==null||a.ctx.emit(I_,v,(g=n.node)==null?void 0:g.data,n.node)};return(v,m)=>{var h,y,g;return b(),M("div",{ref:"node$",class:T([s(o).b("node"),s(o).is("expanded",v.expanded),s(o).is("current",v.current),s(o).is("focusable",!v.disabled),s(o).is("checked",!v.disabled&&v.checked),i(v.node)]),role:"treeitem",tabindex:"-1","aria-expanded":v.expanded,"aria-disabled":v.disabled,"aria-checked":v.checked,"data-key":(h=v.node)==null?void 0:h.key,onClick:qe(u,["stop"]),onContextmenu:p,onDragover:qe(()=>{},["prevent"]),onDragenter:qe(()=>{},["prevent"]),onDrop:qe(c,["stop"])},[x("div",{class:T(s(o).be("node","content")),style:Fe({paddingLeft:`${(v.node.level-1)*s(l)}px`,height:v.itemSize+"px"})},[s(r)?(b(),ue(s(Le),{key:0,class:T([s(o).is("leaf",!!((y=v.node)!=null&&y.isLeaf)),s(o).is("hidden",v.hiddenExpandIcon),{expanded:!((g=v.node)!=null&&g.isLeaf)&&v.expanded},s(o).be("node","expand-icon")]),onClick:qe(d,["stop"])},{default:J(()=>[(b(),ue(rt(s(r))))]),_:1},8,["class","onClick"])):le("v-if",!0),v.showCheckbox?(b(),ue(s(ba),{key:1,"model-value":v.checked,indeterminate:v.indeterminate,disabled:v.disabled,onChange:f,onClick:qe(()=>{},["stop"])},null,8,["model-value","indeterminate","disabled","onClick"])):le("v-if",!0),U(s(dae),{node:v.node},null,8,["node"])],6)],42,["aria-expanded","aria-disabled","aria-checked","data-key","onClick","onDragover","onDragenter","onDrop"])}}});var vae=Me(pae,[["__file","tree-node.vue"]]);const hae=I({name:"ElTreeV2"}),mae=I({...hae,props:aae,emits:rae,setup(e,{expose:t,emit:n}){const a=e,o=an(),l=S(()=>a.itemSize);ft(K2,{ctx:{emit:n,slots:o},props:a,instance:lt()}),ft(Ja,void 0);const{t:r}=gt(),i=ge("tree"),{flattenTree:u,isNotEmpty:c,listRef:d,toggleExpand:f,isExpanded:p,isIndeterminate:v,isChecked:m,isDisabled:h,isCurrent:y,isForceHiddenExpandIcon:g,handleNodeClick:k,handleNodeDrop:w,handleNodeCheck:_,toggleCheckbox:C,getCurrentNode:E,getCurrentKey:$,setCurrentKey:A,getCheckedKeys:O,getCheckedNodes:N,getHalfCheckedKeys:L,getHalfCheckedNodes:R,setChecked:K,setCheckedKeys:X,filter:F,setData:P,getNode:H,expandNode:z,collapseNode:B,setExpandedKeys:j,scrollToNode:G,scrollTo:te}=cae(a,n);return t({toggleCheckbox:C,getCurrentNode:E,getCurrentKey:$,setCurrentKey:A,getCheckedKeys:O,getCheckedNodes:N,getHalfCheckedKeys:L,getHalfCheckedNodes:R,setChecked:K,setCheckedKeys:X,filter:F,setData:P,getNode:H,expandNode:z,collapseNode:B,setExpandedKeys:j,scrollToNode:G,scrollTo:te}),(Y,ie)=>(b(),M("div",{class:T([s(i).b(),{[s(i).m("highlight-current")]:Y.highlightCurrent}]),role:"tree"},[s(c)?(b(),ue(s(vy),{key:0,ref_key:"listRef",ref:d,"class-name":s(i).b("virtual-list"),data:s(u),total:s(u).length,height:Y.height,"item-size":s(l),"perf-mode":Y.perfMode},{default:J(({data:ce,index:re,style:W})=>[(b(),ue(vae,{key:ce[re].key,style:Fe(W),node:ce[re],expanded:s(p)(ce[re]),"show-checkbox":Y.showCheckbox,checked:s(m)(ce[re]),indeterminate:s(v)(ce[re]),"item-size":s(l),disabled:s(h)(ce[re]),current:s(y)(ce[re]),"hidden-expand-icon":s(g)(ce[re]),onClick:s(k),onToggle:s(f),onCheck:s(_),onDrop:s(w)},null,8,["style","node","expanded","show-checkbox","checked","indeterminate","item-size","disabled","current","hidden-expand-icon","onClick","onToggle","onCheck","onDrop"]))]),_:1},8,["class-name","data","total","height","item-size","perf-mode"])):(b(),M("div",{key:1,class:T(s(i).e("empty-block"))},[ne(Y.$slots,"empty",{},()=>{var ce;return[x("span",{class:T(s(i).e("empty-text"))},Te((ce=Y.emptyText)!=null?ce:s(r)("el.tree.emptyText")),3)]})],2))],2))}});var gae=Me(mae,[["__file","tree.vue"]]);const yae=Ze(gae),A_=Symbol("uploadContextKey"),_ae="ElUpload";class bae extends Error{constructor(t,n,a,o){super(t),this.name="UploadAjaxError",this.status=n,this.method=a,this.url=o}}function Pm(e,t,n){let a;return n.response?a=`${n.response.error||n.response}`:n.responseText?a=`${n.responseText}`:a=`fail to ${t.method} ${e} ${n.status}`,new bae(a,n.status,t.method,e)}function wae(e){const t=e.responseText||e.response;if(!t)return t;try{return JSON.parse(t)}catch{return t}}const Cae=e=>{typeof XMLHttpRequest>"u"&&nn(_ae,"XMLHttpRequest is undefined");const t=new XMLHttpRequest,n=e.action;t.upload&&t.upload.addEventListener("progress",l=>{const r=l;r.percent=l.total>0?l.loaded/l.total*100:0,e.onProgress(r)});const a=new FormData;if(e.data)for(const[l,r]of Object.entries(e.data))Se(r)&&r.length?a.append(l,...r):a.append(l,r);a.append(e.filename,e.file,e.file.name),t.addEventListener("error",()=>{e.onError(Pm(n,e,t))}),t.addEventListener("load",()=>{if(t.status<200||t.status>=300)return e.onError(Pm(n,e,t));e.onSuccess(wae(t))}),t.open(e.method,n,!0),e.withCredentials&&"withCredentials"in t&&(t.withCredentials=!0);const o=e.headers||{};if(o instanceof Headers)o.forEach((l,r)=>t.setRequestHeader(r,l));else for(const[l,r]of Object.entries(o))on(r)||t.setRequestHeader(l,String(r));return t.send(a),t},N_=["text","picture","picture-card"];let Sae=1;const A0=()=>Date.now()+Sae++,P_=Ee({action:{type:String,default:"#"},headers:{type:Q(Object)},method:{type:String,default:"post"},data:{type:Q([Object,Function,Promise]),default:()=>Ft({})},multiple:Boolean,name:{type:String,default:"file"},drag:Boolean,withCredentials:Boolean,showFileList:{type:Boolean,default:!0},accept:{type:String,default:""},fileList:{type:Q(Array),default:()=>Ft([])},autoUpload:{type:Boolean,default:!0},listType:{type:String,values:N_,default:"text"},httpRequest:{type:Q(Function),default:Cae},disabled:Boolean,limit:Number}),kae=Ee({...P_,beforeUpload:{type:Q(Function),default:xt},beforeRemove:{type:Q(Function)},onRemove:{type:Q(Function),default:xt},onChange:{type:Q(Function),default:xt},onPreview:{type:Q(Function),default:xt},onSuccess:{type:Q(Function),default:xt},onProgress:{type:Q(Function),default:xt},onError:{type:Q(Function),default:xt},onExceed:{type:Q(Function),default:xt},crossorigin:{type:Q(String)}}),Eae=Ee({files:{type:Q(Array),default:()=>Ft([])},disabled:{type:Boolean,default:!1},handlePreview:{type:Q(Function),default:xt},listType:{type:String,values:N_,default:"text"},crossorigin:{type:Q(String)}}),xae={remove:e=>!!e},Mae=I({name:"ElUploadList"}),Tae=I({...Mae,props:Eae,emits:xae,setup(e,{emit:t}){const n=e,{t:a}=gt(),o=ge("upload"),l=ge("icon"),r=ge("list"),i=En(),u=V(!1),c=S(()=>[o.b("list"),o.bm("list",n.listType),o.is("disabled",n.disabled)]),d=f=>{t("remove",f)};return(f,p)=>(b(),ue(f4,{tag:"ul",class:T(s(c)),name:s(r).b()},{default:J(()=>[(b(!0),M(He,null,pt(f.files,(v,m)=>(b(),M("li",{key:v.uid||v.name,class:T([s(o).be("list","item"),s(o).is(v.status),{focusing:u.value}]),tabindex:"0",onKeydown:Ot(h=>!s(i)&&d(v),["delete"]),onFocus:h=>u.value=!0,onBlur:h=>u.value=!1,onClick:h=>u.value=!1},[ne(f.$slots,"default",{file:v,index:m},()=>[f.listType==="picture"||v.status!=="uploading"&&f.listType==="picture-card"?(b(),M("img",{key:0,class:T(s(o).be("list","item-thumbnail")),src:v.url,crossorigin:f.crossorigin,alt:""},null,10,["src","crossorigin"])):le("v-if",!0),v.status==="uploading"||f.listType!=="picture-card"?(b(),M("div",{key:1,class:T(s(o).be("list","item-info"))},[x("a",{class:T(s(o).be("list","item-name")),onClick:qe(h=>f.handlePreview(v),["prevent"])},[U(s(Le),{class:T(s(l).m("document"))},{default:J(()=>[U(s(W6))]),_:1},8,["class"]),x("span",{class:T(s(o).be("list","item-file-name")),title:v.name},Te(v.name),11,["title"])],10,["onClick"]),v.status==="uploading"?(b(),ue(s(oy),{key:0,type:f.listType==="picture-card"?"circle":"line","stroke-width":f.listType==="picture-card"?6:2,percentage:Number(v.percentage),style:Fe(f.listType==="picture-card"?"":"margin-top: 0.5rem")},null,8,["type","stroke-width","percentage","style"])):le("v-if",!0)],2)):le("v-if",!0),x("label",{class:T(s(o).be("list","item-status-label"))},[f.listType==="text"?(b(),ue(s(Le),{key:0,class:T([s(l).m("upload-success"),s(l).m("circle-check")])},{default:J(()=>[U(s(Uc))]),_:1},8,["class"])):["picture-card","picture"].includes(f.listType)?(b(),ue(s(Le),{key:1,class:T([s(l).m("upload-success"),s(l).m("check")])},{default:J(()=>[U(s(Jr))]),_:1},8,["class"])):le("v-if",!0)],2),s(i)?le("v-if",!0):(b(),ue(s(Le),{key:2,class:T(s(l).m("close")),onClick:h=>d(v)},{default:J(()=>[U(s(ga))]),_:2},1032,["class","onClick"])),le(" Due to close btn only appears when li gets focused disappears after li gets blurred, thus keyboard navigation can never reach close btn"),le(" This is a bug which needs to be fixed "),le(" TODO: Fix the incorrect navigation interaction "),s(i)?le("v-if",!0):(b(),M("i",{key:3,class:T(s(l).m("close-tip"))},Te(s(a)("el.upload.deleteTip")),3)),f.listType==="picture-card"?(b(),M("span",{key:4,class:T(s(o).be("list","item-actions"))},[x("span",{class:T(s(o).be("list","item-preview")),onClick:h=>f.handlePreview(v)},[U(s(Le),{class:T(s(l).m("zoom-in"))},{default:J(()=>[U(s(Op))]),_:1},8,["class"])],10,["onClick"]),s(i)?le("v-if",!0):(b(),M("span",{key:0,class:T(s(o).be("list","item-delete")),onClick:h=>d(v)},[U(s(Le),{class:T(s(l).m("delete"))},{default:J(()=>[U(s(K6))]),_:1},8,["class"])],10,["onClick"]))],2)):le("v-if",!0)])],42,["onKeydown","onFocus","onBlur","onClick"]))),128)),ne(f.$slots,"append")]),_:3},8,["class","name"]))}});var Rm=Me(Tae,[["__file","upload-list.vue"]]);const $ae=Ee({disabled:{type:Boolean,default:!1}}),Oae={file:e=>Se(e)},R_="ElUploadDrag",Iae=I({name:R_}),Aae=I({...Iae,props:$ae,emits:Oae,setup(e,{emit:t}){Pe(A_)||nn(R_,"usage: <el-upload><el-upload-dragger /></el-upload>");const a=ge("upload"),o=V(!1),l=En(),r=u=>{if(l.value)return;o.value=!1,u.stopPropagation();const c=Array.from(u.dataTransfer.files),d=u.dataTransfer.items||[];c.forEach((f,p)=>{var v;const m=d[p],h=(v=m==null?void 0:m.webkitGetAsEntry)==null?void 0:v.call(m);h&&(f.isDirectory=h.isDirectory)}),t("file",c)},i=()=>{l.value||(o.value=!0)};return(u,c)=>(b(),M("div",{class:T([s(a).b("dragger"),s(a).is("dragover",o.value)]),onDrop:qe(r,["prevent"]),onDragover:qe(i,["prevent"]),onDragleave:qe(d=>o.value=!1,["prevent"])},[ne(u.$slots,"default")],42,["onDrop","onDragover","onDragleave"]))}});var Nae=Me(Aae,[["__file","upload-dragger.vue"]]);const Pae=Ee({...P_,beforeUpload:{type:Q(Function),default:xt},onRemove:{type:Q(Function),default:xt},onStart:{type:Q(Function),default:xt},onSuccess:{type:Q(Function),default:xt},onProgress:{type:Q(Function),default:xt},onError:{type:Q(Function),default:xt},onExceed:{type:Q(Function),default:xt}}),Rae=I({name:"ElUploadContent",inheritAttrs:!1}),Lae=I({...Rae,props:Pae,setup(e,{expose:t}){const n=e,a=ge("upload"),o=En(),l=Pt({}),r=Pt(),i=h=>{if(h.length===0)return;const{autoUpload:y,limit:g,fileList:k,multiple:w,onStart:_,onExceed:C}=n;if(g&&k.length+h.length>g){C(h,k);return}w||(h=h.slice(0,1));for(const E of h){const $=E;$.uid=A0(),_($),y&&u($)}},u=async h=>{if(r.value.value="",!n.beforeUpload)return d(h);let y,g={};try{const w=n.data,_=n.beforeUpload(h);g=Hs(n.data)?Zu(n.data):n.data,y=await _,Hs(n.data)&&un(w,g)&&(g=Zu(n.data))}catch{y=!1}if(y===!1){n.onRemove(h);return}let k=h;y instanceof Blob&&(y instanceof File?k=y:k=new File([y],h.name,{type:h.type})),d(Object.assign(k,{uid:h.uid}),g)},c=async(h,y)=>ze(h)?h(y):h,d=async(h,y)=>{const{headers:g,data:k,method:w,withCredentials:_,name:C,action:E,onProgress:$,onSuccess:A,onError:O,httpRequest:N}=n;try{y=await c(y??k,h)}catch{n.onRemove(h);return}const{uid:L}=h,R={headers:g||{},withCredentials:_,file:h,data:y,method:w,filename:C,action:E,onProgress:X=>{$(X,h)},onSuccess:X=>{A(X,h),delete l.value[L]},onError:X=>{O(X,h),delete l.value[L]}},K=N(R);l.value[L]=K,K instanceof Promise&&K.then(R.onSuccess,R.onError)},f=h=>{const y=h.target.files;y&&i(Array.from(y))},p=()=>{o.value||(r.value.value="",r.value.click())},v=()=>{p()};return t({abort:h=>{N6(l.value).filter(h?([g])=>String(h.uid)===g:()=>!0).forEach(([g,k])=>{k instanceof XMLHttpRequest&&k.abort(),delete l.value[g]})},upload:u}),(h,y)=>(b(),M("div",{class:T([s(a).b(),s(a).m(h.listType),s(a).is("drag",h.drag),s(a).is("disabled",s(o))]),tabindex:s(o)?"-1":"0",onClick:p,onKeydown:Ot(qe(v,["self"]),["enter","space"])},[h.drag?(b(),ue(Nae,{key:0,disabled:s(o),onFile:i},{default:J(()=>[ne(h.$slots,"default")]),_:3},8,["disabled"])):ne(h.$slots,"default",{key:1}),x("input",{ref_key:"inputRef",ref:r,class:T(s(a).e("input")),name:h.name,disabled:s(o),multiple:h.multiple,accept:h.accept,type:"file",onChange:f,onClick:qe(()=>{},["stop"])},null,42,["name","disabled","multiple","accept","onClick"])],42,["tabindex","onKeydown"]))}});var Lm=Me(Lae,[["__file","upload-content.vue"]]);const Vm="ElUpload",Bm=e=>{var t;(t=e.url)!=null&&t.startsWith("blob:")&&URL.revokeObjectURL(e.url)},Vae=(e,t)=>{const n=E6(e,"fileList",void 0,{passive:!0}),a=v=>n.value.find(m=>m.uid===v.uid);function o(v){var m;(m=t.value)==null||m.abort(v)}function l(v=["ready","uploading","success","fail"]){n.value=n.value.filter(m=>!v.includes(m.status))}function r(v){n.value=n.value.filter(m=>m.uid!==v.uid)}const i=(v,m)=>{const h=a(m);h&&(console.error(v),h.status="fail",r(h),e.onError(v,h,n.value),e.onChange(h,n.value))},u=(v,m)=>{const h=a(m);h&&(e.onProgress(v,h,n.value),h.status="uploading",h.percentage=Math.round(v.percent))},c=(v,m)=>{const h=a(m);h&&(h.status="success",h.response=v,e.onSuccess(v,h,n.value),e.onChange(h,n.value))},d=v=>{on(v.uid)&&(v.uid=A0());const m={name:v.name,percentage:0,status:"ready",size:v.size,raw:v,uid:v.uid};if(e.listType==="picture-card"||e.listType==="picture")try{m.url=URL.createObjectURL(v)}catch(h){h.message,e.onError(h,m,n.value)}n.value=[...n.value,m],e.onChange(m,n.value)},f=async v=>{const m=v instanceof File?a(v):v;m||nn(Vm,"file to be removed not found");const h=y=>{o(y),r(y),e.onRemove(y,n.value),Bm(y)};e.beforeRemove?await e.beforeRemove(m,n.value)!==!1&&h(m):h(m)};function p(){n.value.filter(({status:v})=>v==="ready").forEach(({raw:v})=>{var m;return v&&((m=t.value)==null?void 0:m.upload(v))})}return pe(()=>e.listType,v=>{v!=="picture-card"&&v!=="picture"||(n.value=n.value.map(m=>{const{raw:h,url:y}=m;if(!y&&h)try{m.url=URL.createObjectURL(h)}catch(g){e.onError(g,m,n.value)}return m}))}),pe(n,v=>{for(const m of v)m.uid||(m.uid=A0()),m.status||(m.status="success")},{immediate:!0,deep:!0}),{uploadFiles:n,abort:o,clearFiles:l,handleError:i,handleProgress:u,handleStart:d,handleSuccess:c,handleRemove:f,submit:p,revokeFileObjectURL:Bm}},Bae=I({name:"ElUpload"}),zae=I({...Bae,props:kae,setup(e,{expose:t}){const n=e,a=En(),o=Pt(),{abort:l,submit:r,clearFiles:i,uploadFiles:u,handleStart:c,handleError:d,handleRemove:f,handleSuccess:p,handleProgress:v,revokeFileObjectURL:m}=Vae(n,o),h=S(()=>n.listType==="picture-card"),y=S(()=>({...n,fileList:u.value,onStart:c,onProgress:v,onSuccess:p,onError:d,onRemove:f}));return At(()=>{u.value.forEach(m)}),ft(A_,{accept:Et(n,"accept")}),t({abort:l,submit:r,clearFiles:i,handleStart:c,handleRemove:f}),(g,k)=>(b(),M("div",null,[s(h)&&g.showFileList?(b(),ue(Rm,{key:0,disabled:s(a),"list-type":g.listType,files:s(u),crossorigin:g.crossorigin,"handle-preview":g.onPreview,onRemove:s(f)},sa({append:J(()=>[U(Lm,st({ref_key:"uploadRef",ref:o},s(y)),{default:J(()=>[g.$slots.trigger?ne(g.$slots,"trigger",{key:0}):le("v-if",!0),!g.$slots.trigger&&g.$slots.default?ne(g.$slots,"default",{key:1}):le("v-if",!0)]),_:3},16)]),_:2},[g.$slots.file?{name:"default",fn:J(({file:w,index:_})=>[ne(g.$slots,"file",{file:w,index:_})])}:void 0]),1032,["disabled","list-type","files","crossorigin","handle-preview","onRemove"])):le("v-if",!0),!s(h)||s(h)&&!g.showFileList?(b(),ue(Lm,st({key:1,ref_key:"uploadRef",ref:o},s(y)),{default:J(()=>[g.$slots.trigger?ne(g.$slots,"trigger",{key:0}):le("v-if",!0),!g.$slots.trigger&&g.$slots.default?ne(g.$slots,"default",{key:1}):le("v-if",!0)]),_:3},16)):le("v-if",!0),g.$slots.trigger?ne(g.$slots,"default",{key:2}):le("v-if",!0),ne(g.$slots,"tip"),!s(h)&&g.showFileList?(b(),ue(Rm,{key:3,disabled:s(a),"list-type":g.listType,files:s(u),crossorigin:g.crossorigin,"handle-preview":g.onPreview,onRemove:s(f)},sa({_:2},[g.$slots.file?{name:"default",fn:J(({file:w,index:_})=>[ne(g.$slots,"file",{file:w,index:_})])}:void 0]),1032,["disabled","list-type","files","crossorigin","handle-preview","onRemove"])):le("v-if",!0)]))}});var Hae=Me(zae,[["__file","upload.vue"]]);const Dae=Ze(Hae),Fae=Ee({zIndex:{type:Number,default:9},rotate:{type:Number,default:-22},width:Number,height:Number,image:String,content:{type:Q([String,Array]),default:"Element Plus"},font:{type:Q(Object)},gap:{type:Q(Array),default:()=>[100,100]},offset:{type:Q(Array)}});function Kae(e){return e.replace(/([A-Z])/g,"-$1").toLowerCase()}function Wae(e){return Object.keys(e).map(t=>`${Kae(t)}: ${e[t]};`).join(" ")}function jae(){return window.devicePixelRatio||1}const qae=(e,t)=>{let n=!1;return e.removedNodes.length&&t&&(n=Array.from(e.removedNodes).includes(t)),e.type==="attributes"&&e.target===t&&(n=!0),n},L_=3;function rf(e,t,n=1){const a=document.createElement("canvas"),o=a.getContext("2d"),l=e*n,r=t*n;return a.setAttribute("width",`${l}px`),a.setAttribute("height",`${r}px`),o.save(),[o,a,l,r]}function Uae(){function e(t,n,a,o,l,r,i,u){const[c,d,f,p]=rf(o,l,a);if(t instanceof HTMLImageElement)c.drawImage(t,0,0,f,p);else{const{color:G,fontSize:te,fontStyle:Y,fontWeight:ie,fontFamily:ce,textAlign:re,textBaseline:W}=r,oe=Number(te)*a;c.font=`${Y} normal ${ie} ${oe}px/${l}px ${ce}`,c.fillStyle=G,c.textAlign=re,c.textBaseline=W;const ae=Se(t)?t:[t];ae==null||ae.forEach((we,me)=>{c.fillText(we??"",f/2,me*(oe+L_*a))})}const v=Math.PI/180*Number(n),m=Math.max(o,l),[h,y,g]=rf(m,m,a);h.translate(g/2,g/2),h.rotate(v),f>0&&p>0&&h.drawImage(d,-f/2,-p/2);function k(G,te){const Y=G*Math.cos(v)-te*Math.sin(v),ie=G*Math.sin(v)+te*Math.cos(v);return[Y,ie]}let w=0,_=0,C=0,E=0;const $=f/2,A=p/2;[[0-$,0-A],[0+$,0-A],[0+$,0+A],[0-$,0+A]].forEach(([G,te])=>{const[Y,ie]=k(G,te);w=Math.min(w,Y),_=Math.max(_,Y),C=Math.min(C,ie),E=Math.max(E,ie)});const N=w+g/2,L=C+g/2,R=_-w,K=E-C,X=i*a,F=u*a,P=(R+X)*2,H=K+F,[z,B]=rf(P,H);function j(G=0,te=0){z.drawImage(y,N,L,R,K,G,te,R,K)}return j(),j(R+X,-K/2-F/2),j(R+X,+K/2+F/2),[B.toDataURL(),P/a,H/a]}return e}const Yae=I({name:"ElWatermark"}),Gae=I({...Yae,props:Fae,setup(e){const t=e,n={position:"relative"},a=S(()=>{var N,L;return(L=(N=t.font)==null?void 0:N.color)!=null?L:"rgba(0,0,0,.15)"}),o=S(()=>{var N,L;return(L=(N=t.font)==null?void 0:N.fontSize)!=null?L:16}),l=S(()=>{var N,L;return(L=(N=t.font)==null?void 0:N.fontWeight)!=null?L:"normal"}),r=S(()=>{var N,L;return(L=(N=t.font)==null?void 0:N.fontStyle)!=null?L:"normal"}),i=S(()=>{var N,L;return(L=(N=t.font)==null?void 0:N.fontFamily)!=null?L:"sans-serif"}),u=S(()=>{var N,L;return(L=(N=t.font)==null?void 0:N.textAlign)!=null?L:"center"}),c=S(()=>{var N,L;return(L=(N=t.font)==null?void 0:N.textBaseline)!=null?L:"hanging"}),d=S(()=>t.gap[0]),f=S(()=>t.gap[1]),p=S(()=>d.value/2),v=S(()=>f.value/2),m=S(()=>{var N,L;return(L=(N=t.offset)==null?void 0:N[0])!=null?L:p.value}),h=S(()=>{var N,L;return(L=(N=t.offset)==null?void 0:N[1])!=null?L:v.value}),y=()=>{const N={zIndex:t.zIndex,position:"absolute",left:0,top:0,width:"100%",height:"100%",pointerEvents:"none",backgroundRepeat:"repeat"};let L=m.value-p.value,R=h.value-v.value;return L>0&&(N.left=`${L}px`,N.width=`calc(100% - ${L}px)`,L=0),R>0&&(N.top=`${R}px`,N.height=`calc(100% - ${R}px)`,R=0),N.backgroundPosition=`${L}px ${R}px`,N},g=Pt(null),k=Pt(),w=V(!1),_=()=>{k.value&&(k.value.remove(),k.value=void 0)},C=(N,L)=>{var R;g.value&&k.value&&(w.value=!0,k.value.setAttribute("style",Wae({...y(),backgroundImage:`url('${N}')`,backgroundSize:`${Math.floor(L)}px`})),(R=g.value)==null||R.append(k.value),setTimeout(()=>{w.value=!1}))},E=N=>{let L=120,R=64;const K=t.image,X=t.content,F=t.width,P=t.height;if(!K&&N.measureText){N.font=`${Number(o.value)}px ${i.value}`;const H=Se(X)?X:[X],z=H.map(B=>{const j=N.measureText(B);return[j.width,j.fontBoundingBoxAscent!==void 0?j.fontBoundingBoxAscent+j.fontBoundingBoxDescent:j.actualBoundingBoxAscent+j.actualBoundingBoxDescent]});L=Math.ceil(Math.max(...z.map(B=>B[0]))),R=Math.ceil(Math.max(...z.map(B=>B[1])))*H.length+(H.length-1)*L_}return[F??L,P??R]},$=Uae(),A=()=>{const L=document.createElement("canvas").getContext("2d"),R=t.image,K=t.content,X=t.rotate;if(L){k.value||(k.value=document.createElement("div"));const F=jae(),[P,H]=E(L),z=B=>{const[j,G]=$(B||"",X,F,P,H,{color:a.value,fontSize:o.value,fontStyle:r.value,fontWeight:l.value,fontFamily:i.value,textAlign:u.value,textBaseline:c.value},d.value,f.value);C(j,G)};if(R){const B=new Image;B.onload=()=>{z(B)},B.onerror=()=>{z(K)},B.crossOrigin="anonymous",B.referrerPolicy="no-referrer",B.src=R}else z(K)}};return at(()=>{A()}),pe(()=>t,()=>{A()},{deep:!0,flush:"post"}),At(()=>{_()}),k6(g,N=>{w.value||N.forEach(L=>{qae(L,k.value)&&(_(),A())})},{attributes:!0,subtree:!0,childList:!0}),(N,L)=>(b(),M("div",{ref_key:"containerRef",ref:g,style:Fe([n])},[ne(N.$slots,"default")],4))}});var Xae=Me(Gae,[["__file","watermark.vue"]]);const Zae=Ze(Xae),Jae=Ee({zIndex:{type:Number,default:1001},visible:Boolean,fill:{type:String,default:"rgba(0,0,0,0.5)"},pos:{type:Q(Object)},targetAreaClickable:{type:Boolean,default:!0}}),Qae=(e,t,n,a,o)=>{const l=V(null),r=()=>{let f;return Ve(e.value)?f=document.querySelector(e.value):ze(e.value)?f=e.value():f=e.value,f},i=()=>{const f=r();if(!f||!t.value){l.value=null;return}eoe(f)||f.scrollIntoView(o.value);const{left:p,top:v,width:m,height:h}=f.getBoundingClientRect();l.value={left:p,top:v,width:m,height:h,radius:0}};at(()=>{pe([t,e],()=>{i()},{immediate:!0}),window.addEventListener("resize",i)}),At(()=>{window.removeEventListener("resize",i)});const u=f=>{var p;return(p=Se(n.value.offset)?n.value.offset[f]:n.value.offset)!=null?p:6},c=S(()=>{var f;if(!l.value)return l.value;const p=u(0),v=u(1),m=((f=n.value)==null?void 0:f.radius)||2;return{left:l.value.left-p,top:l.value.top-v,width:l.value.width+p*2,height:l.value.height+v*2,radius:m}}),d=S(()=>{const f=r();return!a.value||!f||!window.DOMRect?f||void 0:{getBoundingClientRect(){var p,v,m,h;return window.DOMRect.fromRect({width:((p=c.value)==null?void 0:p.width)||0,height:((v=c.value)==null?void 0:v.height)||0,x:((m=c.value)==null?void 0:m.left)||0,y:((h=c.value)==null?void 0:h.top)||0})}}});return{mergedPosInfo:c,triggerTarget:d}},fd=Symbol("ElTour");function eoe(e){const t=window.innerWidth||document.documentElement.clientWidth,n=window.innerHeight||document.documentElement.clientHeight,{top:a,right:o,bottom:l,left:r}=e.getBoundingClientRect();return a>=0&&r>=0&&o<=t&&l<=n}const toe=(e,t,n,a,o,l,r,i)=>{const u=V(),c=V(),d=V({}),f={x:u,y:c,placement:a,strategy:o,middlewareData:d},p=S(()=>{const g=[m_(s(l)),lne(),one(),noe()];return s(i)&&s(n)&&g.push(g_({element:s(n)})),g}),v=async()=>{if(!mt)return;const g=s(e),k=s(t);if(!g||!k)return;const w=await y_(g,k,{placement:s(a),strategy:s(o),middleware:s(p)});Tr(f).forEach(_=>{f[_].value=w[_]})},m=S(()=>{if(!s(e))return{position:"fixed",top:"50%",left:"50%",transform:"translate3d(-50%, -50%, 0)",maxWidth:"100vw",zIndex:s(r)};const{overflow:g}=s(d);return{position:s(o),zIndex:s(r),top:s(c)!=null?`${s(c)}px`:"",left:s(u)!=null?`${s(u)}px`:"",maxWidth:g!=null&&g.maxWidth?`${g==null?void 0:g.maxWidth}px`:""}}),h=S(()=>{if(!s(i))return{};const{arrow:g}=s(d);return{left:(g==null?void 0:g.x)!=null?`${g==null?void 0:g.x}px`:"",top:(g==null?void 0:g.y)!=null?`${g==null?void 0:g.y}px`:""}});let y;return at(()=>{const g=s(e),k=s(t);g&&k&&(y=nne(g,k,v)),wn(()=>{v()})}),At(()=>{y&&y()}),{update:v,contentStyle:m,arrowStyle:h}},noe=()=>({name:"overflow",async fn(e){const t=await ane(e);let n=0;return t.left>0&&(n=t.left),t.right>0&&(n=t.right),{data:{maxWidth:e.rects.floating.width-n}}}}),aoe=I({name:"ElTourMask",inheritAttrs:!1}),ooe=I({...aoe,props:Jae,setup(e){const t=e,{ns:n}=Pe(fd),a=S(()=>{var i,u;return(u=(i=t.pos)==null?void 0:i.radius)!=null?u:2}),o=S(()=>{const i=a.value,u=`a${i},${i} 0 0 1`;return{topRight:`${u} ${i},${i}`,bottomRight:`${u} ${-i},${i}`,bottomLeft:`${u} ${-i},${-i}`,topLeft:`${u} ${i},${-i}`}}),l=S(()=>{const i=window.innerWidth,u=window.innerHeight,c=o.value,d=`M${i},0 L0,0 L0,${u} L${i},${u} L${i},0 Z`,f=a.value;return t.pos?`${d} M${t.pos.left+f},${t.pos.top} h${t.pos.width-f*2} ${c.topRight} v${t.pos.height-f*2} ${c.bottomRight} h${-t.pos.width+f*2} ${c.bottomLeft} v${-t.pos.height+f*2} ${c.topLeft} z`:d}),r=S(()=>({fill:t.fill,pointerEvents:"auto",cursor:"auto"}));return i2(Et(t,"visible"),{ns:n}),(i,u)=>i.visible?(b(),M("div",st({key:0,class:s(n).e("mask"),style:{position:"fixed",left:0,right:0,top:0,bottom:0,zIndex:i.zIndex,pointerEvents:i.pos&&i.targetAreaClickable?"none":"auto"}},i.$attrs),[(b(),M("svg",{style:{width:"100%",height:"100%"}},[x("path",{class:T(s(n).e("hollow")),style:Fe(s(r)),d:s(l)},null,14,["d"])]))],16)):le("v-if",!0)}});var loe=Me(ooe,[["__file","mask.vue"]]);const roe=["absolute","fixed"],soe=["top-start","top-end","top","bottom-start","bottom-end","bottom","left-start","left-end","left","right-start","right-end","right"],W2=Ee({placement:{type:Q(String),values:soe,default:"bottom"},reference:{type:Q(Object),default:null},strategy:{type:Q(String),values:roe,default:"absolute"},offset:{type:Number,default:10},showArrow:Boolean,zIndex:{type:Number,default:2001}}),ioe={close:()=>!0},uoe=I({name:"ElTourContent"}),coe=I({...uoe,props:W2,emits:ioe,setup(e,{emit:t}){const n=e,a=V(n.placement),o=V(n.strategy),l=V(null),r=V(null);pe(()=>n.placement,()=>{a.value=n.placement});const{contentStyle:i,arrowStyle:u}=toe(Et(n,"reference"),l,r,a,o,Et(n,"offset"),Et(n,"zIndex"),Et(n,"showArrow")),c=S(()=>a.value.split("-")[0]),{ns:d}=Pe(fd),f=()=>{t("close")},p=v=>{v.detail.focusReason==="pointer"&&v.preventDefault()};return(v,m)=>(b(),M("div",{ref_key:"contentRef",ref:l,style:Fe(s(i)),class:T(s(d).e("content")),"data-side":s(c),tabindex:"-1"},[U(s(Qr),{loop:"",trapped:"","focus-start-el":"container","focus-trap-el":l.value||void 0,onReleaseRequested:f,onFocusoutPrevented:p},{default:J(()=>[ne(v.$slots,"default")]),_:3},8,["focus-trap-el"]),v.showArrow?(b(),M("span",{key:0,ref_key:"arrowRef",ref:r,style:Fe(s(u)),class:T(s(d).e("arrow"))},null,6)):le("v-if",!0)],14,["data-side"]))}});var doe=Me(coe,[["__file","content.vue"]]),foe=I({name:"ElTourSteps",props:{current:{type:Number,default:0}},emits:["update-total"],setup(e,{slots:t,emit:n}){let a=0;return()=>{var o,l;const r=(o=t.default)==null?void 0:o.call(t),i=[];let u=0;function c(d){Se(d)&&d.forEach(f=>{var p;((p=(f==null?void 0:f.type)||{})==null?void 0:p.name)==="ElTourStep"&&(i.push(f),u+=1)})}return r.length&&c(go((l=r[0])==null?void 0:l.children)),a!==u&&(a=u,n("update-total",u)),i.length?i[e.current]:null}}});const poe=Ee({modelValue:Boolean,current:{type:Number,default:0},showArrow:{type:Boolean,default:!0},showClose:{type:Boolean,default:!0},closeIcon:{type:Tt},placement:W2.placement,contentStyle:{type:Q([Object])},mask:{type:Q([Boolean,Object]),default:!0},gap:{type:Q(Object),default:()=>({offset:6,radius:2})},zIndex:{type:Number},scrollIntoViewOptions:{type:Q([Boolean,Object]),default:()=>({block:"center"})},type:{type:Q(String)},appendTo:{type:Q([String,Object]),default:"body"},closeOnPressEscape:{type:Boolean,default:!0},targetAreaClickable:{type:Boolean,default:!0}}),voe={[Ue]:e=>It(e),"update:current":e=>Ke(e),close:e=>Ke(e),finish:()=>!0,change:e=>Ke(e)},hoe=I({name:"ElTour"}),moe=I({...hoe,props:poe,emits:voe,setup(e,{emit:t}){const n=e,a=ge("tour"),o=V(0),l=V(),r=E6(n,"current",t,{passive:!0}),i=S(()=>{var O;return(O=l.value)==null?void 0:O.target}),u=S(()=>[a.b(),y.value==="primary"?a.m("primary"):""]),c=S(()=>{var O;return((O=l.value)==null?void 0:O.placement)||n.placement}),d=S(()=>{var O,N;return(N=(O=l.value)==null?void 0:O.contentStyle)!=null?N:n.contentStyle}),f=S(()=>{var O,N;return(N=(O=l.value)==null?void 0:O.mask)!=null?N:n.mask}),p=S(()=>!!f.value&&n.modelValue),v=S(()=>It(f.value)?void 0:f.value),m=S(()=>{var O,N;return!!i.value&&((N=(O=l.value)==null?void 0:O.showArrow)!=null?N:n.showArrow)}),h=S(()=>{var O,N;return(N=(O=l.value)==null?void 0:O.scrollIntoViewOptions)!=null?N:n.scrollIntoViewOptions}),y=S(()=>{var O,N;return(N=(O=l.value)==null?void 0:O.type)!=null?N:n.type}),{nextZIndex:g}=Xr(),k=g(),w=S(()=>{var O;return(O=n.zIndex)!=null?O:k}),{mergedPosInfo:_,triggerTarget:C}=Qae(i,Et(n,"modelValue"),Et(n,"gap"),f,h);pe(()=>n.modelValue,O=>{O||(r.value=0)});const E=()=>{n.closeOnPressEscape&&(t(Ue,!1),t("close",r.value))},$=O=>{o.value=O},A=an();return ft(fd,{currentStep:l,current:r,total:o,showClose:Et(n,"showClose"),closeIcon:Et(n,"closeIcon"),mergedType:y,ns:a,slots:A,updateModelValue(O){t(Ue,O)},onClose(){t("close",r.value)},onFinish(){t("finish")},onChange(){t(it,r.value)}}),(O,N)=>(b(),M(He,null,[U(s(es),{to:O.appendTo},{default:J(()=>{var L,R;return[x("div",st({class:s(u)},O.$attrs),[U(loe,{visible:s(p),fill:(L=s(v))==null?void 0:L.color,style:Fe((R=s(v))==null?void 0:R.style),pos:s(_),"z-index":s(w),"target-area-clickable":O.targetAreaClickable},null,8,["visible","fill","style","pos","z-index","target-area-clickable"]),O.modelValue?(b(),ue(doe,{key:s(r),reference:s(C),placement:s(c),"show-arrow":s(m),"z-index":s(w),style:Fe(s(d)),onClose:E},{default:J(()=>[U(s(foe),{current:s(r),onUpdateTotal:$},{default:J(()=>[ne(O.$slots,"default")]),_:3},8,["current"])]),_:3},8,["reference","placement","show-arrow","z-index","style"])):le("v-if",!0)],16)]}),_:3},8,["to"]),le(" just for IDE "),le("v-if",!0)],64))}});var goe=Me(moe,[["__file","tour.vue"]]);const yoe=Ee({target:{type:Q([String,Object,Function])},title:String,description:String,showClose:{type:Boolean,default:void 0},closeIcon:{type:Tt},showArrow:{type:Boolean,default:void 0},placement:W2.placement,mask:{type:Q([Boolean,Object]),default:void 0},contentStyle:{type:Q([Object])},prevButtonProps:{type:Q(Object)},nextButtonProps:{type:Q(Object)},scrollIntoViewOptions:{type:Q([Boolean,Object]),default:void 0},type:{type:Q(String)}}),_oe={close:()=>!0},boe=I({name:"ElTourStep"}),woe=I({...boe,props:yoe,emits:_oe,setup(e,{emit:t}){const n=e,{Close:a}=Ip,{t:o}=gt(),{currentStep:l,current:r,total:i,showClose:u,closeIcon:c,mergedType:d,ns:f,slots:p,updateModelValue:v,onClose:m,onFinish:h,onChange:y}=Pe(fd);pe(n,A=>{l.value=A},{immediate:!0});const g=S(()=>{var A;return(A=n.showClose)!=null?A:u.value}),k=S(()=>{var A,O;return(O=(A=n.closeIcon)!=null?A:c.value)!=null?O:a}),w=A=>{if(A)return AE(A,["children","onClick"])},_=()=>{var A,O;r.value-=1,(A=n.prevButtonProps)!=null&&A.onClick&&((O=n.prevButtonProps)==null||O.onClick()),y()},C=()=>{var A;r.value>=i.value-1?E():r.value+=1,(A=n.nextButtonProps)!=null&&A.onClick&&n.nextButtonProps.onClick(),y()},E=()=>{$(),h()},$=()=>{v(!1),m(),t("close")};return(A,O)=>(b(),M(He,null,[s(g)?(b(),M("button",{key:0,"aria-label":"Close",class:T(s(f).e("closebtn")),type:"button",onClick:$},[U(s(Le),{class:T(s(f).e("close"))},{default:J(()=>[(b(),ue(rt(s(k))))]),_:1},8,["class"])],2)):le("v-if",!0),x("header",{class:T([s(f).e("header"),{"show-close":s(u)}])},[ne(A.$slots,"header",{},()=>[x("span",{role:"heading",class:T(s(f).e("title"))},Te(A.title),3)])],2),x("div",{class:T(s(f).e("body"))},[ne(A.$slots,"default",{},()=>[x("span",null,Te(A.description),1)])],2),x("footer",{class:T(s(f).e("footer"))},[x("div",{class:T(s(f).b("indicators"))},[s(p).indicators?(b(),ue(rt(s(p).indicators),{key:0,current:s(r),total:s(i)},null,8,["current","total"])):(b(!0),M(He,{key:1},pt(s(i),(N,L)=>(b(),M("span",{key:N,class:T([s(f).b("indicator"),L===s(r)?"is-active":""])},null,2))),128))],2),x("div",{class:T(s(f).b("buttons"))},[s(r)>0?(b(),ue(s(dn),st({key:0,size:"small",type:s(d)},w(A.prevButtonProps),{onClick:_}),{default:J(()=>{var N,L;return[ct(Te((L=(N=A.prevButtonProps)==null?void 0:N.children)!=null?L:s(o)("el.tour.previous")),1)]}),_:1},16,["type"])):le("v-if",!0),s(r)<=s(i)-1?(b(),ue(s(dn),st({key:1,size:"small",type:s(d)==="primary"?"default":"primary"},w(A.nextButtonProps),{onClick:C}),{default:J(()=>{var N,L;return[ct(Te((L=(N=A.nextButtonProps)==null?void 0:N.children)!=null?L:s(r)===s(i)-1?s(o)("el.tour.finish"):s(o)("el.tour.next")),1)]}),_:1},16,["type"])):le("v-if",!0)],2)],2)],64))}});var V_=Me(woe,[["__file","step.vue"]]);const Coe=Ze(goe,{TourStep:V_}),Soe=Kt(V_),koe=Ee({container:{type:Q([String,Object])},offset:{type:Number,default:0},bound:{type:Number,default:15},duration:{type:Number,default:300},marker:{type:Boolean,default:!0},type:{type:Q(String),default:"default"},direction:{type:Q(String),default:"vertical"},selectScrollTop:{type:Boolean,default:!1}}),Eoe={change:e=>Ve(e),click:(e,t)=>e instanceof MouseEvent&&(Ve(t)||bt(t))},B_=Symbol("anchor"),su=e=>{if(!mt||e==="")return null;if(Ve(e))try{return document.querySelector(e)}catch{return null}return e};function xoe(e){let t=0;const n=(...a)=>{t&&Ll(t),t=tl(()=>{e(...a),t=0})};return n.cancel=()=>{Ll(t),t=0},n}const Moe=I({name:"ElAnchor"}),Toe=I({...Moe,props:koe,emits:Eoe,setup(e,{expose:t,emit:n}){const a=e,o=V(""),l=V(null),r=V(null),i=V(),u={};let c=!1,d=0;const f=ge("anchor"),p=S(()=>[f.b(),a.type==="underline"?f.m("underline"):"",f.m(a.direction)]),v=A=>{u[A.href]=A.el},m=A=>{delete u[A]},h=A=>{o.value!==A&&(o.value=A,n(it,A))};let y=null;const g=A=>{if(!i.value)return;const O=su(A);if(!O)return;y&&y(),c=!0;const N=Dh(O,i.value),L=Wf(O,N),R=N.scrollHeight-N.clientHeight,K=Math.min(L-a.offset,R);y=Rx(i.value,d,K,a.duration,()=>{setTimeout(()=>{c=!1},20)})},k=A=>{A&&(h(A),g(A))},w=(A,O)=>{n("click",A,O),k(O)},_=xoe(()=>{i.value&&(d=Fh(i.value));const A=C();c||bt(A)||h(A)}),C=()=>{if(!i.value)return;const A=Fh(i.value),O=[];for(const N of Object.keys(u)){const L=su(N);if(!L)continue;const R=Dh(L,i.value),K=Wf(L,R);O.push({top:K-a.offset-a.bound,href:N})}O.sort((N,L)=>N.top-L.top);for(let N=0;N<O.length;N++){const L=O[N],R=O[N+1];if(N===0&&A===0)return a.selectScrollTop?L.href:"";if(L.top<=A&&(!R||R.top>A))return L.href}},E=()=>{const A=su(a.container);!A||Fc(A)?i.value=window:i.value=A};Mt(i,"scroll",_);const $=S(()=>{if(!l.value||!r.value||!o.value)return{};const A=u[o.value];if(!A)return{};const O=l.value.getBoundingClientRect(),N=r.value.getBoundingClientRect(),L=A.getBoundingClientRect();return a.direction==="horizontal"?{left:`${L.left-O.left}px`,width:`${L.width}px`,opacity:1}:{top:`${L.top-O.top+(L.height-N.height)/2}px`,opacity:1}});return at(()=>{E();const A=decodeURIComponent(window.location.hash);su(A)?k(A):_()}),pe(()=>a.container,()=>{E()}),ft(B_,{ns:f,direction:a.direction,currentAnchor:o,addLink:v,removeLink:m,handleClick:w}),t({scrollTo:k}),(A,O)=>(b(),M("div",{ref_key:"anchorRef",ref:l,class:T(s(p))},[A.marker?(b(),M("div",{key:0,ref_key:"markerRef",ref:r,class:T(s(f).e("marker")),style:Fe(s($))},null,6)):le("v-if",!0),x("div",{class:T(s(f).e("list"))},[ne(A.$slots,"default")],2)],2))}});var $oe=Me(Toe,[["__file","anchor.vue"]]);const Ooe=Ee({title:String,href:String}),Ioe=I({name:"ElAnchorLink"}),Aoe=I({...Ioe,props:Ooe,setup(e){const t=e,n=V(null),{ns:a,direction:o,currentAnchor:l,addLink:r,removeLink:i,handleClick:u}=Pe(B_),c=S(()=>[a.e("link"),a.is("active",l.value===t.href)]),d=f=>{u(f,t.href)};return pe(()=>t.href,(f,p)=>{Be(()=>{p&&i(p),f&&r({href:f,el:n.value})})}),at(()=>{const{href:f}=t;f&&r({href:f,el:n.value})}),At(()=>{const{href:f}=t;f&&i(f)}),(f,p)=>(b(),M("div",{class:T(s(a).e("item"))},[x("a",{ref_key:"linkRef",ref:n,class:T(s(c)),href:f.href,onClick:d},[ne(f.$slots,"default",{},()=>[ct(Te(f.title),1)])],10,["href"]),f.$slots["sub-link"]&&s(o)==="vertical"?(b(),M("div",{key:0,class:T(s(a).e("list"))},[ne(f.$slots,"sub-link")],2)):le("v-if",!0)],2))}});var z_=Me(Aoe,[["__file","anchor-link.vue"]]);const Noe=Ze($oe,{AnchorLink:z_}),Poe=Kt(z_),Roe=Ee({direction:{type:Q(String),default:"horizontal"},options:{type:Q(Array),default:()=>[]},modelValue:{type:[String,Number,Boolean],default:void 0},block:Boolean,size:Qt,disabled:Boolean,validateEvent:{type:Boolean,default:!0},id:String,name:String,...yn(["ariaLabel"])}),Loe={[Ue]:e=>Ve(e)||Ke(e)||It(e),[it]:e=>Ve(e)||Ke(e)||It(e)},Voe=I({name:"ElSegmented"}),Boe=I({...Voe,props:Roe,emits:Loe,setup(e,{emit:t}){const n=e,a=ge("segmented"),o=gn(),l=Jt(),r=En(),{formItem:i}=kn(),{inputId:u,isLabeledByFormItem:c}=wa(n,{formItemContext:i}),d=V(null),f=ox(),p=Ct({isInit:!1,width:0,height:0,translateX:0,translateY:0,focusVisible:!1}),v=O=>{const N=m(O);t(Ue,N),t(it,N)},m=O=>nt(O)?O.value:O,h=O=>nt(O)?O.label:O,y=O=>!!(r.value||nt(O)&&O.disabled),g=O=>n.modelValue===m(O),k=O=>n.options.find(N=>m(N)===O),w=O=>[a.e("item"),a.is("selected",g(O)),a.is("disabled",y(O))],_=()=>{if(!d.value)return;const O=d.value.querySelector(".is-selected"),N=d.value.querySelector(".is-selected input");if(!O||!N){p.width=0,p.height=0,p.translateX=0,p.translateY=0,p.focusVisible=!1;return}const L=O.getBoundingClientRect();p.isInit=!0,n.direction==="vertical"?(p.height=L.height,p.translateY=O.offsetTop):(p.width=L.width,p.translateX=O.offsetLeft);try{p.focusVisible=N.matches(":focus-visible")}catch{}},C=S(()=>[a.b(),a.m(l.value),a.is("block",n.block)]),E=S(()=>({width:n.direction==="vertical"?"100%":`${p.width}px`,height:n.direction==="vertical"?`${p.height}px`:"100%",transform:n.direction==="vertical"?`translateY(${p.translateY}px)`:`translateX(${p.translateX}px)`,display:p.isInit?"block":"none"})),$=S(()=>[a.e("item-selected"),a.is("disabled",y(k(n.modelValue))),a.is("focus-visible",p.focusVisible)]),A=S(()=>n.name||o.value);return Dt(d,_),pe(f,_),pe(()=>n.modelValue,()=>{var O;_(),n.validateEvent&&((O=i==null?void 0:i.validate)==null||O.call(i,"change").catch(N=>void 0))},{flush:"post"}),(O,N)=>O.options.length?(b(),M("div",{key:0,id:s(u),ref_key:"segmentedRef",ref:d,class:T(s(C)),role:"radiogroup","aria-label":s(c)?void 0:O.ariaLabel||"segmented","aria-labelledby":s(c)?s(i).labelId:void 0},[x("div",{class:T([s(a).e("group"),s(a).m(n.direction)])},[x("div",{style:Fe(s(E)),class:T(s($))},null,6),(b(!0),M(He,null,pt(O.options,(L,R)=>(b(),M("label",{key:R,class:T(w(L))},[x("input",{class:T(s(a).e("item-input")),type:"radio",name:s(A),disabled:y(L),checked:g(L),onChange:K=>v(L)},null,42,["name","disabled","checked","onChange"]),x("div",{class:T(s(a).e("item-label"))},[ne(O.$slots,"default",{item:L},()=>[ct(Te(h(L)),1)])],2)],2))),128))],2)],10,["id","aria-label","aria-labelledby"])):le("v-if",!0)}});var zoe=Me(Boe,[["__file","segmented.vue"]]);const Hoe=Ze(zoe),Doe=(e,t)=>{const n=e.toLowerCase();return(t.label||t.value).toLowerCase().includes(n)},Foe=(e,t,n)=>{const{selectionEnd:a}=e;if(a===null)return;const o=e.value,l=On(t);let r=-1,i;for(let u=a-1;u>=0;--u){const c=o[u];if(c===n||c===`
-`||c==="\r"){r=u;continue}if(l.includes(c)){const d=r===-1?a:r;i={pattern:o.slice(u+1,d),start:u+1,end:d,prefix:c,prefixIndex:u,splitIndex:r,selectionEnd:a};break}}return i},Koe=(e,t={debug:!1,useSelectionEnd:!1})=>{const n=e.selectionStart!==null?e.selectionStart:0,a=e.selectionEnd!==null?e.selectionEnd:0,o=t.useSelectionEnd?a:n,l=["direction","boxSizing","width","height","overflowX","overflowY","borderTopWidth","borderRightWidth","borderBottomWidth","borderLeftWidth","borderStyle","paddingTop","paddingRight","paddingBottom","paddingLeft","fontStyle","fontVariant","fontWeight","fontStretch","fontSize","fontSizeAdjust","lineHeight","fontFamily","textAlign","textTransform","textIndent","textDecoration","letterSpacing","wordSpacing","tabSize","MozTabSize"];if(t.debug){const p=document.querySelector("#input-textarea-caret-position-mirror-div");p!=null&&p.parentNode&&p.parentNode.removeChild(p)}const r=document.createElement("div");r.id="input-textarea-caret-position-mirror-div",document.body.appendChild(r);const i=r.style,u=window.getComputedStyle(e),c=e.nodeName==="INPUT";i.whiteSpace=c?"nowrap":"pre-wrap",c||(i.wordWrap="break-word"),i.position="absolute",t.debug||(i.visibility="hidden"),l.forEach(p=>{if(c&&p==="lineHeight")if(u.boxSizing==="border-box"){const v=Number.parseInt(u.height),m=Number.parseInt(u.paddingTop)+Number.parseInt(u.paddingBottom)+Number.parseInt(u.borderTopWidth)+Number.parseInt(u.borderBottomWidth),h=m+Number.parseInt(u.lineHeight);v>h?i.lineHeight=`${v-m}px`:v===h?i.lineHeight=u.lineHeight:i.lineHeight="0"}else i.lineHeight=u.height;else i[p]=u[p]}),Zc()?e.scrollHeight>Number.parseInt(u.height)&&(i.overflowY="scroll"):i.overflow="hidden",r.textContent=e.value.slice(0,Math.max(0,o)),c&&r.textContent&&(r.textContent=r.textContent.replace(/\s/g," "));const d=document.createElement("span");d.textContent=e.value.slice(Math.max(0,o))||".",d.style.position="relative",d.style.left=`${-e.scrollLeft}px`,d.style.top=`${-e.scrollTop}px`,r.appendChild(d);const f={top:d.offsetTop+Number.parseInt(u.borderTopWidth),left:d.offsetLeft+Number.parseInt(u.borderLeftWidth),height:Number.parseInt(u.fontSize)*1.5};return t.debug?d.style.backgroundColor="#aaa":document.body.removeChild(r),f.left>=e.clientWidth&&(f.left=e.clientWidth),f},Woe=Ee({...Np,options:{type:Q(Array),default:()=>[]},prefix:{type:Q([String,Array]),default:"@",validator:e=>Ve(e)?e.length===1:e.every(t=>Ve(t)&&t.length===1)},split:{type:String,default:" ",validator:e=>e.length===1},filterOption:{type:Q([Boolean,Function]),default:()=>Doe,validator:e=>e===!1?!0:ze(e)},placement:{type:Q(String),default:"bottom"},showArrow:Boolean,offset:{type:Number,default:0},whole:Boolean,checkIsWhole:{type:Q(Function)},modelValue:String,loading:Boolean,popperClass:{type:String,default:""},popperOptions:{type:Q(Object),default:()=>({})}}),joe={[Ue]:e=>Ve(e),search:(e,t)=>Ve(e)&&Ve(t),select:(e,t)=>Ve(e.value)&&Ve(t),focus:e=>e instanceof FocusEvent,blur:e=>e instanceof FocusEvent},qoe=Ee({options:{type:Q(Array),default:()=>[]},loading:Boolean,disabled:Boolean,contentId:String,ariaLabel:String}),Uoe={select:e=>Ve(e.value)},Yoe=I({name:"ElMentionDropdown"}),Goe=I({...Yoe,props:qoe,emits:Uoe,setup(e,{expose:t,emit:n}){const a=e,o=ge("mention"),{t:l}=gt(),r=V(-1),i=V(),u=V(),c=V(),d=(w,_)=>[o.be("dropdown","item"),o.is("hovering",r.value===_),o.is("disabled",w.disabled||a.disabled)],f=w=>{w.disabled||a.disabled||n("select",w)},p=w=>{r.value=w},v=S(()=>a.disabled||a.options.every(w=>w.disabled)),m=S(()=>a.options[r.value]),h=()=>{m.value&&n("select",m.value)},y=w=>{const{options:_}=a;if(_.length===0||v.value)return;w==="next"?(r.value++,r.value===_.length&&(r.value=0)):w==="prev"&&(r.value--,r.value<0&&(r.value=_.length-1));const C=_[r.value];if(C.disabled){y(w);return}Be(()=>g(C))},g=w=>{var _,C,E,$;const{options:A}=a,O=A.findIndex(L=>L.value===w.value),N=(_=u.value)==null?void 0:_[O];if(N){const L=(E=(C=c.value)==null?void 0:C.querySelector)==null?void 0:E.call(C,`.${o.be("dropdown","wrap")}`);L&&Ep(L,N)}($=i.value)==null||$.handleScroll()};return pe(()=>a.options,()=>{v.value||a.options.length===0?r.value=-1:r.value=0},{immediate:!0}),t({hoveringIndex:r,navigateOptions:y,selectHoverOption:h,hoverOption:m}),(w,_)=>(b(),M("div",{ref_key:"dropdownRef",ref:c,class:T(s(o).b("dropdown"))},[w.$slots.header?(b(),M("div",{key:0,class:T(s(o).be("dropdown","header"))},[ne(w.$slots,"header")],2)):le("v-if",!0),Qe(U(s(oo),{id:w.contentId,ref_key:"scrollbarRef",ref:i,tag:"ul","wrap-class":s(o).be("dropdown","wrap"),"view-class":s(o).be("dropdown","list"),role:"listbox","aria-label":w.ariaLabel,"aria-orientation":"vertical"},{default:J(()=>[(b(!0),M(He,null,pt(w.options,(C,E)=>(b(),M("li",{id:`${w.contentId}-${E}`,ref_for:!0,ref_key:"optionRefs",ref:u,key:E,class:T(d(C,E)),role:"option","aria-disabled":C.disabled||w.disabled||void 0,"aria-selected":r.value===E,onMousemove:$=>p(E),onClick:qe($=>f(C),["stop"])},[ne(w.$slots,"label",{item:C,index:E},()=>{var $;return[x("span",null,Te(($=C.label)!=null?$:C.value),1)]})],42,["id","aria-disabled","aria-selected","onMousemove","onClick"]))),128))]),_:3},8,["id","wrap-class","view-class","aria-label"]),[[_t,w.options.length>0&&!w.loading]]),w.loading?(b(),M("div",{key:1,class:T(s(o).be("dropdown","loading"))},[ne(w.$slots,"loading",{},()=>[ct(Te(s(l)("el.mention.loading")),1)])],2)):le("v-if",!0),w.$slots.footer?(b(),M("div",{key:2,class:T(s(o).be("dropdown","footer"))},[ne(w.$slots,"footer")],2)):le("v-if",!0)],2))}});var Xoe=Me(Goe,[["__file","mention-dropdown.vue"]]);const Zoe=I({name:"ElMention",inheritAttrs:!1}),Joe=I({...Zoe,props:Woe,emits:joe,setup(e,{expose:t,emit:n}){const a=e,o=S(()=>Ea(a,Object.keys(Np))),l=ge("mention"),r=En(),i=gn(),u=V(),c=V(),d=V(),f=V(!1),p=V(),v=V(),m=S(()=>a.showArrow?a.placement:`${a.placement}-start`),h=S(()=>a.showArrow?["bottom","top"]:["bottom-start","top-start"]),y=S(()=>{const{filterOption:R,options:K}=a;return!v.value||!R?K:K.filter(X=>R(v.value.pattern,X))}),g=S(()=>f.value&&(!!y.value.length||a.loading)),k=S(()=>{var R;return`${i.value}-${(R=d.value)==null?void 0:R.hoveringIndex}`}),w=R=>{n(Ue,R),O()},_=R=>{var K,X,F,P;if(!(!("code"in R)||(K=u.value)!=null&&K.isComposing))switch(R.code){case Ae.left:case Ae.right:O();break;case Ae.up:case Ae.down:if(!f.value)return;R.preventDefault(),(X=d.value)==null||X.navigateOptions(R.code===Ae.up?"prev":"next");break;case Ae.enter:case Ae.numpadEnter:if(!f.value)return;R.preventDefault(),(F=d.value)!=null&&F.hoverOption?(P=d.value)==null||P.selectHoverOption():f.value=!1;break;case Ae.esc:if(!f.value)return;R.preventDefault(),f.value=!1;break;case Ae.backspace:if(a.whole&&v.value){const{splitIndex:H,selectionEnd:z,pattern:B,prefixIndex:j,prefix:G}=v.value,te=A();if(!te)return;const Y=te.value,ie=a.options.find(re=>re.value===B);if((ze(a.checkIsWhole)?a.checkIsWhole(B,G):ie)&&H!==-1&&H+1===z){R.preventDefault();const re=Y.slice(0,j)+Y.slice(H+1);n(Ue,re);const W=j;Be(()=>{te.selectionStart=W,te.selectionEnd=W,L()})}}}},{wrapperRef:C}=il(u,{beforeFocus(){return r.value},afterFocus(){O()},beforeBlur(R){var K;return(K=c.value)==null?void 0:K.isFocusInsideContent(R)},afterBlur(){f.value=!1}}),E=()=>{O()},$=R=>{if(!v.value)return;const K=A();if(!K)return;const X=K.value,{split:F}=a,P=X.slice(v.value.end),H=P.startsWith(F),z=`${R.value}${H?"":F}`,B=X.slice(0,v.value.start)+z+P;n(Ue,B),n("select",R,v.value.prefix);const j=v.value.start+z.length+(H?1:0);Be(()=>{K.selectionStart=j,K.selectionEnd=j,K.focus(),L()})},A=()=>{var R,K;return a.type==="textarea"?(R=u.value)==null?void 0:R.textarea:(K=u.value)==null?void 0:K.input},O=()=>{setTimeout(()=>{N(),L(),Be(()=>{var R;return(R=c.value)==null?void 0:R.updatePopper()})},0)},N=()=>{const R=A();if(!R)return;const K=Koe(R),X=R.getBoundingClientRect(),F=u.value.$el.getBoundingClientRect();p.value={position:"absolute",width:0,height:`${K.height}px`,left:`${K.left+X.left-F.left}px`,top:`${K.top+X.top-F.top}px`}},L=()=>{const R=A();if(document.activeElement!==R){f.value=!1;return}const{prefix:K,split:X}=a;if(v.value=Foe(R,K,X),v.value&&v.value.splitIndex===-1){f.value=!0,n("search",v.value.pattern,v.value.prefix);return}f.value=!1};return t({input:u,tooltip:c,dropdownVisible:g}),(R,K)=>(b(),M("div",{ref_key:"wrapperRef",ref:C,class:T([s(l).b(),s(l).is("disabled",s(r))])},[U(s(An),st(st(s(o),R.$attrs),{ref_key:"elInputRef",ref:u,"model-value":R.modelValue,disabled:s(r),role:s(g)?"combobox":void 0,"aria-activedescendant":s(g)?s(k)||"":void 0,"aria-controls":s(g)?s(i):void 0,"aria-expanded":s(g)||void 0,"aria-label":R.ariaLabel,"aria-autocomplete":s(g)?"none":void 0,"aria-haspopup":s(g)?"listbox":void 0,onInput:w,onKeydown:_,onMousedown:E}),sa({_:2},[pt(R.$slots,(X,F)=>({name:F,fn:J(P=>[ne(R.$slots,F,aa(Xa(P)))])}))]),1040,["model-value","disabled","role","aria-activedescendant","aria-controls","aria-expanded","aria-label","aria-autocomplete","aria-haspopup"]),U(s(Sn),{ref_key:"tooltipRef",ref:c,visible:s(g),"popper-class":[s(l).e("popper"),R.popperClass],"popper-options":R.popperOptions,placement:s(m),"fallback-placements":s(h),effect:"light",pure:"",offset:R.offset,"show-arrow":R.showArrow},{default:J(()=>[x("div",{style:Fe(p.value)},null,4)]),content:J(()=>{var X;return[U(Xoe,{ref_key:"dropdownRef",ref:d,options:s(y),disabled:s(r),loading:R.loading,"content-id":s(i),"aria-label":R.ariaLabel,onSelect:$,onClick:qe((X=u.value)==null?void 0:X.focus,["stop"])},sa({_:2},[pt(R.$slots,(F,P)=>({name:P,fn:J(H=>[ne(R.$slots,P,aa(Xa(H)))])}))]),1032,["options","disabled","loading","content-id","aria-label","onClick"])]}),_:3},8,["visible","popper-class","popper-options","placement","fallback-placements","offset","show-arrow"])],2))}});var Qoe=Me(Joe,[["__file","mention.vue"]]);const ele=Ze(Qoe);var tle=[Hx,nV,Xz,Lee,nH,uH,R3,wH,CH,dn,F3,mD,wD,BD,zD,zF,i8,jF,ba,QD,Q3,XF,fK,pK,td,zK,p8,QK,eW,tW,nW,aW,Tj,Bj,zj,Zj,L8,sq,Xq,Zq,Jq,j8,ZU,JU,Le,uY,Y8,An,G8,kY,OY,GY,XY,ZY,JY,oG,YG,eX,uX,I3,oy,r8,fF,dF,_X,kX,IX,oo,Vl,rc,TG,uZ,yZ,_Z,BZ,KZ,gy,eJ,uJ,cJ,mJ,xQ,MQ,Ree,Zee,Jee,Pr,ate,YW,ute,vte,hte,Sn,Sne,Lne,yc,tae,yae,Dae,Zae,Coe,Soe,Noe,Poe,Hoe,ele];const da="ElInfiniteScroll",nle=50,ale=200,ole=0,lle={delay:{type:Number,default:ale},distance:{type:Number,default:ole},disabled:{type:Boolean,default:!1},immediate:{type:Boolean,default:!0}},j2=(e,t)=>Object.entries(lle).reduce((n,[a,o])=>{var l,r;const{type:i,default:u}=o,c=e.getAttribute(`infinite-scroll-${a}`);let d=(r=(l=t[c])!=null?l:c)!=null?r:u;return d=d==="false"?!1:d,d=i(d),n[a]=Number.isNaN(d)?u:d,n},{}),H_=e=>{const{observer:t}=e[da];t&&(t.disconnect(),delete e[da].observer)},rle=(e,t)=>{const{container:n,containerEl:a,instance:o,observer:l,lastScrollTop:r}=e[da],{disabled:i,distance:u}=j2(e,o),{clientHeight:c,scrollHeight:d,scrollTop:f}=a,p=f-r;if(e[da].lastScrollTop=f,l||i||p<0)return;let v=!1;if(n===e)v=d-(c+f)<=u;else{const{clientTop:m,scrollHeight:h}=e,y=Wf(e,a);v=f+c>=y+m+h-u}v&&t.call(o)};function sf(e,t){const{containerEl:n,instance:a}=e[da],{disabled:o}=j2(e,a);o||n.clientHeight===0||(n.scrollHeight<=n.clientHeight?t.call(a):H_(e))}const sle={async mounted(e,t){const{instance:n,value:a}=t;ze(a)||nn(da,"'v-infinite-scroll' binding value must be a function"),await Be();const{delay:o,immediate:l}=j2(e,n),r=kp(e,!0),i=r===window?document.documentElement:r,u=Tl(rle.bind(null,e,a),o);if(r){if(e[da]={instance:n,container:r,containerEl:i,delay:o,cb:a,onScroll:u,lastScrollTop:i.scrollTop},l){const c=new MutationObserver(Tl(sf.bind(null,e,a),nle));e[da].observer=c,c.observe(e,{childList:!0,subtree:!0}),sf(e,a)}r.addEventListener("scroll",u)}},unmounted(e){if(!e[da])return;const{container:t,onScroll:n}=e[da];t==null||t.removeEventListener("scroll",n),H_(e)},async updated(e){if(!e[da])await Be();else{const{containerEl:t,cb:n,observer:a}=e[da];t.clientHeight&&a&&sf(e,n)}}},N0=sle;N0.install=e=>{e.directive("InfiniteScroll",N0)};const ile=N0;function ule(e){let t;const n=V(!1),a=Ct({...e,originalPosition:"",originalOverflow:"",visible:!1});function o(p){a.text=p}function l(){const p=a.parent,v=f.ns;if(!p.vLoadingAddClassList){let m=p.getAttribute("loading-number");m=Number.parseInt(m)-1,m?p.setAttribute("loading-number",m.toString()):(Nn(p,v.bm("parent","relative")),p.removeAttribute("loading-number")),Nn(p,v.bm("parent","hidden"))}r(),d.unmount()}function r(){var p,v;(v=(p=f.$el)==null?void 0:p.parentNode)==null||v.removeChild(f.$el)}function i(){var p;e.beforeClose&&!e.beforeClose()||(n.value=!0,clearTimeout(t),t=setTimeout(u,400),a.visible=!1,(p=e.closed)==null||p.call(e))}function u(){if(!n.value)return;const p=a.parent;n.value=!1,p.vLoadingAddClassList=void 0,l()}const d=g4(I({name:"ElLoading",setup(p,{expose:v}){const{ns:m,zIndex:h}=jc("loading");return v({ns:m,zIndex:h}),()=>{const y=a.spinner||a.svg,g=We("svg",{class:"circular",viewBox:a.svgViewBox?a.svgViewBox:"0 0 50 50",...y?{innerHTML:y}:{}},[We("circle",{class:"path",cx:"25",cy:"25",r:"20",fill:"none"})]),k=a.text?We("p",{class:m.b("text")},[a.text]):void 0;return We(vn,{name:m.b("fade"),onAfterLeave:u},{default:J(()=>[Qe(U("div",{style:{backgroundColor:a.background||""},class:[m.b("mask"),a.customClass,a.fullscreen?"is-fullscreen":""]},[We("div",{class:m.b("spinner")},[g,k])]),[[_t,a.visible]])])})}}})),f=d.mount(document.createElement("div"));return{...pn(a),setText:o,removeElLoadingChild:r,close:i,handleAfterLeave:u,vm:f,get $el(){return f.$el}}}let iu;const P0=function(e={}){if(!mt)return;const t=cle(e);if(t.fullscreen&&iu)return iu;const n=ule({...t,closed:()=>{var o;(o=t.closed)==null||o.call(t),t.fullscreen&&(iu=void 0)}});dle(t,t.parent,n),zm(t,t.parent,n),t.parent.vLoadingAddClassList=()=>zm(t,t.parent,n);let a=t.parent.getAttribute("loading-number");return a?a=`${Number.parseInt(a)+1}`:a="1",t.parent.setAttribute("loading-number",a),t.parent.appendChild(n.$el),Be(()=>n.visible.value=t.visible),t.fullscreen&&(iu=n),n},cle=e=>{var t,n,a,o;let l;return Ve(e.target)?l=(t=document.querySelector(e.target))!=null?t:document.body:l=e.target||document.body,{parent:l===document.body||e.body?document.body:l,background:e.background||"",svg:e.svg||"",svgViewBox:e.svgViewBox||"",spinner:e.spinner||!1,text:e.text||"",fullscreen:l===document.body&&((n=e.fullscreen)!=null?n:!0),lock:(a=e.lock)!=null?a:!1,customClass:e.customClass||"",visible:(o=e.visible)!=null?o:!0,beforeClose:e.beforeClose,closed:e.closed,target:l}},dle=async(e,t,n)=>{const{nextZIndex:a}=n.vm.zIndex||n.vm._.exposed.zIndex,o={};if(e.fullscreen)n.originalPosition.value=ho(document.body,"position"),n.originalOverflow.value=ho(document.body,"overflow"),o.zIndex=a();else if(e.parent===document.body){n.originalPosition.value=ho(document.body,"position"),await Be();for(const l of["top","left"]){const r=l==="top"?"scrollTop":"scrollLeft";o[l]=`${e.target.getBoundingClientRect()[l]+document.body[r]+document.documentElement[r]-Number.parseInt(ho(document.body,`margin-${l}`),10)}px`}for(const l of["height","width"])o[l]=`${e.target.getBoundingClientRect()[l]}px`}else n.originalPosition.value=ho(t,"position");for(const[l,r]of Object.entries(o))n.$el.style[l]=r},zm=(e,t,n)=>{const a=n.vm.ns||n.vm._.exposed.ns;["absolute","fixed","sticky"].includes(n.originalPosition.value)?Nn(t,a.bm("parent","relative")):fa(t,a.bm("parent","relative")),e.fullscreen&&e.lock?fa(t,a.bm("parent","hidden")):Nn(t,a.bm("parent","hidden"))},Bu=Symbol("ElLoading"),Hm=(e,t)=>{var n,a,o,l;const r=t.instance,i=p=>nt(t.value)?t.value[p]:void 0,u=p=>{const v=Ve(p)&&(r==null?void 0:r[p])||p;return v&&V(v)},c=p=>u(i(p)||e.getAttribute(`element-loading-${Mo(p)}`)),d=(n=i("fullscreen"))!=null?n:t.modifiers.fullscreen,f={text:c("text"),svg:c("svg"),svgViewBox:c("svgViewBox"),spinner:c("spinner"),background:c("background"),customClass:c("customClass"),fullscreen:d,target:(a=i("target"))!=null?a:d?void 0:e,body:(o=i("body"))!=null?o:t.modifiers.body,lock:(l=i("lock"))!=null?l:t.modifiers.lock};e[Bu]={options:f,instance:P0(f)}},fle=(e,t)=>{for(const n of Object.keys(t))Rt(t[n])&&(t[n].value=e[n])},Dm={mounted(e,t){t.value&&Hm(e,t)},updated(e,t){const n=e[Bu];t.oldValue!==t.value&&(t.value&&!t.oldValue?Hm(e,t):t.value&&t.oldValue?nt(t.value)&&fle(t.value,n.options):n==null||n.instance.close())},unmounted(e){var t;(t=e[Bu])==null||t.instance.close(),e[Bu]=null}},ple={install(e){e.directive("loading",Dm),e.config.globalProperties.$loading=P0},directive:Dm,service:P0},D_=["success","info","warning","error"],Mn=Ft({customClass:"",center:!1,dangerouslyUseHTMLString:!1,duration:3e3,icon:void 0,id:"",message:"",onClose:void 0,showClose:!1,type:"info",plain:!1,offset:16,zIndex:0,grouping:!1,repeatNum:1,appendTo:mt?document.body:void 0}),vle=Ee({customClass:{type:String,default:Mn.customClass},center:{type:Boolean,default:Mn.center},dangerouslyUseHTMLString:{type:Boolean,default:Mn.dangerouslyUseHTMLString},duration:{type:Number,default:Mn.duration},icon:{type:Tt,default:Mn.icon},id:{type:String,default:Mn.id},message:{type:Q([String,Object,Function]),default:Mn.message},onClose:{type:Q(Function),default:Mn.onClose},showClose:{type:Boolean,default:Mn.showClose},type:{type:String,values:D_,default:Mn.type},plain:{type:Boolean,default:Mn.plain},offset:{type:Number,default:Mn.offset},zIndex:{type:Number,default:Mn.zIndex},grouping:{type:Boolean,default:Mn.grouping},repeatNum:{type:Number,default:Mn.repeatNum}}),hle={destroy:()=>!0},xa=j0([]),mle=e=>{const t=xa.findIndex(o=>o.id===e),n=xa[t];let a;return t>0&&(a=xa[t-1]),{current:n,prev:a}},gle=e=>{const{prev:t}=mle(e);return t?t.vm.exposed.bottom.value:0},yle=(e,t)=>xa.findIndex(a=>a.id===e)>0?16:t,_le=I({name:"ElMessage"}),ble=I({..._le,props:vle,emits:hle,setup(e,{expose:t}){const n=e,{Close:a}=Ap,{ns:o,zIndex:l}=jc("message"),{currentZIndex:r,nextZIndex:i}=l,u=V(),c=V(!1),d=V(0);let f;const p=S(()=>n.type?n.type==="error"?"danger":n.type:"info"),v=S(()=>{const $=n.type;return{[o.bm("icon",$)]:$&&nl[$]}}),m=S(()=>n.icon||nl[n.type]||""),h=S(()=>gle(n.id)),y=S(()=>yle(n.id,n.offset)+h.value),g=S(()=>d.value+y.value),k=S(()=>({top:`${y.value}px`,zIndex:r.value}));function w(){n.duration!==0&&({stop:f}=Rl(()=>{C()},n.duration))}function _(){f==null||f()}function C(){c.value=!1}function E({code:$}){$===Ae.esc&&C()}return at(()=>{w(),i(),c.value=!0}),pe(()=>n.repeatNum,()=>{_(),w()}),Mt(document,"keydown",E),Dt(u,()=>{d.value=u.value.getBoundingClientRect().height}),t({visible:c,bottom:g,close:C}),($,A)=>(b(),ue(vn,{name:s(o).b("fade"),onBeforeLeave:$.onClose,onAfterLeave:O=>$.$emit("destroy"),persisted:""},{default:J(()=>[Qe(x("div",{id:$.id,ref_key:"messageRef",ref:u,class:T([s(o).b(),{[s(o).m($.type)]:$.type},s(o).is("center",$.center),s(o).is("closable",$.showClose),s(o).is("plain",$.plain),$.customClass]),style:Fe(s(k)),role:"alert",onMouseenter:_,onMouseleave:w},[$.repeatNum>1?(b(),ue(s(R3),{key:0,value:$.repeatNum,type:s(p),class:T(s(o).e("badge"))},null,8,["value","type","class"])):le("v-if",!0),s(m)?(b(),ue(s(Le),{key:1,class:T([s(o).e("icon"),s(v)])},{default:J(()=>[(b(),ue(rt(s(m))))]),_:1},8,["class"])):le("v-if",!0),ne($.$slots,"default",{},()=>[$.dangerouslyUseHTMLString?(b(),M(He,{key:1},[le(" Caution here, message could've been compromised, never use user's input as message "),x("p",{class:T(s(o).e("content")),innerHTML:$.message},null,10,["innerHTML"])],2112)):(b(),M("p",{key:0,class:T(s(o).e("content"))},Te($.message),3))]),$.showClose?(b(),ue(s(Le),{key:2,class:T(s(o).e("closeBtn")),onClick:qe(C,["stop"])},{default:J(()=>[U(s(a))]),_:1},8,["class","onClick"])):le("v-if",!0)],46,["id"]),[[_t,c.value]])]),_:3},8,["name","onBeforeLeave","onAfterLeave"]))}});var wle=Me(ble,[["__file","message.vue"]]);let Cle=1;const F_=e=>{const t=!e||Ve(e)||Bt(e)||ze(e)?{message:e}:e,n={...Mn,...t};if(!n.appendTo)n.appendTo=document.body;else if(Ve(n.appendTo)){let a=document.querySelector(n.appendTo);Kn(a)||(a=document.body),n.appendTo=a}return It(Sa.grouping)&&!n.grouping&&(n.grouping=Sa.grouping),Ke(Sa.duration)&&n.duration===3e3&&(n.duration=Sa.duration),Ke(Sa.offset)&&n.offset===16&&(n.offset=Sa.offset),It(Sa.showClose)&&!n.showClose&&(n.showClose=Sa.showClose),n},Sle=e=>{const t=xa.indexOf(e);if(t===-1)return;xa.splice(t,1);const{handler:n}=e;n.close()},kle=({appendTo:e,...t},n)=>{const a=`message_${Cle++}`,o=t.onClose,l=document.createElement("div"),r={...t,id:a,onClose:()=>{o==null||o(),Sle(d)},onDestroy:()=>{Qo(null,l)}},i=U(wle,r,ze(r.message)||Bt(r.message)?{default:ze(r.message)?r.message:()=>r.message}:null);i.appContext=n||Fr._context,Qo(i,l),e.appendChild(l.firstElementChild);const u=i.component,d={id:a,vnode:i,vm:u,handler:{close:()=>{u.exposed.visible.value=!1}},props:i.component.props};return d},Fr=(e={},t)=>{if(!mt)return{close:()=>{}};const n=F_(e);if(n.grouping&&xa.length){const o=xa.find(({vnode:l})=>{var r;return((r=l.props)==null?void 0:r.message)===n.message});if(o)return o.props.repeatNum+=1,o.props.type=n.type,o.handler}if(Ke(Sa.max)&&xa.length>=Sa.max)return{close:()=>{}};const a=kle(n,t);return xa.push(a),a.handler};D_.forEach(e=>{Fr[e]=(t={},n)=>{const a=F_(t);return Fr({...a,type:e},n)}});function Ele(e){for(const t of xa)(!e||e===t.props.type)&&t.handler.close()}Fr.closeAll=Ele;Fr._context=null;const xle=B6(Fr,"$message"),R0="_trap-focus-children",xl=[],Fm=e=>{if(xl.length===0)return;const t=xl[xl.length-1][R0];if(t.length>0&&e.code===Ae.tab){if(t.length===1){e.preventDefault(),document.activeElement!==t[0]&&t[0].focus();return}const n=e.shiftKey,a=e.target===t[0],o=e.target===t[t.length-1];a&&n&&(e.preventDefault(),t[t.length-1].focus()),o&&!n&&(e.preventDefault(),t[0].focus())}},Mle={beforeMount(e){e[R0]=qh(e),xl.push(e),xl.length<=1&&document.addEventListener("keydown",Fm)},updated(e){Be(()=>{e[R0]=qh(e)})},unmounted(){xl.shift(),xl.length===0&&document.removeEventListener("keydown",Fm)}},Tle=I({name:"ElMessageBox",directives:{TrapFocus:Mle},components:{ElButton:dn,ElFocusTrap:Qr,ElInput:An,ElOverlay:s2,ElIcon:Le,...Ap},inheritAttrs:!1,props:{buttonSize:{type:String,validator:_y},modal:{type:Boolean,default:!0},lockScroll:{type:Boolean,default:!0},showClose:{type:Boolean,default:!0},closeOnClickModal:{type:Boolean,default:!0},closeOnPressEscape:{type:Boolean,default:!0},closeOnHashChange:{type:Boolean,default:!0},center:Boolean,draggable:Boolean,overflow:Boolean,roundButton:{default:!1,type:Boolean},container:{type:String,default:"body"},boxType:{type:String,default:""}},emits:["vanish","action"],setup(e,{emit:t}){const{locale:n,zIndex:a,ns:o,size:l}=jc("message-box",S(()=>e.buttonSize)),{t:r}=n,{nextZIndex:i}=a,u=V(!1),c=Ct({autofocus:!0,beforeClose:null,callback:null,cancelButtonText:"",cancelButtonClass:"",confirmButtonText:"",confirmButtonClass:"",customClass:"",customStyle:{},dangerouslyUseHTMLString:!1,distinguishCancelAndClose:!1,icon:"",closeIcon:"",inputPattern:null,inputPlaceholder:"",inputType:"text",inputValue:"",inputValidator:void 0,inputErrorMessage:"",message:"",modalFade:!0,modalClass:"",showCancelButton:!1,showConfirmButton:!0,type:"",title:void 0,showInput:!1,action:"",confirmButtonLoading:!1,cancelButtonLoading:!1,confirmButtonLoadingIcon:yo(Na),cancelButtonLoadingIcon:yo(Na),confirmButtonDisabled:!1,editorErrorMessage:"",validateError:!1,zIndex:i()}),d=S(()=>{const P=c.type;return{[o.bm("icon",P)]:P&&nl[P]}}),f=gn(),p=gn(),v=S(()=>{const P=c.type;return c.icon||P&&nl[P]||""}),m=S(()=>!!c.message),h=V(),y=V(),g=V(),k=V(),w=V(),_=S(()=>c.confirmButtonClass);pe(()=>c.inputValue,async P=>{await Be(),e.boxType==="prompt"&&P&&R()},{immediate:!0}),pe(()=>u.value,P=>{var H,z;P&&(e.boxType!=="prompt"&&(c.autofocus?g.value=(z=(H=w.value)==null?void 0:H.$el)!=null?z:h.value:g.value=h.value),c.zIndex=i()),e.boxType==="prompt"&&(P?Be().then(()=>{var B;k.value&&k.value.$el&&(c.autofocus?g.value=(B=K())!=null?B:h.value:g.value=h.value)}):(c.editorErrorMessage="",c.validateError=!1))});const C=S(()=>e.draggable),E=S(()=>e.overflow);A8(h,y,C,E),at(async()=>{await Be(),e.closeOnHashChange&&window.addEventListener("hashchange",$)}),At(()=>{e.closeOnHashChange&&window.removeEventListener("hashchange",$)});function $(){u.value&&(u.value=!1,Be(()=>{c.action&&t("action",c.action)}))}const A=()=>{e.closeOnClickModal&&L(c.distinguishCancelAndClose?"close":"cancel")},O=r2(A),N=P=>{if(c.inputType!=="textarea")return P.preventDefault(),L("confirm")},L=P=>{var H;e.boxType==="prompt"&&P==="confirm"&&!R()||(c.action=P,c.beforeClose?(H=c.beforeClose)==null||H.call(c,P,c,$):$())},R=()=>{if(e.boxType==="prompt"){const P=c.inputPattern;if(P&&!P.test(c.inputValue||""))return c.editorErrorMessage=c.inputErrorMessage||r("el.messagebox.error"),c.validateError=!0,!1;const H=c.inputValidator;if(ze(H)){const z=H(c.inputValue);if(z===!1)return c.editorErrorMessage=c.inputErrorMessage||r("el.messagebox.error"),c.validateError=!0,!1;if(Ve(z))return c.editorErrorMessage=z,c.validateError=!0,!1}}return c.editorErrorMessage="",c.validateError=!1,!0},K=()=>{var P,H;const z=(P=k.value)==null?void 0:P.$refs;return(H=z==null?void 0:z.input)!=null?H:z==null?void 0:z.textarea},X=()=>{L("close")},F=()=>{e.closeOnPressEscape&&X()};return e.lockScroll&&i2(u),{...pn(c),ns:o,overlayEvent:O,visible:u,hasMessage:m,typeClass:d,contentId:f,inputId:p,btnSize:l,iconComponent:v,confirmButtonClasses:_,rootRef:h,focusStartRef:g,headerRef:y,inputRef:k,confirmRef:w,doClose:$,handleClose:X,onCloseRequested:F,handleWrapperClick:A,handleInputEnter:N,handleAction:L,t:r}}});function $le(e,t,n,a,o,l){const r=ut("el-icon"),i=ut("el-input"),u=ut("el-button"),c=ut("el-focus-trap"),d=ut("el-overlay");return b(),ue(vn,{name:"fade-in-linear",onAfterLeave:f=>e.$emit("vanish"),persisted:""},{default:J(()=>[Qe(U(d,{"z-index":e.zIndex,"overlay-class":[e.ns.is("message-box"),e.modalClass],mask:e.modal},{default:J(()=>[x("div",{role:"dialog","aria-label":e.title,"aria-modal":"true","aria-describedby":e.showInput?void 0:e.contentId,class:T(`${e.ns.namespace.value}-overlay-message-box`),onClick:e.overlayEvent.onClick,onMousedown:e.overlayEvent.onMousedown,onMouseup:e.overlayEvent.onMouseup},[U(c,{loop:"",trapped:e.visible,"focus-trap-el":e.rootRef,"focus-start-el":e.focusStartRef,onReleaseRequested:e.onCloseRequested},{default:J(()=>[x("div",{ref:"rootRef",class:T([e.ns.b(),e.customClass,e.ns.is("draggable",e.draggable),{[e.ns.m("center")]:e.center}]),style:Fe(e.customStyle),tabindex:"-1",onClick:qe(()=>{},["stop"])},[e.title!==null&&e.title!==void 0?(b(),M("div",{key:0,ref:"headerRef",class:T([e.ns.e("header"),{"show-close":e.showClose}])},[x("div",{class:T(e.ns.e("title"))},[e.iconComponent&&e.center?(b(),ue(r,{key:0,class:T([e.ns.e("status"),e.typeClass])},{default:J(()=>[(b(),ue(rt(e.iconComponent)))]),_:1},8,["class"])):le("v-if",!0),x("span",null,Te(e.title),1)],2),e.showClose?(b(),M("button",{key:0,type:"button",class:T(e.ns.e("headerbtn")),"aria-label":e.t("el.messagebox.close"),onClick:f=>e.handleAction(e.distinguishCancelAndClose?"close":"cancel"),onKeydown:Ot(qe(f=>e.handleAction(e.distinguishCancelAndClose?"close":"cancel"),["prevent"]),["enter"])},[U(r,{class:T(e.ns.e("close"))},{default:J(()=>[(b(),ue(rt(e.closeIcon||"close")))]),_:1},8,["class"])],42,["aria-label","onClick","onKeydown"])):le("v-if",!0)],2)):le("v-if",!0),x("div",{id:e.contentId,class:T(e.ns.e("content"))},[x("div",{class:T(e.ns.e("container"))},[e.iconComponent&&!e.center&&e.hasMessage?(b(),ue(r,{key:0,class:T([e.ns.e("status"),e.typeClass])},{default:J(()=>[(b(),ue(rt(e.iconComponent)))]),_:1},8,["class"])):le("v-if",!0),e.hasMessage?(b(),M("div",{key:1,class:T(e.ns.e("message"))},[ne(e.$slots,"default",{},()=>[e.dangerouslyUseHTMLString?(b(),ue(rt(e.showInput?"label":"p"),{key:1,for:e.showInput?e.inputId:void 0,innerHTML:e.message},null,8,["for","innerHTML"])):(b(),ue(rt(e.showInput?"label":"p"),{key:0,for:e.showInput?e.inputId:void 0},{default:J(()=>[ct(Te(e.dangerouslyUseHTMLString?"":e.message),1)]),_:1},8,["for"]))])],2)):le("v-if",!0)],2),Qe(x("div",{class:T(e.ns.e("input"))},[U(i,{id:e.inputId,ref:"inputRef",modelValue:e.inputValue,"onUpdate:modelValue":f=>e.inputValue=f,type:e.inputType,placeholder:e.inputPlaceholder,"aria-invalid":e.validateError,class:T({invalid:e.validateError}),onKeydown:Ot(e.handleInputEnter,["enter"])},null,8,["id","modelValue","onUpdate:modelValue","type","placeholder","aria-invalid","class","onKeydown"]),x("div",{class:T(e.ns.e("errormsg")),style:Fe({visibility:e.editorErrorMessage?"visible":"hidden"})},Te(e.editorErrorMessage),7)],2),[[_t,e.showInput]])],10,["id"]),x("div",{class:T(e.ns.e("btns"))},[e.showCancelButton?(b(),ue(u,{key:0,loading:e.cancelButtonLoading,"loading-icon":e.cancelButtonLoadingIcon,class:T([e.cancelButtonClass]),round:e.roundButton,size:e.btnSize,onClick:f=>e.handleAction("cancel"),onKeydown:Ot(qe(f=>e.handleAction("cancel"),["prevent"]),["enter"])},{default:J(()=>[ct(Te(e.cancelButtonText||e.t("el.messagebox.cancel")),1)]),_:1},8,["loading","loading-icon","class","round","size","onClick","onKeydown"])):le("v-if",!0),Qe(U(u,{ref:"confirmRef",type:"primary",loading:e.confirmButtonLoading,"loading-icon":e.confirmButtonLoadingIcon,class:T([e.confirmButtonClasses]),round:e.roundButton,disabled:e.confirmButtonDisabled,size:e.btnSize,onClick:f=>e.handleAction("confirm"),onKeydown:Ot(qe(f=>e.handleAction("confirm"),["prevent"]),["enter"])},{default:J(()=>[ct(Te(e.confirmButtonText||e.t("el.messagebox.confirm")),1)]),_:1},8,["loading","loading-icon","class","round","disabled","size","onClick","onKeydown"]),[[_t,e.showConfirmButton]])],2)],14,["onClick"])]),_:3},8,["trapped","focus-trap-el","focus-start-el","onReleaseRequested"])],42,["aria-label","aria-describedby","onClick","onMousedown","onMouseup"])]),_:3},8,["z-index","overlay-class","mask"]),[[_t,e.visible]])]),_:3},8,["onAfterLeave"])}var Ole=Me(Tle,[["render",$le],["__file","index.vue"]]);const hi=new Map,Ile=e=>{let t=document.body;return e.appendTo&&(Ve(e.appendTo)&&(t=document.querySelector(e.appendTo)),Kn(e.appendTo)&&(t=e.appendTo),Kn(t)||(t=document.body)),t},Ale=(e,t,n=null)=>{const a=U(Ole,e,ze(e.message)||Bt(e.message)?{default:ze(e.message)?e.message:()=>e.message}:null);return a.appContext=n,Qo(a,t),Ile(e).appendChild(t.firstElementChild),a.component},Nle=()=>document.createElement("div"),Ple=(e,t)=>{const n=Nle();e.onVanish=()=>{Qo(null,n),hi.delete(o)},e.onAction=l=>{const r=hi.get(o);let i;e.showInput?i={value:o.inputValue,action:l}:i=l,e.callback?e.callback(i,a.proxy):l==="cancel"||l==="close"?e.distinguishCancelAndClose&&l!=="cancel"?r.reject("close"):r.reject("cancel"):r.resolve(i)};const a=Ale(e,n,t),o=a.proxy;for(const l in e)yt(e,l)&&!yt(o.$props,l)&&(l==="closeIcon"&&nt(e[l])?o[l]=yo(e[l]):o[l]=e[l]);return o.visible=!0,o};function os(e,t=null){if(!mt)return Promise.reject();let n;return Ve(e)||Bt(e)?e={message:e}:n=e.callback,new Promise((a,o)=>{const l=Ple(e,t??os._context);hi.set(l,{options:e,callback:n,resolve:a,reject:o})})}const Rle=["alert","confirm","prompt"],Lle={alert:{closeOnPressEscape:!1,closeOnClickModal:!1},confirm:{showCancelButton:!0},prompt:{showCancelButton:!0,showInput:!0}};Rle.forEach(e=>{os[e]=Vle(e)});function Vle(e){return(t,n,a,o)=>{let l="";return nt(n)?(a=n,l=""):bt(n)?l="":l=n,os(Object.assign({title:l,message:t,type:"",...Lle[e]},a,{boxType:e}),o)}}os.close=()=>{hi.forEach((e,t)=>{t.doClose()}),hi.clear()};os._context=null;const Do=os;Do.install=e=>{Do._context=e._context,e.config.globalProperties.$msgbox=Do,e.config.globalProperties.$messageBox=Do,e.config.globalProperties.$alert=Do.alert,e.config.globalProperties.$confirm=Do.confirm,e.config.globalProperties.$prompt=Do.prompt};const Ble=Do,K_=["success","info","warning","error"],zle=Ee({customClass:{type:String,default:""},dangerouslyUseHTMLString:Boolean,duration:{type:Number,default:4500},icon:{type:Tt},id:{type:String,default:""},message:{type:Q([String,Object,Function]),default:""},offset:{type:Number,default:0},onClick:{type:Q(Function),default:()=>{}},onClose:{type:Q(Function),required:!0},position:{type:String,values:["top-right","top-left","bottom-right","bottom-left"],default:"top-right"},showClose:{type:Boolean,default:!0},title:{type:String,default:""},type:{type:String,values:[...K_,""],default:""},zIndex:Number}),Hle={destroy:()=>!0},Dle=I({name:"ElNotification"}),Fle=I({...Dle,props:zle,emits:Hle,setup(e,{expose:t}){const n=e,{ns:a,zIndex:o}=jc("notification"),{nextZIndex:l,currentZIndex:r}=o,{Close:i}=Ip,u=V(!1);let c;const d=S(()=>{const w=n.type;return w&&nl[n.type]?a.m(w):""}),f=S(()=>n.type&&nl[n.type]||n.icon),p=S(()=>n.position.endsWith("right")?"right":"left"),v=S(()=>n.position.startsWith("top")?"top":"bottom"),m=S(()=>{var w;return{[v.value]:`${n.offset}px`,zIndex:(w=n.zIndex)!=null?w:r.value}});function h(){n.duration>0&&({stop:c}=Rl(()=>{u.value&&g()},n.duration))}function y(){c==null||c()}function g(){u.value=!1}function k({code:w}){w===Ae.delete||w===Ae.backspace?y():w===Ae.esc?u.value&&g():h()}return at(()=>{h(),l(),u.value=!0}),Mt(document,"keydown",k),t({visible:u,close:g}),(w,_)=>(b(),ue(vn,{name:s(a).b("fade"),onBeforeLeave:w.onClose,onAfterLeave:C=>w.$emit("destroy"),persisted:""},{default:J(()=>[Qe(x("div",{id:w.id,class:T([s(a).b(),w.customClass,s(p)]),style:Fe(s(m)),role:"alert",onMouseenter:y,onMouseleave:h,onClick:w.onClick},[s(f)?(b(),ue(s(Le),{key:0,class:T([s(a).e("icon"),s(d)])},{default:J(()=>[(b(),ue(rt(s(f))))]),_:1},8,["class"])):le("v-if",!0),x("div",{class:T(s(a).e("group"))},[x("h2",{class:T(s(a).e("title")),textContent:Te(w.title)},null,10,["textContent"]),Qe(x("div",{class:T(s(a).e("content")),style:Fe(w.title?void 0:{margin:0})},[ne(w.$slots,"default",{},()=>[w.dangerouslyUseHTMLString?(b(),M(He,{key:1},[le(" Caution here, message could've been compromised, never use user's input as message "),x("p",{innerHTML:w.message},null,8,["innerHTML"])],2112)):(b(),M("p",{key:0},Te(w.message),1))])],6),[[_t,w.message]]),w.showClose?(b(),ue(s(Le),{key:0,class:T(s(a).e("closeBtn")),onClick:qe(g,["stop"])},{default:J(()=>[U(s(i))]),_:1},8,["class","onClick"])):le("v-if",!0)],2)],46,["id","onClick"]),[[_t,u.value]])]),_:3},8,["name","onBeforeLeave","onAfterLeave"]))}});var Kle=Me(Fle,[["__file","notification.vue"]]);const _c={"top-left":[],"top-right":[],"bottom-left":[],"bottom-right":[]},L0=16;let Wle=1;const Kr=function(e={},t){if(!mt)return{close:()=>{}};(Ve(e)||Bt(e))&&(e={message:e});const n=e.position||"top-right";let a=e.offset||0;_c[n].forEach(({vm:d})=>{var f;a+=(((f=d.el)==null?void 0:f.offsetHeight)||0)+L0}),a+=L0;const o=`notification_${Wle++}`,l=e.onClose,r={...e,offset:a,id:o,onClose:()=>{jle(o,n,l)}};let i=document.body;Kn(e.appendTo)?i=e.appendTo:Ve(e.appendTo)&&(i=document.querySelector(e.appendTo)),Kn(i)||(i=document.body);const u=document.createElement("div"),c=U(Kle,r,ze(r.message)?r.message:Bt(r.message)?()=>r.message:null);return c.appContext=bt(t)?Kr._context:t,c.props.onDestroy=()=>{Qo(null,u)},Qo(c,u),_c[n].push({vm:c}),i.appendChild(u.firstElementChild),{close:()=>{c.component.exposed.visible.value=!1}}};K_.forEach(e=>{Kr[e]=(t={},n)=>((Ve(t)||Bt(t))&&(t={message:t}),Kr({...t,type:e},n))});function jle(e,t,n){const a=_c[t],o=a.findIndex(({vm:c})=>{var d;return((d=c.component)==null?void 0:d.props.id)===e});if(o===-1)return;const{vm:l}=a[o];if(!l)return;n==null||n(l);const r=l.el.offsetHeight,i=t.split("-")[0];a.splice(o,1);const u=a.length;if(!(u<1))for(let c=o;c<u;c++){const{el:d,component:f}=a[c].vm,p=Number.parseInt(d.style[i],10)-r-L0;f.props.offset=p}}function qle(){for(const e of Object.values(_c))e.forEach(({vm:t})=>{t.component.exposed.visible.value=!1})}Kr.closeAll=qle;Kr._context=null;const Ule=B6(Kr,"$notify");var Yle=[ile,ple,xle,Ble,Ule,ay],Gle=Ox([...tle,...Yle]);const Xle={class:"app-container"},Zle={class:"wrapper"},Jle=I({__name:"App",setup(e){return(t,n)=>(b(),ue(s(p8),null,{default:J(()=>[x("div",Xle,[x("header",null,[x("div",Zle,[n[3]||(n[3]=x("h1",{class:"app-title"},"文件共享系统",-1)),x("nav",null,[U(s(pu),{to:"/fileserver"},{default:J(()=>n[0]||(n[0]=[ct("共享")])),_:1}),U(s(pu),{to:"/manage"},{default:J(()=>n[1]||(n[1]=[ct("管理维护")])),_:1}),U(s(pu),{to:"/about"},{default:J(()=>n[2]||(n[2]=[ct("关于")])),_:1})])])]),x("main",null,[U(s(I4))])])]),_:1}))}}),Qle=(e,t)=>{const n=e.__vccOpts||e;for(const[a,o]of t)n[a]=o;return n},ere=Qle(Jle,[["__scopeId","data-v-f136f0a9"]]),tre="modulepreload",nre=function(e){return"/"+e},Km={},uf=function(t,n,a){let o=Promise.resolve();if(n&&n.length>0){document.getElementsByTagName("link");const r=document.querySelector("meta[property=csp-nonce]"),i=(r==null?void 0:r.nonce)||(r==null?void 0:r.getAttribute("nonce"));o=Promise.allSettled(n.map(u=>{if(u=nre(u),u in Km)return;Km[u]=!0;const c=u.endsWith(".css"),d=c?'[rel="stylesheet"]':"";if(document.querySelector(`link[href="${u}"]${d}`))return;const f=document.createElement("link");if(f.rel=c?"stylesheet":tre,c||(f.as="script"),f.crossOrigin="",f.href=u,i&&f.setAttribute("nonce",i),document.head.appendChild(f),c)return new Promise((p,v)=>{f.addEventListener("load",p),f.addEventListener("error",()=>v(new Error(`Unable to preload CSS for ${u}`)))})}))}function l(r){const i=new Event("vite:preloadError",{cancelable:!0});if(i.payload=r,window.dispatchEvent(i),!i.defaultPrevented)throw r}return o.then(r=>{for(const i of r||[])i.status==="rejected"&&l(i.reason);return t().catch(l)})},are=v9({history:j5("/"),routes:[{path:"/fileserver",name:"share",component:()=>uf(()=>import("./ShareView-DW4xIDsm.js"),__vite__mapDeps([0,1]))},{path:"/manage",name:"manage",component:()=>uf(()=>import("./ManageView-qaCSx95K.js"),__vite__mapDeps([2,3]))},{path:"/about",name:"about",component:()=>uf(()=>import("./AboutView-Dr_wGXAT.js"),__vite__mapDeps([4,5]))}]}),pd=g4(ere);for(const[e,t]of Object.entries(GL))pd.component(e,t);pd.use(are);pd.use(Gle);pd.mount("#app");export{xle as E,He as F,Qle as _,Ct as a,x as b,M as c,I as d,U as e,ut as f,ue as g,b as h,le as i,ct as j,Ble as k,qe as l,Qe as m,Fe as n,at as o,Ot as p,lA as q,V as r,j$ as s,Te as t,s as u,_t as v,J as w,pt as x,Oc as y,T as z};
+`||c==="\r"){r=u;continue}if(l.includes(c)){const d=r===-1?a:r;i={pattern:o.slice(u+1,d),start:u+1,end:d,prefix:c,prefixIndex:u,splitIndex:r,selectionEnd:a};break}}return i},Koe=(e,t={debug:!1,useSelectionEnd:!1})=>{const n=e.selectionStart!==null?e.selectionStart:0,a=e.selectionEnd!==null?e.selectionEnd:0,o=t.useSelectionEnd?a:n,l=["direction","boxSizing","width","height","overflowX","overflowY","borderTopWidth","borderRightWidth","borderBottomWidth","borderLeftWidth","borderStyle","paddingTop","paddingRight","paddingBottom","paddingLeft","fontStyle","fontVariant","fontWeight","fontStretch","fontSize","fontSizeAdjust","lineHeight","fontFamily","textAlign","textTransform","textIndent","textDecoration","letterSpacing","wordSpacing","tabSize","MozTabSize"];if(t.debug){const p=document.querySelector("#input-textarea-caret-position-mirror-div");p!=null&&p.parentNode&&p.parentNode.removeChild(p)}const r=document.createElement("div");r.id="input-textarea-caret-position-mirror-div",document.body.appendChild(r);const i=r.style,u=window.getComputedStyle(e),c=e.nodeName==="INPUT";i.whiteSpace=c?"nowrap":"pre-wrap",c||(i.wordWrap="break-word"),i.position="absolute",t.debug||(i.visibility="hidden"),l.forEach(p=>{if(c&&p==="lineHeight")if(u.boxSizing==="border-box"){const v=Number.parseInt(u.height),m=Number.parseInt(u.paddingTop)+Number.parseInt(u.paddingBottom)+Number.parseInt(u.borderTopWidth)+Number.parseInt(u.borderBottomWidth),h=m+Number.parseInt(u.lineHeight);v>h?i.lineHeight=`${v-m}px`:v===h?i.lineHeight=u.lineHeight:i.lineHeight="0"}else i.lineHeight=u.height;else i[p]=u[p]}),Zc()?e.scrollHeight>Number.parseInt(u.height)&&(i.overflowY="scroll"):i.overflow="hidden",r.textContent=e.value.slice(0,Math.max(0,o)),c&&r.textContent&&(r.textContent=r.textContent.replace(/\s/g," "));const d=document.createElement("span");d.textContent=e.value.slice(Math.max(0,o))||".",d.style.position="relative",d.style.left=`${-e.scrollLeft}px`,d.style.top=`${-e.scrollTop}px`,r.appendChild(d);const f={top:d.offsetTop+Number.parseInt(u.borderTopWidth),left:d.offsetLeft+Number.parseInt(u.borderLeftWidth),height:Number.parseInt(u.fontSize)*1.5};return t.debug?d.style.backgroundColor="#aaa":document.body.removeChild(r),f.left>=e.clientWidth&&(f.left=e.clientWidth),f},Woe=Ee({...Np,options:{type:Q(Array),default:()=>[]},prefix:{type:Q([String,Array]),default:"@",validator:e=>Ve(e)?e.length===1:e.every(t=>Ve(t)&&t.length===1)},split:{type:String,default:" ",validator:e=>e.length===1},filterOption:{type:Q([Boolean,Function]),default:()=>Doe,validator:e=>e===!1?!0:ze(e)},placement:{type:Q(String),default:"bottom"},showArrow:Boolean,offset:{type:Number,default:0},whole:Boolean,checkIsWhole:{type:Q(Function)},modelValue:String,loading:Boolean,popperClass:{type:String,default:""},popperOptions:{type:Q(Object),default:()=>({})}}),joe={[Ue]:e=>Ve(e),search:(e,t)=>Ve(e)&&Ve(t),select:(e,t)=>Ve(e.value)&&Ve(t),focus:e=>e instanceof FocusEvent,blur:e=>e instanceof FocusEvent},qoe=Ee({options:{type:Q(Array),default:()=>[]},loading:Boolean,disabled:Boolean,contentId:String,ariaLabel:String}),Uoe={select:e=>Ve(e.value)},Yoe=I({name:"ElMentionDropdown"}),Goe=I({...Yoe,props:qoe,emits:Uoe,setup(e,{expose:t,emit:n}){const a=e,o=ge("mention"),{t:l}=gt(),r=V(-1),i=V(),u=V(),c=V(),d=(w,_)=>[o.be("dropdown","item"),o.is("hovering",r.value===_),o.is("disabled",w.disabled||a.disabled)],f=w=>{w.disabled||a.disabled||n("select",w)},p=w=>{r.value=w},v=S(()=>a.disabled||a.options.every(w=>w.disabled)),m=S(()=>a.options[r.value]),h=()=>{m.value&&n("select",m.value)},y=w=>{const{options:_}=a;if(_.length===0||v.value)return;w==="next"?(r.value++,r.value===_.length&&(r.value=0)):w==="prev"&&(r.value--,r.value<0&&(r.value=_.length-1));const C=_[r.value];if(C.disabled){y(w);return}Be(()=>g(C))},g=w=>{var _,C,E,$;const{options:A}=a,O=A.findIndex(L=>L.value===w.value),N=(_=u.value)==null?void 0:_[O];if(N){const L=(E=(C=c.value)==null?void 0:C.querySelector)==null?void 0:E.call(C,`.${o.be("dropdown","wrap")}`);L&&Ep(L,N)}($=i.value)==null||$.handleScroll()};return pe(()=>a.options,()=>{v.value||a.options.length===0?r.value=-1:r.value=0},{immediate:!0}),t({hoveringIndex:r,navigateOptions:y,selectHoverOption:h,hoverOption:m}),(w,_)=>(b(),M("div",{ref_key:"dropdownRef",ref:c,class:T(s(o).b("dropdown"))},[w.$slots.header?(b(),M("div",{key:0,class:T(s(o).be("dropdown","header"))},[ne(w.$slots,"header")],2)):le("v-if",!0),Qe(U(s(oo),{id:w.contentId,ref_key:"scrollbarRef",ref:i,tag:"ul","wrap-class":s(o).be("dropdown","wrap"),"view-class":s(o).be("dropdown","list"),role:"listbox","aria-label":w.ariaLabel,"aria-orientation":"vertical"},{default:J(()=>[(b(!0),M(He,null,pt(w.options,(C,E)=>(b(),M("li",{id:`${w.contentId}-${E}`,ref_for:!0,ref_key:"optionRefs",ref:u,key:E,class:T(d(C,E)),role:"option","aria-disabled":C.disabled||w.disabled||void 0,"aria-selected":r.value===E,onMousemove:$=>p(E),onClick:qe($=>f(C),["stop"])},[ne(w.$slots,"label",{item:C,index:E},()=>{var $;return[x("span",null,Te(($=C.label)!=null?$:C.value),1)]})],42,["id","aria-disabled","aria-selected","onMousemove","onClick"]))),128))]),_:3},8,["id","wrap-class","view-class","aria-label"]),[[_t,w.options.length>0&&!w.loading]]),w.loading?(b(),M("div",{key:1,class:T(s(o).be("dropdown","loading"))},[ne(w.$slots,"loading",{},()=>[ct(Te(s(l)("el.mention.loading")),1)])],2)):le("v-if",!0),w.$slots.footer?(b(),M("div",{key:2,class:T(s(o).be("dropdown","footer"))},[ne(w.$slots,"footer")],2)):le("v-if",!0)],2))}});var Xoe=Me(Goe,[["__file","mention-dropdown.vue"]]);const Zoe=I({name:"ElMention",inheritAttrs:!1}),Joe=I({...Zoe,props:Woe,emits:joe,setup(e,{expose:t,emit:n}){const a=e,o=S(()=>Ea(a,Object.keys(Np))),l=ge("mention"),r=En(),i=gn(),u=V(),c=V(),d=V(),f=V(!1),p=V(),v=V(),m=S(()=>a.showArrow?a.placement:`${a.placement}-start`),h=S(()=>a.showArrow?["bottom","top"]:["bottom-start","top-start"]),y=S(()=>{const{filterOption:R,options:K}=a;return!v.value||!R?K:K.filter(X=>R(v.value.pattern,X))}),g=S(()=>f.value&&(!!y.value.length||a.loading)),k=S(()=>{var R;return`${i.value}-${(R=d.value)==null?void 0:R.hoveringIndex}`}),w=R=>{n(Ue,R),O()},_=R=>{var K,X,F,P;if(!(!("code"in R)||(K=u.value)!=null&&K.isComposing))switch(R.code){case Ae.left:case Ae.right:O();break;case Ae.up:case Ae.down:if(!f.value)return;R.preventDefault(),(X=d.value)==null||X.navigateOptions(R.code===Ae.up?"prev":"next");break;case Ae.enter:case Ae.numpadEnter:if(!f.value)return;R.preventDefault(),(F=d.value)!=null&&F.hoverOption?(P=d.value)==null||P.selectHoverOption():f.value=!1;break;case Ae.esc:if(!f.value)return;R.preventDefault(),f.value=!1;break;case Ae.backspace:if(a.whole&&v.value){const{splitIndex:H,selectionEnd:z,pattern:B,prefixIndex:j,prefix:G}=v.value,te=A();if(!te)return;const Y=te.value,ie=a.options.find(re=>re.value===B);if((ze(a.checkIsWhole)?a.checkIsWhole(B,G):ie)&&H!==-1&&H+1===z){R.preventDefault();const re=Y.slice(0,j)+Y.slice(H+1);n(Ue,re);const W=j;Be(()=>{te.selectionStart=W,te.selectionEnd=W,L()})}}}},{wrapperRef:C}=il(u,{beforeFocus(){return r.value},afterFocus(){O()},beforeBlur(R){var K;return(K=c.value)==null?void 0:K.isFocusInsideContent(R)},afterBlur(){f.value=!1}}),E=()=>{O()},$=R=>{if(!v.value)return;const K=A();if(!K)return;const X=K.value,{split:F}=a,P=X.slice(v.value.end),H=P.startsWith(F),z=`${R.value}${H?"":F}`,B=X.slice(0,v.value.start)+z+P;n(Ue,B),n("select",R,v.value.prefix);const j=v.value.start+z.length+(H?1:0);Be(()=>{K.selectionStart=j,K.selectionEnd=j,K.focus(),L()})},A=()=>{var R,K;return a.type==="textarea"?(R=u.value)==null?void 0:R.textarea:(K=u.value)==null?void 0:K.input},O=()=>{setTimeout(()=>{N(),L(),Be(()=>{var R;return(R=c.value)==null?void 0:R.updatePopper()})},0)},N=()=>{const R=A();if(!R)return;const K=Koe(R),X=R.getBoundingClientRect(),F=u.value.$el.getBoundingClientRect();p.value={position:"absolute",width:0,height:`${K.height}px`,left:`${K.left+X.left-F.left}px`,top:`${K.top+X.top-F.top}px`}},L=()=>{const R=A();if(document.activeElement!==R){f.value=!1;return}const{prefix:K,split:X}=a;if(v.value=Foe(R,K,X),v.value&&v.value.splitIndex===-1){f.value=!0,n("search",v.value.pattern,v.value.prefix);return}f.value=!1};return t({input:u,tooltip:c,dropdownVisible:g}),(R,K)=>(b(),M("div",{ref_key:"wrapperRef",ref:C,class:T([s(l).b(),s(l).is("disabled",s(r))])},[U(s(An),st(st(s(o),R.$attrs),{ref_key:"elInputRef",ref:u,"model-value":R.modelValue,disabled:s(r),role:s(g)?"combobox":void 0,"aria-activedescendant":s(g)?s(k)||"":void 0,"aria-controls":s(g)?s(i):void 0,"aria-expanded":s(g)||void 0,"aria-label":R.ariaLabel,"aria-autocomplete":s(g)?"none":void 0,"aria-haspopup":s(g)?"listbox":void 0,onInput:w,onKeydown:_,onMousedown:E}),sa({_:2},[pt(R.$slots,(X,F)=>({name:F,fn:J(P=>[ne(R.$slots,F,aa(Xa(P)))])}))]),1040,["model-value","disabled","role","aria-activedescendant","aria-controls","aria-expanded","aria-label","aria-autocomplete","aria-haspopup"]),U(s(Sn),{ref_key:"tooltipRef",ref:c,visible:s(g),"popper-class":[s(l).e("popper"),R.popperClass],"popper-options":R.popperOptions,placement:s(m),"fallback-placements":s(h),effect:"light",pure:"",offset:R.offset,"show-arrow":R.showArrow},{default:J(()=>[x("div",{style:Fe(p.value)},null,4)]),content:J(()=>{var X;return[U(Xoe,{ref_key:"dropdownRef",ref:d,options:s(y),disabled:s(r),loading:R.loading,"content-id":s(i),"aria-label":R.ariaLabel,onSelect:$,onClick:qe((X=u.value)==null?void 0:X.focus,["stop"])},sa({_:2},[pt(R.$slots,(F,P)=>({name:P,fn:J(H=>[ne(R.$slots,P,aa(Xa(H)))])}))]),1032,["options","disabled","loading","content-id","aria-label","onClick"])]}),_:3},8,["visible","popper-class","popper-options","placement","fallback-placements","offset","show-arrow"])],2))}});var Qoe=Me(Joe,[["__file","mention.vue"]]);const ele=Ze(Qoe);var tle=[Hx,nV,Xz,Lee,nH,uH,R3,wH,CH,dn,F3,mD,wD,BD,zD,zF,i8,jF,ba,QD,Q3,XF,fK,pK,td,zK,p8,QK,eW,tW,nW,aW,Tj,Bj,zj,Zj,L8,sq,Xq,Zq,Jq,j8,ZU,JU,Le,uY,Y8,An,G8,kY,OY,GY,XY,ZY,JY,oG,YG,eX,uX,I3,oy,r8,fF,dF,_X,kX,IX,oo,Vl,rc,TG,uZ,yZ,_Z,BZ,KZ,gy,eJ,uJ,cJ,mJ,xQ,MQ,Ree,Zee,Jee,Pr,ate,YW,ute,vte,hte,Sn,Sne,Lne,yc,tae,yae,Dae,Zae,Coe,Soe,Noe,Poe,Hoe,ele];const da="ElInfiniteScroll",nle=50,ale=200,ole=0,lle={delay:{type:Number,default:ale},distance:{type:Number,default:ole},disabled:{type:Boolean,default:!1},immediate:{type:Boolean,default:!0}},j2=(e,t)=>Object.entries(lle).reduce((n,[a,o])=>{var l,r;const{type:i,default:u}=o,c=e.getAttribute(`infinite-scroll-${a}`);let d=(r=(l=t[c])!=null?l:c)!=null?r:u;return d=d==="false"?!1:d,d=i(d),n[a]=Number.isNaN(d)?u:d,n},{}),H_=e=>{const{observer:t}=e[da];t&&(t.disconnect(),delete e[da].observer)},rle=(e,t)=>{const{container:n,containerEl:a,instance:o,observer:l,lastScrollTop:r}=e[da],{disabled:i,distance:u}=j2(e,o),{clientHeight:c,scrollHeight:d,scrollTop:f}=a,p=f-r;if(e[da].lastScrollTop=f,l||i||p<0)return;let v=!1;if(n===e)v=d-(c+f)<=u;else{const{clientTop:m,scrollHeight:h}=e,y=Wf(e,a);v=f+c>=y+m+h-u}v&&t.call(o)};function sf(e,t){const{containerEl:n,instance:a}=e[da],{disabled:o}=j2(e,a);o||n.clientHeight===0||(n.scrollHeight<=n.clientHeight?t.call(a):H_(e))}const sle={async mounted(e,t){const{instance:n,value:a}=t;ze(a)||nn(da,"'v-infinite-scroll' binding value must be a function"),await Be();const{delay:o,immediate:l}=j2(e,n),r=kp(e,!0),i=r===window?document.documentElement:r,u=Tl(rle.bind(null,e,a),o);if(r){if(e[da]={instance:n,container:r,containerEl:i,delay:o,cb:a,onScroll:u,lastScrollTop:i.scrollTop},l){const c=new MutationObserver(Tl(sf.bind(null,e,a),nle));e[da].observer=c,c.observe(e,{childList:!0,subtree:!0}),sf(e,a)}r.addEventListener("scroll",u)}},unmounted(e){if(!e[da])return;const{container:t,onScroll:n}=e[da];t==null||t.removeEventListener("scroll",n),H_(e)},async updated(e){if(!e[da])await Be();else{const{containerEl:t,cb:n,observer:a}=e[da];t.clientHeight&&a&&sf(e,n)}}},N0=sle;N0.install=e=>{e.directive("InfiniteScroll",N0)};const ile=N0;function ule(e){let t;const n=V(!1),a=Ct({...e,originalPosition:"",originalOverflow:"",visible:!1});function o(p){a.text=p}function l(){const p=a.parent,v=f.ns;if(!p.vLoadingAddClassList){let m=p.getAttribute("loading-number");m=Number.parseInt(m)-1,m?p.setAttribute("loading-number",m.toString()):(Nn(p,v.bm("parent","relative")),p.removeAttribute("loading-number")),Nn(p,v.bm("parent","hidden"))}r(),d.unmount()}function r(){var p,v;(v=(p=f.$el)==null?void 0:p.parentNode)==null||v.removeChild(f.$el)}function i(){var p;e.beforeClose&&!e.beforeClose()||(n.value=!0,clearTimeout(t),t=setTimeout(u,400),a.visible=!1,(p=e.closed)==null||p.call(e))}function u(){if(!n.value)return;const p=a.parent;n.value=!1,p.vLoadingAddClassList=void 0,l()}const d=g4(I({name:"ElLoading",setup(p,{expose:v}){const{ns:m,zIndex:h}=jc("loading");return v({ns:m,zIndex:h}),()=>{const y=a.spinner||a.svg,g=We("svg",{class:"circular",viewBox:a.svgViewBox?a.svgViewBox:"0 0 50 50",...y?{innerHTML:y}:{}},[We("circle",{class:"path",cx:"25",cy:"25",r:"20",fill:"none"})]),k=a.text?We("p",{class:m.b("text")},[a.text]):void 0;return We(vn,{name:m.b("fade"),onAfterLeave:u},{default:J(()=>[Qe(U("div",{style:{backgroundColor:a.background||""},class:[m.b("mask"),a.customClass,a.fullscreen?"is-fullscreen":""]},[We("div",{class:m.b("spinner")},[g,k])]),[[_t,a.visible]])])})}}})),f=d.mount(document.createElement("div"));return{...pn(a),setText:o,removeElLoadingChild:r,close:i,handleAfterLeave:u,vm:f,get $el(){return f.$el}}}let iu;const P0=function(e={}){if(!mt)return;const t=cle(e);if(t.fullscreen&&iu)return iu;const n=ule({...t,closed:()=>{var o;(o=t.closed)==null||o.call(t),t.fullscreen&&(iu=void 0)}});dle(t,t.parent,n),zm(t,t.parent,n),t.parent.vLoadingAddClassList=()=>zm(t,t.parent,n);let a=t.parent.getAttribute("loading-number");return a?a=`${Number.parseInt(a)+1}`:a="1",t.parent.setAttribute("loading-number",a),t.parent.appendChild(n.$el),Be(()=>n.visible.value=t.visible),t.fullscreen&&(iu=n),n},cle=e=>{var t,n,a,o;let l;return Ve(e.target)?l=(t=document.querySelector(e.target))!=null?t:document.body:l=e.target||document.body,{parent:l===document.body||e.body?document.body:l,background:e.background||"",svg:e.svg||"",svgViewBox:e.svgViewBox||"",spinner:e.spinner||!1,text:e.text||"",fullscreen:l===document.body&&((n=e.fullscreen)!=null?n:!0),lock:(a=e.lock)!=null?a:!1,customClass:e.customClass||"",visible:(o=e.visible)!=null?o:!0,beforeClose:e.beforeClose,closed:e.closed,target:l}},dle=async(e,t,n)=>{const{nextZIndex:a}=n.vm.zIndex||n.vm._.exposed.zIndex,o={};if(e.fullscreen)n.originalPosition.value=ho(document.body,"position"),n.originalOverflow.value=ho(document.body,"overflow"),o.zIndex=a();else if(e.parent===document.body){n.originalPosition.value=ho(document.body,"position"),await Be();for(const l of["top","left"]){const r=l==="top"?"scrollTop":"scrollLeft";o[l]=`${e.target.getBoundingClientRect()[l]+document.body[r]+document.documentElement[r]-Number.parseInt(ho(document.body,`margin-${l}`),10)}px`}for(const l of["height","width"])o[l]=`${e.target.getBoundingClientRect()[l]}px`}else n.originalPosition.value=ho(t,"position");for(const[l,r]of Object.entries(o))n.$el.style[l]=r},zm=(e,t,n)=>{const a=n.vm.ns||n.vm._.exposed.ns;["absolute","fixed","sticky"].includes(n.originalPosition.value)?Nn(t,a.bm("parent","relative")):fa(t,a.bm("parent","relative")),e.fullscreen&&e.lock?fa(t,a.bm("parent","hidden")):Nn(t,a.bm("parent","hidden"))},Bu=Symbol("ElLoading"),Hm=(e,t)=>{var n,a,o,l;const r=t.instance,i=p=>nt(t.value)?t.value[p]:void 0,u=p=>{const v=Ve(p)&&(r==null?void 0:r[p])||p;return v&&V(v)},c=p=>u(i(p)||e.getAttribute(`element-loading-${Mo(p)}`)),d=(n=i("fullscreen"))!=null?n:t.modifiers.fullscreen,f={text:c("text"),svg:c("svg"),svgViewBox:c("svgViewBox"),spinner:c("spinner"),background:c("background"),customClass:c("customClass"),fullscreen:d,target:(a=i("target"))!=null?a:d?void 0:e,body:(o=i("body"))!=null?o:t.modifiers.body,lock:(l=i("lock"))!=null?l:t.modifiers.lock};e[Bu]={options:f,instance:P0(f)}},fle=(e,t)=>{for(const n of Object.keys(t))Rt(t[n])&&(t[n].value=e[n])},Dm={mounted(e,t){t.value&&Hm(e,t)},updated(e,t){const n=e[Bu];t.oldValue!==t.value&&(t.value&&!t.oldValue?Hm(e,t):t.value&&t.oldValue?nt(t.value)&&fle(t.value,n.options):n==null||n.instance.close())},unmounted(e){var t;(t=e[Bu])==null||t.instance.close(),e[Bu]=null}},ple={install(e){e.directive("loading",Dm),e.config.globalProperties.$loading=P0},directive:Dm,service:P0},D_=["success","info","warning","error"],Mn=Ft({customClass:"",center:!1,dangerouslyUseHTMLString:!1,duration:3e3,icon:void 0,id:"",message:"",onClose:void 0,showClose:!1,type:"info",plain:!1,offset:16,zIndex:0,grouping:!1,repeatNum:1,appendTo:mt?document.body:void 0}),vle=Ee({customClass:{type:String,default:Mn.customClass},center:{type:Boolean,default:Mn.center},dangerouslyUseHTMLString:{type:Boolean,default:Mn.dangerouslyUseHTMLString},duration:{type:Number,default:Mn.duration},icon:{type:Tt,default:Mn.icon},id:{type:String,default:Mn.id},message:{type:Q([String,Object,Function]),default:Mn.message},onClose:{type:Q(Function),default:Mn.onClose},showClose:{type:Boolean,default:Mn.showClose},type:{type:String,values:D_,default:Mn.type},plain:{type:Boolean,default:Mn.plain},offset:{type:Number,default:Mn.offset},zIndex:{type:Number,default:Mn.zIndex},grouping:{type:Boolean,default:Mn.grouping},repeatNum:{type:Number,default:Mn.repeatNum}}),hle={destroy:()=>!0},xa=j0([]),mle=e=>{const t=xa.findIndex(o=>o.id===e),n=xa[t];let a;return t>0&&(a=xa[t-1]),{current:n,prev:a}},gle=e=>{const{prev:t}=mle(e);return t?t.vm.exposed.bottom.value:0},yle=(e,t)=>xa.findIndex(a=>a.id===e)>0?16:t,_le=I({name:"ElMessage"}),ble=I({..._le,props:vle,emits:hle,setup(e,{expose:t}){const n=e,{Close:a}=Ap,{ns:o,zIndex:l}=jc("message"),{currentZIndex:r,nextZIndex:i}=l,u=V(),c=V(!1),d=V(0);let f;const p=S(()=>n.type?n.type==="error"?"danger":n.type:"info"),v=S(()=>{const $=n.type;return{[o.bm("icon",$)]:$&&nl[$]}}),m=S(()=>n.icon||nl[n.type]||""),h=S(()=>gle(n.id)),y=S(()=>yle(n.id,n.offset)+h.value),g=S(()=>d.value+y.value),k=S(()=>({top:`${y.value}px`,zIndex:r.value}));function w(){n.duration!==0&&({stop:f}=Rl(()=>{C()},n.duration))}function _(){f==null||f()}function C(){c.value=!1}function E({code:$}){$===Ae.esc&&C()}return at(()=>{w(),i(),c.value=!0}),pe(()=>n.repeatNum,()=>{_(),w()}),Mt(document,"keydown",E),Dt(u,()=>{d.value=u.value.getBoundingClientRect().height}),t({visible:c,bottom:g,close:C}),($,A)=>(b(),ue(vn,{name:s(o).b("fade"),onBeforeLeave:$.onClose,onAfterLeave:O=>$.$emit("destroy"),persisted:""},{default:J(()=>[Qe(x("div",{id:$.id,ref_key:"messageRef",ref:u,class:T([s(o).b(),{[s(o).m($.type)]:$.type},s(o).is("center",$.center),s(o).is("closable",$.showClose),s(o).is("plain",$.plain),$.customClass]),style:Fe(s(k)),role:"alert",onMouseenter:_,onMouseleave:w},[$.repeatNum>1?(b(),ue(s(R3),{key:0,value:$.repeatNum,type:s(p),class:T(s(o).e("badge"))},null,8,["value","type","class"])):le("v-if",!0),s(m)?(b(),ue(s(Le),{key:1,class:T([s(o).e("icon"),s(v)])},{default:J(()=>[(b(),ue(rt(s(m))))]),_:1},8,["class"])):le("v-if",!0),ne($.$slots,"default",{},()=>[$.dangerouslyUseHTMLString?(b(),M(He,{key:1},[le(" Caution here, message could've been compromised, never use user's input as message "),x("p",{class:T(s(o).e("content")),innerHTML:$.message},null,10,["innerHTML"])],2112)):(b(),M("p",{key:0,class:T(s(o).e("content"))},Te($.message),3))]),$.showClose?(b(),ue(s(Le),{key:2,class:T(s(o).e("closeBtn")),onClick:qe(C,["stop"])},{default:J(()=>[U(s(a))]),_:1},8,["class","onClick"])):le("v-if",!0)],46,["id"]),[[_t,c.value]])]),_:3},8,["name","onBeforeLeave","onAfterLeave"]))}});var wle=Me(ble,[["__file","message.vue"]]);let Cle=1;const F_=e=>{const t=!e||Ve(e)||Bt(e)||ze(e)?{message:e}:e,n={...Mn,...t};if(!n.appendTo)n.appendTo=document.body;else if(Ve(n.appendTo)){let a=document.querySelector(n.appendTo);Kn(a)||(a=document.body),n.appendTo=a}return It(Sa.grouping)&&!n.grouping&&(n.grouping=Sa.grouping),Ke(Sa.duration)&&n.duration===3e3&&(n.duration=Sa.duration),Ke(Sa.offset)&&n.offset===16&&(n.offset=Sa.offset),It(Sa.showClose)&&!n.showClose&&(n.showClose=Sa.showClose),n},Sle=e=>{const t=xa.indexOf(e);if(t===-1)return;xa.splice(t,1);const{handler:n}=e;n.close()},kle=({appendTo:e,...t},n)=>{const a=`message_${Cle++}`,o=t.onClose,l=document.createElement("div"),r={...t,id:a,onClose:()=>{o==null||o(),Sle(d)},onDestroy:()=>{Qo(null,l)}},i=U(wle,r,ze(r.message)||Bt(r.message)?{default:ze(r.message)?r.message:()=>r.message}:null);i.appContext=n||Fr._context,Qo(i,l),e.appendChild(l.firstElementChild);const u=i.component,d={id:a,vnode:i,vm:u,handler:{close:()=>{u.exposed.visible.value=!1}},props:i.component.props};return d},Fr=(e={},t)=>{if(!mt)return{close:()=>{}};const n=F_(e);if(n.grouping&&xa.length){const o=xa.find(({vnode:l})=>{var r;return((r=l.props)==null?void 0:r.message)===n.message});if(o)return o.props.repeatNum+=1,o.props.type=n.type,o.handler}if(Ke(Sa.max)&&xa.length>=Sa.max)return{close:()=>{}};const a=kle(n,t);return xa.push(a),a.handler};D_.forEach(e=>{Fr[e]=(t={},n)=>{const a=F_(t);return Fr({...a,type:e},n)}});function Ele(e){for(const t of xa)(!e||e===t.props.type)&&t.handler.close()}Fr.closeAll=Ele;Fr._context=null;const xle=B6(Fr,"$message"),R0="_trap-focus-children",xl=[],Fm=e=>{if(xl.length===0)return;const t=xl[xl.length-1][R0];if(t.length>0&&e.code===Ae.tab){if(t.length===1){e.preventDefault(),document.activeElement!==t[0]&&t[0].focus();return}const n=e.shiftKey,a=e.target===t[0],o=e.target===t[t.length-1];a&&n&&(e.preventDefault(),t[t.length-1].focus()),o&&!n&&(e.preventDefault(),t[0].focus())}},Mle={beforeMount(e){e[R0]=qh(e),xl.push(e),xl.length<=1&&document.addEventListener("keydown",Fm)},updated(e){Be(()=>{e[R0]=qh(e)})},unmounted(){xl.shift(),xl.length===0&&document.removeEventListener("keydown",Fm)}},Tle=I({name:"ElMessageBox",directives:{TrapFocus:Mle},components:{ElButton:dn,ElFocusTrap:Qr,ElInput:An,ElOverlay:s2,ElIcon:Le,...Ap},inheritAttrs:!1,props:{buttonSize:{type:String,validator:_y},modal:{type:Boolean,default:!0},lockScroll:{type:Boolean,default:!0},showClose:{type:Boolean,default:!0},closeOnClickModal:{type:Boolean,default:!0},closeOnPressEscape:{type:Boolean,default:!0},closeOnHashChange:{type:Boolean,default:!0},center:Boolean,draggable:Boolean,overflow:Boolean,roundButton:{default:!1,type:Boolean},container:{type:String,default:"body"},boxType:{type:String,default:""}},emits:["vanish","action"],setup(e,{emit:t}){const{locale:n,zIndex:a,ns:o,size:l}=jc("message-box",S(()=>e.buttonSize)),{t:r}=n,{nextZIndex:i}=a,u=V(!1),c=Ct({autofocus:!0,beforeClose:null,callback:null,cancelButtonText:"",cancelButtonClass:"",confirmButtonText:"",confirmButtonClass:"",customClass:"",customStyle:{},dangerouslyUseHTMLString:!1,distinguishCancelAndClose:!1,icon:"",closeIcon:"",inputPattern:null,inputPlaceholder:"",inputType:"text",inputValue:"",inputValidator:void 0,inputErrorMessage:"",message:"",modalFade:!0,modalClass:"",showCancelButton:!1,showConfirmButton:!0,type:"",title:void 0,showInput:!1,action:"",confirmButtonLoading:!1,cancelButtonLoading:!1,confirmButtonLoadingIcon:yo(Na),cancelButtonLoadingIcon:yo(Na),confirmButtonDisabled:!1,editorErrorMessage:"",validateError:!1,zIndex:i()}),d=S(()=>{const P=c.type;return{[o.bm("icon",P)]:P&&nl[P]}}),f=gn(),p=gn(),v=S(()=>{const P=c.type;return c.icon||P&&nl[P]||""}),m=S(()=>!!c.message),h=V(),y=V(),g=V(),k=V(),w=V(),_=S(()=>c.confirmButtonClass);pe(()=>c.inputValue,async P=>{await Be(),e.boxType==="prompt"&&P&&R()},{immediate:!0}),pe(()=>u.value,P=>{var H,z;P&&(e.boxType!=="prompt"&&(c.autofocus?g.value=(z=(H=w.value)==null?void 0:H.$el)!=null?z:h.value:g.value=h.value),c.zIndex=i()),e.boxType==="prompt"&&(P?Be().then(()=>{var B;k.value&&k.value.$el&&(c.autofocus?g.value=(B=K())!=null?B:h.value:g.value=h.value)}):(c.editorErrorMessage="",c.validateError=!1))});const C=S(()=>e.draggable),E=S(()=>e.overflow);A8(h,y,C,E),at(async()=>{await Be(),e.closeOnHashChange&&window.addEventListener("hashchange",$)}),At(()=>{e.closeOnHashChange&&window.removeEventListener("hashchange",$)});function $(){u.value&&(u.value=!1,Be(()=>{c.action&&t("action",c.action)}))}const A=()=>{e.closeOnClickModal&&L(c.distinguishCancelAndClose?"close":"cancel")},O=r2(A),N=P=>{if(c.inputType!=="textarea")return P.preventDefault(),L("confirm")},L=P=>{var H;e.boxType==="prompt"&&P==="confirm"&&!R()||(c.action=P,c.beforeClose?(H=c.beforeClose)==null||H.call(c,P,c,$):$())},R=()=>{if(e.boxType==="prompt"){const P=c.inputPattern;if(P&&!P.test(c.inputValue||""))return c.editorErrorMessage=c.inputErrorMessage||r("el.messagebox.error"),c.validateError=!0,!1;const H=c.inputValidator;if(ze(H)){const z=H(c.inputValue);if(z===!1)return c.editorErrorMessage=c.inputErrorMessage||r("el.messagebox.error"),c.validateError=!0,!1;if(Ve(z))return c.editorErrorMessage=z,c.validateError=!0,!1}}return c.editorErrorMessage="",c.validateError=!1,!0},K=()=>{var P,H;const z=(P=k.value)==null?void 0:P.$refs;return(H=z==null?void 0:z.input)!=null?H:z==null?void 0:z.textarea},X=()=>{L("close")},F=()=>{e.closeOnPressEscape&&X()};return e.lockScroll&&i2(u),{...pn(c),ns:o,overlayEvent:O,visible:u,hasMessage:m,typeClass:d,contentId:f,inputId:p,btnSize:l,iconComponent:v,confirmButtonClasses:_,rootRef:h,focusStartRef:g,headerRef:y,inputRef:k,confirmRef:w,doClose:$,handleClose:X,onCloseRequested:F,handleWrapperClick:A,handleInputEnter:N,handleAction:L,t:r}}});function $le(e,t,n,a,o,l){const r=ut("el-icon"),i=ut("el-input"),u=ut("el-button"),c=ut("el-focus-trap"),d=ut("el-overlay");return b(),ue(vn,{name:"fade-in-linear",onAfterLeave:f=>e.$emit("vanish"),persisted:""},{default:J(()=>[Qe(U(d,{"z-index":e.zIndex,"overlay-class":[e.ns.is("message-box"),e.modalClass],mask:e.modal},{default:J(()=>[x("div",{role:"dialog","aria-label":e.title,"aria-modal":"true","aria-describedby":e.showInput?void 0:e.contentId,class:T(`${e.ns.namespace.value}-overlay-message-box`),onClick:e.overlayEvent.onClick,onMousedown:e.overlayEvent.onMousedown,onMouseup:e.overlayEvent.onMouseup},[U(c,{loop:"",trapped:e.visible,"focus-trap-el":e.rootRef,"focus-start-el":e.focusStartRef,onReleaseRequested:e.onCloseRequested},{default:J(()=>[x("div",{ref:"rootRef",class:T([e.ns.b(),e.customClass,e.ns.is("draggable",e.draggable),{[e.ns.m("center")]:e.center}]),style:Fe(e.customStyle),tabindex:"-1",onClick:qe(()=>{},["stop"])},[e.title!==null&&e.title!==void 0?(b(),M("div",{key:0,ref:"headerRef",class:T([e.ns.e("header"),{"show-close":e.showClose}])},[x("div",{class:T(e.ns.e("title"))},[e.iconComponent&&e.center?(b(),ue(r,{key:0,class:T([e.ns.e("status"),e.typeClass])},{default:J(()=>[(b(),ue(rt(e.iconComponent)))]),_:1},8,["class"])):le("v-if",!0),x("span",null,Te(e.title),1)],2),e.showClose?(b(),M("button",{key:0,type:"button",class:T(e.ns.e("headerbtn")),"aria-label":e.t("el.messagebox.close"),onClick:f=>e.handleAction(e.distinguishCancelAndClose?"close":"cancel"),onKeydown:Ot(qe(f=>e.handleAction(e.distinguishCancelAndClose?"close":"cancel"),["prevent"]),["enter"])},[U(r,{class:T(e.ns.e("close"))},{default:J(()=>[(b(),ue(rt(e.closeIcon||"close")))]),_:1},8,["class"])],42,["aria-label","onClick","onKeydown"])):le("v-if",!0)],2)):le("v-if",!0),x("div",{id:e.contentId,class:T(e.ns.e("content"))},[x("div",{class:T(e.ns.e("container"))},[e.iconComponent&&!e.center&&e.hasMessage?(b(),ue(r,{key:0,class:T([e.ns.e("status"),e.typeClass])},{default:J(()=>[(b(),ue(rt(e.iconComponent)))]),_:1},8,["class"])):le("v-if",!0),e.hasMessage?(b(),M("div",{key:1,class:T(e.ns.e("message"))},[ne(e.$slots,"default",{},()=>[e.dangerouslyUseHTMLString?(b(),ue(rt(e.showInput?"label":"p"),{key:1,for:e.showInput?e.inputId:void 0,innerHTML:e.message},null,8,["for","innerHTML"])):(b(),ue(rt(e.showInput?"label":"p"),{key:0,for:e.showInput?e.inputId:void 0},{default:J(()=>[ct(Te(e.dangerouslyUseHTMLString?"":e.message),1)]),_:1},8,["for"]))])],2)):le("v-if",!0)],2),Qe(x("div",{class:T(e.ns.e("input"))},[U(i,{id:e.inputId,ref:"inputRef",modelValue:e.inputValue,"onUpdate:modelValue":f=>e.inputValue=f,type:e.inputType,placeholder:e.inputPlaceholder,"aria-invalid":e.validateError,class:T({invalid:e.validateError}),onKeydown:Ot(e.handleInputEnter,["enter"])},null,8,["id","modelValue","onUpdate:modelValue","type","placeholder","aria-invalid","class","onKeydown"]),x("div",{class:T(e.ns.e("errormsg")),style:Fe({visibility:e.editorErrorMessage?"visible":"hidden"})},Te(e.editorErrorMessage),7)],2),[[_t,e.showInput]])],10,["id"]),x("div",{class:T(e.ns.e("btns"))},[e.showCancelButton?(b(),ue(u,{key:0,loading:e.cancelButtonLoading,"loading-icon":e.cancelButtonLoadingIcon,class:T([e.cancelButtonClass]),round:e.roundButton,size:e.btnSize,onClick:f=>e.handleAction("cancel"),onKeydown:Ot(qe(f=>e.handleAction("cancel"),["prevent"]),["enter"])},{default:J(()=>[ct(Te(e.cancelButtonText||e.t("el.messagebox.cancel")),1)]),_:1},8,["loading","loading-icon","class","round","size","onClick","onKeydown"])):le("v-if",!0),Qe(U(u,{ref:"confirmRef",type:"primary",loading:e.confirmButtonLoading,"loading-icon":e.confirmButtonLoadingIcon,class:T([e.confirmButtonClasses]),round:e.roundButton,disabled:e.confirmButtonDisabled,size:e.btnSize,onClick:f=>e.handleAction("confirm"),onKeydown:Ot(qe(f=>e.handleAction("confirm"),["prevent"]),["enter"])},{default:J(()=>[ct(Te(e.confirmButtonText||e.t("el.messagebox.confirm")),1)]),_:1},8,["loading","loading-icon","class","round","disabled","size","onClick","onKeydown"]),[[_t,e.showConfirmButton]])],2)],14,["onClick"])]),_:3},8,["trapped","focus-trap-el","focus-start-el","onReleaseRequested"])],42,["aria-label","aria-describedby","onClick","onMousedown","onMouseup"])]),_:3},8,["z-index","overlay-class","mask"]),[[_t,e.visible]])]),_:3},8,["onAfterLeave"])}var Ole=Me(Tle,[["render",$le],["__file","index.vue"]]);const hi=new Map,Ile=e=>{let t=document.body;return e.appendTo&&(Ve(e.appendTo)&&(t=document.querySelector(e.appendTo)),Kn(e.appendTo)&&(t=e.appendTo),Kn(t)||(t=document.body)),t},Ale=(e,t,n=null)=>{const a=U(Ole,e,ze(e.message)||Bt(e.message)?{default:ze(e.message)?e.message:()=>e.message}:null);return a.appContext=n,Qo(a,t),Ile(e).appendChild(t.firstElementChild),a.component},Nle=()=>document.createElement("div"),Ple=(e,t)=>{const n=Nle();e.onVanish=()=>{Qo(null,n),hi.delete(o)},e.onAction=l=>{const r=hi.get(o);let i;e.showInput?i={value:o.inputValue,action:l}:i=l,e.callback?e.callback(i,a.proxy):l==="cancel"||l==="close"?e.distinguishCancelAndClose&&l!=="cancel"?r.reject("close"):r.reject("cancel"):r.resolve(i)};const a=Ale(e,n,t),o=a.proxy;for(const l in e)yt(e,l)&&!yt(o.$props,l)&&(l==="closeIcon"&&nt(e[l])?o[l]=yo(e[l]):o[l]=e[l]);return o.visible=!0,o};function os(e,t=null){if(!mt)return Promise.reject();let n;return Ve(e)||Bt(e)?e={message:e}:n=e.callback,new Promise((a,o)=>{const l=Ple(e,t??os._context);hi.set(l,{options:e,callback:n,resolve:a,reject:o})})}const Rle=["alert","confirm","prompt"],Lle={alert:{closeOnPressEscape:!1,closeOnClickModal:!1},confirm:{showCancelButton:!0},prompt:{showCancelButton:!0,showInput:!0}};Rle.forEach(e=>{os[e]=Vle(e)});function Vle(e){return(t,n,a,o)=>{let l="";return nt(n)?(a=n,l=""):bt(n)?l="":l=n,os(Object.assign({title:l,message:t,type:"",...Lle[e]},a,{boxType:e}),o)}}os.close=()=>{hi.forEach((e,t)=>{t.doClose()}),hi.clear()};os._context=null;const Do=os;Do.install=e=>{Do._context=e._context,e.config.globalProperties.$msgbox=Do,e.config.globalProperties.$messageBox=Do,e.config.globalProperties.$alert=Do.alert,e.config.globalProperties.$confirm=Do.confirm,e.config.globalProperties.$prompt=Do.prompt};const Ble=Do,K_=["success","info","warning","error"],zle=Ee({customClass:{type:String,default:""},dangerouslyUseHTMLString:Boolean,duration:{type:Number,default:4500},icon:{type:Tt},id:{type:String,default:""},message:{type:Q([String,Object,Function]),default:""},offset:{type:Number,default:0},onClick:{type:Q(Function),default:()=>{}},onClose:{type:Q(Function),required:!0},position:{type:String,values:["top-right","top-left","bottom-right","bottom-left"],default:"top-right"},showClose:{type:Boolean,default:!0},title:{type:String,default:""},type:{type:String,values:[...K_,""],default:""},zIndex:Number}),Hle={destroy:()=>!0},Dle=I({name:"ElNotification"}),Fle=I({...Dle,props:zle,emits:Hle,setup(e,{expose:t}){const n=e,{ns:a,zIndex:o}=jc("notification"),{nextZIndex:l,currentZIndex:r}=o,{Close:i}=Ip,u=V(!1);let c;const d=S(()=>{const w=n.type;return w&&nl[n.type]?a.m(w):""}),f=S(()=>n.type&&nl[n.type]||n.icon),p=S(()=>n.position.endsWith("right")?"right":"left"),v=S(()=>n.position.startsWith("top")?"top":"bottom"),m=S(()=>{var w;return{[v.value]:`${n.offset}px`,zIndex:(w=n.zIndex)!=null?w:r.value}});function h(){n.duration>0&&({stop:c}=Rl(()=>{u.value&&g()},n.duration))}function y(){c==null||c()}function g(){u.value=!1}function k({code:w}){w===Ae.delete||w===Ae.backspace?y():w===Ae.esc?u.value&&g():h()}return at(()=>{h(),l(),u.value=!0}),Mt(document,"keydown",k),t({visible:u,close:g}),(w,_)=>(b(),ue(vn,{name:s(a).b("fade"),onBeforeLeave:w.onClose,onAfterLeave:C=>w.$emit("destroy"),persisted:""},{default:J(()=>[Qe(x("div",{id:w.id,class:T([s(a).b(),w.customClass,s(p)]),style:Fe(s(m)),role:"alert",onMouseenter:y,onMouseleave:h,onClick:w.onClick},[s(f)?(b(),ue(s(Le),{key:0,class:T([s(a).e("icon"),s(d)])},{default:J(()=>[(b(),ue(rt(s(f))))]),_:1},8,["class"])):le("v-if",!0),x("div",{class:T(s(a).e("group"))},[x("h2",{class:T(s(a).e("title")),textContent:Te(w.title)},null,10,["textContent"]),Qe(x("div",{class:T(s(a).e("content")),style:Fe(w.title?void 0:{margin:0})},[ne(w.$slots,"default",{},()=>[w.dangerouslyUseHTMLString?(b(),M(He,{key:1},[le(" Caution here, message could've been compromised, never use user's input as message "),x("p",{innerHTML:w.message},null,8,["innerHTML"])],2112)):(b(),M("p",{key:0},Te(w.message),1))])],6),[[_t,w.message]]),w.showClose?(b(),ue(s(Le),{key:0,class:T(s(a).e("closeBtn")),onClick:qe(g,["stop"])},{default:J(()=>[U(s(i))]),_:1},8,["class","onClick"])):le("v-if",!0)],2)],46,["id","onClick"]),[[_t,u.value]])]),_:3},8,["name","onBeforeLeave","onAfterLeave"]))}});var Kle=Me(Fle,[["__file","notification.vue"]]);const _c={"top-left":[],"top-right":[],"bottom-left":[],"bottom-right":[]},L0=16;let Wle=1;const Kr=function(e={},t){if(!mt)return{close:()=>{}};(Ve(e)||Bt(e))&&(e={message:e});const n=e.position||"top-right";let a=e.offset||0;_c[n].forEach(({vm:d})=>{var f;a+=(((f=d.el)==null?void 0:f.offsetHeight)||0)+L0}),a+=L0;const o=`notification_${Wle++}`,l=e.onClose,r={...e,offset:a,id:o,onClose:()=>{jle(o,n,l)}};let i=document.body;Kn(e.appendTo)?i=e.appendTo:Ve(e.appendTo)&&(i=document.querySelector(e.appendTo)),Kn(i)||(i=document.body);const u=document.createElement("div"),c=U(Kle,r,ze(r.message)?r.message:Bt(r.message)?()=>r.message:null);return c.appContext=bt(t)?Kr._context:t,c.props.onDestroy=()=>{Qo(null,u)},Qo(c,u),_c[n].push({vm:c}),i.appendChild(u.firstElementChild),{close:()=>{c.component.exposed.visible.value=!1}}};K_.forEach(e=>{Kr[e]=(t={},n)=>((Ve(t)||Bt(t))&&(t={message:t}),Kr({...t,type:e},n))});function jle(e,t,n){const a=_c[t],o=a.findIndex(({vm:c})=>{var d;return((d=c.component)==null?void 0:d.props.id)===e});if(o===-1)return;const{vm:l}=a[o];if(!l)return;n==null||n(l);const r=l.el.offsetHeight,i=t.split("-")[0];a.splice(o,1);const u=a.length;if(!(u<1))for(let c=o;c<u;c++){const{el:d,component:f}=a[c].vm,p=Number.parseInt(d.style[i],10)-r-L0;f.props.offset=p}}function qle(){for(const e of Object.values(_c))e.forEach(({vm:t})=>{t.component.exposed.visible.value=!1})}Kr.closeAll=qle;Kr._context=null;const Ule=B6(Kr,"$notify");var Yle=[ile,ple,xle,Ble,Ule,ay],Gle=Ox([...tle,...Yle]);const Xle={class:"app-container"},Zle={class:"wrapper"},Jle=I({__name:"App",setup(e){return(t,n)=>(b(),ue(s(p8),null,{default:J(()=>[x("div",Xle,[x("header",null,[x("div",Zle,[n[3]||(n[3]=x("h1",{class:"app-title"},"文件共享系统",-1)),x("nav",null,[U(s(pu),{to:Xre||"/"},{default:J(()=>n[0]||(n[0]=[ct("共享")])),_:1}),U(s(pu),{to:"/manage"},{default:J(()=>n[1]||(n[1]=[ct("管理维护")])),_:1}),U(s(pu),{to:"/about"},{default:J(()=>n[2]||(n[2]=[ct("关于")])),_:1})])])]),x("main",null,[U(s(I4))])])]),_:1}))}}),Qle=(e,t)=>{const n=e.__vccOpts||e;for(const[a,o]of t)n[a]=o;return n},ere=Qle(Jle,[["__scopeId","data-v-f136f0a9"]]),tre="modulepreload",nre=function(e,t){return new URL(e,t).href},Km={},uf=function(t,n,a){let o=Promise.resolve();if(n&&n.length>0){const r=document.getElementsByTagName("link"),i=document.querySelector("meta[property=csp-nonce]"),u=(i==null?void 0:i.nonce)||(i==null?void 0:i.getAttribute("nonce"));o=Promise.allSettled(n.map(c=>{if(c=nre(c,a),c in Km)return;Km[c]=!0;const d=c.endsWith(".css"),f=d?'[rel="stylesheet"]':"";if(!!a)for(let v=r.length-1;v>=0;v--){const m=r[v];if(m.href===c&&(!d||m.rel==="stylesheet"))return}else if(document.querySelector(`link[href="${c}"]${f}`))return;const p=document.createElement("link");if(p.rel=d?"stylesheet":tre,d||(p.as="script"),p.crossOrigin="",p.href=c,u&&p.setAttribute("nonce",u),document.head.appendChild(p),d)return new Promise((v,m)=>{p.addEventListener("load",v),p.addEventListener("error",()=>m(new Error(`Unable to preload CSS for ${c}`)))})}))}function l(r){const i=new Event("vite:preloadError",{cancelable:!0});if(i.payload=r,window.dispatchEvent(i),!i.defaultPrevented)throw r}return o.then(r=>{for(const i of r||[])i.status==="rejected"&&l(i.reason);return t().catch(l)})},are=v9({history:j5(Hre||"/"),routes:[{path:Xre||"/",name:"share",component:()=>uf(()=>import("./ShareView-YzdVJs69.js"),__vite__mapDeps([0,1]),import.meta.url)},{path:"/manage",name:"manage",component:()=>uf(()=>import("./ManageView-lRc_G3F8.js"),__vite__mapDeps([2,3]),import.meta.url)},{path:"/about",name:"about",component:()=>uf(()=>import("./AboutView-9rJK8Xg_.js"),__vite__mapDeps([4,5]),import.meta.url)}]}),pd=g4(ere);for(const[e,t]of Object.entries(GL))pd.component(e,t);pd.use(are);pd.use(Gle);pd.mount("#app");export{xle as E,He as F,Qle as _,Ct as a,x as b,M as c,I as d,U as e,ut as f,ue as g,b as h,le as i,ct as j,Ble as k,qe as l,Qe as m,Fe as n,at as o,Ot as p,lA as q,V as r,j$ as s,Te as t,s as u,_t as v,J as w,pt as x,Oc as y,T as z,Wre as A,Qre as B};
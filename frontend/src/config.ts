@@ -0,0 +1,31 @@
+// 服务端在index.html中写入的运行时配置，部署在子路径或修改了接口路径时由服务端生成
+interface RuntimeConfig {
+  basePath?: string
+  uiPath?: string
+  manageApi?: string
+  shareApi?: string
+}
+
+const readConfig = (): RuntimeConfig => {
+  const meta = document.querySelector('meta[name="fileshare-config"]')
+  try {
+    return JSON.parse(meta?.getAttribute('content') || '{}')
+  } catch {
+    return {}
+  }
+}
+
+const runtimeConfig = readConfig()
+
+// 客户端看到的路径前缀
+export const basePath = runtimeConfig.basePath ?? ''
+// 共享页面的路由
+export const uiPath = runtimeConfig.uiPath ?? '/fileserver'
+// 管理API地址
+export const manageApi = runtimeConfig.manageApi ?? '/fileshare/api'
+// 共享API地址
+export const shareApi = runtimeConfig.shareApi ?? '/filesharePreview/api'
+
+// 构建产物中动态加载的资源地址（见vite.config.ts中的renderBuiltUrl）
+;(window as unknown as { __fileshareAsset: (filename: string) => string }).__fileshareAsset = (filename) =>
+  `${basePath}${uiPath}/${filename}`
@@ -1,3 +1,4 @@
+import './config'
 import './assets/main.css'
 import 'element-plus/dist/index.css'
 
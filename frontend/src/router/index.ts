@@ -1,10 +1,12 @@
 import { createRouter, createWebHistory } from 'vue-router'
+import { basePath, uiPath } from '@/config'
 
 const router = createRouter({
-  history: createWebHistory(import.meta.env.BASE_URL),
+  // 页面路由从路径前缀开始（BASE_URL是构建时的资源路径，不是页面路径）
+  history: createWebHistory(basePath || '/'),
   routes: [
     {
-      path: '/fileserver',
+      path: uiPath || '/',
       name: 'share',
       component: () => import('../views/ShareView.vue'),
     },
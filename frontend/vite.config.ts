@@ -6,6 +6,17 @@ import vueDevTools from 'vite-plugin-vue-devtools'
 
 // https://vite.dev/config/
 export default defineConfig({
+  // 构建时的资源路径，服务端返回index.html时会替换为实际的访问路径
+  base: '/fileserver/',
+  experimental: {
+    // 动态加载的资源地址在运行时根据服务端下发的路径前缀计算，支持部署在子路径下
+    renderBuiltUrl(filename, { hostType }) {
+      if (hostType === 'js') {
+        return { runtime: `window.__fileshareAsset(${JSON.stringify(filename)})` }
+      }
+      return { relative: true }
+    },
+  },
   plugins: [
     vue(),
     vueDevTools(),